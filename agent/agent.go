@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/client"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 type Agent struct {
@@ -152,8 +154,16 @@ func (a *Agent) probeServerInfo() error {
 
 // authorize send auth request to the server.
 func (a *Agent) authorize() error {
+	// The SSH server of the agent uses the device's key as its host key.
+	hostKey, err := gossh.NewPublicKey(a.pubKey)
+	if err != nil {
+		return err
+	}
+
 	authData, err := a.cli.AuthDevice(&models.DeviceAuthRequest{
-		Info: a.Info,
+		Info:       a.Info,
+		Attributes: a.opts.Attributes,
+		HostKey:    strings.TrimSuffix(string(gossh.MarshalAuthorizedKey(hostKey)), "\n"),
 		DeviceAuth: &models.DeviceAuth{
 			Hostname:  a.opts.PreferredHostname,
 			Identity:  a.Identity,
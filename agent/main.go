@@ -56,6 +56,11 @@ type ConfigOptions struct {
 	// use this identity if it is available.
 	PreferredIdentity string `envconfig:"preferred_identity" default:""`
 
+	// Set the device attributes, as a comma-separated list of key:value pairs
+	// (e.g: "site:berlin,rack:12"). They are set on the device at each
+	// registration, replacing the values of the same keys.
+	Attributes map[string]string `envconfig:"attributes"`
+
 	// Set password for single-user mode (without root privileges). If not provided,
 	// multi-user mode (with root privileges) is enabled by default.
 	// NOTE: The password hash could be generated by ```openssl passwd```.
@@ -1,9 +1,7 @@
 package server
 
 import (
-	"crypto"
-	"crypto/rsa"
-	"crypto/sha256"
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -359,6 +357,10 @@ func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey
 		return false
 	}
 
+	if certificate, ok := key.(*gossh.Certificate); ok {
+		return s.certificateHandler(ctx, certificate)
+	}
+
 	type Signature struct {
 		Username  string
 		Namespace string
@@ -374,10 +376,8 @@ func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey
 		return false
 	}
 
-	sigHash := sha256.Sum256(sigBytes)
-
 	res, err := s.api.AuthPublicKey(&models.PublicKeyAuthRequest{
-		Fingerprint: gossh.FingerprintLegacyMD5(key),
+		Fingerprint: gossh.FingerprintSHA256(key),
 		Data:        string(sigBytes),
 	}, s.authData.Token)
 	if err != nil {
@@ -389,25 +389,84 @@ func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey
 		return false
 	}
 
-	cryptoKey, ok := key.(gossh.CryptoPublicKey)
-	if !ok {
+	// The servers that predate the signature format only sign with RSA keys, using SHA-256.
+	format := res.Format
+	if format == "" {
+		format = gossh.KeyAlgoRSASHA256
+	}
+
+	if err := key.Verify(sigBytes, &gossh.Signature{Format: format, Blob: digest}); err != nil {
+		return false
+	}
+
+	return true
+}
+
+// certificateHandler checks a user certificate against the certificate authority of the device's namespace, without
+// calling back to the server. The certificate must be valid for the user and be bound to the device.
+func (s *Server) certificateHandler(ctx gliderssh.Context, certificate *gossh.Certificate) bool {
+	log := log.WithFields(log.Fields{
+		"user":    ctx.User(),
+		"session": certificate.KeyId,
+	})
+
+	if s.authData.CertificateAuthority == "" {
+		log.Info("Failed user certificate as the certificate authority is unknown")
+
 		return false
 	}
 
-	pubCrypto := cryptoKey.CryptoPublicKey()
+	authority, _, _, _, err := gossh.ParseAuthorizedKey([]byte(s.authData.CertificateAuthority)) // nolint:dogsled
+	if err != nil {
+		log.WithError(err).Error("Failed to parse the certificate authority")
 
-	pubKey, ok := pubCrypto.(*rsa.PublicKey)
-	if !ok {
 		return false
 	}
 
-	if err = rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, sigHash[:], digest); err != nil {
+	if err := checkCertificate(authority, ctx.User(), s.authData.UID, certificate); err != nil {
+		log.WithError(err).Info("Failed user certificate")
+
 		return false
 	}
 
+	log.Info("Accepted user certificate")
+
 	return true
 }
 
+// checkCertificate checks that a user certificate was signed by the authority for the user on the device.
+//
+// CertChecker.CheckCert only verifies the certificate against its own signature key, so the certificate's type and
+// authority are checked before it.
+func checkCertificate(authority gossh.PublicKey, user, device string, certificate *gossh.Certificate) error {
+	if certificate.CertType != gossh.UserCert {
+		return fmt.Errorf("certificate is not a user certificate")
+	}
+
+	if !bytes.Equal(certificate.SignatureKey.Marshal(), authority.Marshal()) {
+		return fmt.Errorf("certificate is not signed by the certificate authority")
+	}
+
+	// A certificate without principals is valid for every user.
+	if len(certificate.ValidPrincipals) == 0 {
+		return fmt.Errorf("certificate has no principals")
+	}
+
+	checker := &gossh.CertChecker{
+		SupportedCriticalOptions: []string{models.CertificateOptionDevice},
+	}
+
+	if err := checker.CheckCert(user, certificate); err != nil {
+		return err
+	}
+
+	if certificate.CriticalOptions[models.CertificateOptionDevice] != device {
+		return fmt.Errorf("certificate is issued to another device")
+	}
+
+	return nil
+}
+
 func (s *Server) sessionRequestCallback(session gliderssh.Session, requestType string) bool {
 	session.Context().SetValue("request_type", requestType)
 
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestCheckCertificate(t *testing.T) {
+	newSigner := func() gossh.Signer {
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+
+		signer, err := gossh.NewSignerFromKey(private)
+		assert.NoError(t, err)
+
+		return signer
+	}
+
+	authority := newSigner()
+	user := newSigner()
+
+	sign := func(signer gossh.Signer, certType uint32, principals []string, device string) *gossh.Certificate {
+		certificate := &gossh.Certificate{
+			Key:             user.PublicKey(),
+			CertType:        certType,
+			KeyId:           "session",
+			ValidPrincipals: principals,
+			ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+			ValidBefore:     uint64(time.Now().Add(time.Minute).Unix()),
+			Permissions: gossh.Permissions{
+				CriticalOptions: map[string]string{models.CertificateOptionDevice: device},
+			},
+		}
+
+		assert.NoError(t, certificate.SignCert(rand.Reader, signer))
+
+		return certificate
+	}
+
+	cases := []struct {
+		description string
+		certificate *gossh.Certificate
+		user        string
+		fails       bool
+	}{
+		{
+			description: "fails when the certificate is self-signed",
+			certificate: sign(user, gossh.UserCert, []string{"root"}, "device"),
+			user:        "root",
+			fails:       true,
+		},
+		{
+			description: "fails when the certificate is signed by another authority",
+			certificate: sign(newSigner(), gossh.UserCert, []string{"root"}, "device"),
+			user:        "root",
+			fails:       true,
+		},
+		{
+			description: "fails when the certificate is a host certificate",
+			certificate: sign(authority, gossh.HostCert, []string{"root"}, "device"),
+			user:        "root",
+			fails:       true,
+		},
+		{
+			description: "fails when the certificate has no principals",
+			certificate: sign(authority, gossh.UserCert, nil, "device"),
+			user:        "root",
+			fails:       true,
+		},
+		{
+			description: "fails when the certificate is for another user",
+			certificate: sign(authority, gossh.UserCert, []string{"root"}, "device"),
+			user:        "admin",
+			fails:       true,
+		},
+		{
+			description: "fails when the certificate is issued to another device",
+			certificate: sign(authority, gossh.UserCert, []string{"root"}, "other"),
+			user:        "root",
+			fails:       true,
+		},
+		{
+			description: "succeeds",
+			certificate: sign(authority, gossh.UserCert, []string{"root"}, "device"),
+			user:        "root",
+			fails:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := checkCertificate(authority.PublicKey(), tc.user, "device", tc.certificate)
+			if tc.fails {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
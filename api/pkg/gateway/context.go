@@ -56,6 +56,11 @@ func (c *Context) ID() *models.ID {
 	return nil
 }
 
+// RequestID returns the request's ID set by the request ID middleware.
+func (c *Context) RequestID() string {
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
+
 func (c *Context) Ctx() context.Context {
 	return c.Request().Context()
 }
@@ -54,3 +54,29 @@ func IDFromContext(ctx context.Context) *models.ID {
 
 	return nil
 }
+
+func RoleFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.Role()
+	}
+
+	return ""
+}
+
+// IPFromContext returns the IP of the request's client. It is taken from the X-Real-IP header only when the request comes
+// from the gateway, as configured by the API server's IP extractor.
+func IPFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.RealIP()
+	}
+
+	return ""
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.RequestID()
+	}
+
+	return ""
+}
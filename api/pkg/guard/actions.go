@@ -9,11 +9,17 @@ type AllActions struct {
 	Firewall  FirewallActions
 	PublicKey PublicKeyActions
 	Namespace NamespaceActions
+	APIKey    APIKeyActions
+	Webhook   WebhookActions
+	Group     DeviceGroupActions
+	Role      RoleActions
+	Grant     AccessGrantActions
+	Request   AccessRequestActions
 	Billing   BillingActions
 }
 
 type DeviceActions struct {
-	Accept, Reject, Update, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
+	Accept, Reject, Update, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag, SetAttribute, RemoveAttribute, UpdateAttributes, RotateHostKey int
 }
 
 type SessionActions struct {
@@ -29,7 +35,31 @@ type PublicKeyActions struct {
 }
 
 type NamespaceActions struct {
-	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete, AuditLog, RequireMFA, ApprovalTags int
+}
+
+type APIKeyActions struct {
+	Create, Remove int
+}
+
+type WebhookActions struct {
+	Create, Remove, Redeliver int
+}
+
+type DeviceGroupActions struct {
+	Create, Edit, Remove int
+}
+
+type RoleActions struct {
+	Create, Edit, Remove int
+}
+
+type AccessGrantActions struct {
+	Create, Revoke int
+}
+
+type AccessRequestActions struct {
+	Decide int
 }
 
 type BillingActions struct {
@@ -51,6 +81,11 @@ var Actions = AllActions{
 		RemoveTag: DeviceRemoveTag,
 		RenameTag: DeviceRenameTag,
 		DeleteTag: DeviceDeleteTag,
+
+		SetAttribute:     DeviceSetAttribute,
+		RemoveAttribute:  DeviceRemoveAttribute,
+		UpdateAttributes: DeviceUpdateAttributes,
+		RotateHostKey:    DeviceRotateHostKey,
 	},
 	Session: SessionActions{
 		Play:    SessionPlay,
@@ -59,9 +94,12 @@ var Actions = AllActions{
 		Details: SessionDetails,
 	},
 	Firewall: FirewallActions{
-		Create: FirewallCreate,
-		Edit:   FirewallEdit,
-		Remove: FirewallRemove,
+		Create:    FirewallCreate,
+		Edit:      FirewallEdit,
+		Remove:    FirewallRemove,
+		AddTag:    FirewallAddTag,
+		UpdateTag: FirewallUpdateTag,
+		RemoveTag: FirewallRemoveTag,
 	},
 	PublicKey: PublicKeyActions{
 		Create:    PublicKeyCreate,
@@ -78,6 +116,35 @@ var Actions = AllActions{
 		EditMember:          NamespaceEditMember,
 		EnableSessionRecord: NamespaceEnableSessionRecord,
 		Delete:              NamespaceDelete,
+		AuditLog:            NamespaceAuditLog,
+		RequireMFA:          NamespaceRequireMFA,
+		ApprovalTags:        NamespaceApprovalTags,
+	},
+	APIKey: APIKeyActions{
+		Create: APIKeyCreate,
+		Remove: APIKeyRemove,
+	},
+	Webhook: WebhookActions{
+		Create:    WebhookCreate,
+		Remove:    WebhookRemove,
+		Redeliver: WebhookRedeliver,
+	},
+	Group: DeviceGroupActions{
+		Create: DeviceGroupCreate,
+		Edit:   DeviceGroupEdit,
+		Remove: DeviceGroupRemove,
+	},
+	Role: RoleActions{
+		Create: RoleCreate,
+		Edit:   RoleEdit,
+		Remove: RoleRemove,
+	},
+	Grant: AccessGrantActions{
+		Create: AccessGrantCreate,
+		Revoke: AccessGrantRevoke,
+	},
+	Request: AccessRequestActions{
+		Decide: AccessRequestDecide,
 	},
 	Billing: BillingActions{
 		ChooseDevices:       BillingChooseDevices,
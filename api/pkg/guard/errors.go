@@ -6,8 +6,18 @@ import "github.com/shellhub-io/shellhub/pkg/errors"
 // ErrLayer is the errors' level for guard's error.
 var ErrLayer = "guard"
 
-// ErrCodeForbidden is the error code when the access to a resource is forbidden.
-const ErrCodeForbidden = iota + 1
+const (
+	// ErrCodeForbidden is the error code when the access to a resource is forbidden.
+	ErrCodeForbidden = iota + 1
+	// ErrCodeInvalid is the error code when a guard's definition is invalid.
+	ErrCodeInvalid
+)
 
 // ErrForbidden is used to indicate that access to a resource is forbidden.
 var ErrForbidden = errors.New("access forbidden", ErrLayer, ErrCodeForbidden)
+
+// ErrGroupRoleInvalid is used to indicate that a mapping from an external group to a namespace's role is invalid.
+var ErrGroupRoleInvalid = errors.New("invalid group role", ErrLayer, ErrCodeInvalid)
+
+// ErrPermissionInvalid is used to indicate that a custom role's permission does not exist or cannot be granted.
+var ErrPermissionInvalid = errors.New("invalid permission", ErrLayer, ErrCodeInvalid)
@@ -0,0 +1,54 @@
+package guard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupRole is the role on a namespace given to the members of an external group, as a group of an OpenID Connect
+// provider or of an LDAP directory.
+type GroupRole struct {
+	Group  string
+	Tenant string
+	Role   string
+}
+
+// ParseGroupRoles parses the "group:tenant:role" entries. As the group's name is the only part that can contain
+// colons, the entry is split from its end. A group cannot be mapped to the owner's role.
+func ParseGroupRoles(entries []string) ([]GroupRole, error) {
+	roles := make([]GroupRole, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 3 {
+			return nil, fmt.Errorf("%w: %s", ErrGroupRoleInvalid, entry)
+		}
+
+		role := GroupRole{
+			Group:  strings.Join(parts[:len(parts)-2], ":"),
+			Tenant: parts[len(parts)-2],
+			Role:   parts[len(parts)-1],
+		}
+
+		if role.Group == "" || role.Tenant == "" || role.Role == RoleOwner || GetRoleCode(role.Role) == RoleInvalidCode {
+			return nil, fmt.Errorf("%w: %s", ErrGroupRoleInvalid, entry)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// GroupsRoles returns the greatest role mapped to the groups on each namespace.
+func GroupsRoles(roles []GroupRole, groups []string) map[string]string {
+	mapped := make(map[string]string)
+	for _, role := range roles {
+		for _, group := range groups {
+			if group == role.Group && GetRoleCode(role.Role) > GetRoleCode(mapped[role.Tenant]) {
+				mapped[role.Tenant] = role.Role
+			}
+		}
+	}
+
+	return mapped
+}
@@ -72,6 +72,13 @@ func GetRoleCode(role string) int {
 	return code
 }
 
+// IsBuiltinRole checks if a role is one of the builtin roles, instead of a namespace's custom role.
+func IsBuiltinRole(role string) bool {
+	_, ok := Roles[role]
+
+	return ok
+}
+
 // CheckRole checks if a models.Member's role from a models.Namespace can act over the other. Active is the member's role
 // from who is acting, and passive is the member who is receiving. Active and passive roles must be members of the
 // same models.Namespace.
@@ -79,15 +86,21 @@ func GetRoleCode(role string) int {
 // If active or passive is an invalid member, a member with a role no mapped, it returns false. If active and passive are
 // equal, it returns false too.
 //
-// The valid roles are: RoleObserver, RoleOperator, RoleAdmin or RoleOwner.
+// The valid roles are: RoleObserver, RoleOperator, RoleAdmin or RoleOwner. A passive role that is not builtin is
+// treated as a namespace's custom role, which only RoleAdmin and RoleOwner can act over. A custom role cannot act over
+// any other.
 func CheckRole(active, passive string) bool {
 	first := GetRoleCode(active)
 	second := GetRoleCode(passive)
 
-	if first == RoleInvalidCode || second == RoleInvalidCode {
+	if first == RoleInvalidCode {
 		return false
 	}
 
+	if second == RoleInvalidCode {
+		return passive != "" && first >= RoleAdministratorCode
+	}
+
 	if first == second {
 		return false
 	}
@@ -99,8 +112,9 @@ func CheckRole(active, passive string) bool {
 // allowed actions.
 //
 // Role is the member's role from who is acting, Action is the action that is being performed and callback is a function
-// to be called if the action is allowed.
-func EvaluatePermission(role string, action int, callback func() error) error {
+// to be called if the action is allowed. When the role is not builtin, it is looked up by its name in custom, the
+// namespace's custom roles, and allows the actions of its permissions.
+func EvaluatePermission(role string, action int, callback func() error, custom ...models.Role) error {
 	check := func(action int, permissions Permissions) bool {
 		for _, permission := range permissions {
 			if permission == action {
@@ -112,6 +126,10 @@ func EvaluatePermission(role string, action int, callback func() error) error {
 	}
 
 	permission, ok := RolePermissions[role]
+	if !ok {
+		permission, ok = customPermissions(role, custom)
+	}
+
 	if !ok {
 		return ErrForbidden
 	}
@@ -123,11 +141,32 @@ func EvaluatePermission(role string, action int, callback func() error) error {
 	return callback()
 }
 
-func EvaluateNamespace(namespace *models.Namespace, userID string, action int, callback func() error) error {
+func EvaluateNamespace(namespace *models.Namespace, userID string, action int, callback func() error, custom ...models.Role) error {
 	member, ok := CheckMember(namespace, userID)
 	if !ok {
 		return ErrForbidden
 	}
 
-	return EvaluatePermission(member.Role, action, callback)
+	return EvaluatePermission(member.Role, action, callback, custom...)
+}
+
+// customPermissions returns the permissions of the custom role with the name. The names of permissions that cannot be
+// granted are ignored.
+func customPermissions(name string, custom []models.Role) (Permissions, bool) {
+	for _, role := range custom {
+		if role.Name != name {
+			continue
+		}
+
+		permissions := make(Permissions, 0, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			if code, ok := PermissionNames[permission]; ok {
+				permissions = append(permissions, code)
+			}
+		}
+
+		return permissions, true
+	}
+
+	return nil, false
 }
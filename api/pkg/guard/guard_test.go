@@ -421,6 +421,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
 				Actions.Namespace.Delete,
+				Actions.Namespace.RequireMFA,
 
 				Actions.Billing.AddPaymentMethod,
 				Actions.Billing.UpdatePaymentMethod,
@@ -506,3 +507,73 @@ func ExampleGetRoleCode() {
 	// -1
 	// -1
 }
+
+func TestParseGroupRoles(t *testing.T) {
+	roles, err := ParseGroupRoles([]string{"devs:tenant:operator", "org:admins:tenant:administrator"})
+	assert.NoError(t, err)
+	assert.Equal(t, []GroupRole{
+		{Group: "devs", Tenant: "tenant", Role: RoleOperator},
+		{Group: "org:admins", Tenant: "tenant", Role: RoleAdministrator},
+	}, roles)
+
+	for _, entry := range []string{"devs:operator", "devs:tenant:owner", "devs:tenant:root", ":tenant:observer"} {
+		_, err := ParseGroupRoles([]string{entry})
+		assert.True(t, errors.Is(err, ErrGroupRoleInvalid), entry)
+	}
+}
+
+func TestGroupsRoles(t *testing.T) {
+	roles := []GroupRole{
+		{Group: "devs", Tenant: "tenant", Role: RoleOperator},
+		{Group: "ops", Tenant: "tenant", Role: RoleObserver},
+		{Group: "admins", Tenant: "other", Role: RoleAdministrator},
+	}
+
+	assert.Equal(t, map[string]string{"tenant": RoleOperator}, GroupsRoles(roles, []string{"ops", "devs"}))
+	assert.Equal(t, map[string]string{}, GroupsRoles(roles, nil))
+}
+
+func TestCheckRoleCustom(t *testing.T) {
+	assert.True(t, CheckRole(RoleAdministrator, "auditor"))
+	assert.True(t, CheckRole(RoleOwner, "auditor"))
+	assert.False(t, CheckRole(RoleOperator, "auditor"))
+	assert.False(t, CheckRole("auditor", RoleObserver))
+	assert.False(t, CheckRole("auditor", "auditor"))
+	assert.False(t, CheckRole(RoleAdministrator, ""))
+}
+
+func TestEvaluatePermissionCustom(t *testing.T) {
+	custom := []models.Role{
+		{Name: "auditor", Permissions: []string{"device:connect", "session:play", "unknown"}},
+		{Name: "empty"},
+	}
+
+	called := func() error { return nil }
+
+	assert.NoError(t, EvaluatePermission("auditor", Actions.Device.Connect, called, custom...))
+	assert.NoError(t, EvaluatePermission("auditor", Actions.Session.Play, called, custom...))
+	assert.ErrorIs(t, EvaluatePermission("auditor", Actions.Device.Accept, called, custom...), ErrForbidden)
+	assert.ErrorIs(t, EvaluatePermission("empty", Actions.Device.Connect, called, custom...), ErrForbidden)
+	assert.ErrorIs(t, EvaluatePermission("missing", Actions.Device.Connect, called, custom...), ErrForbidden)
+	assert.ErrorIs(t, EvaluatePermission("auditor", Actions.Device.Connect, called), ErrForbidden)
+
+	// The builtin roles are not overridden by a custom role with the same name.
+	assert.ErrorIs(t, EvaluatePermission(RoleObserver, Actions.Device.Accept, called, models.Role{Name: RoleObserver, Permissions: []string{"device:accept"}}), ErrForbidden)
+
+	namespace := &models.Namespace{Members: []models.Member{{ID: "id", Role: "auditor"}}}
+	assert.NoError(t, EvaluateNamespace(namespace, "id", Actions.Session.Play, called, custom...))
+	assert.ErrorIs(t, EvaluateNamespace(namespace, "id", Actions.Session.Remove, called, custom...), ErrForbidden)
+}
+
+func TestParsePermissions(t *testing.T) {
+	permissions, err := ParsePermissions([]string{"device:connect", "session:play"})
+	assert.NoError(t, err)
+	assert.Equal(t, Permissions{DeviceConnect, SessionPlay}, permissions)
+
+	_, err = ParsePermissions([]string{"device:connect", "namespace:delete"})
+	assert.ErrorIs(t, err, ErrPermissionInvalid)
+
+	for name, permission := range PermissionNames {
+		assert.Contains(t, adminPermissions, permission, name)
+	}
+}
@@ -1,5 +1,7 @@
 package guard
 
+import "fmt"
+
 type Permissions []int
 
 const (
@@ -17,6 +19,11 @@ const (
 	DeviceRenameTag
 	DeviceDeleteTag
 
+	DeviceSetAttribute
+	DeviceRemoveAttribute
+	DeviceUpdateAttributes
+	DeviceRotateHostKey
+
 	SessionPlay
 	SessionClose
 	SessionRemove
@@ -44,6 +51,29 @@ const (
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
 	NamespaceDelete
+	NamespaceAuditLog
+	NamespaceRequireMFA
+	NamespaceApprovalTags
+
+	APIKeyCreate
+	APIKeyRemove
+
+	WebhookCreate
+	WebhookRemove
+	WebhookRedeliver
+
+	DeviceGroupCreate
+	DeviceGroupEdit
+	DeviceGroupRemove
+
+	RoleCreate
+	RoleEdit
+	RoleRemove
+
+	AccessGrantCreate
+	AccessGrantRevoke
+
+	AccessRequestDecide
 
 	BillingChooseDevices
 	BillingAddPaymentMethod
@@ -74,6 +104,9 @@ var operatorPermissions = Permissions{
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceSetAttribute,
+	DeviceRemoveAttribute,
+	DeviceUpdateAttributes,
 
 	SessionDetails,
 }
@@ -92,6 +125,10 @@ var adminPermissions = Permissions{
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceSetAttribute,
+	DeviceRemoveAttribute,
+	DeviceUpdateAttributes,
+	DeviceRotateHostKey,
 
 	DeviceUpdate,
 
@@ -119,6 +156,28 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceAuditLog,
+	NamespaceApprovalTags,
+
+	APIKeyCreate,
+	APIKeyRemove,
+
+	WebhookCreate,
+	WebhookRemove,
+	WebhookRedeliver,
+
+	DeviceGroupCreate,
+	DeviceGroupEdit,
+	DeviceGroupRemove,
+
+	RoleCreate,
+	RoleEdit,
+	RoleRemove,
+
+	AccessGrantCreate,
+	AccessGrantRevoke,
+
+	AccessRequestDecide,
 }
 
 var ownerPermissions = Permissions{
@@ -135,6 +194,10 @@ var ownerPermissions = Permissions{
 	DeviceRemoveTag,
 	DeviceRenameTag,
 	DeviceDeleteTag,
+	DeviceSetAttribute,
+	DeviceRemoveAttribute,
+	DeviceUpdateAttributes,
+	DeviceRotateHostKey,
 
 	DeviceUpdate,
 
@@ -163,6 +226,29 @@ var ownerPermissions = Permissions{
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
 	NamespaceDelete,
+	NamespaceAuditLog,
+	NamespaceRequireMFA,
+	NamespaceApprovalTags,
+
+	APIKeyCreate,
+	APIKeyRemove,
+
+	WebhookCreate,
+	WebhookRemove,
+	WebhookRedeliver,
+
+	DeviceGroupCreate,
+	DeviceGroupEdit,
+	DeviceGroupRemove,
+
+	RoleCreate,
+	RoleEdit,
+	RoleRemove,
+
+	AccessGrantCreate,
+	AccessGrantRevoke,
+
+	AccessRequestDecide,
 
 	BillingChooseDevices,
 	BillingAddPaymentMethod,
@@ -172,3 +258,68 @@ var ownerPermissions = Permissions{
 	BillingCreateSubscription,
 	BillingGetSubscription,
 }
+
+// PermissionNames maps the permissions that can be granted to a custom role to their names.
+//
+// The permissions to manage the namespace's members, its API keys, its roles and its access grants, and to decide the
+// access requests, are not listed, as a custom role holding them could grant itself, or another member, more
+// permissions than it has. The owner's permissions are not listed either.
+var PermissionNames = map[string]int{
+	"device:accept":            DeviceAccept,
+	"device:reject":            DeviceReject,
+	"device:update":            DeviceUpdate,
+	"device:remove":            DeviceRemove,
+	"device:connect":           DeviceConnect,
+	"device:rename":            DeviceRename,
+	"device:details":           DeviceDetails,
+	"device:tag:create":        DeviceCreateTag,
+	"device:tag:update":        DeviceUpdateTag,
+	"device:tag:remove":        DeviceRemoveTag,
+	"device:tag:rename":        DeviceRenameTag,
+	"device:tag:delete":        DeviceDeleteTag,
+	"device:attribute:set":     DeviceSetAttribute,
+	"device:attribute:remove":  DeviceRemoveAttribute,
+	"device:attribute:update":  DeviceUpdateAttributes,
+	"device:host_key:rotate":   DeviceRotateHostKey,
+	"session:play":             SessionPlay,
+	"session:close":            SessionClose,
+	"session:remove":           SessionRemove,
+	"session:details":          SessionDetails,
+	"firewall:create":          FirewallCreate,
+	"firewall:edit":            FirewallEdit,
+	"firewall:remove":          FirewallRemove,
+	"firewall:tag:add":         FirewallAddTag,
+	"firewall:tag:remove":      FirewallRemoveTag,
+	"firewall:tag:update":      FirewallUpdateTag,
+	"public_key:create":        PublicKeyCreate,
+	"public_key:edit":          PublicKeyEdit,
+	"public_key:remove":        PublicKeyRemove,
+	"public_key:tag:add":       PublicKeyAddTag,
+	"public_key:tag:remove":    PublicKeyRemoveTag,
+	"public_key:tag:update":    PublicKeyUpdateTag,
+	"namespace:rename":         NamespaceRename,
+	"namespace:session_record": NamespaceEnableSessionRecord,
+	"namespace:audit_log":      NamespaceAuditLog,
+	"webhook:create":           WebhookCreate,
+	"webhook:remove":           WebhookRemove,
+	"webhook:redeliver":        WebhookRedeliver,
+	"device_group:create":      DeviceGroupCreate,
+	"device_group:edit":        DeviceGroupEdit,
+	"device_group:remove":      DeviceGroupRemove,
+}
+
+// ParsePermissions converts the names of a custom role's permissions to Permissions. It returns ErrPermissionInvalid
+// when a name is not in PermissionNames.
+func ParsePermissions(names []string) (Permissions, error) {
+	permissions := make(Permissions, 0, len(names))
+	for _, name := range names {
+		permission, ok := PermissionNames[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionInvalid, name)
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,205 @@
+// Package ldap authenticates the users against an LDAP directory, as OpenLDAP or Active Directory.
+//
+// The user's entry is searched with a service account and the user's password is verified by binding as the entry
+// found. Any directory can be used, including the in-memory one from the ldaptest package.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+var (
+	ErrConfig             = errors.New("base dn is required")
+	ErrUserNotFound       = errors.New("user not found on the directory")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUnavailable        = errors.New("directory unavailable")
+)
+
+// Timeout is the time that each connection to the directory can take.
+const Timeout = 10 * time.Second
+
+// Config defines the LDAP directory and how its users are found.
+type Config struct {
+	// LDAPURL is the URL of the directory, as "ldap://host:389" or "ldaps://host:636". When it is empty, the LDAP
+	// authentication is disabled.
+	LDAPURL string `envconfig:"ldap_url"`
+	// LDAPStartTLS upgrades an "ldap://" connection to TLS.
+	LDAPStartTLS bool `envconfig:"ldap_start_tls" default:"false"`
+	// LDAPInsecureSkipVerify accepts any certificate of the directory.
+	LDAPInsecureSkipVerify bool `envconfig:"ldap_insecure_skip_verify" default:"false"`
+	// LDAPBindDN is the service account that searches the users. When it is empty, the search is anonymous.
+	LDAPBindDN string `envconfig:"ldap_bind_dn"`
+	// LDAPBindPassword is the password of the service account.
+	LDAPBindPassword string `envconfig:"ldap_bind_password"`
+	// LDAPBaseDN is the entry where the users are searched from.
+	LDAPBaseDN string `envconfig:"ldap_base_dn"`
+	// LDAPUserFilter is the filter that finds the user, where "%s" is replaced by the escaped username. On Active
+	// Directory, it is usually "(sAMAccountName=%s)".
+	LDAPUserFilter string `envconfig:"ldap_user_filter" default:"(uid=%s)"`
+	// LDAPUsernameAttribute is the user's attribute with its username.
+	LDAPUsernameAttribute string `envconfig:"ldap_username_attribute" default:"uid"`
+	// LDAPEmailAttribute is the user's attribute with its email.
+	LDAPEmailAttribute string `envconfig:"ldap_email_attribute" default:"mail"`
+	// LDAPNameAttribute is the user's attribute with its name.
+	LDAPNameAttribute string `envconfig:"ldap_name_attribute" default:"cn"`
+	// LDAPGroupAttribute is the user's attribute with the DNs of its groups.
+	LDAPGroupAttribute string `envconfig:"ldap_group_attribute" default:"memberOf"`
+	// LDAPGroupRoles maps the directory's groups, by their common names, to namespaces' members, as
+	// "group:tenant:role" entries. See guard.ParseGroupRoles.
+	LDAPGroupRoles []string `envconfig:"ldap_group_roles"`
+}
+
+// Identity is the user authenticated by the directory.
+type Identity struct {
+	DN       string
+	Username string
+	Email    string
+	Name     string
+	// Groups are the common names of the user's groups.
+	Groups []string
+}
+
+// Authenticator authenticates the users against an LDAP directory.
+type Authenticator struct {
+	config Config
+}
+
+// New returns the authenticator defined by the config, or nil when no directory is defined. The directory is only
+// connected when a user is authenticated, so it does not need to be reachable when the API starts.
+func New(cfg Config) (*Authenticator, error) {
+	if cfg.LDAPURL == "" {
+		return nil, nil
+	}
+
+	if cfg.LDAPBaseDN == "" {
+		return nil, ErrConfig
+	}
+
+	return &Authenticator{config: cfg}, nil
+}
+
+// Authenticate verifies the user's password, returning its identity on the directory.
+//
+// It returns ErrUserNotFound when the username does not match a single entry, ErrInvalidCredentials when the password
+// is wrong and ErrUnavailable when the directory cannot be used.
+func (a *Authenticator) Authenticate(ctx context.Context, username, password string) (*Identity, error) {
+	// An empty password would be an unauthenticated bind, which succeeds on many directories.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := a.connect(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	defer conn.Close()
+
+	if a.config.LDAPBindDN != "" {
+		if err := conn.Bind(a.config.LDAPBindDN, a.config.LDAPBindPassword); err != nil {
+			return nil, fmt.Errorf("%w: failed to bind the service account: %s", ErrUnavailable, err)
+		}
+	}
+
+	attributes := []string{a.config.LDAPUsernameAttribute, a.config.LDAPEmailAttribute, a.config.LDAPNameAttribute, a.config.LDAPGroupAttribute}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		a.config.LDAPBaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		2,
+		int(Timeout.Seconds()),
+		false,
+		fmt.Sprintf(a.config.LDAPUserFilter, goldap.EscapeFilter(username)),
+		attributes,
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("%w: failed to search the user: %s", ErrUnavailable, err)
+	}
+
+	if result == nil || len(result.Entries) != 1 {
+		return nil, ErrUserNotFound
+	}
+
+	entry := result.Entries[0]
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, fmt.Errorf("%w: failed to bind the user: %s", ErrUnavailable, err)
+	}
+
+	identity := &Identity{
+		DN:       entry.DN,
+		Username: entry.GetEqualFoldAttributeValue(a.config.LDAPUsernameAttribute),
+		Email:    entry.GetEqualFoldAttributeValue(a.config.LDAPEmailAttribute),
+		Name:     entry.GetEqualFoldAttributeValue(a.config.LDAPNameAttribute),
+	}
+
+	for _, group := range entry.GetEqualFoldAttributeValues(a.config.LDAPGroupAttribute) {
+		if name := commonName(group); name != "" {
+			identity.Groups = append(identity.Groups, name)
+		}
+	}
+
+	return identity, nil
+}
+
+// connect dials the directory, upgrading the connection to TLS when it is required.
+func (a *Authenticator) connect(ctx context.Context) (*goldap.Conn, error) {
+	addr, err := url.Parse(a.config.LDAPURL)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{ServerName: addr.Hostname(), InsecureSkipVerify: a.config.LDAPInsecureSkipVerify} //nolint:gosec
+	dialer := &net.Dialer{Timeout: Timeout}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+
+	conn, err := goldap.DialURL(a.config.LDAPURL, goldap.DialWithDialer(dialer), goldap.DialWithTLSConfig(config))
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(Timeout)
+
+	if a.config.LDAPStartTLS && addr.Scheme != "ldaps" {
+		if err := conn.StartTLS(config); err != nil {
+			conn.Close()
+
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// commonName returns the value of the group's first attribute when it is a common name, or the value itself when it
+// is not a DN.
+func commonName(group string) string {
+	dn, err := goldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return group
+	}
+
+	attribute := dn.RDNs[0].Attributes[0]
+	if !strings.EqualFold(attribute.Type, "cn") {
+		return group
+	}
+
+	return attribute.Value
+}
@@ -0,0 +1,168 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/ldap/ldaptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDirectory() *ldaptest.Server {
+	return ldaptest.NewServer(
+		ldaptest.Entry{DN: "cn=shellhub,ou=services,dc=example,dc=com", Password: "service"},
+		ldaptest.Entry{
+			DN:       "uid=john,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"uid":      {"john"},
+				"mail":     {"john@example.com"},
+				"cn":       {"John Doe"},
+				"memberOf": {"cn=devs,ou=groups,dc=example,dc=com", "cn=ops,ou=groups,dc=example,dc=com"},
+			},
+		},
+		ldaptest.Entry{DN: "uid=twin,ou=people,dc=example,dc=com", Password: "secret", Attributes: map[string][]string{"uid": {"twin"}}},
+		ldaptest.Entry{DN: "uid=twin,ou=others,dc=example,dc=com", Password: "secret", Attributes: map[string][]string{"uid": {"twin"}}},
+	)
+}
+
+func TestNew(t *testing.T) {
+	authenticator, err := New(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, authenticator)
+
+	_, err = New(Config{LDAPURL: "ldap://localhost"})
+	assert.ErrorIs(t, err, ErrConfig)
+}
+
+func TestAuthenticate(t *testing.T) {
+	directory := newDirectory()
+	defer directory.Close()
+
+	config := Config{
+		LDAPURL:               directory.URL,
+		LDAPBindDN:            "cn=shellhub,ou=services,dc=example,dc=com",
+		LDAPBindPassword:      "service",
+		LDAPBaseDN:            "dc=example,dc=com",
+		LDAPUserFilter:        "(&(uid=%s)(uid=*))",
+		LDAPUsernameAttribute: "uid",
+		LDAPEmailAttribute:    "mail",
+		LDAPNameAttribute:     "cn",
+		LDAPGroupAttribute:    "memberOf",
+	}
+
+	cases := []struct {
+		description string
+		config      func(Config) Config
+		username    string
+		password    string
+		expected    *Identity
+		err         error
+	}{
+		{
+			description: "fails when the password is empty",
+			username:    "john",
+			password:    "",
+			err:         ErrInvalidCredentials,
+		},
+		{
+			description: "fails when the service account cannot bind",
+			config: func(c Config) Config {
+				c.LDAPBindPassword = "wrong"
+
+				return c
+			},
+			username: "john",
+			password: "secret",
+			err:      ErrUnavailable,
+		},
+		{
+			description: "fails when the directory is unreachable",
+			config: func(c Config) Config {
+				c.LDAPURL = "ldap://127.0.0.1:1"
+
+				return c
+			},
+			username: "john",
+			password: "secret",
+			err:      ErrUnavailable,
+		},
+		{
+			description: "fails when the user is not found",
+			username:    "jane",
+			password:    "secret",
+			err:         ErrUserNotFound,
+		},
+		{
+			description: "fails when the username matches many entries",
+			username:    "twin",
+			password:    "secret",
+			err:         ErrUserNotFound,
+		},
+		{
+			description: "fails when the username tries to change the filter",
+			username:    "*",
+			password:    "secret",
+			err:         ErrUserNotFound,
+		},
+		{
+			description: "fails when the password is wrong",
+			username:    "john",
+			password:    "wrong",
+			err:         ErrInvalidCredentials,
+		},
+		{
+			description: "authenticates the user with an anonymous search",
+			config: func(c Config) Config {
+				c.LDAPBindDN, c.LDAPBindPassword = "", ""
+
+				return c
+			},
+			username: "john",
+			password: "secret",
+			expected: &Identity{
+				DN:       "uid=john,ou=people,dc=example,dc=com",
+				Username: "john",
+				Email:    "john@example.com",
+				Name:     "John Doe",
+				Groups:   []string{"devs", "ops"},
+			},
+		},
+		{
+			description: "authenticates the user",
+			username:    "john",
+			password:    "secret",
+			expected: &Identity{
+				DN:       "uid=john,ou=people,dc=example,dc=com",
+				Username: "john",
+				Email:    "john@example.com",
+				Name:     "John Doe",
+				Groups:   []string{"devs", "ops"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := config
+			if tc.config != nil {
+				cfg = tc.config(cfg)
+			}
+
+			authenticator, err := New(cfg)
+			assert.NoError(t, err)
+
+			identity, err := authenticator.Authenticate(context.TODO(), tc.username, tc.password)
+			assert.True(t, errors.Is(err, tc.err), err)
+			assert.Equal(t, tc.expected, identity)
+		})
+	}
+}
+
+func TestCommonName(t *testing.T) {
+	assert.Equal(t, "devs", commonName("cn=devs,ou=groups,dc=example,dc=com"))
+	assert.Equal(t, "devs", commonName("CN=devs,OU=Groups,DC=example,DC=com"))
+	assert.Equal(t, "ou=groups,dc=example,dc=com", commonName("ou=groups,dc=example,dc=com"))
+	assert.Equal(t, "devs", commonName("devs"))
+}
@@ -0,0 +1,289 @@
+// Package ldaptest provides an in-memory LDAP directory, to test the LDAP authentication without a directory server.
+//
+// It supports the simple bind and the search of entries with equality, presence, and, or and not filters, which is
+// what the authentication needs.
+package ldaptest
+
+import (
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// LDAP's protocol operations, as defined by RFC 4511.
+const (
+	opBindRequest      = 0
+	opBindResponse     = 1
+	opUnbindRequest    = 2
+	opSearchRequest    = 3
+	opSearchEntry      = 4
+	opSearchDone       = 5
+	opExtendedResponse = 24
+)
+
+// LDAP's result codes used by the directory.
+const (
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+)
+
+// Entry is an entry of the directory. An entry with a password can be bound to.
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server is an in-memory LDAP directory listening on the loopback interface.
+type Server struct {
+	// URL is the directory's URL, as "ldap://127.0.0.1:port".
+	URL string
+
+	listener net.Listener
+	mu       sync.Mutex
+	entries  []Entry
+}
+
+// NewServer starts a directory with the entries. It must be closed after its use.
+func NewServer(entries ...Entry) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{URL: "ldap://" + listener.Addr().String(), listener: listener, entries: entries}
+
+	go s.serve()
+
+	return s
+}
+
+// Close stops the directory.
+func (s *Server) Close() {
+	s.listener.Close()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle answers the requests of a connection until it is unbound or closed.
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		id, ok := packet.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+
+		var responses []*ber.Packet
+
+		op := packet.Children[1]
+		switch op.Tag {
+		case opBindRequest:
+			responses = []*ber.Packet{s.bind(op)}
+		case opSearchRequest:
+			responses = s.search(op)
+		case opUnbindRequest:
+			return
+		default:
+			responses = []*ber.Packet{result(opExtendedResponse, resultProtocolError, "operation not supported")}
+		}
+
+		for _, response := range responses {
+			message := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+			message.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+			message.AppendChild(response)
+
+			if _, err := conn.Write(message.Bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// bind verifies the password of an entry. A bind without name and password is anonymous.
+func (s *Server) bind(op *ber.Packet) *ber.Packet {
+	if len(op.Children) < 3 {
+		return result(opBindResponse, resultProtocolError, "invalid bind request")
+	}
+
+	name, password := value(op.Children[1]), value(op.Children[2])
+	if name == "" && password == "" {
+		return result(opBindResponse, resultSuccess, "")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.DN, name) && entry.Password != "" && entry.Password == password {
+			return result(opBindResponse, resultSuccess, "")
+		}
+	}
+
+	return result(opBindResponse, resultInvalidCredentials, "invalid credentials")
+}
+
+// search returns the entries under the base object that match the filter, followed by the search's result.
+func (s *Server) search(op *ber.Packet) []*ber.Packet {
+	if len(op.Children) < 8 {
+		return []*ber.Packet{result(opSearchDone, resultProtocolError, "invalid search request")}
+	}
+
+	base := strings.ToLower(value(op.Children[0]))
+	limit, _ := op.Children[3].Value.(int64)
+	filter := op.Children[6]
+
+	var requested []string
+	for _, attribute := range op.Children[7].Children {
+		requested = append(requested, value(attribute))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var responses []*ber.Packet
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.DN), base) || !match(filter, entry) {
+			continue
+		}
+
+		if limit > 0 && int64(len(responses)) == limit {
+			return append(responses, result(opSearchDone, resultSizeLimitExceeded, "size limit exceeded"))
+		}
+
+		responses = append(responses, searchEntry(entry, requested))
+	}
+
+	return append(responses, result(opSearchDone, resultSuccess, ""))
+}
+
+// match checks if an entry matches a filter. The attributes' names and values are compared case-insensitively.
+func match(filter *ber.Packet, entry Entry) bool {
+	switch filter.Tag {
+	case 0: // and
+		for _, child := range filter.Children {
+			if !match(child, entry) {
+				return false
+			}
+		}
+
+		return true
+	case 1: // or
+		for _, child := range filter.Children {
+			if match(child, entry) {
+				return true
+			}
+		}
+
+		return false
+	case 2: // not
+		return len(filter.Children) == 1 && !match(filter.Children[0], entry)
+	case 3: // equality match
+		if len(filter.Children) != 2 {
+			return false
+		}
+
+		for _, v := range attribute(entry, value(filter.Children[0])) {
+			if strings.EqualFold(v, value(filter.Children[1])) {
+				return true
+			}
+		}
+
+		return false
+	case 7: // present
+		return len(attribute(entry, filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// attribute returns the values of an entry's attribute.
+func attribute(entry Entry, name string) []string {
+	for key, values := range entry.Attributes {
+		if strings.EqualFold(key, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+// searchEntry encodes an entry with the requested attributes, or all of them when none is requested.
+func searchEntry(entry Entry, requested []string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchEntry, nil, "Search Result Entry")
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range entry.Attributes {
+		if len(requested) > 0 && !contains(requested, name) {
+			continue
+		}
+
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+
+		attr.AppendChild(set)
+		attributes.AppendChild(attr)
+	}
+
+	op.AppendChild(attributes)
+
+	return op
+}
+
+// result encodes an operation's result.
+func result(tag ber.Tag, code int64, message string) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+
+	return op
+}
+
+// value returns the string of a primitive packet, whatever its class.
+func value(packet *ber.Packet) string {
+	if v, ok := packet.Value.(string); ok {
+		return v
+	}
+
+	if packet.Data != nil {
+		return packet.Data.String()
+	}
+
+	return ""
+}
+
+func contains(values []string, wanted string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, wanted) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,157 @@
+// Package mailer delivers the emails sent by the API, as the namespaces' invitations, through an SMTP server.
+//
+// Any SMTP server can be used, including a local sink, as Mailpit, to inspect the emails while developing.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// Config defines the SMTP server used to deliver the emails.
+type Config struct {
+	// SMTPHost is the host of the SMTP server. When it is empty, no email is delivered.
+	SMTPHost string `envconfig:"smtp_host"`
+	// SMTPPort is the port of the SMTP server.
+	SMTPPort int `envconfig:"smtp_port" default:"587"`
+	// SMTPUsername is the username used to authenticate on the SMTP server. When it is empty, no authentication is
+	// made.
+	SMTPUsername string `envconfig:"smtp_username"`
+	// SMTPPassword is the password used to authenticate on the SMTP server.
+	SMTPPassword string `envconfig:"smtp_password"`
+	// SMTPFrom is the address that sends the emails.
+	SMTPFrom string `envconfig:"smtp_from" default:"ShellHub <noreply@localhost>"`
+}
+
+// SMTP delivers emails through an SMTP server, upgrading the connection to TLS when the server supports it.
+type SMTP struct {
+	addr     string
+	host     string
+	from     *mail.Address
+	username string
+	password string
+}
+
+// New returns the SMTP mailer defined by the config, or nil when no SMTP server is defined.
+func New(cfg Config) (*SMTP, error) {
+	if cfg.SMTPHost == "" {
+		return nil, nil
+	}
+
+	from, err := mail.ParseAddress(cfg.SMTPFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	return &SMTP{
+		addr:     net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		host:     cfg.SMTPHost,
+		from:     from,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+	}, nil
+}
+
+// Send delivers a plain text email to an address.
+func (m *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	rcpt, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	message, err := m.message(rcpt, subject, body)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) //nolint:errcheck
+	} else {
+		conn.SetDeadline(time.Now().Add(time.Minute)) //nolint:errcheck
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.from.Address); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(rcpt.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// message builds the email's headers and its body, encoded as quoted-printable.
+func (m *SMTP) message(to *mail.Address, subject, body string) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&buffer, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buffer, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buffer.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buffer.WriteString("\r\n")
+
+	writer := quotedprintable.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sink is the email received by the SMTP sink.
+type sink struct {
+	from, to string
+	message  *mail.Message
+}
+
+// newSink starts a local SMTP server that accepts a single email, returning its port and a channel with the email.
+func newSink(t *testing.T) (int, <-chan sink) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan sink, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		text := textproto.NewConn(conn)
+
+		var email sink
+		text.PrintfLine("220 sink") //nolint:errcheck
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+				text.PrintfLine("250 sink") //nolint:errcheck
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				email.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				text.PrintfLine("250 OK") //nolint:errcheck
+			case strings.HasPrefix(command, "RCPT TO:"):
+				email.to = strings.Trim(line[len("RCPT TO:"):], "<>")
+				text.PrintfLine("250 OK") //nolint:errcheck
+			case command == "DATA":
+				text.PrintfLine("354 Go ahead") //nolint:errcheck
+
+				data, err := io.ReadAll(text.DotReader())
+				if err != nil {
+					return
+				}
+
+				email.message, _ = mail.ReadMessage(bufio.NewReader(strings.NewReader(string(data))))
+				text.PrintfLine("250 OK") //nolint:errcheck
+			case command == "QUIT":
+				text.PrintfLine("221 Bye") //nolint:errcheck
+				received <- email
+
+				return
+			default:
+				text.PrintfLine("502 Not implemented") //nolint:errcheck
+			}
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestNew(t *testing.T) {
+	mailer, err := New(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, mailer)
+
+	_, err = New(Config{SMTPHost: "localhost", SMTPPort: 25, SMTPFrom: "invalid"})
+	assert.Error(t, err)
+}
+
+func TestSMTPSend(t *testing.T) {
+	port, received := newSink(t)
+
+	mailer, err := New(Config{SMTPHost: "127.0.0.1", SMTPPort: port, SMTPFrom: "ShellHub <noreply@example.com>"})
+	assert.NoError(t, err)
+
+	err = mailer.Send(context.TODO(), "john@example.com", "Invitation to déjà vu", "Open the link:\nhttp://localhost/accept-invite?token=token\n")
+	assert.NoError(t, err)
+
+	email := <-received
+	assert.Equal(t, "noreply@example.com", email.from)
+	assert.Equal(t, "john@example.com", email.to)
+	assert.NotNil(t, email.message)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(email.message.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Invitation to déjà vu", subject)
+	assert.Equal(t, "<john@example.com>", email.message.Header.Get("To"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(email.message.Body))
+	assert.NoError(t, err)
+	assert.Equal(t, "Open the link:\nhttp://localhost/accept-invite?token=token\n", string(body))
+}
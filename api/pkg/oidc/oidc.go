@@ -0,0 +1,385 @@
+// Package oidc authenticates the users through an OpenID Connect provider, using the authorization code flow.
+//
+// The provider's endpoints and keys are discovered from its issuer, so any compliant provider can be used, including
+// the mock one from the oidctest package.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrDiscovery   = errors.New("failed to discover the provider")
+	ErrExchange    = errors.New("failed to exchange the authorization code")
+	ErrIDToken     = errors.New("invalid id token")
+	ErrKeyNotFound = errors.New("signing key not found")
+	ErrGroupRole   = errors.New("invalid group role")
+	ErrConfig      = errors.New("client id and redirect url are required")
+)
+
+// Config defines the OpenID Connect provider and the client registered on it.
+type Config struct {
+	// OIDCIssuer is the URL of the provider. When it is empty, the OpenID Connect login is disabled.
+	OIDCIssuer string `envconfig:"oidc_issuer"`
+	// OIDCClientID is the identifier of the client registered on the provider.
+	OIDCClientID string `envconfig:"oidc_client_id"`
+	// OIDCClientSecret is the secret of the client registered on the provider.
+	OIDCClientSecret string `envconfig:"oidc_client_secret"`
+	// OIDCRedirectURL is the page where the provider redirects the user back to, with the authorization code and the
+	// state as query parameters.
+	OIDCRedirectURL string `envconfig:"oidc_redirect_url" default:"http://localhost/login/oidc"`
+	// OIDCScopes are the scopes requested to the provider.
+	OIDCScopes []string `envconfig:"oidc_scopes" default:"openid,email,profile"`
+	// OIDCGroupsClaim is the ID token's claim with the user's groups.
+	OIDCGroupsClaim string `envconfig:"oidc_groups_claim" default:"groups"`
+	// OIDCGroupRoles maps the provider's groups to namespaces' members, as "group:tenant:role" entries. See
+	// guard.ParseGroupRoles.
+	OIDCGroupRoles []string `envconfig:"oidc_group_roles"`
+}
+
+// Identity is the user authenticated by the provider.
+type Identity struct {
+	Subject string
+	Email   string
+	// EmailVerified is false only when the provider states that the email is not verified.
+	EmailVerified bool
+	Name          string
+	Username      string
+	Groups        []string
+}
+
+// discovery is the provider's metadata relevant to the authorization code flow.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is a client of an OpenID Connect provider.
+type Provider struct {
+	config Config
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]interface{}
+}
+
+// New returns the provider defined by the config, or nil when no issuer is defined. The provider is discovered on its
+// first use, so it does not need to be reachable when the API starts.
+func New(cfg Config) (*Provider, error) {
+	if cfg.OIDCIssuer == "" {
+		return nil, nil
+	}
+
+	if cfg.OIDCClientID == "" || cfg.OIDCRedirectURL == "" {
+		return nil, ErrConfig
+	}
+
+	cfg.OIDCIssuer = strings.TrimSuffix(cfg.OIDCIssuer, "/")
+
+	return &Provider{config: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// AuthCodeURL returns the provider's URL where the user is authenticated. The state is sent back to the redirect URL
+// and the nonce is bound to the ID token.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.config.OIDCClientID},
+		"redirect_uri":  {p.config.OIDCRedirectURL},
+		"scope":         {strings.Join(p.config.OIDCScopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+
+	separator := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return d.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange exchanges an authorization code for the ID token, returning the identity on it when the token is valid and
+// bound to the nonce.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.config.OIDCRedirectURL},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.config.OIDCClientID), url.QueryEscape(p.config.OIDCClientSecret))
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrExchange, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+
+		return nil, fmt.Errorf("%w: %s %s", ErrExchange, res.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&token); err != nil || token.IDToken == "" {
+		return nil, fmt.Errorf("%w: the response has no id token", ErrExchange)
+	}
+
+	return p.verify(ctx, d, token.IDToken, nonce)
+}
+
+// verify verifies the ID token's signature, issuer, audience, expiration and nonce, returning the identity on it.
+func (p *Provider) verify(ctx context.Context, d *discovery, raw, nonce string) (*Identity, error) {
+	claims := jwt.MapClaims{}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}))
+	if _, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		return p.key(ctx, d, kid)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrIDToken, err)
+	}
+
+	now := jwt.TimeFunc().Unix()
+	switch {
+	case !claims.VerifyIssuer(d.Issuer, true):
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrIDToken)
+	case !claims.VerifyAudience(p.config.OIDCClientID, true):
+		return nil, fmt.Errorf("%w: unexpected audience", ErrIDToken)
+	case !claims.VerifyExpiresAt(now, true):
+		return nil, fmt.Errorf("%w: token is expired", ErrIDToken)
+	case claims["nonce"] != nonce:
+		return nil, fmt.Errorf("%w: unexpected nonce", ErrIDToken)
+	}
+
+	identity := &Identity{EmailVerified: true}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	identity.Username, _ = claims["preferred_username"].(string)
+
+	// Some providers send the email_verified claim as a string.
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	switch groups := claims[p.config.OIDCGroupsClaim].(type) {
+	case []interface{}:
+		for _, group := range groups {
+			if group, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, group)
+			}
+		}
+	case string:
+		identity.Groups = []string{groups}
+	}
+
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("%w: the token has no subject", ErrIDToken)
+	}
+
+	return identity, nil
+}
+
+// discover fetches the provider's metadata, caching it once it is valid.
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d discovery
+	if err := p.get(ctx, p.config.OIDCIssuer+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDiscovery, err)
+	}
+
+	if strings.TrimSuffix(d.Issuer, "/") != p.config.OIDCIssuer {
+		return nil, fmt.Errorf("%w: issuer %q does not match %q", ErrDiscovery, d.Issuer, p.config.OIDCIssuer)
+	}
+
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: incomplete metadata", ErrDiscovery)
+	}
+
+	p.discovery = &d
+
+	return p.discovery, nil
+}
+
+// key returns the provider's key identified by kid. The keys are fetched again when it is not known, as the provider
+// may have rotated them.
+func (p *Provider) key(ctx context.Context, d *discovery, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := lookup(p.keys, kid); ok {
+		return key, nil
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := p.get(ctx, d.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+
+	p.keys = make(map[string]interface{})
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		if key, err := k.public(); err == nil {
+			p.keys[k.Kid] = key
+		}
+	}
+
+	if key, ok := lookup(p.keys, kid); ok {
+		return key, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// lookup finds a key by its kid. A token without kid is accepted only when the provider has a single key.
+func lookup(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+
+	key, ok := keys[kid]
+
+	return key, ok
+}
+
+// get decodes the JSON returned by a provider's endpoint.
+func (p *Provider) get(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", res.Status, endpoint)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// jwk is a public key from the provider's JSON Web Key Set.
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// public decodes the RSA or ECDSA public key.
+func (k jwk) public() (interface{}, error) {
+	decode := func(value string) (*big.Int, error) {
+		data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+		if err != nil {
+			return nil, err
+		}
+
+		return new(big.Int).SetBytes(data), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
@@ -0,0 +1,233 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProvider(t *testing.T, server *oidctest.Server) *Provider {
+	t.Helper()
+
+	provider, err := New(Config{
+		OIDCIssuer:       server.URL,
+		OIDCClientID:     server.ClientID,
+		OIDCClientSecret: server.ClientSecret,
+		OIDCRedirectURL:  "http://localhost/login/oidc",
+		OIDCScopes:       []string{"openid", "email"},
+		OIDCGroupsClaim:  "groups",
+	})
+	assert.NoError(t, err)
+
+	return provider
+}
+
+func TestNew(t *testing.T) {
+	provider, err := New(Config{})
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = New(Config{OIDCIssuer: "http://localhost", OIDCRedirectURL: "http://localhost/login/oidc"})
+	assert.ErrorIs(t, err, ErrConfig)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	server := oidctest.NewServer("shellhub", "secret")
+	defer server.Close()
+
+	server.Claims["name"] = "John Doe"
+	server.Claims["preferred_username"] = "john"
+	server.Claims["groups"] = []string{"devs", "ops"}
+
+	provider := newProvider(t, server)
+	ctx := context.TODO()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce")
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "openid email", parsed.Query().Get("scope"))
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	res, err := client.Get(authURL)
+	assert.NoError(t, err)
+	res.Body.Close()
+
+	redirect, err := url.Parse(res.Header.Get("Location"))
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", redirect.Host)
+	assert.Equal(t, "state", redirect.Query().Get("state"))
+
+	identity, err := provider.Exchange(ctx, redirect.Query().Get("code"), "nonce")
+	assert.NoError(t, err)
+	assert.Equal(t, &Identity{
+		Subject:       "user",
+		Email:         "john@example.com",
+		EmailVerified: true,
+		Name:          "John Doe",
+		Username:      "john",
+		Groups:        []string{"devs", "ops"},
+	}, identity)
+
+	// The code is used only once.
+	_, err = provider.Exchange(ctx, redirect.Query().Get("code"), "nonce")
+	assert.ErrorIs(t, err, ErrExchange)
+}
+
+func TestExchange(t *testing.T) {
+	server := oidctest.NewServer("shellhub", "secret")
+	defer server.Close()
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description string
+		secret      string
+		nonce       string
+		claims      map[string]interface{}
+		err         error
+	}{
+		{
+			description: "fails when the client's secret is wrong",
+			secret:      "wrong",
+			nonce:       "nonce",
+			err:         ErrExchange,
+		},
+		{
+			description: "fails when the nonce does not match",
+			secret:      "secret",
+			nonce:       "other",
+			err:         ErrIDToken,
+		},
+		{
+			description: "reports an email that is not verified",
+			secret:      "secret",
+			nonce:       "nonce",
+			claims:      map[string]interface{}{"email_verified": "false", "groups": "devs"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			for key, value := range tc.claims {
+				server.Claims[key] = value
+			}
+
+			provider := newProvider(t, server)
+			provider.config.OIDCClientSecret = tc.secret
+
+			identity, err := provider.Exchange(ctx, server.Authorize("nonce"), tc.nonce)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.False(t, identity.EmailVerified)
+			assert.Equal(t, []string{"devs"}, identity.Groups)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	server := oidctest.NewServer("shellhub", "secret")
+	defer server.Close()
+
+	provider := newProvider(t, server)
+	ctx := context.TODO()
+
+	d, err := provider.discover(ctx)
+	assert.NoError(t, err)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{"iss": server.URL, "aud": "shellhub", "sub": "user", "nonce": "nonce", "exp": time.Now().Add(time.Minute).Unix()}
+	}
+
+	cases := []struct {
+		description string
+		token       func() string
+		err         error
+	}{
+		{
+			description: "accepts a valid token",
+			token:       func() string { return server.Sign(valid()) },
+		},
+		{
+			description: "fails when the issuer is another",
+			token: func() string {
+				claims := valid()
+				claims["iss"] = "http://localhost"
+
+				return server.Sign(claims)
+			},
+			err: ErrIDToken,
+		},
+		{
+			description: "fails when the audience is another client",
+			token: func() string {
+				claims := valid()
+				claims["aud"] = []string{"other"}
+
+				return server.Sign(claims)
+			},
+			err: ErrIDToken,
+		},
+		{
+			description: "fails when the token is expired",
+			token: func() string {
+				claims := valid()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+				return server.Sign(claims)
+			},
+			err: ErrIDToken,
+		},
+		{
+			description: "fails when the token has no expiration",
+			token: func() string {
+				claims := valid()
+				delete(claims, "exp")
+
+				return server.Sign(claims)
+			},
+			err: ErrIDToken,
+		},
+		{
+			description: "fails when the token is not signed by the provider",
+			token: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, valid())
+				token.Header["kid"] = oidctest.KeyID
+
+				signed, _ := token.SignedString([]byte("secret"))
+
+				return signed
+			},
+			err: ErrIDToken,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			identity, err := provider.verify(ctx, d, tc.token(), "nonce")
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err), err)
+				assert.Nil(t, identity)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "user", identity.Subject)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+// Package oidctest provides a mock OpenID Connect provider, which authenticates a configurable identity without any
+// user interaction, to test the login through OpenID Connect.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+// KeyID is the kid of the key that signs the ID tokens.
+const KeyID = "oidctest"
+
+// Server is a mock OpenID Connect provider with a single client registered.
+type Server struct {
+	*httptest.Server
+
+	ClientID     string
+	ClientSecret string
+	// Claims are the claims of the authenticated user, added to the ID tokens next to the registered ones.
+	Claims map[string]interface{}
+
+	key   *rsa.PrivateKey
+	mu    sync.Mutex
+	codes map[string]map[string]interface{}
+}
+
+// NewServer starts a mock provider with the client registered. It must be closed after its use.
+func NewServer(clientID, clientSecret string) *Server {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Claims:       map[string]interface{}{"sub": "user", "email": "john@example.com", "email_verified": true},
+		key:          key,
+		codes:        make(map[string]map[string]interface{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/keys", s.keys)
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Authorize authenticates the user with the current claims, returning the authorization code that the provider would
+// send to the client's redirect URL.
+func (s *Server) Authorize(nonce string) string {
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+
+	code := hex.EncodeToString(secret)
+
+	claims := map[string]interface{}{"nonce": nonce}
+	for key, value := range s.Claims {
+		claims[key] = value
+	}
+
+	s.mu.Lock()
+	s.codes[code] = claims
+	s.mu.Unlock()
+
+	return code
+}
+
+// Sign signs an ID token with the provider's key, so tokens with arbitrary claims can be tested.
+func (s *Server) Sign(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = KeyID
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+
+	return signed
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/keys",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+// authorize authenticates the user immediately, redirecting back to the client with the code and the state.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+
+		return
+	}
+
+	values := redirect.Query()
+	values.Set("code", s.Authorize(query.Get("nonce")))
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// token exchanges a code, which can be used only once, for the ID token.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok {
+		id, secret = r.PostFormValue("client_id"), r.PostFormValue("client_secret")
+	}
+
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+
+	if id != s.ClientID || secret != s.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+
+		return
+	}
+
+	code := r.PostFormValue("code")
+
+	s.mu.Lock()
+	claims, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	if r.PostFormValue("grant_type") != "authorization_code" || !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+		return
+	}
+
+	now := time.Now()
+
+	token := jwt.MapClaims{"iss": s.URL, "aud": s.ClientID, "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}
+	for key, value := range claims {
+		token[key] = value
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": code,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     s.Sign(token),
+	})
+}
+
+func (s *Server) keys(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": KeyID,
+				"kty": "RSA",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
@@ -0,0 +1,113 @@
+// Package totp implements the time-based one-time passwords of RFC 6238, as generated by the authenticator apps.
+//
+// The codes have 6 digits, change every 30 seconds and are derived from the secret with HMAC-SHA1, the defaults
+// supported by every authenticator app.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the number of digits of a code.
+	Digits = 6
+	// Period is the time that a code is valid.
+	Period = 30 * time.Second
+	// Skew is the number of periods, before and after the current one, whose codes are also accepted to tolerate the
+	// clock's drift between the server and the authenticator.
+	Skew = 1
+)
+
+// encoding is the base32 encoding of the secrets, without padding as expected by the authenticator apps.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret encoded as base32.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// Code returns the code of a secret at a time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	return code(key, uint64(t.Unix())/uint64(Period.Seconds())), nil
+}
+
+// Validate checks if a code is the code of the secret at a time, tolerating the Skew.
+func Validate(secret, passcode string, t time.Time) bool {
+	_, ok := Counter(secret, passcode, t)
+
+	return ok
+}
+
+// Counter returns the counter, the number of periods since the Unix epoch, of the code of the secret at a time,
+// tolerating the Skew. As a code is valid for more than one period, the counter of the last accepted code is kept to
+// reject the codes that were already used. It returns false when the code is not valid.
+func Counter(secret, passcode string, t time.Time) (uint64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(passcode) != Digits {
+		return 0, false
+	}
+
+	counter := uint64(t.Unix()) / uint64(Period.Seconds())
+	for i := -Skew; i <= Skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(code(key, counter+uint64(i))), []byte(passcode)) == 1 {
+			return counter + uint64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns the provisioning URI of a secret, which is encoded as a QR code to be scanned by the authenticator apps.
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(Digits))
+	query.Set("period", strconv.Itoa(int(Period.Seconds())))
+
+	link := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+
+	return link.String()
+}
+
+// code computes the HOTP value, from RFC 4226, of a key and a counter.
+func code(key []byte, counter uint64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%uint32(math.Pow10(Digits)))
+}
@@ -0,0 +1,86 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret is the secret of the RFC 6238's test vectors, "12345678901234567890", encoded as base32.
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The RFC 6238's test vectors for SHA1, truncated to 6 digits.
+	cases := []struct {
+		time     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tc := range cases {
+		code, err := Code(secret, time.Unix(tc.time, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, code)
+	}
+
+	_, err := Code("invalid secret!", time.Unix(59, 0))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	assert.True(t, Validate(secret, "050471", now))
+	assert.True(t, Validate(secret, "050471", now.Add(Period)))
+	assert.True(t, Validate(secret, "050471", now.Add(-Period)))
+	assert.False(t, Validate(secret, "050471", now.Add(2*Period)))
+	assert.False(t, Validate(secret, "050472", now))
+	assert.False(t, Validate(secret, "50471", now))
+	assert.False(t, Validate("invalid secret!", "050471", now))
+}
+
+func TestCounter(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	counter := uint64(now.Unix()) / uint64(Period.Seconds())
+
+	value, ok := Counter(secret, "050471", now)
+	assert.True(t, ok)
+	assert.Equal(t, counter, value)
+
+	value, ok = Counter(secret, "050471", now.Add(Period))
+	assert.True(t, ok)
+	assert.Equal(t, counter, value)
+
+	_, ok = Counter(secret, "050472", now)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	generated, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, generated, 32)
+
+	code, err := Code(generated, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, Validate(generated, code, time.Now()))
+}
+
+func TestURI(t *testing.T) {
+	uri, err := url.Parse(URI("ShellHub", "john", secret))
+	assert.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/ShellHub:john", uri.Path)
+	assert.Equal(t, secret, uri.Query().Get("secret"))
+	assert.Equal(t, "ShellHub", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+	assert.Equal(t, "30", uri.Query().Get("period"))
+}
@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetAccessGrantsURL   = "/access-grants"
+	CreateAccessGrantURL = "/access-grants"
+	GetAccessGrantURL    = "/access-grants/:id"
+	RevokeAccessGrantURL = "/access-grants/:id"
+)
+
+func (h *Handler) GetAccessGrants(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	grants, count, err := h.service.ListAccessGrants(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, grants)
+}
+
+func (h *Handler) CreateAccessGrant(c gateway.Context) error {
+	var req requests.AccessGrantCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var grant *models.AccessGrant
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Grant.Create, func() error {
+		var err error
+		grant, err = h.service.CreateAccessGrant(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, grant)
+}
+
+func (h *Handler) GetAccessGrant(c gateway.Context) error {
+	var req requests.AccessGrantGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	grant, err := h.service.GetAccessGrant(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, grant)
+}
+
+func (h *Handler) RevokeAccessGrant(c gateway.Context) error {
+	var req requests.AccessGrantRevoke
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Grant.Revoke, func() error {
+		return h.service.RevokeAccessGrant(c.Ctx(), tenant, req.ID)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,226 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetAccessRequestsURL         = "/access-requests"
+	CreateAccessRequestURL       = "/access-requests"
+	GetAccessRequestURL          = "/access-requests/:id"
+	ApproveAccessRequestURL      = "/access-requests/:id/approve"
+	DenyAccessRequestURL         = "/access-requests/:id/deny"
+	EditNamespaceApprovalTagsURL = "/namespaces/:tenant/approval-tags"
+	EvaluateAccessRequestURL     = "/access-requests/evaluate"
+	GetAccessRequestInternalURL  = "/access-requests/:tenant/:id"
+)
+
+type accessRequestQuery struct {
+	requests.AccessRequestList
+	paginator.Query
+}
+
+func (h *Handler) GetAccessRequests(c gateway.Context) error {
+	query := accessRequestQuery{}
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&query.AccessRequestList); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	list, count, err := h.service.ListAccessRequests(c.Ctx(), tenant, models.AccessRequestStatus(query.Status), query.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, list)
+}
+
+func (h *Handler) CreateAccessRequest(c gateway.Context) error {
+	var req requests.AccessRequestCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var member string
+	if id := c.ID(); id != nil {
+		member = id.ID
+	}
+
+	var request *models.AccessRequest
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		request, err = h.service.CreateAccessRequest(c.Ctx(), tenant, member, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) GetAccessRequest(c gateway.Context) error {
+	var req requests.AccessRequestGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	request, err := h.service.GetAccessRequest(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) ApproveAccessRequest(c gateway.Context) error {
+	return h.decideAccessRequest(c, h.service.ApproveAccessRequest)
+}
+
+func (h *Handler) DenyAccessRequest(c gateway.Context) error {
+	return h.decideAccessRequest(c, h.service.DenyAccessRequest)
+}
+
+// decideAccessRequest approves or denies an access request through the decide function, on behalf of the authenticated
+// user.
+func (h *Handler) decideAccessRequest(c gateway.Context, decide func(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error)) error {
+	var req requests.AccessRequestDecide
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var userID string
+	if id := c.ID(); id != nil {
+		userID = id.ID
+	}
+
+	var request *models.AccessRequest
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Request.Decide, func() error {
+		var err error
+		request, err = decide(c.Ctx(), tenant, req.ID, userID)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+func (h *Handler) EditNamespaceApprovalTags(c gateway.Context) error {
+	var req requests.NamespaceEditApprovalTags
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.ApprovalTags, func() error {
+		return h.service.EditNamespaceApprovalTags(c.Ctx(), ns.TenantID, req.Tags)
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EvaluateAccessRequest is used by the SSH server to check if a connection must wait for an access request to be
+// approved. It responds null when the connection can go on.
+func (h *Handler) EvaluateAccessRequest(c gateway.Context) error {
+	var req requests.AccessRequestEvaluate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	request, err := h.service.EvaluateAccessRequest(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
+
+// GetAccessRequestInternal is used by the SSH server to follow the access request of a held connection.
+func (h *Handler) GetAccessRequestInternal(c gateway.Context) error {
+	var req requests.AccessRequestGetInternal
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	request, err := h.service.GetAccessRequest(c.Ctx(), req.Tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, request)
+}
@@ -0,0 +1,103 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+)
+
+const (
+	GetAPIKeysURL   = "/apikeys"
+	CreateAPIKeyURL = "/apikeys"
+	DeleteAPIKeyURL = "/apikeys/:id"
+)
+
+// APIKeyHeader is the header used to authenticate a request with an API key instead of a JWT.
+const APIKeyHeader = "X-API-Key"
+
+func (h *Handler) GetAPIKeys(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	keys, count, err := h.service.ListAPIKeys(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (h *Handler) CreateAPIKey(c gateway.Context) error {
+	var req requests.APIKeyCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var id string
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	var res *responses.APIKeyCreate
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.Create, func() error {
+		var err error
+		res, err = h.service.CreateAPIKey(c.Ctx(), tenant, id, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteAPIKey(c gateway.Context) error {
+	var req requests.APIKeyDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.Remove, func() error {
+		return h.service.DeleteAPIKey(c.Ctx(), tenant, req.ID)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetAuditLogsURL = "/audit"
+)
+
+type auditQuery struct {
+	Filter string `query:"filter"`
+	paginator.Query
+}
+
+func (h *Handler) GetAuditLogs(c gateway.Context) error {
+	query := auditQuery{}
+	if err := c.Bind(&query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	raw, err := base64.StdEncoding.DecodeString(query.Filter)
+	if err != nil {
+		return err
+	}
+
+	var filter []models.Filter
+	if err := json.Unmarshal(raw, &filter); len(raw) > 0 && err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var logs []models.AuditLog
+	var count int
+
+	err = guard.EvaluatePermission(c.Role(), guard.Actions.Namespace.AuditLog, func() error {
+		var err error
+		logs, count, err = h.service.ListAuditLogs(c.Ctx(), tenant, query.Query, filter)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, logs)
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	jwt "github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
@@ -13,6 +14,7 @@ import (
 	svc "github.com/shellhub-io/shellhub/api/services"
 	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	pkgerrors "github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -24,9 +26,25 @@ const (
 	AuthUserURLV2    = "/auth/user"
 	AuthUserTokenURL = "/auth/token/:tenant" //nolint:gosec
 	AuthPublicKeyURL = "/auth/ssh"
+	AuthUnlockURL    = "/auth/unlock"
 )
 
 func (h *Handler) AuthRequest(c gateway.Context) error {
+	// API keys are accepted next to the JWTs, acting as the namespace's member who created them.
+	if key := c.Request().Header.Get(APIKeyHeader); key != "" {
+		claims, err := h.service.AuthAPIKey(c.Ctx(), key)
+		if err != nil {
+			return err
+		}
+
+		c.Response().Header().Set("X-Tenant-ID", claims.Tenant)
+		c.Response().Header().Set("X-Username", claims.Username)
+		c.Response().Header().Set("X-ID", claims.ID)
+		c.Response().Header().Set("X-Role", claims.Role)
+
+		return c.NoContent(http.StatusOK)
+	}
+
 	token, ok := c.Get(middleware.DefaultJWTConfig.ContextKey).(*jwt.Token)
 	if !ok {
 		return svc.ErrTypeAssertion
@@ -112,6 +130,14 @@ func (h *Handler) AuthUser(c gateway.Context) error {
 
 	res, err := h.service.AuthUser(c.Ctx(), req)
 	if err != nil {
+		// A login locked out tells when it can be tried again.
+		var locked pkgerrors.Error
+		if errors.As(err, &locked) {
+			if data, ok := locked.Data.(svc.ErrDataLocked); ok {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(data.RetryAfter))
+			}
+		}
+
 		if errors.Is(err, svc.ErrUserNotFound) {
 			return errs.NewErrUnauthorized(err)
 		}
@@ -122,6 +148,25 @@ func (h *Handler) AuthUser(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+// AuthUnlock unlocks the logins locked out after too many failed attempts. It is used by the administrators, through
+// the CLI.
+func (h *Handler) AuthUnlock(c gateway.Context) error {
+	var req requests.AuthUnlock
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.AuthUnlock(c.Ctx(), req.Username, req.IP); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) AuthUserInfo(c gateway.Context) error {
 	username := c.Request().Header.Get("X-Username")
 	tenant := c.Request().Header.Get("X-Tenant-ID")
@@ -196,6 +241,11 @@ func (h *Handler) AuthPublicKey(c gateway.Context) error {
 
 func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		// A request authenticated by an API key has no JWT to be parsed.
+		if c.Request().Header.Get(APIKeyHeader) != "" {
+			return next(c)
+		}
+
 		ctx, ok := c.Get("ctx").(*gateway.Context)
 		if !ok {
 			return svc.ErrTypeAssertion
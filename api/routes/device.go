@@ -8,8 +8,10 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -26,6 +28,17 @@ const (
 	UpdateTagURL       = "/devices/:uid/tags"      // Update device's tags with a new set.
 	RemoveTagURL       = "/devices/:uid/tags/:tag" // Delete a tag from a device.
 	UpdateDevice       = "/devices/:uid"
+
+	SetDeviceAttributeURL     = "/devices/:uid/attributes/:key" // Set the value of a device's attribute.
+	RemoveDeviceAttributeURL  = "/devices/:uid/attributes/:key" // Delete an attribute from a device.
+	UpdateDeviceAttributesURL = "/devices/:uid/attributes"      // Update device's attributes with a new set.
+
+	RotateDeviceHostKeyURL         = "/devices/:uid/host-key"          // Pin a new host key to a device.
+	ReportDeviceHostKeyMismatchURL = "/devices/:uid/host-key/mismatch" // Report a host key other than the pinned one.
+
+	EvaluateDeviceScopeURL = "/devices/:uid/scope" // Check if a connection to a device is in its member's scope.
+
+	BulkDevicesURL = "/devices/bulk" // Apply an action to a batch of devices.
 )
 
 const (
@@ -60,6 +73,11 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 		return err
 	}
 
+	// The stores skip the unknown operators, joining the properties around them, so they are not accepted.
+	if err := models.ValidateFilter(filter); err != nil {
+		return services.NewErrDeviceFilterInvalid(query.Filter, err)
+	}
+
 	var tenant string
 	if c.Tenant() != nil {
 		tenant = c.Tenant().ID
@@ -112,7 +130,7 @@ func (h *Handler) DeleteDevice(c gateway.Context) error {
 		err := h.service.DeleteDevice(c.Ctx(), models.UID(req.UID), tenant)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -139,7 +157,7 @@ func (h *Handler) RenameDevice(c gateway.Context) error {
 		err := h.service.RenameDevice(c.Ctx(), models.UID(req.UID), req.Name, tenant)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -179,6 +197,12 @@ func (h *Handler) LookupDevice(c gateway.Context) error {
 		return err
 	}
 
+	if req.Member != "" {
+		if err := h.service.CheckMemberScope(c.Ctx(), req.Member, device); err != nil {
+			return err
+		}
+	}
+
 	return c.JSON(http.StatusOK, device)
 }
 
@@ -207,7 +231,7 @@ func (h *Handler) UpdatePendingStatus(c gateway.Context) error {
 		err := h.service.UpdatePendingStatus(c.Ctx(), models.UID(req.UID), models.DeviceStatus(status[req.Status]), tenant)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -240,7 +264,7 @@ func (h *Handler) CreateDeviceTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.CreateTag, func() error {
 		return h.service.CreateDeviceTag(c.Ctx(), models.UID(req.UID), req.Tag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -260,7 +284,7 @@ func (h *Handler) RemoveDeviceTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RemoveTag, func() error {
 		return h.service.RemoveDeviceTag(c.Ctx(), models.UID(req.UID), req.Tag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -280,7 +304,32 @@ func (h *Handler) UpdateDeviceTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.UpdateTag, func() error {
 		return h.service.UpdateDeviceTag(c.Ctx(), models.UID(req.UID), req.Tags)
-	})
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) SetDeviceAttribute(c gateway.Context) error {
+	var req requests.DeviceSetAttribute
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.SetAttribute, func() error {
+		return h.service.SetDeviceAttribute(c.Ctx(), tenant, models.UID(req.UID), req.Key, req.Value)
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -288,6 +337,117 @@ func (h *Handler) UpdateDeviceTag(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) RemoveDeviceAttribute(c gateway.Context) error {
+	var req requests.DeviceRemoveAttribute
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RemoveAttribute, func() error {
+		return h.service.RemoveDeviceAttribute(c.Ctx(), tenant, models.UID(req.UID), req.Key)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UpdateDeviceAttributes(c gateway.Context) error {
+	var req requests.DeviceUpdateAttributes
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.UpdateAttributes, func() error {
+		return h.service.UpdateDeviceAttributes(c.Ctx(), tenant, models.UID(req.UID), req.Attributes)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RotateDeviceHostKey(c gateway.Context) error {
+	var req requests.DeviceRotateHostKey
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RotateHostKey, func() error {
+		return h.service.RotateDeviceHostKey(c.Ctx(), tenant, models.UID(req.UID), req.HostKey)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ReportDeviceHostKeyMismatch is used by the SSH server to alert that a device presented a host key other than the
+// one pinned to it.
+func (h *Handler) ReportDeviceHostKeyMismatch(c gateway.Context) error {
+	var req requests.DeviceReportHostKeyMismatch
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ReportDeviceHostKeyMismatch(c.Ctx(), models.UID(req.UID), req.HostKey); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EvaluateDeviceScope(c gateway.Context) error {
+	var req requests.DeviceEvaluateScope
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateConnectionScope(c.Ctx(), models.UID(req.UID), req.Member, req.Fingerprint); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) UpdateDevice(c gateway.Context) error {
 	var req requests.DeviceUpdate
 	if err := c.Bind(&req); err != nil {
@@ -305,9 +465,53 @@ func (h *Handler) UpdateDevice(c gateway.Context) error {
 
 	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
 		return h.service.UpdateDevice(c.Ctx(), tenant, models.UID(req.UID), req.Name, req.PublicURL)
-	}); err != nil {
+	}, h.customRoles(c)...); err != nil {
 		return err
 	}
 
 	return c.NoContent(http.StatusOK)
 }
+
+// deviceBulkActions maps the actions of a bulk operation to the guard's action required by them.
+var deviceBulkActions = map[string]int{
+	services.DeviceBulkActionAccept:    guard.Actions.Device.Accept,
+	services.DeviceBulkActionReject:    guard.Actions.Device.Reject,
+	services.DeviceBulkActionRemove:    guard.Actions.Device.Remove,
+	services.DeviceBulkActionAddTag:    guard.Actions.Device.CreateTag,
+	services.DeviceBulkActionRemoveTag: guard.Actions.Device.RemoveTag,
+	services.DeviceBulkActionRename:    guard.Actions.Device.Rename,
+}
+
+func (h *Handler) BulkDevices(c gateway.Context) error {
+	var req requests.DeviceBulk
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	action, ok := deviceBulkActions[req.Action]
+	if !ok {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	var res *responses.DeviceBulk
+	err := guard.EvaluatePermission(c.Role(), action, func() error {
+		var err error
+		res, err = h.service.BulkDevices(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
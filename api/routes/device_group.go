@@ -0,0 +1,182 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetDeviceGroupsURL       = "/groups"
+	CreateDeviceGroupURL     = "/groups"
+	GetDeviceGroupURL        = "/groups/:id"
+	UpdateDeviceGroupURL     = "/groups/:id"
+	DeleteDeviceGroupURL     = "/groups/:id"
+	GetDeviceGroupDevicesURL = "/groups/:id/devices"
+)
+
+func (h *Handler) GetDeviceGroups(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	groups, count, err := h.service.ListDeviceGroups(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *Handler) CreateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Group.Create, func() error {
+		var err error
+		group, err = h.service.CreateDeviceGroup(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) GetDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	group, err := h.service.GetDeviceGroup(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) UpdateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Group.Edit, func() error {
+		var err error
+		group, err = h.service.UpdateDeviceGroup(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) DeleteDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Group.Remove, func() error {
+		return h.service.DeleteDeviceGroup(c.Ctx(), tenant, req.ID)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GetDeviceGroupDevices(c gateway.Context) error {
+	var req requests.DeviceGroupDevicesList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	devices, count, err := h.service.ListDeviceGroupDevices(c.Ctx(), tenant, req.ID, *query, models.DeviceStatus(req.Status), req.SortBy, req.OrderBy)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, devices)
+}
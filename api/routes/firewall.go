@@ -0,0 +1,242 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetFirewallRulesURL       = "/firewall/rules"
+	GetFirewallRuleURL        = "/firewall/rules/:id"
+	CreateFirewallRuleURL     = "/firewall/rules"
+	UpdateFirewallRuleURL     = "/firewall/rules/:id"
+	DeleteFirewallRuleURL     = "/firewall/rules/:id"
+	EvaluateFirewallURL       = "/firewall/rules/evaluate"
+	AddFirewallRuleTagURL     = "/firewall/rules/:id/tags"      // Add a tag to a firewall rule.
+	RemoveFirewallRuleTagURL  = "/firewall/rules/:id/tags/:tag" // Remove a tag from a firewall rule.
+	UpdateFirewallRuleTagsURL = "/firewall/rules/:id/tags"      // Update all tags from a firewall rule.
+)
+
+func (h *Handler) GetFirewallRules(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	rules, count, err := h.service.ListFirewallRules(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	rule, err := h.service.GetFirewallRule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Create, func() error {
+		var err error
+		rule, err = h.service.CreateFirewallRule(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var rule *models.FirewallRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Edit, func() error {
+		var err error
+		rule, err = h.service.UpdateFirewallRule(c.Ctx(), tenant, req.ID, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteFirewallRule(c gateway.Context) error {
+	var req requests.FirewallRuleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.Remove, func() error {
+		return h.service.DeleteFirewallRule(c.Ctx(), tenant, req.ID)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EvaluateFirewall is the internal endpoint used by the SSH server to check if a connection is allowed by the firewall
+// rules of the device's namespace. It responds with HTTP status 200 when the connection is allowed.
+func (h *Handler) EvaluateFirewall(c gateway.Context) error {
+	var req requests.FirewallEvaluate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.EvaluateFirewall(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) AddFirewallRuleTag(c gateway.Context) error {
+	var req requests.FirewallRuleTagAdd
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.AddTag, func() error {
+		return h.service.AddFirewallRuleTag(c.Ctx(), tenant, req.ID, req.Tag)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RemoveFirewallRuleTag(c gateway.Context) error {
+	var req requests.FirewallRuleTagRemove
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.RemoveTag, func() error {
+		return h.service.RemoveFirewallRuleTag(c.Ctx(), tenant, req.ID, req.Tag)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) UpdateFirewallRuleTags(c gateway.Context) error {
+	var req requests.FirewallRuleTagsUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Firewall.UpdateTag, func() error {
+		return h.service.UpdateFirewallRuleTags(c.Ctx(), tenant, req.ID, req.Tags)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,126 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	GenerateMFAURL             = "/users/mfa/generate"
+	EnableMFAURL               = "/users/mfa/enable"
+	DisableMFAURL              = "/users/mfa/disable"
+	AuthMFAURL                 = "/auth/mfa"
+	EditNamespaceRequireMFAURL = "/namespaces/:tenant/mfa"
+)
+
+func (h *Handler) GenerateMFA(c gateway.Context) error {
+	var id string
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	res, err := h.service.GenerateMFA(c.Ctx(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) EnableMFA(c gateway.Context) error {
+	var req requests.MFAEnable
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var id string
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	res, err := h.service.EnableMFA(c.Ctx(), id, req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DisableMFA(c gateway.Context) error {
+	var req requests.MFADisable
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var id string
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	if err := h.service.DisableMFA(c.Ctx(), id, req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// AuthMFA exchanges the MFA-pending token, returned by AuthUser, for the user's JWT. As the user has no JWT yet, the
+// route does not require authentication.
+func (h *Handler) AuthMFA(c gateway.Context) error {
+	var req requests.MFAAuth
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	res, err := h.service.AuthMFA(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) EditNamespaceRequireMFA(c gateway.Context) error {
+	var req requests.NamespaceEditRequireMFA
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.RequireMFA, func() error {
+		return h.service.EditNamespaceRequireMFA(c.Ctx(), ns.TenantID, uid, req.RequireMFA)
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
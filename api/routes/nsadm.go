@@ -21,6 +21,12 @@ const (
 	AddNamespaceUserURL        = "/namespaces/:tenant/members"
 	RemoveNamespaceUserURL     = "/namespaces/:tenant/members/:uid"
 	EditNamespaceUserURL       = "/namespaces/:tenant/members/:uid"
+	EditNamespaceUserScopeURL  = "/namespaces/:tenant/members/:uid/scope"
+	CreateInvitationURL        = "/namespaces/:tenant/invitations"
+	ListInvitationsURL         = "/namespaces/:tenant/invitations"
+	RevokeInvitationURL        = "/namespaces/:tenant/invitations/:id"
+	AcceptInvitationURL        = "/invitations/accept"
+	DeclineInvitationURL       = "/invitations/decline"
 	GetSessionRecordURL        = "/users/security"
 	EditSessionRecordStatusURL = "/users/security/:tenant"
 )
@@ -129,7 +135,7 @@ func (h *Handler) DeleteNamespace(c gateway.Context) error {
 		err := h.service.DeleteNamespace(c.Ctx(), ns.TenantID)
 
 		return err
-	})
+	}, h.memberRoles(c, ns, uid)...)
 	if err != nil {
 		return err
 	}
@@ -163,7 +169,7 @@ func (h *Handler) EditNamespace(c gateway.Context) error {
 		nns, err = h.service.EditNamespace(c.Ctx(), namespace.TenantID, req.Name)
 
 		return err
-	})
+	}, h.memberRoles(c, namespace, uid)...)
 	if err != nil {
 		return err
 	}
@@ -197,7 +203,7 @@ func (h *Handler) AddNamespaceUser(c gateway.Context) error {
 		namespace, err = h.service.AddNamespaceUser(c.Ctx(), req.Username, req.Role, ns.TenantID, uid)
 
 		return err
-	})
+	}, h.memberRoles(c, ns, uid)...)
 	if err != nil {
 		return err
 	}
@@ -231,7 +237,7 @@ func (h *Handler) RemoveNamespaceUser(c gateway.Context) error {
 		nns, err = h.service.RemoveNamespaceUser(c.Ctx(), ns.TenantID, req.MemberUID, uid)
 
 		return err
-	})
+	}, h.memberRoles(c, ns, uid)...)
 	if err != nil {
 		return err
 	}
@@ -263,7 +269,38 @@ func (h *Handler) EditNamespaceUser(c gateway.Context) error {
 		err := h.service.EditNamespaceUser(c.Ctx(), ns.TenantID, uid, req.MemberUID, req.Role)
 
 		return err
-	})
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EditNamespaceUserScope restricts a member to the devices with the tags or in the device groups.
+func (h *Handler) EditNamespaceUserScope(c gateway.Context) error {
+	var req requests.NamespaceEditUserScope
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditMember, func() error {
+		return h.service.EditNamespaceUserScope(c.Ctx(), ns.TenantID, uid, req.MemberUID, &models.MemberScope{Tags: req.Tags, Groups: req.Groups})
+	}, h.memberRoles(c, ns, uid)...)
 	if err != nil {
 		return err
 	}
@@ -292,10 +329,16 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	}
 
 	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EnableSessionRecord, func() error {
-		err := h.service.EditSessionRecordStatus(c.Ctx(), req.SessionRecord, ns.TenantID)
+		if err := h.service.EditSessionRecordStatus(c.Ctx(), req.SessionRecord, ns.TenantID); err != nil {
+			return err
+		}
 
-		return err
-	})
+		if req.RecordInput != nil {
+			return h.service.EditSessionRecordInput(c.Ctx(), *req.RecordInput, ns.TenantID)
+		}
+
+		return nil
+	}, h.memberRoles(c, ns, uid)...)
 	if err != nil {
 		return err
 	}
@@ -316,3 +359,148 @@ func (h *Handler) GetSessionRecord(c gateway.Context) error {
 
 	return c.JSON(http.StatusOK, status)
 }
+
+func (h *Handler) CreateInvitation(c gateway.Context) error {
+	var req requests.InvitationCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	var invitation *models.Invitation
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.AddMember, func() error {
+		var err error
+		invitation, err = h.service.CreateInvitation(c.Ctx(), ns.TenantID, uid, req)
+
+		return err
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, invitation)
+}
+
+func (h *Handler) ListInvitations(c gateway.Context) error {
+	var req requests.InvitationList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Query.Normalize()
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	var invitations []models.Invitation
+	var count int
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.AddMember, func() error {
+		var err error
+		invitations, count, err = h.service.ListInvitations(c.Ctx(), ns.TenantID, req.Query)
+
+		return err
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, invitations)
+}
+
+func (h *Handler) RevokeInvitation(c gateway.Context) error {
+	var req requests.InvitationRevoke
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.AddMember, func() error {
+		return h.service.RevokeInvitation(c.Ctx(), ns.TenantID, req.ID)
+	}, h.memberRoles(c, ns, uid)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) AcceptInvitation(c gateway.Context) error {
+	var req requests.InvitationToken
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	namespace, err := h.service.AcceptInvitation(c.Ctx(), uid, req.Token)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, namespace)
+}
+
+// DeclineInvitation declines an invitation from its token. As the invited person may not have an account, the route
+// does not require authentication.
+func (h *Handler) DeclineInvitation(c gateway.Context) error {
+	var req requests.InvitationToken
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.DeclineInvitation(c.Ctx(), req.Token); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,65 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	OIDCLoginURL = "/auth/oidc"
+	OIDCAuthURL  = "/auth/oidc"
+)
+
+// OIDCStateCookie is the cookie that binds the OpenID Connect login's state to the browser where it was started.
+const OIDCStateCookie = "oidc_state"
+
+// OIDCLogin redirects the user to the OpenID Connect provider. As the user has no JWT yet, the route does not require
+// authentication.
+func (h *Handler) OIDCLogin(c gateway.Context) error {
+	url, state, err := h.service.OIDCLogin(c.Ctx())
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     OIDCStateCookie,
+		Value:    state,
+		Path:     "/api" + OIDCAuthURL,
+		MaxAge:   int(svc.OIDCStateExpiration.Seconds()),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return c.Redirect(http.StatusFound, url)
+}
+
+// OIDCAuth exchanges the authorization code, sent by the OpenID Connect provider to the redirect URL, for the user's
+// JWT. The state must be the one bound to the browser by OIDCLogin.
+func (h *Handler) OIDCAuth(c gateway.Context) error {
+	var req requests.OIDCAuth
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	cookie, err := c.Cookie(OIDCStateCookie)
+	if err != nil || cookie.Value != req.State {
+		return svc.NewErrOIDCStateInvalid(err)
+	}
+
+	c.SetCookie(&http.Cookie{Name: OIDCStateCookie, Path: "/api" + OIDCAuthURL, MaxAge: -1, HttpOnly: true})
+
+	res, err := h.service.OIDCAuth(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
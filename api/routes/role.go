@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetRolesURL   = "/roles"
+	CreateRoleURL = "/roles"
+	GetRoleURL    = "/roles/:name"
+	UpdateRoleURL = "/roles/:name"
+	DeleteRoleURL = "/roles/:name"
+)
+
+func (h *Handler) GetRoles(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	roles, count, err := h.service.ListRoles(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, roles)
+}
+
+func (h *Handler) CreateRole(c gateway.Context) error {
+	var req requests.RoleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var role *models.Role
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Role.Create, func() error {
+		var err error
+		role, err = h.service.CreateRole(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) GetRole(c gateway.Context) error {
+	var req requests.RoleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	role, err := h.service.GetRole(c.Ctx(), tenant, req.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) UpdateRole(c gateway.Context) error {
+	var req requests.RoleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var role *models.Role
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Role.Edit, func() error {
+		var err error
+		role, err = h.service.UpdateRole(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (h *Handler) DeleteRole(c gateway.Context) error {
+	var req requests.RoleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Role.Remove, func() error {
+		return h.service.DeleteRole(c.Ctx(), tenant, req.Name)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// customRoles returns the custom role of the request's member, so the guard can resolve its permissions. It returns
+// nothing when the member's role is builtin or when the custom role does not exist anymore.
+func (h *Handler) customRoles(c gateway.Context) []models.Role {
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	return h.lookupRole(c, tenant, c.Role())
+}
+
+// memberRoles returns the custom role of a namespace's member, as customRoles does for the request's member.
+func (h *Handler) memberRoles(c gateway.Context, namespace *models.Namespace, userID string) []models.Role {
+	member, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return nil
+	}
+
+	return h.lookupRole(c, namespace.TenantID, member.Role)
+}
+
+func (h *Handler) lookupRole(c gateway.Context, tenant, name string) []models.Role {
+	if name == "" || guard.IsBuiltinRole(name) {
+		return nil
+	}
+
+	role, err := h.service.GetRole(c.Ctx(), tenant, name)
+	if err != nil {
+		return nil
+	}
+
+	return []models.Role{*role}
+}
@@ -1,12 +1,16 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/asciicast"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -19,6 +23,8 @@ const (
 	KeepAliveSessionURL        = "/sessions/:uid/keepalive"
 	RecordSessionURL           = "/sessions/:uid/record"
 	PlaySessionURL             = "/sessions/:uid/play"
+	ExportSessionRecordURL     = "/sessions/:uid/records.cast"
+	SearchSessionRecordsURL    = "/sessions/search"
 )
 
 const (
@@ -62,6 +68,45 @@ func (h *Handler) GetSession(c gateway.Context) error {
 	return c.JSON(http.StatusOK, session)
 }
 
+func (h *Handler) SearchSessionRecords(c gateway.Context) error {
+	var req requests.SessionSearch
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var lines []models.SessionRecordLine
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Details, func() error {
+		var err error
+		lines, count, err = h.service.SearchSessionRecords(c.Ctx(), tenant, req.Query, *query)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, lines)
+}
+
 func (h *Handler) SetSessionAuthenticated(c gateway.Context) error {
 	var req requests.SessionAuthenticatedSet
 	if err := c.Bind(&req); err != nil {
@@ -120,11 +165,76 @@ func (h *Handler) KeepAliveSession(c gateway.Context) error {
 }
 
 func (h *Handler) RecordSession(c gateway.Context) error {
+	var req requests.SessionRecord
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordSession(c.Ctx(), models.UID(req.UID), req); err != nil {
+		return err
+	}
+
 	return c.NoContent(http.StatusOK)
 }
 
 func (h *Handler) PlaySession(c gateway.Context) error {
-	return c.NoContent(http.StatusOK)
+	var req requests.SessionGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var frames []models.RecordedSession
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		frames, count, err = h.service.ListSessionRecordFrames(c.Ctx(), models.UID(req.UID))
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, frames)
+}
+
+// ExportSessionRecord streams a session's record as an asciicast v2 file.
+func (h *Handler) ExportSessionRecord(c gateway.Context) error {
+	var req requests.SessionGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var cast *asciicast.Cast
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		cast, err = h.service.ExportSessionRecord(c.Ctx(), models.UID(req.UID))
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, asciicast.ContentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", req.UID+".cast"))
+	c.Response().WriteHeader(http.StatusOK)
+
+	return cast.Encode(c.Response())
 }
 
 func (h *Handler) DeleteRecordedSession(c gateway.Context) error {
@@ -20,6 +20,7 @@ const (
 	UpdatePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
 	DeletePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
 	CreatePrivateKeyURL    = "/sshkeys/private-keys"
+	IssueCertificateURL    = "/sshkeys/certificates"
 	EvaluateKeyURL         = "/sshkeys/public-keys/evaluate/:fingerprint/:username"
 	AddPublicKeyTagURL     = "/sshkeys/public-keys/:fingerprint/tags"      // Add a tag to a public key.
 	RemovePublicKeyTagURL  = "/sshkeys/public-keys/:fingerprint/tags/:tag" // Remove a tag to a public key.
@@ -95,7 +96,7 @@ func (h *Handler) CreatePublicKey(c gateway.Context) error {
 		res, err = h.service.CreatePublicKey(c.Ctx(), req, tenant)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -124,7 +125,7 @@ func (h *Handler) UpdatePublicKey(c gateway.Context) error {
 		key, err = h.service.UpdatePublicKey(c.Ctx(), req.Fingerprint, tenant, req)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -151,7 +152,7 @@ func (h *Handler) DeletePublicKey(c gateway.Context) error {
 		err := h.service.DeletePublicKey(c.Ctx(), req.Fingerprint, tenant)
 
 		return err
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -168,6 +169,25 @@ func (h *Handler) CreatePrivateKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, privKey)
 }
 
+// IssueUserCertificate is used by the SSH server to get a user certificate to authenticate on a device.
+func (h *Handler) IssueUserCertificate(c gateway.Context) error {
+	var req requests.UserCertificateIssue
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	certificate, err := h.service.IssueUserCertificate(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, certificate)
+}
+
 func (h *Handler) EvaluateKey(c gateway.Context) error {
 	var device models.Device
 	if err := c.Bind(&device); err != nil {
@@ -189,6 +209,16 @@ func (h *Handler) EvaluateKey(c gateway.Context) error {
 		return err
 	}
 
+	// An access grant to the key gives it access to the devices out of its filter until the grant expires.
+	if usernameOk && !filterOk {
+		grant, err := h.service.EvaluateAccessGrant(c.Ctx(), device.TenantID, "", pubKey.Fingerprint, &device)
+		if err != nil {
+			return err
+		}
+
+		filterOk = grant != nil
+	}
+
 	return c.JSON(http.StatusOK, usernameOk && filterOk)
 }
 
@@ -209,7 +239,7 @@ func (h *Handler) AddPublicKeyTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.AddTag, func() error {
 		return h.service.AddPublicKeyTag(c.Ctx(), tenant, req.Fingerprint, req.Tag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -234,7 +264,7 @@ func (h *Handler) RemovePublicKeyTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.RemoveTag, func() error {
 		return h.service.RemovePublicKeyTag(c.Ctx(), tenant, req.Fingerprint, req.Tag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -259,7 +289,7 @@ func (h *Handler) UpdatePublicKeyTags(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.UpdateTag, func() error {
 		return h.service.UpdatePublicKeyTags(c.Ctx(), tenant, req.Fingerprint, req.Tags)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
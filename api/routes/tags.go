@@ -51,7 +51,7 @@ func (h *Handler) RenameTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RenameTag, func() error {
 		return h.service.RenameTag(c.Ctx(), tenant, req.Tag, req.NewTag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -76,7 +76,7 @@ func (h *Handler) DeleteTag(c gateway.Context) error {
 
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.DeleteTag, func() error {
 		return h.service.DeleteTag(c.Ctx(), tenant, req.Tag)
-	})
+	}, h.customRoles(c)...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,159 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetWebhooksURL          = "/webhooks"
+	CreateWebhookURL        = "/webhooks"
+	DeleteWebhookURL        = "/webhooks/:id"
+	GetWebhookDeliveriesURL = "/webhooks/:id/deliveries"
+	RedeliverWebhookURL     = "/webhooks/:id/deliveries/:delivery/redeliver"
+)
+
+func (h *Handler) GetWebhooks(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	hooks, count, err := h.service.ListWebhooks(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, hooks)
+}
+
+func (h *Handler) CreateWebhook(c gateway.Context) error {
+	var req requests.WebhookCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var res *responses.WebhookCreate
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Create, func() error {
+		var err error
+		res, err = h.service.CreateWebhook(c.Ctx(), tenant, req)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteWebhook(c gateway.Context) error {
+	var req requests.WebhookDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Remove, func() error {
+		return h.service.DeleteWebhook(c.Ctx(), tenant, req.ID)
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GetWebhookDeliveries(c gateway.Context) error {
+	var req requests.WebhookDeliveriesList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	deliveries, count, err := h.service.ListWebhookDeliveries(c.Ctx(), tenant, req.ID, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *Handler) RedeliverWebhook(c gateway.Context) error {
+	var req requests.WebhookRedeliver
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var delivery *models.WebhookDelivery
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Redeliver, func() error {
+		var err error
+		delivery, err = h.service.RedeliverWebhook(c.Ctx(), tenant, req.ID, req.DeliveryID)
+
+		return err
+	}, h.customRoles(c)...)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+type AccessGrantService interface {
+	CreateAccessGrant(ctx context.Context, tenant string, req requests.AccessGrantCreate) (*models.AccessGrant, error)
+	ListAccessGrants(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error)
+	GetAccessGrant(ctx context.Context, tenant, id string) (*models.AccessGrant, error)
+	RevokeAccessGrant(ctx context.Context, tenant, id string) error
+	EvaluateAccessGrant(ctx context.Context, tenant, member, fingerprint string, device *models.Device) (*models.AccessGrant, error)
+	ExpireAccessGrants(ctx context.Context) error
+}
+
+// CreateAccessGrant grants a namespace's member, or one of its public keys, access to a device or to the devices with a
+// tag until the grant expires.
+func (s *service) CreateAccessGrant(ctx context.Context, tenant string, req requests.AccessGrantCreate) (*models.AccessGrant, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if req.Member != "" {
+		if _, ok := guard.CheckMember(namespace, req.Member); !ok {
+			return nil, NewErrNamespaceMemberNotFound(req.Member, nil)
+		}
+	}
+
+	if req.Fingerprint != "" {
+		if _, err := s.store.PublicKeyGet(ctx, req.Fingerprint, tenant); err != nil {
+			return nil, NewErrPublicKeyNotFound(req.Fingerprint, err)
+		}
+	}
+
+	if req.Device != "" {
+		if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.Device), tenant); err != nil {
+			return nil, NewErrDeviceNotFound(models.UID(req.Device), err)
+		}
+	}
+
+	grant := &models.AccessGrant{
+		TenantID:    tenant,
+		Member:      req.Member,
+		Fingerprint: req.Fingerprint,
+		Device:      req.Device,
+		Tag:         req.Tag,
+		Reason:      req.Reason,
+		CreatedAt:   clock.Now(),
+	}
+
+	grant.ExpiresAt = grant.CreatedAt.Add(time.Duration(req.ExpiresIn) * time.Minute)
+
+	if id := gateway.IDFromContext(ctx); id != nil {
+		grant.CreatedBy = id.ID
+	}
+
+	if err := s.store.AccessGrantCreate(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionAccessGrantCreate, models.AuditTarget{Type: models.AuditTargetAccessGrant, ID: grant.ID}, nil, accessGrantAuditFields(grant))
+
+	return grant, nil
+}
+
+// ListAccessGrants lists the access grants of a namespace, from the newest to the oldest, including the expired ones.
+func (s *service) ListAccessGrants(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	return s.store.AccessGrantList(ctx, tenant, pagination)
+}
+
+func (s *service) GetAccessGrant(ctx context.Context, tenant, id string) (*models.AccessGrant, error) {
+	grant, err := s.store.AccessGrantGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrAccessGrantNotFound(id, err)
+	}
+
+	return grant, nil
+}
+
+// RevokeAccessGrant deletes an access grant before it expires, closing the sessions opened under it.
+func (s *service) RevokeAccessGrant(ctx context.Context, tenant, id string) error {
+	grant, err := s.GetAccessGrant(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.AccessGrantDelete(ctx, tenant, id); err != nil {
+		return NewErrAccessGrantNotFound(id, err)
+	}
+
+	s.closeAccessGrantSessions(ctx, grant)
+
+	s.audit(ctx, tenant, models.AuditActionAccessGrantRevoke, models.AuditTarget{Type: models.AuditTargetAccessGrant, ID: id}, accessGrantAuditFields(grant), nil)
+
+	return nil
+}
+
+// EvaluateAccessGrant returns the namespace's active grant that gives the member, or the public key with the
+// fingerprint, access to the device, or nil when there is none.
+func (s *service) EvaluateAccessGrant(ctx context.Context, tenant, member, fingerprint string, device *models.Device) (*models.AccessGrant, error) {
+	grants, err := s.store.AccessGrantListActive(ctx, tenant, member, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range grants {
+		if grants[i].Covers(device) {
+			return &grants[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ExpireAccessGrants enforces the expiration of the access grants whose time is over, closing the sessions opened under
+// them. It is meant to be run periodically, as the grants stop giving access when they expire, but their sessions are
+// only closed here.
+func (s *service) ExpireAccessGrants(ctx context.Context) error {
+	grants, err := s.store.AccessGrantListExpired(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range grants {
+		grant := &grants[i]
+
+		s.closeAccessGrantSessions(ctx, grant)
+
+		if err := s.store.AccessGrantSetExpired(ctx, grant.ID); err != nil {
+			return err
+		}
+
+		logrus.WithFields(logrus.Fields{"tenant": grant.TenantID, "grant": grant.ID}).Info("Access grant expired")
+	}
+
+	return nil
+}
+
+// closeAccessGrantSessions closes the sessions opened under an access grant. When the SSH server cannot close a
+// session, as its device is offline, the session is closed on the API, so it is not left active.
+func (s *service) closeAccessGrantSessions(ctx context.Context, grant *models.AccessGrant) {
+	sessions, err := s.store.SessionListByGrant(ctx, grant.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("grant", grant.ID).Error("Failed to list the sessions of the access grant")
+
+		return
+	}
+
+	for _, session := range sessions {
+		if err := s.client.(req.Client).CloseSession(session.UID, string(session.DeviceUID)); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{"grant": grant.ID, "session": session.UID}).Warn("Failed to close the session of the access grant")
+
+			if err := s.DeactivateSession(ctx, models.UID(session.UID)); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{"grant": grant.ID, "session": session.UID}).Error("Failed to deactivate the session of the access grant")
+			}
+		}
+	}
+}
+
+// sessionAccessGrant returns the ID of the access grant a session is opened under, or an empty string when the member,
+// or the public key, that opened it has access to the device without a grant.
+func (s *service) sessionAccessGrant(ctx context.Context, session requests.SessionCreate) string {
+	if session.Member == "" && session.Fingerprint == "" {
+		return ""
+	}
+
+	device, err := s.store.DeviceGet(ctx, models.UID(session.DeviceUID))
+	if err != nil {
+		return ""
+	}
+
+	member, fingerprint := session.Member, ""
+
+	// The web terminal authenticates its public keys with the magic key, so its member is evaluated instead.
+	if member != "" {
+		scope, err := s.memberScope(ctx, device.TenantID, member)
+		if err != nil {
+			return ""
+		}
+
+		if ok, err := s.inScope(ctx, device.TenantID, scope, device); err != nil || ok {
+			return ""
+		}
+	} else {
+		if key, err := s.store.PublicKeyGet(ctx, session.Fingerprint, device.TenantID); err == nil {
+			if ok, err := s.EvaluateKeyFilter(ctx, key, *device); err != nil || ok {
+				return ""
+			}
+		}
+
+		fingerprint = session.Fingerprint
+	}
+
+	grant, err := s.EvaluateAccessGrant(ctx, device.TenantID, member, fingerprint, device)
+	if err != nil || grant == nil {
+		return ""
+	}
+
+	return grant.ID
+}
+
+// accessGrantAuditFields returns the fields of an access grant recorded on the audit log.
+func accessGrantAuditFields(grant *models.AccessGrant) map[string]interface{} {
+	return map[string]interface{}{
+		"member":      grant.Member,
+		"fingerprint": grant.Fingerprint,
+		"device":      grant.Device,
+		"tag":         grant.Tag,
+		"reason":      grant.Reason,
+		"expires_at":  grant.ExpiresAt,
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAccessGrant(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "owner", Role: guard.RoleOwner}, {ID: "oncall", Role: guard.RoleOperator}},
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.AccessGrantCreate
+		requiredMocks func()
+		expected      *models.AccessGrant
+		err           error
+	}{
+		{
+			description: "fails when the namespace does not exist",
+			req:         requests.AccessGrantCreate{Member: "oncall", Tag: "production", ExpiresIn: 240},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			err: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the member is not in the namespace",
+			req:         requests.AccessGrantCreate{Member: "unknown", Tag: "production", ExpiresIn: 240},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			err: NewErrNamespaceMemberNotFound("unknown", nil),
+		},
+		{
+			description: "fails when the public key does not exist",
+			req:         requests.AccessGrantCreate{Fingerprint: "fingerprint", Tag: "production", ExpiresIn: 240},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrPublicKeyNotFound("fingerprint", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device does not exist",
+			req:         requests.AccessGrantCreate{Member: "oncall", Device: "uid", ExpiresIn: 240},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds granting the member access to the tag for the window",
+			req:         requests.AccessGrantCreate{Member: "oncall", Tag: "production", Reason: "incident", ExpiresIn: 240},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessGrantCreate", ctx, &models.AccessGrant{
+					TenantID:  "tenant",
+					Member:    "oncall",
+					Tag:       "production",
+					Reason:    "incident",
+					CreatedAt: now,
+					ExpiresAt: now.Add(4 * time.Hour),
+				}).Return(nil).Once()
+			},
+			expected: &models.AccessGrant{
+				TenantID:  "tenant",
+				Member:    "oncall",
+				Tag:       "production",
+				Reason:    "incident",
+				CreatedAt: now,
+				ExpiresAt: now.Add(4 * time.Hour),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			grant, err := s.CreateAccessGrant(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, grant)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRevokeAccessGrant(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	grant := &models.AccessGrant{ID: "grant", TenantID: "tenant", Member: "oncall", Device: "uid"}
+
+	cases := []struct {
+		description   string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the grant does not exist",
+			id:          "unknown",
+			requiredMocks: func() {
+				storeMock.On("AccessGrantGet", ctx, "tenant", "unknown").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrAccessGrantNotFound("unknown", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds deleting the grant and closing its sessions",
+			id:          "grant",
+			requiredMocks: func() {
+				storeMock.On("AccessGrantGet", ctx, "tenant", "grant").Return(grant, nil).Once()
+				storeMock.On("AccessGrantDelete", ctx, "tenant", "grant").Return(nil).Once()
+				storeMock.On("SessionListByGrant", ctx, "grant").Return([]models.Session{{UID: "session", DeviceUID: "uid", Grant: "grant"}}, nil).Once()
+				clientMock.On("CloseSession", "session", "uid").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.RevokeAccessGrant(ctx, "tenant", tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestExpireAccessGrants(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	grants := []models.AccessGrant{
+		{ID: "first", TenantID: "tenant", Member: "oncall", Device: "uid"},
+		{ID: "second", TenantID: "tenant", Fingerprint: "fingerprint", Tag: "production"},
+	}
+
+	storeMock.On("AccessGrantListExpired", ctx).Return(grants, nil).Once()
+
+	storeMock.On("SessionListByGrant", ctx, "first").Return([]models.Session{{UID: "online", DeviceUID: "uid", Grant: "first"}}, nil).Once()
+	clientMock.On("CloseSession", "online", "uid").Return(nil).Once()
+	storeMock.On("AccessGrantSetExpired", ctx, "first").Return(nil).Once()
+
+	// The session whose device cannot be reached is closed on the API.
+	storeMock.On("SessionListByGrant", ctx, "second").Return([]models.Session{{UID: "offline", DeviceUID: "other", Grant: "second"}}, nil).Once()
+	clientMock.On("CloseSession", "offline", "other").Return(internalclient.ErrUnknown).Once()
+	storeMock.On("SessionDeleteActives", ctx, models.UID("offline")).Return(nil).Once()
+	storeMock.On("SessionGet", ctx, models.UID("offline")).Return(&models.Session{UID: "offline", TenantID: "tenant"}, nil).Once()
+	storeMock.On("AccessGrantSetExpired", ctx, "second").Return(nil).Once()
+
+	assert.NoError(t, s.ExpireAccessGrants(ctx))
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestEvaluateAccessGrant(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	grants := []models.AccessGrant{
+		{ID: "device", TenantID: "tenant", Fingerprint: "fingerprint", Device: "other"},
+		{ID: "tag", TenantID: "tenant", Fingerprint: "fingerprint", Tag: "production"},
+	}
+
+	cases := []struct {
+		description   string
+		device        *models.Device
+		requiredMocks func()
+		expected      *models.AccessGrant
+	}{
+		{
+			description: "returns the grant to one of the device's tags",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"production"}},
+			requiredMocks: func() {
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "", "fingerprint").Return(grants, nil).Once()
+			},
+			expected: &grants[1],
+		},
+		{
+			description: "returns nothing when no grant covers the device",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"staging"}},
+			requiredMocks: func() {
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "", "fingerprint").Return(grants, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			grant, err := s.EvaluateAccessGrant(ctx, "tenant", "", "fingerprint", tc.device)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, grant)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// accessRequestDuration is the number of minutes the access is requested for by the requests filed on behalf of the
+// connections.
+const accessRequestDuration = 60
+
+type AccessRequestService interface {
+	CreateAccessRequest(ctx context.Context, tenant, member string, req requests.AccessRequestCreate) (*models.AccessRequest, error)
+	ListAccessRequests(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error)
+	GetAccessRequest(ctx context.Context, tenant, id string) (*models.AccessRequest, error)
+	ApproveAccessRequest(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error)
+	DenyAccessRequest(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error)
+	EvaluateAccessRequest(ctx context.Context, req requests.AccessRequestEvaluate) (*models.AccessRequest, error)
+	EditNamespaceApprovalTags(ctx context.Context, tenant string, tags []string) error
+}
+
+// CreateAccessRequest files a member's request to connect to a device for a duration. The request stays pending until
+// an administrator approves or denies it.
+func (s *service) CreateAccessRequest(ctx context.Context, tenant, member string, req requests.AccessRequestCreate) (*models.AccessRequest, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(req.Device), tenant); err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.Device), err)
+	}
+
+	request := &models.AccessRequest{
+		TenantID:  tenant,
+		Member:    member,
+		Device:    req.Device,
+		Reason:    req.Reason,
+		Duration:  req.Duration,
+		Status:    models.AccessRequestStatusPending,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.createAccessRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ListAccessRequests lists the access requests of a namespace with the status, from the newest to the oldest. An
+// empty status lists the requests of all statuses.
+func (s *service) ListAccessRequests(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	return s.store.AccessRequestList(ctx, tenant, status, pagination)
+}
+
+func (s *service) GetAccessRequest(ctx context.Context, tenant, id string) (*models.AccessRequest, error) {
+	request, err := s.store.AccessRequestGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrAccessRequestNotFound(id, err)
+	}
+
+	return request, nil
+}
+
+// ApproveAccessRequest approves a pending access request, giving the requester an access grant to the device for the
+// request's duration. The requests filed on behalf of password authenticated connections have no requester, so only
+// their held connection is let through.
+//
+// A member cannot approve their own request.
+func (s *service) ApproveAccessRequest(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error) {
+	request, err := s.pendingAccessRequest(ctx, tenant, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+
+	if request.Member != "" || request.Fingerprint != "" {
+		grant := &models.AccessGrant{
+			TenantID:    tenant,
+			Member:      request.Member,
+			Fingerprint: request.Fingerprint,
+			Device:      request.Device,
+			Reason:      request.Reason,
+			CreatedBy:   userID,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Duration(request.Duration) * time.Minute),
+		}
+
+		if err := s.store.AccessGrantCreate(ctx, grant); err != nil {
+			return nil, err
+		}
+
+		request.Grant = grant.ID
+	}
+
+	request.Status = models.AccessRequestStatusApproved
+	request.DecidedBy = userID
+	request.DecidedAt = &now
+
+	if err := s.store.AccessRequestDecide(ctx, request); err != nil {
+		// The request was decided meanwhile, so the grant given for it is withdrawn.
+		if request.Grant != "" {
+			if err := s.store.AccessGrantDelete(ctx, tenant, request.Grant); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{"tenant": tenant, "grant": request.Grant}).Error("Failed to delete the grant of an access request already decided")
+			}
+		}
+
+		return nil, NewErrAccessRequestDecided(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionAccessRequestApprove, models.AuditTarget{Type: models.AuditTargetAccessRequest, ID: id}, nil, map[string]interface{}{"grant": request.Grant})
+
+	return request, nil
+}
+
+// DenyAccessRequest denies a pending access request. A member cannot deny their own request.
+func (s *service) DenyAccessRequest(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error) {
+	request, err := s.pendingAccessRequest(ctx, tenant, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+
+	request.Status = models.AccessRequestStatusDenied
+	request.DecidedBy = userID
+	request.DecidedAt = &now
+
+	if err := s.store.AccessRequestDecide(ctx, request); err != nil {
+		return nil, NewErrAccessRequestDecided(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionAccessRequestDeny, models.AuditTarget{Type: models.AuditTargetAccessRequest, ID: id}, nil, nil)
+
+	return request, nil
+}
+
+// EvaluateAccessRequest checks if a connection to a device must wait for an access request to be approved. It returns
+// nil when the device does not require approval or an access grant already gives the connection's member, or public
+// key, access to it. Otherwise, it returns the pending request of the member, or of the public key, to the device,
+// filing one on behalf of the connection when there is none.
+func (s *service) EvaluateAccessRequest(ctx context.Context, req requests.AccessRequestEvaluate) (*models.AccessRequest, error) {
+	device, err := s.store.DeviceGet(ctx, models.UID(req.Device))
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.Device), err)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	if !namespace.RequiresApproval(device) {
+		return nil, nil
+	}
+
+	// The web terminal authenticates its public keys with the magic key, so its member is evaluated instead.
+	member, fingerprint := req.Member, req.Fingerprint
+	if member != "" {
+		fingerprint = ""
+	}
+
+	grant, err := s.EvaluateAccessGrant(ctx, device.TenantID, member, fingerprint, device)
+	if err != nil {
+		return nil, err
+	}
+
+	if grant != nil {
+		return nil, nil
+	}
+
+	pending, err := s.store.AccessRequestGetPending(ctx, device.TenantID, device.UID, member, fingerprint)
+	switch {
+	case err == nil:
+		return pending, nil
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	request := &models.AccessRequest{
+		TenantID:    device.TenantID,
+		Member:      member,
+		Fingerprint: fingerprint,
+		Device:      device.UID,
+		Reason:      req.Reason,
+		Duration:    accessRequestDuration,
+		Status:      models.AccessRequestStatusPending,
+		CreatedAt:   clock.Now(),
+	}
+
+	if err := s.createAccessRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// EditNamespaceApprovalTags sets the tags of the namespace's devices that can only be connected to with an approved
+// access request. Empty tags remove the requirement.
+func (s *service) EditNamespaceApprovalTags(ctx context.Context, tenant string, tags []string) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if err := s.store.NamespaceSetApprovalTags(ctx, tags, tenant); err != nil {
+		return err
+	}
+
+	var previous []string
+	if namespace.Settings != nil {
+		previous = namespace.Settings.ApprovalTags
+	}
+
+	s.audit(ctx, tenant, models.AuditActionNamespaceApprovalTags, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenant}, map[string]interface{}{"approval_tags": previous}, map[string]interface{}{"approval_tags": tags})
+
+	return nil
+}
+
+// createAccessRequest stores a pending access request, notifying the namespace's webhooks so the administrators can
+// decide it.
+func (s *service) createAccessRequest(ctx context.Context, request *models.AccessRequest) error {
+	if err := s.store.AccessRequestCreate(ctx, request); err != nil {
+		return err
+	}
+
+	s.audit(ctx, request.TenantID, models.AuditActionAccessRequestCreate, models.AuditTarget{Type: models.AuditTargetAccessRequest, ID: request.ID}, nil, map[string]interface{}{
+		"device":   request.Device,
+		"reason":   request.Reason,
+		"duration": request.Duration,
+	})
+
+	s.dispatch(ctx, request.TenantID, webhook.WebhookAccessRequestCreatedEvent, request)
+
+	return nil
+}
+
+// pendingAccessRequest returns an access request to be decided by the user, failing when it was already decided or
+// when it was filed by the user.
+func (s *service) pendingAccessRequest(ctx context.Context, tenant, id, userID string) (*models.AccessRequest, error) {
+	request, err := s.GetAccessRequest(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.AccessRequestStatusPending {
+		return nil, NewErrAccessRequestDecided(id, nil)
+	}
+
+	if request.Member != "" && request.Member == userID {
+		return nil, NewErrAccessRequestOwn(nil)
+	}
+
+	return request, nil
+}
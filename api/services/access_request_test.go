@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		req           requests.AccessRequestCreate
+		requiredMocks func()
+		expected      *models.AccessRequest
+		err           error
+	}{
+		{
+			description: "fails when the device does not exist",
+			req:         requests.AccessRequestCreate{Device: "uid", Reason: "incident", Duration: 120},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds filing a pending request",
+			req:         requests.AccessRequestCreate{Device: "uid", Reason: "incident", Duration: 120},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestCreate", ctx, &models.AccessRequest{
+					TenantID:  "tenant",
+					Member:    "oncall",
+					Device:    "uid",
+					Reason:    "incident",
+					Duration:  120,
+					Status:    models.AccessRequestStatusPending,
+					CreatedAt: now,
+				}).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.AccessRequest).ID = "request"
+				}).Return(nil).Once()
+			},
+			expected: &models.AccessRequest{
+				ID:        "request",
+				TenantID:  "tenant",
+				Member:    "oncall",
+				Device:    "uid",
+				Reason:    "incident",
+				Duration:  120,
+				Status:    models.AccessRequestStatusPending,
+				CreatedAt: now,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			request, err := s.CreateAccessRequest(ctx, "tenant", "oncall", tc.req)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, request)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestApproveAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	pending := func() *models.AccessRequest {
+		return &models.AccessRequest{
+			ID:       "request",
+			TenantID: "tenant",
+			Member:   "oncall",
+			Device:   "uid",
+			Reason:   "incident",
+			Duration: 120,
+			Status:   models.AccessRequestStatusPending,
+		}
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		requiredMocks func()
+		expected      *models.AccessRequest
+		err           error
+	}{
+		{
+			description: "fails when the request does not exist",
+			userID:      "owner",
+			requiredMocks: func() {
+				storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrAccessRequestNotFound("request", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the request was already decided",
+			userID:      "owner",
+			requiredMocks: func() {
+				request := pending()
+				request.Status = models.AccessRequestStatusDenied
+
+				storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(request, nil).Once()
+			},
+			err: NewErrAccessRequestDecided("request", nil),
+		},
+		{
+			description: "fails when the member approves their own request",
+			userID:      "oncall",
+			requiredMocks: func() {
+				storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(pending(), nil).Once()
+			},
+			err: NewErrAccessRequestOwn(nil),
+		},
+		{
+			description: "fails and withdraws the grant when the request is decided meanwhile",
+			userID:      "owner",
+			requiredMocks: func() {
+				storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(pending(), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessGrantCreate", ctx, mock.AnythingOfType("*models.AccessGrant")).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.AccessGrant).ID = "grant"
+				}).Return(nil).Once()
+				storeMock.On("AccessRequestDecide", ctx, mock.AnythingOfType("*models.AccessRequest")).Return(store.ErrNoDocuments).Once()
+				storeMock.On("AccessGrantDelete", ctx, "tenant", "grant").Return(nil).Once()
+			},
+			err: NewErrAccessRequestDecided("request", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds granting the requester access to the device for the duration",
+			userID:      "owner",
+			requiredMocks: func() {
+				storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(pending(), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessGrantCreate", ctx, &models.AccessGrant{
+					TenantID:  "tenant",
+					Member:    "oncall",
+					Device:    "uid",
+					Reason:    "incident",
+					CreatedBy: "owner",
+					CreatedAt: now,
+					ExpiresAt: now.Add(2 * time.Hour),
+				}).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.AccessGrant).ID = "grant"
+				}).Return(nil).Once()
+				storeMock.On("AccessRequestDecide", ctx, mock.AnythingOfType("*models.AccessRequest")).Return(nil).Once()
+			},
+			expected: &models.AccessRequest{
+				ID:        "request",
+				TenantID:  "tenant",
+				Member:    "oncall",
+				Device:    "uid",
+				Reason:    "incident",
+				Duration:  120,
+				Status:    models.AccessRequestStatusApproved,
+				DecidedBy: "owner",
+				DecidedAt: &now,
+				Grant:     "grant",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			request, err := s.ApproveAccessRequest(ctx, "tenant", "request", tc.userID)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, request)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDenyAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	storeMock.On("AccessRequestGet", ctx, "tenant", "request").Return(&models.AccessRequest{
+		ID:       "request",
+		TenantID: "tenant",
+		Device:   "uid",
+		Duration: 60,
+		Status:   models.AccessRequestStatusPending,
+	}, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("AccessRequestDecide", ctx, &models.AccessRequest{
+		ID:        "request",
+		TenantID:  "tenant",
+		Device:    "uid",
+		Duration:  60,
+		Status:    models.AccessRequestStatusDenied,
+		DecidedBy: "owner",
+		DecidedAt: &now,
+	}).Return(nil).Once()
+
+	request, err := s.DenyAccessRequest(ctx, "tenant", "request", "owner")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccessRequestStatusDenied, request.Status)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEvaluateAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	device := &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"production"}}
+	namespace := &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{ApprovalTags: []string{"production"}}}
+
+	cases := []struct {
+		description   string
+		req           requests.AccessRequestEvaluate
+		requiredMocks func()
+		expected      *models.AccessRequest
+		err           error
+	}{
+		{
+			description: "fails when the device does not exist",
+			req:         requests.AccessRequestEvaluate{Device: "uid", Member: "oncall"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			err: NewErrDeviceNotFound("uid", Err),
+		},
+		{
+			description: "succeeds when the device does not require approval",
+			req:         requests.AccessRequestEvaluate{Device: "uid", Member: "oncall"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+		},
+		{
+			description: "succeeds when an access grant covers the device",
+			req:         requests.AccessRequestEvaluate{Device: "uid", Member: "oncall", Fingerprint: "fingerprint"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "oncall", "").Return([]models.AccessGrant{{ID: "grant", Device: "uid"}}, nil).Once()
+			},
+		},
+		{
+			description: "succeeds returning the pending request of the member",
+			req:         requests.AccessRequestEvaluate{Device: "uid", Member: "oncall"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "oncall", "").Return([]models.AccessGrant{}, nil).Once()
+				storeMock.On("AccessRequestGetPending", ctx, "tenant", "uid", "oncall", "").Return(&models.AccessRequest{ID: "request", Status: models.AccessRequestStatusPending}, nil).Once()
+			},
+			expected: &models.AccessRequest{ID: "request", Status: models.AccessRequestStatusPending},
+		},
+		{
+			description: "succeeds filing a request on behalf of the public key",
+			req:         requests.AccessRequestEvaluate{Device: "uid", Fingerprint: "fingerprint", Reason: "connection"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "", "fingerprint").Return([]models.AccessGrant{}, nil).Once()
+				storeMock.On("AccessRequestGetPending", ctx, "tenant", "uid", "", "fingerprint").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestCreate", ctx, &models.AccessRequest{
+					TenantID:    "tenant",
+					Fingerprint: "fingerprint",
+					Device:      "uid",
+					Reason:      "connection",
+					Duration:    accessRequestDuration,
+					Status:      models.AccessRequestStatusPending,
+					CreatedAt:   now,
+				}).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.AccessRequest).ID = "request"
+				}).Return(nil).Once()
+			},
+			expected: &models.AccessRequest{
+				ID:          "request",
+				TenantID:    "tenant",
+				Fingerprint: "fingerprint",
+				Device:      "uid",
+				Reason:      "connection",
+				Duration:    accessRequestDuration,
+				Status:      models.AccessRequestStatusPending,
+				CreatedAt:   now,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			request, err := s.EvaluateAccessRequest(ctx, tc.req)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, request)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// APIKeyPrefix is the prefix of every API key, used to tell them apart from other credentials.
+const APIKeyPrefix = "shk_"
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, tenant, userID string, req requests.APIKeyCreate) (*responses.APIKeyCreate, error)
+	ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error)
+	DeleteAPIKey(ctx context.Context, tenant, id string) error
+	AuthAPIKey(ctx context.Context, key string) (*models.UserAuthClaims, error)
+}
+
+// CreateAPIKey creates a new API key to a namespace on behalf of one of its members.
+//
+// The key's role cannot be greater than the member's role. The key itself is returned only here, as only its digest
+// is stored.
+func (s *service) CreateAPIKey(ctx context.Context, tenant, userID string, req requests.APIKeyCreate) (*responses.APIKeyCreate, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	member, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	if guard.GetRoleCode(req.Role) > guard.GetRoleCode(member.Role) {
+		return nil, guard.ErrForbidden
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	apiKey := &models.APIKey{
+		TenantID:  tenant,
+		UserID:    userID,
+		Name:      req.Name,
+		Role:      req.Role,
+		Digest:    tokenDigest(key),
+		CreatedAt: clock.Now(),
+	}
+
+	if req.ExpiresIn > 0 {
+		expiresAt := apiKey.CreatedAt.AddDate(0, 0, req.ExpiresIn)
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	if err := s.store.APIKeyCreate(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionAPIKeyCreate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: apiKey.ID}, nil, apiKeyAuditFields(apiKey))
+
+	return &responses.APIKeyCreate{APIKey: *apiKey, Key: key}, nil
+}
+
+// ListAPIKeys lists the API keys of a namespace, from the newest to the oldest.
+func (s *service) ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	return s.store.APIKeyList(ctx, tenant, pagination)
+}
+
+// DeleteAPIKey deletes an API key from a namespace, revoking it.
+func (s *service) DeleteAPIKey(ctx context.Context, tenant, id string) error {
+	apiKey, err := s.store.APIKeyGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrAPIKeyNotFound(id, err)
+	}
+
+	if err := s.store.APIKeyDelete(ctx, tenant, id); err != nil {
+		return NewErrAPIKeyNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionAPIKeyDelete, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: id}, apiKeyAuditFields(apiKey), nil)
+
+	return nil
+}
+
+// AuthAPIKey authenticates an API key, returning the claims of the requests made with it.
+//
+// The key is rejected when it does not exist, when it is expired or when its creator is no longer a member of the
+// namespace. As the creator's role may have changed since the key was created, the claims have the lowest of the key's
+// and the creator's roles.
+//
+// As a key is a single factor, it is also rejected, with a NewErrMFARequired error, when the namespace requires MFA from
+// its members.
+func (s *service) AuthAPIKey(ctx context.Context, key string) (*models.UserAuthClaims, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	apiKey, err := s.store.APIKeyGetByDigest(ctx, tokenDigest(key))
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	now := clock.Now()
+	if apiKey.IsExpired(now) {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, apiKey.TenantID)
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	if namespace.Settings != nil && namespace.Settings.RequireMFA {
+		return nil, NewErrMFARequired(nil)
+	}
+
+	member, ok := guard.CheckMember(namespace, apiKey.UserID)
+	if !ok {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, apiKey.UserID, false)
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	role := apiKey.Role
+	if guard.GetRoleCode(member.Role) < guard.GetRoleCode(role) {
+		role = member.Role
+	}
+
+	if err := s.store.APIKeySetLastUsed(ctx, apiKey.ID, now); err != nil {
+		logrus.WithError(err).WithField("id", apiKey.ID).Error("Failed to set the last use of the API key")
+	}
+
+	return &models.UserAuthClaims{
+		Username: user.Username,
+		Tenant:   apiKey.TenantID,
+		ID:       user.ID,
+		Role:     role,
+	}, nil
+}
+
+// tokenDigest returns the digest of a secret token, as an API key or an invitation's token, which is what is stored.
+func tokenDigest(key string) string {
+	digest := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(digest[:])
+}
+
+// apiKeyAuditFields returns the fields of an API key recorded on the audit log.
+func apiKeyAuditFields(key *models.APIKey) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       key.Name,
+		"role":       key.Role,
+		"expires_at": key.ExpiresAt,
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "operator", Role: guard.RoleOperator},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		req           requests.APIKeyCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace does not exist",
+			userID:      "owner",
+			req:         requests.APIKeyCreate{Name: "ci", Role: guard.RoleOperator},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the user is not a member of the namespace",
+			userID:      "other",
+			req:         requests.APIKeyCreate{Name: "ci", Role: guard.RoleOperator},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrNamespaceMemberNotFound("other", nil),
+		},
+		{
+			description: "fails when the key's role is greater than the member's role",
+			userID:      "operator",
+			req:         requests.APIKeyCreate{Name: "ci", Role: guard.RoleAdministrator},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			description: "succeeds storing only the key's digest",
+			userID:      "operator",
+			req:         requests.APIKeyCreate{Name: "ci", Role: guard.RoleOperator, ExpiresIn: 30},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("APIKeyCreate", ctx, mock.MatchedBy(func(key *models.APIKey) bool {
+					return key.TenantID == "tenant" && key.UserID == "operator" && key.Role == guard.RoleOperator &&
+						len(key.Digest) == 64 && key.ExpiresAt != nil && key.ExpiresAt.Equal(now.AddDate(0, 0, 30))
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.CreateAPIKey(ctx, "tenant", tc.userID, tc.req)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.True(t, strings.HasPrefix(res.Key, APIKeyPrefix))
+				assert.Equal(t, tokenDigest(res.Key), res.Digest)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the key does not exist in the namespace",
+			requiredMocks: func() {
+				storeMock.On("APIKeyGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: NewErrAPIKeyNotFound("id", Err),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("APIKeyGet", ctx, "tenant", "id").Return(&models.APIKey{ID: "id", TenantID: "tenant"}, nil).Once()
+				storeMock.On("APIKeyDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.DeleteAPIKey(ctx, "tenant", "id")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	key := APIKeyPrefix + "secret"
+	digest := tokenDigest(key)
+	expired := now.Add(-1)
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "id", Role: guard.RoleObserver},
+		},
+	}
+
+	type Expected struct {
+		claims *models.UserAuthClaims
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the key does not have the prefix",
+			key:           "secret",
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the key does not exist",
+			key:         key,
+			requiredMocks: func() {
+				storeMock.On("APIKeyGetByDigest", ctx, digest).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(Err)},
+		},
+		{
+			description: "fails when the key is expired",
+			key:         key,
+			requiredMocks: func() {
+				storeMock.On("APIKeyGetByDigest", ctx, digest).Return(&models.APIKey{ID: "key", TenantID: "tenant", UserID: "id", ExpiresAt: &expired}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the key's creator is no longer a member of the namespace",
+			key:         key,
+			requiredMocks: func() {
+				storeMock.On("APIKeyGetByDigest", ctx, digest).Return(&models.APIKey{ID: "key", TenantID: "tenant", UserID: "other"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(nil)},
+		},
+		{
+			description: "fails when the namespace requires MFA",
+			key:         key,
+			requiredMocks: func() {
+				storeMock.On("APIKeyGetByDigest", ctx, digest).Return(&models.APIKey{ID: "key", TenantID: "tenant", UserID: "id"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Members: namespace.Members, Settings: &models.NamespaceSettings{RequireMFA: true}}, nil).Once()
+			},
+			expected: Expected{nil, NewErrMFARequired(nil)},
+		},
+		{
+			description: "succeeds limiting the role to the creator's role",
+			key:         key,
+			requiredMocks: func() {
+				storeMock.On("APIKeyGetByDigest", ctx, digest).Return(&models.APIKey{ID: "key", TenantID: "tenant", UserID: "id", Role: guard.RoleOperator}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", UserData: models.UserData{Username: "username"}}, 0, nil).Once()
+				storeMock.On("APIKeySetLastUsed", ctx, "key", now).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.UserAuthClaims{Username: "username", Tenant: "tenant", ID: "id", Role: guard.RoleObserver},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			claims, err := s.AuthAPIKey(ctx, tc.key)
+			assert.Equal(t, tc.expected, Expected{claims, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
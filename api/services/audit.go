@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error)
+}
+
+// ListAuditLogs lists the audit log's entries of a namespace, from the newest to the oldest.
+func (s *service) ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	return s.store.AuditList(ctx, tenant, pagination, filters)
+}
+
+// audit records on the namespace's audit log an action performed by the user of the request, with the target's fields
+// changed by it.
+//
+// Actions performed without a user, like the ones requested by the internal services, are not recorded. As the action
+// was already performed when it is recorded, a failure to record it is logged instead of returned.
+func (s *service) audit(ctx context.Context, tenant, action string, target models.AuditTarget, before, after map[string]interface{}) {
+	s.auditBy(ctx, requestActor(ctx), tenant, action, target, before, after)
+}
+
+// requestActor returns the user of the request as the actor of an audit log, or nil when the request has no user.
+func requestActor(ctx context.Context) *models.AuditActor {
+	id := gateway.IDFromContext(ctx)
+	if id == nil {
+		return nil
+	}
+
+	actor := &models.AuditActor{ID: id.ID, Role: gateway.RoleFromContext(ctx)}
+	if username := gateway.UsernameFromContext(ctx); username != nil {
+		actor.Username = username.ID
+	}
+
+	return actor
+}
+
+// auditBy records on the namespace's audit log an action performed by the actor, as audit does for the user of the
+// request. Nothing is recorded when the actor is nil.
+func (s *service) auditBy(ctx context.Context, actor *models.AuditActor, tenant, action string, target models.AuditTarget, before, after map[string]interface{}) {
+	if actor == nil {
+		return
+	}
+
+	log := &models.AuditLog{
+		TenantID:  tenant,
+		Actor:     *actor,
+		Action:    action,
+		Target:    target,
+		Diff:      models.AuditDiff{Before: before, After: after},
+		IPAddress: gateway.IPFromContext(ctx),
+		RequestID: gateway.RequestIDFromContext(ctx),
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.AuditCreate(ctx, log); err != nil {
+		logrus.
+			WithError(err).
+			WithFields(logrus.Fields{
+				"tenant": tenant,
+				"action": action,
+				"target": target.ID,
+			}).Error("Failed to record the action on the audit log")
+	}
+}
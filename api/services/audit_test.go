@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAuditContext creates a context with the gateway's information of a request made by a namespace's member.
+func newAuditContext() context.Context {
+	req := httptest.NewRequest(http.MethodPut, "/", nil)
+	req.Header.Set("X-ID", "id")
+	req.Header.Set("X-Username", "username")
+	req.Header.Set("X-Role", "owner")
+	req.Header.Set("X-Real-Ip", "127.0.0.1")
+
+	c := gateway.NewContext(nil, echo.New().NewContext(req, httptest.NewRecorder()))
+	c.Response().Header().Set(echo.HeaderXRequestID, "request")
+
+	return context.WithValue(req.Context(), "ctx", c) //nolint:revive
+}
+
+// newTenantContext creates a context with the gateway's information of a request made by the member "id" in the
+// namespace "tenant".
+func newTenantContext() context.Context {
+	ctx := newAuditContext()
+	ctx.Value("ctx").(*gateway.Context).Request().Header.Set("X-Tenant-ID", "tenant")
+
+	return ctx
+}
+
+func TestListAuditLogs(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	logs := []models.AuditLog{
+		{ID: "id", TenantID: "tenant", Action: models.AuditActionDeviceRename},
+	}
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "action", Operator: "eq", Value: models.AuditActionDeviceRename},
+		},
+	}
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	type Expected struct {
+		logs  []models.AuditLog
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the store fails",
+			requiredMocks: func() {
+				mock.On("AuditList", ctx, "tenant", query, filters).Return(nil, 0, Err).Once()
+			},
+			expected: Expected{nil, 0, Err},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("AuditList", ctx, "tenant", query, filters).Return(logs, len(logs), nil).Once()
+			},
+			expected: Expected{logs, len(logs), nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			logs, count, err := s.ListAuditLogs(ctx, "tenant", query, filters)
+			assert.Equal(t, tc.expected, Expected{logs, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAudit(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	Err := errors.New("error", "", 0)
+
+	log := &models.AuditLog{
+		TenantID:  "tenant",
+		Actor:     models.AuditActor{ID: "id", Username: "username", Role: "owner"},
+		Action:    models.AuditActionTagRename,
+		Target:    models.AuditTarget{Type: models.AuditTargetTag, ID: "old"},
+		Diff:      models.AuditDiff{Before: map[string]interface{}{"name": "old"}, After: map[string]interface{}{"name": "new"}},
+		IPAddress: "127.0.0.1",
+		RequestID: "request",
+		CreatedAt: now,
+	}
+
+	cases := []struct {
+		description   string
+		ctx           context.Context
+		requiredMocks func(ctx context.Context)
+		expected      error
+	}{
+		{
+			description: "does not record the action when there is no user",
+			ctx:         context.TODO(),
+			requiredMocks: func(ctx context.Context) {
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"old"}, 1, nil).Once()
+				mock.On("TagRename", ctx, "tenant", "old", "new").Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "does not fail the action when the record fails",
+			ctx:         newAuditContext(),
+			requiredMocks: func(ctx context.Context) {
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"old"}, 1, nil).Once()
+				mock.On("TagRename", ctx, "tenant", "old", "new").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("AuditCreate", ctx, log).Return(Err).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "records the action performed by the user",
+			ctx:         newAuditContext(),
+			requiredMocks: func(ctx context.Context) {
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"old"}, 1, nil).Once()
+				mock.On("TagRename", ctx, "tenant", "old", "new").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("AuditCreate", ctx, log).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.ctx)
+
+			err := s.RenameTag(tc.ctx, "tenant", "old", "new")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -2,22 +2,26 @@ package services
 
 import (
 	"context"
-	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
-	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/pem"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/cnf/structhash"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/ldap"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
 )
 
 type AuthService interface {
@@ -30,6 +34,9 @@ type AuthService interface {
 	AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth) (*models.PublicKeyAuthResponse, error)
 	AuthSwapToken(ctx context.Context, ID, tenant string) (*models.UserAuthResponse, error)
 	AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error)
+	// AuthUnlock unlocks the logins of the username and from the IP, locked out after too many failed attempts. An
+	// empty username or IP is ignored.
+	AuthUnlock(ctx context.Context, username, ip string) error
 	PublicKey() *rsa.PublicKey
 }
 
@@ -64,18 +71,20 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	}
 
 	type Device struct {
-		Name      string
-		Namespace string
+		Name                 string
+		Namespace            string
+		CertificateAuthority string
 	}
 
 	var value *Device
 
 	if err := s.cache.Get(ctx, strings.Join([]string{"auth_device", key}, "/"), &value); err == nil && value != nil {
 		return &models.DeviceAuthResponse{
-			UID:       key,
-			Token:     tokenStr,
-			Name:      value.Name,
-			Namespace: value.Namespace,
+			UID:                  key,
+			Token:                tokenStr,
+			Name:                 value.Name,
+			Namespace:            value.Namespace,
+			CertificateAuthority: value.CertificateAuthority,
 		}, nil
 	}
 	var info *models.DeviceInfo
@@ -96,6 +105,7 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		TenantID:   req.TenantID,
 		LastSeen:   clock.Now(),
 		RemoteAddr: remoteAddr,
+		Attributes: req.Attributes,
 	}
 
 	// The order here is critical as we don't want to register devices if the tenant id is invalid
@@ -106,10 +116,36 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 
 	hostname := strings.ToLower(req.Hostname)
 
+	// The agents that predate the host key use the public key they register with as their host key.
+	var hostKey string
+	if req.HostKey != "" {
+		if hostKey, err = normalizeHostKey(req.HostKey); err != nil {
+			return nil, NewErrDeviceHostKeyInvalid(err)
+		}
+	} else if hostKey, err = hostKeyFromPublicKey(req.PublicKey); err != nil {
+		logrus.WithError(err).WithField("uid", device.UID).Warn("Failed to get the host key from the device's public key")
+	}
+
+	// A device unknown before its authentication is a new one, waiting to be accepted.
+	existing, err := s.store.DeviceGetByUID(ctx, models.UID(device.UID), device.TenantID)
+	created := err == store.ErrNoDocuments
+
 	if err := s.store.DeviceCreate(ctx, device, hostname); err != nil {
 		return nil, NewErrDeviceCreate(device, err)
 	}
 
+	// The host key is pinned at the device's first registration. A later one is never trusted over it, as only an
+	// administrator can rotate it.
+	switch {
+	case hostKey == "":
+	case existing == nil || existing.HostKey == "":
+		if err := s.store.DeviceSetHostKey(ctx, models.UID(device.UID), hostKey); err != nil {
+			return nil, err
+		}
+	case existing.HostKey != hostKey:
+		s.alertHostKeyMismatch(ctx, existing, hostKey)
+	}
+
 	if err := s.store.DeviceSetOnline(ctx, models.UID(device.UID), true); err != nil {
 		return nil, NewErrDeviceSetOnline(models.UID(device.UID), err)
 	}
@@ -124,24 +160,58 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
-	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
+
+	if created && dev.Status == models.DeviceStatusPending {
+		s.dispatch(ctx, dev.TenantID, webhook.WebhookDevicePendingEvent, dev)
+	}
+
+	s.dispatch(ctx, dev.TenantID, webhook.WebhookDeviceOnlineEvent, dev)
+
+	// Without the certificate authority, the device is still authenticated with the keys issued by the API.
+	var authority string
+	if ca, err := s.certificateAuthority(ctx, dev.TenantID); err == nil {
+		authority = ca.PublicKey
+	} else {
+		logrus.WithError(err).WithField("tenant", dev.TenantID).Warn("Failed to get the certificate authority of the namespace")
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name, CertificateAuthority: authority}, time.Second*30); err != nil {
 		return nil, err
 	}
 
 	return &models.DeviceAuthResponse{
-		UID:       key,
-		Token:     tokenStr,
-		Name:      dev.Name,
-		Namespace: namespace.Name,
+		UID:                  key,
+		Token:                tokenStr,
+		Name:                 dev.Name,
+		Namespace:            namespace.Name,
+		CertificateAuthority: authority,
 	}, nil
 }
 
 func (s *service) AuthUser(ctx context.Context, req requests.UserAuth) (*models.UserAuthResponse, error) {
-	user, err := s.store.UserGetByUsername(ctx, strings.ToLower(req.Username))
+	username := strings.ToLower(req.Username)
+	ip := gateway.IPFromContext(ctx)
+
+	if left := s.loginLocked(ctx, username, ip); left > 0 {
+		return nil, NewErrAuthLocked(left, nil)
+	}
+
+	// The LDAP directory is tried first, falling back to the local accounts when it does not authenticate the user.
+	if s.ldap != nil {
+		identity, err := s.ldap.Authenticate(ctx, req.Username, req.Password)
+		switch {
+		case err == nil:
+			return s.authLDAPUser(ctx, identity, username)
+		case errors.Is(err, ldap.ErrUnavailable):
+			logrus.WithError(err).Warn("Failed to authenticate the user on the LDAP directory, falling back to the local accounts")
+		}
+	}
+
+	user, err := s.store.UserGetByUsername(ctx, username)
 	if err != nil {
-		user, err = s.store.UserGetByEmail(ctx, strings.ToLower(req.Username))
+		user, err = s.store.UserGetByEmail(ctx, username)
 		if err != nil {
-			return nil, NewErrUserNotFound(req.Username, err)
+			return nil, s.loginFailed(ctx, username, ip, NewErrUserNotFound(req.Username, err))
 		}
 	}
 
@@ -151,9 +221,29 @@ func (s *service) AuthUser(ctx context.Context, req requests.UserAuth) (*models.
 
 	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
 
+	password := sha256.Sum256([]byte(req.Password))
+	if user.Password == hex.EncodeToString(password[:]) {
+		// A user with MFA enabled receives the JWT only after a TOTP code, or a recovery code, is verified.
+		if user.MFA.Enabled {
+			return s.authMFAPending(ctx, user, username)
+		}
+
+		s.loginSucceeded(ctx, username)
+
+		return s.authUserToken(ctx, user, namespace)
+	}
+
+	return nil, s.loginFailed(ctx, username, ip, NewErrAuthUnathorized(nil))
+}
+
+// authUserToken signs and caches the JWT of an authenticated user on a namespace, updating the user's last login.
+//
+// When the namespace requires MFA and the user does not have it enabled, the token is not bound to the namespace, so
+// the user can still enable MFA.
+func (s *service) authUserToken(ctx context.Context, user *models.User, namespace *models.Namespace) (*models.UserAuthResponse, error) {
 	var role string
 	var tenant string
-	if namespace != nil {
+	if namespace != nil && !mfaRequired(namespace, user) {
 		tenant = namespace.TenantID
 
 		for _, member := range namespace.Members {
@@ -165,47 +255,42 @@ func (s *service) AuthUser(ctx context.Context, req requests.UserAuth) (*models.
 		}
 	}
 
-	password := sha256.Sum256([]byte(req.Password))
-	if user.Password == hex.EncodeToString(password[:]) {
-		token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
-			Username: user.Username,
-			Admin:    true,
-			Tenant:   tenant,
-			Role:     role,
-			ID:       user.ID,
-			AuthClaims: models.AuthClaims{
-				Claims: "user",
-			},
-			RegisteredClaims: jwt.RegisteredClaims{
-				ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
-			},
-		})
-
-		tokenStr, err := token.SignedString(s.privKey)
-		if err != nil {
-			return nil, NewErrTokenSigned(err)
-		}
-
-		user.LastLogin = clock.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
+		Username: user.Username,
+		Admin:    true,
+		Tenant:   tenant,
+		Role:     role,
+		ID:       user.ID,
+		AuthClaims: models.AuthClaims{
+			Claims: "user",
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
+		},
+	})
 
-		if err := s.store.UserUpdateData(ctx, user.ID, *user); err != nil {
-			return nil, NewErrUserUpdate(user, err)
-		}
+	tokenStr, err := token.SignedString(s.privKey)
+	if err != nil {
+		return nil, NewErrTokenSigned(err)
+	}
 
-		s.AuthCacheToken(ctx, tenant, user.ID, tokenStr) // nolint: errcheck
+	user.LastLogin = clock.Now()
 
-		return &models.UserAuthResponse{
-			Token:  tokenStr,
-			Name:   user.Name,
-			ID:     user.ID,
-			User:   user.Username,
-			Tenant: tenant,
-			Role:   role,
-			Email:  user.Email,
-		}, nil
+	if err := s.store.UserUpdateData(ctx, user.ID, *user); err != nil {
+		return nil, NewErrUserUpdate(user, err)
 	}
 
-	return nil, NewErrAuthUnathorized(nil)
+	s.AuthCacheToken(ctx, tenant, user.ID, tokenStr) // nolint: errcheck
+
+	return &models.UserAuthResponse{
+		Token:  tokenStr,
+		Name:   user.Name,
+		ID:     user.ID,
+		User:   user.Username,
+		Tenant: tenant,
+		Role:   role,
+		Email:  user.Email,
+	}, nil
 }
 
 func (s *service) AuthGetToken(ctx context.Context, id string) (*models.UserAuthResponse, error) {
@@ -266,27 +351,32 @@ func (s *service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth)
 		return nil, NewErrPublicKeyNotFound(req.Fingerprint, err)
 	}
 
-	block, _ := pem.Decode(privKey.Data)
-	if block == nil {
-		return nil, err
-	}
-
-	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	signer, err := ssh.ParsePrivateKey(privKey.Data)
 	if err != nil {
 		return nil, err
 	}
 
-	digest := sha256.Sum256([]byte(req.Data))
-	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	signature, err := signData(signer, []byte(req.Data))
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.PublicKeyAuthResponse{
-		Signature: base64.StdEncoding.EncodeToString(signature),
+		Signature: base64.StdEncoding.EncodeToString(signature.Blob),
+		Format:    signature.Format,
 	}, nil
 }
 
+// signData signs the data with the signer. RSA keys sign with SHA-256, as the agents that predate the signature format
+// verify it, while the other key types sign with their own algorithm.
+func signData(signer ssh.Signer, data []byte) (*ssh.Signature, error) {
+	if algorithmSigner, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		return algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA256)
+	}
+
+	return signer.Sign(rand.Reader, data)
+}
+
 func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models.UserAuthResponse, error) {
 	namespace, err := s.store.NamespaceGet(ctx, tenant)
 	if err != nil {
@@ -298,6 +388,10 @@ func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models
 		return nil, NewErrUserNotFound(id, err)
 	}
 
+	if mfaRequired(namespace, user) {
+		return nil, NewErrMFARequired(nil)
+	}
+
 	var role string
 	for _, member := range namespace.Members {
 		if member.ID == user.ID {
@@ -2,10 +2,18 @@ package services
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +26,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/undefinedlabs/go-mpatch"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestAuthDevice(t *testing.T) {
@@ -30,12 +39,16 @@ func TestAuthDevice(t *testing.T) {
 
 	ctx := context.TODO()
 
+	hostKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+
 	authReq := requests.DeviceAuth{
 		TenantID: "tenant",
 		Identity: &requests.DeviceIdentity{
 			MAC: "mac",
 		},
 		Sessions: []string{"session"},
+		HostKey:  string(ssh.MarshalAuthorizedKey(hostKey)),
 	}
 
 	auth := models.DeviceAuth{
@@ -67,9 +80,13 @@ func TestAuthDevice(t *testing.T) {
 	mock.On("SessionSetLastSeen", ctx, models.UID(authReq.Sessions[0])).
 		Return(nil).Once()
 	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
-		Return(device, nil).Once()
+		Return(device, nil).Twice()
+	mock.On("DeviceSetHostKey", ctx, models.UID(device.UID), strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(hostKey)), "\n")).
+		Return(nil).Once()
 	mock.On("NamespaceGet", ctx, namespace.TenantID).
 		Return(namespace, nil).Once()
+	mock.On("CertificateAuthorityGet", ctx, namespace.TenantID).
+		Return(&models.CertificateAuthority{TenantID: namespace.TenantID, PublicKey: "ssh-ed25519 authority"}, nil).Once()
 
 	// Mock time.Now using monkey patch
 	patch, err := mpatch.PatchMethod(time.Now, func() time.Time { return now })
@@ -82,6 +99,7 @@ func TestAuthDevice(t *testing.T) {
 	assert.Equal(t, device.UID, authRes.UID)
 	assert.Equal(t, device.Name, authRes.Name)
 	assert.Equal(t, namespace.Name, authRes.Namespace)
+	assert.Equal(t, "ssh-ed25519 authority", authRes.CertificateAuthority)
 	assert.NotEmpty(t, authRes.Token)
 	assert.Equal(t, device.RemoteAddr, "0.0.0.0")
 
@@ -302,3 +320,89 @@ func TestAuthUserInfo(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestAuthPublicKey(t *testing.T) {
+	mock := &mocks.Store{}
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	encode := func(key interface{}) []byte {
+		if key, ok := key.(*rsa.PrivateKey); ok {
+			return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+		}
+
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		assert.NoError(t, err)
+
+		return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	}
+
+	req := requests.PublicKeyAuth{Fingerprint: "fingerprint", Data: "data"}
+
+	cases := []struct {
+		description string
+		key         interface{}
+		format      string
+	}{
+		{
+			description: "succeeds signing with a RSA key",
+			key:         rsaKey,
+			format:      ssh.KeyAlgoRSASHA256,
+		},
+		{
+			description: "succeeds signing with a ed25519 key",
+			key:         ed25519Key,
+			format:      ssh.KeyAlgoED25519,
+		},
+		{
+			description: "succeeds signing with a ECDSA key",
+			key:         ecdsaKey,
+			format:      ssh.KeyAlgoECDSA256,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock.On("PrivateKeyGet", ctx, "fingerprint").Return(&models.PrivateKey{Data: encode(tc.key), Fingerprint: "fingerprint"}, nil).Once()
+
+			res, err := s.AuthPublicKey(ctx, req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.format, res.Format)
+
+			blob, err := base64.StdEncoding.DecodeString(res.Signature)
+			assert.NoError(t, err)
+
+			signer, err := ssh.NewSignerFromKey(tc.key)
+			assert.NoError(t, err)
+
+			assert.NoError(t, signer.PublicKey().Verify([]byte(req.Data), &ssh.Signature{Format: res.Format, Blob: blob}))
+
+			// The agents that predate the signature format verify the RSA signatures with PKCS #1 v1.5 and SHA-256.
+			if key, ok := tc.key.(*rsa.PrivateKey); ok {
+				digest := sha256.Sum256([]byte(req.Data))
+				assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], blob))
+			}
+		})
+	}
+
+	t.Run("fails when the private key does not exist", func(t *testing.T) {
+		mock.On("PrivateKeyGet", ctx, "fingerprint").Return(nil, store.ErrNoDocuments).Once()
+
+		res, err := s.AuthPublicKey(ctx, req)
+		assert.Nil(t, res)
+		assert.Equal(t, NewErrPublicKeyNotFound("fingerprint", store.ErrNoDocuments), err)
+	})
+
+	mock.AssertExpectations(t)
+}
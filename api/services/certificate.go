@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// certificateLifetime is how long a user certificate is valid for. It is only used to authenticate on the device,
+	// right after being issued.
+	certificateLifetime = 5 * time.Minute
+	// certificateSkew is how long before being issued a user certificate is valid from, tolerating the devices whose
+	// clocks are behind.
+	certificateSkew = time.Minute
+)
+
+type CertificateService interface {
+	IssueUserCertificate(ctx context.Context, req requests.UserCertificateIssue) (*models.UserCertificate, error)
+}
+
+// IssueUserCertificate signs a short-lived OpenSSH user certificate for the public key with the certificate authority
+// of the device's namespace. The certificate is bound to the device's user, as its principal, to the session, as its
+// key ID, and to the device, as a critical option.
+func (s *service) IssueUserCertificate(ctx context.Context, req requests.UserCertificateIssue) (*models.UserCertificate, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey)) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), err)
+	}
+
+	if _, ok := key.(*ssh.Certificate); ok {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), nil)
+	}
+
+	device, err := s.store.DeviceGet(ctx, models.UID(req.Device))
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.Device), err)
+	}
+
+	ca, err := s.certificateAuthority(ctx, device.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.ParsePrivateKey(ca.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+
+	certificate := &ssh.Certificate{
+		Key:             key,
+		Serial:          uint64(now.UnixNano()),
+		CertType:        ssh.UserCert,
+		KeyId:           req.Session,
+		ValidPrincipals: []string{req.Username},
+		ValidAfter:      uint64(now.Add(-certificateSkew).Unix()),
+		ValidBefore:     uint64(now.Add(certificateLifetime).Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: map[string]string{
+				models.CertificateOptionDevice: device.UID,
+			},
+			Extensions: map[string]string{
+				"permit-pty":              "",
+				"permit-port-forwarding":  "",
+				"permit-agent-forwarding": "",
+				"permit-X11-forwarding":   "",
+				"permit-user-rc":          "",
+			},
+		},
+	}
+
+	if err := certificate.SignCert(rand.Reader, signer); err != nil {
+		return nil, err
+	}
+
+	return &models.UserCertificate{
+		Certificate: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(certificate))),
+	}, nil
+}
+
+// certificateAuthority returns the certificate authority of a namespace, creating it on its first use.
+func (s *service) certificateAuthority(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	ca, err := s.store.CertificateAuthorityGet(ctx, tenant)
+	if err != store.ErrNoDocuments {
+		return ca, err
+	}
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	ca = &models.CertificateAuthority{
+		TenantID:  tenant,
+		Data:      pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data}),
+		PublicKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		CreatedAt: clock.Now(),
+	}
+
+	switch err := s.store.CertificateAuthorityCreate(ctx, ca); {
+	case err == store.ErrDuplicate:
+		// The authority was created meanwhile, by another connection to the namespace's devices.
+		return s.store.CertificateAuthorityGet(ctx, tenant)
+	case err != nil:
+		return nil, err
+	}
+
+	return ca, nil
+}
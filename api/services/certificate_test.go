@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestIssueUserCertificate(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(public)
+	assert.NoError(t, err)
+
+	req := requests.UserCertificateIssue{
+		Device:    "uid",
+		Username:  "root",
+		Session:   "session",
+		PublicKey: string(ssh.MarshalAuthorizedKey(key)),
+	}
+
+	_, _, _, _, parseErr := ssh.ParseAuthorizedKey([]byte("invalid")) //nolint:dogsled
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+
+	var authority *models.CertificateAuthority
+
+	cases := []struct {
+		description   string
+		req           requests.UserCertificateIssue
+		requiredMocks func()
+		err           error
+	}{
+		{
+			description:   "fails when the public key is invalid",
+			req:           requests.UserCertificateIssue{Device: "uid", Username: "root", Session: "session", PublicKey: "invalid"},
+			requiredMocks: func() {},
+			err:           NewErrPublicKeyDataInvalid([]byte("invalid"), parseErr),
+		},
+		{
+			description: "fails when the device does not exist",
+			req:         req,
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds creating the certificate authority on its first use",
+			req:         req,
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("CertificateAuthorityGet", ctx, "tenant").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("CertificateAuthorityCreate", ctx, mock.AnythingOfType("*models.CertificateAuthority")).Run(func(args mock.Arguments) {
+					authority = args.Get(1).(*models.CertificateAuthority)
+				}).Return(nil).Once()
+			},
+		},
+		{
+			description: "succeeds signing with the certificate authority of the namespace",
+			req:         req,
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("CertificateAuthorityGet", ctx, "tenant").Return(authority, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			issued, err := s.IssueUserCertificate(ctx, tc.req)
+			assert.Equal(t, tc.err, err)
+
+			if tc.err != nil {
+				assert.Nil(t, issued)
+
+				return
+			}
+
+			parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(issued.Certificate)) //nolint:dogsled
+			assert.NoError(t, err)
+
+			certificate, ok := parsed.(*ssh.Certificate)
+			assert.True(t, ok)
+
+			assert.Equal(t, "session", certificate.KeyId)
+			assert.Equal(t, "uid", certificate.CriticalOptions[models.CertificateOptionDevice])
+			assert.Equal(t, key.Marshal(), certificate.Key.Marshal())
+
+			ca, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authority.PublicKey)) //nolint:dogsled
+			assert.NoError(t, err)
+
+			checker := &ssh.CertChecker{
+				IsUserAuthority: func(auth ssh.PublicKey) bool {
+					return bytes.Equal(auth.Marshal(), ca.Marshal())
+				},
+				SupportedCriticalOptions: []string{models.CertificateOptionDevice},
+				Clock:                    func() time.Time { return now },
+			}
+
+			assert.NoError(t, checker.CheckCert("root", certificate))
+			assert.Error(t, checker.CheckCert("admin", certificate))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
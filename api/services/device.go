@@ -10,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
@@ -32,6 +33,18 @@ type DeviceService interface {
 }
 
 func (s *service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort, order string) ([]models.Device, int, error) {
+	// The members restricted to a device scope only list the devices in it.
+	scope, err := s.requestScope(ctx, tenant)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if scope != nil {
+		if filter, err = s.restrictDeviceFilter(ctx, tenant, scope, filter); err != nil {
+			return nil, 0, err
+		}
+	}
+
 	if status == models.DeviceStatusPending || status == models.DeviceStatusRejected {
 		ns, err := s.store.NamespaceGet(ctx, tenant)
 		if err != nil {
@@ -57,6 +70,10 @@ func (s *service) GetDevice(ctx context.Context, uid models.UID) (*models.Device
 		return nil, NewErrDeviceNotFound(uid, err)
 	}
 
+	if err := s.checkRequestScope(ctx, device); err != nil {
+		return nil, err
+	}
+
 	return device, nil
 }
 
@@ -92,7 +109,13 @@ func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant strin
 		return err
 	}
 
-	return s.store.DeviceDelete(ctx, uid)
+	if err := s.store.DeviceDelete(ctx, uid); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceDelete, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"name": device.Name}, nil)
+
+	return nil
 }
 
 func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error {
@@ -136,7 +159,13 @@ func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant
 		return NewErrDeviceDuplicated(otherDevice.Name, err)
 	}
 
-	return s.store.DeviceRename(ctx, uid, name)
+	if err := s.store.DeviceRename(ctx, uid, name); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceRename, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"name": device.Name}, map[string]interface{}{"name": name})
+
+	return nil
 }
 
 // LookupDevice looks for a device in a namespace.
@@ -158,7 +187,18 @@ func (s *service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	// The device is only fetched when there is somewhere to dispatch the event to.
+	if !online && s.webhooks != nil {
+		if device, err := s.store.DeviceGet(ctx, uid); err == nil {
+			s.dispatch(ctx, device.TenantID, webhook.WebhookDeviceOfflineEvent, device)
+		}
+	}
+
+	return nil
 }
 
 func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, status models.DeviceStatus, tenant string) error {
@@ -187,7 +227,13 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 	}
 
 	if status != models.DeviceStatusAccepted {
-		return s.store.DeviceUpdateStatus(ctx, uid, status)
+		if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+			return err
+		}
+
+		s.audit(ctx, tenant, models.AuditActionDeviceUpdateStatus, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"status": device.Status}, map[string]interface{}{"status": status})
+
+		return nil
 	}
 
 	// NOTICE: The logic below is only executed when the new status is "accepted".
@@ -245,7 +291,16 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 		}
 	}
 
-	return s.store.DeviceUpdateStatus(ctx, uid, status)
+	if err := s.store.DeviceUpdateStatus(ctx, uid, status); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceUpdateStatus, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"status": device.Status}, map[string]interface{}{"status": status})
+
+	device.Status = status
+	s.dispatch(ctx, tenant, webhook.WebhookDeviceAcceptedEvent, device)
+
+	return nil
 }
 
 // SetDevicePosition sets the position to a device from its IP.
@@ -317,5 +372,20 @@ func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UI
 		}
 	}
 
-	return s.store.DeviceUpdate(ctx, uid, name, publicURL)
+	if err := s.store.DeviceUpdate(ctx, uid, name, publicURL); err != nil {
+		return err
+	}
+
+	before, after := map[string]interface{}{}, map[string]interface{}{}
+	if name != nil {
+		before["name"], after["name"] = device.Name, *name
+	}
+
+	if publicURL != nil {
+		before["public_url"], after["public_url"] = device.PublicURL, *publicURL
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, before, after)
+
+	return nil
 }
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceAttributes contains the service's function to manage device attributes.
+type DeviceAttributes interface {
+	SetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key, value string) error
+	RemoveDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error
+	UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error
+}
+
+// DeviceMaxAttributes is the number of attributes that a device can have.
+const DeviceMaxAttributes = 32
+
+// SetDeviceAttribute sets the value of a device's attribute, creating it when it does not exist.
+//
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+// If the attribute does not exist and the device already has the maximum number of attributes, a
+// NewErrDeviceAttributeLimit error will be returned.
+func (s *service) SetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key, value string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	current, ok := device.Attributes[key]
+	if !ok && len(device.Attributes) >= DeviceMaxAttributes {
+		return NewErrDeviceAttributeLimit(DeviceMaxAttributes, nil)
+	}
+
+	if err := s.store.DeviceSetAttribute(ctx, uid, key, value); err != nil {
+		return err
+	}
+
+	var before map[string]interface{}
+	if ok {
+		before = map[string]interface{}{"key": key, "value": current}
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceAttributeSet, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, before, map[string]interface{}{"key": key, "value": value})
+
+	return nil
+}
+
+// RemoveDeviceAttribute removes an attribute from a device.
+//
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+// If the attribute does not exist, a NewErrDeviceAttributeNotFound error will be returned.
+func (s *service) RemoveDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	value, ok := device.Attributes[key]
+	if !ok {
+		return NewErrDeviceAttributeNotFound(key, nil)
+	}
+
+	if err := s.store.DeviceRemoveAttribute(ctx, uid, key); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceAttributeRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"key": key, "value": value}, nil)
+
+	return nil
+}
+
+// UpdateDeviceAttributes replaces all the attributes of a device.
+//
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+// If the length of attributes is greater than DeviceMaxAttributes, a NewErrDeviceAttributeLimit error will be returned.
+func (s *service) UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	if len(attributes) > DeviceMaxAttributes {
+		return NewErrDeviceAttributeLimit(DeviceMaxAttributes, nil)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DeviceUpdateAttributes(ctx, uid, attributes); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceAttributeUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"attributes": device.Attributes}, map[string]interface{}{"attributes": attributes})
+
+	return nil
+}
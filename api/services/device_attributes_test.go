@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetDeviceAttribute(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	full := make(map[string]string, DeviceMaxAttributes)
+	for i := 0; i < DeviceMaxAttributes; i++ {
+		full[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device does not exist",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), Err),
+		},
+		{
+			description: "fails when the device is in another namespace",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device has the maximum number of attributes",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Attributes: full}, nil).Once()
+			},
+			expected: NewErrDeviceAttributeLimit(DeviceMaxAttributes, nil),
+		},
+		{
+			description: "succeeds replacing an attribute of a device with the maximum number of attributes",
+			key:         "key0",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Attributes: full}, nil).Once()
+				storeMock.On("DeviceSetAttribute", ctx, models.UID("uid"), "key0", "berlin").Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				storeMock.On("DeviceSetAttribute", ctx, models.UID("uid"), "site", "berlin").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.SetDeviceAttribute(ctx, "tenant", models.UID("uid"), tc.key, "berlin"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRemoveDeviceAttribute(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	device := &models.Device{UID: "uid", Attributes: map[string]string{"site": "berlin"}}
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device does not exist",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), Err),
+		},
+		{
+			description: "fails when the device does not have the attribute",
+			key:         "rack",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+			},
+			expected: NewErrDeviceAttributeNotFound("rack", nil),
+		},
+		{
+			description: "succeeds",
+			key:         "site",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				storeMock.On("DeviceRemoveAttribute", ctx, models.UID("uid"), "site").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.RemoveDeviceAttribute(ctx, "tenant", models.UID("uid"), tc.key))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceBulkService interface {
+	BulkDevices(ctx context.Context, tenant string, req requests.DeviceBulk) (*responses.DeviceBulk, error)
+}
+
+// DeviceBulkMax is the number of devices that a bulk operation can select.
+const DeviceBulkMax = 1000
+
+// Actions of a bulk operation on devices.
+const (
+	DeviceBulkActionAccept    = "accept"
+	DeviceBulkActionReject    = "reject"
+	DeviceBulkActionRemove    = "remove"
+	DeviceBulkActionAddTag    = "add_tag"
+	DeviceBulkActionRemoveTag = "remove_tag"
+	DeviceBulkActionRename    = "rename"
+)
+
+// deviceBulkTemplate is the data used to execute the template of a bulk rename.
+type deviceBulkTemplate struct {
+	// Index is the position, starting at 1, of the device in the batch.
+	Index  int
+	Device models.Device
+}
+
+// BulkDevices applies an action to the devices selected by a list of UIDs, an encoded filter or a device group.
+//
+// The action is applied to each device independently and the result of each one is reported. Errors that concern the
+// whole batch, as an invalid filter or template, the selection of more than DeviceBulkMax devices or, when accepting,
+// the namespace's limit of devices, are returned before any device is changed.
+func (s *service) BulkDevices(ctx context.Context, tenant string, req requests.DeviceBulk) (*responses.DeviceBulk, error) {
+	devices, results, err := s.bulkDevicesSelect(ctx, tenant, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var apply func(i int, device *models.Device) error
+	switch req.Action {
+	case DeviceBulkActionAccept:
+		apply, err = s.bulkDevicesAccept(ctx, tenant, devices)
+		if err != nil {
+			return nil, err
+		}
+	case DeviceBulkActionReject:
+		apply = func(_ int, device *models.Device) error {
+			return s.UpdatePendingStatus(ctx, models.UID(device.UID), models.DeviceStatusRejected, tenant)
+		}
+	case DeviceBulkActionRemove:
+		apply = func(_ int, device *models.Device) error {
+			return s.DeleteDevice(ctx, models.UID(device.UID), tenant)
+		}
+	case DeviceBulkActionAddTag:
+		apply = func(_ int, device *models.Device) error {
+			return s.CreateDeviceTag(ctx, models.UID(device.UID), req.Tag)
+		}
+	case DeviceBulkActionRemoveTag:
+		apply = func(_ int, device *models.Device) error {
+			return s.RemoveDeviceTag(ctx, models.UID(device.UID), req.Tag)
+		}
+	case DeviceBulkActionRename:
+		tmpl, err := template.New("name").Option("missingkey=error").Parse(req.Template)
+		if err != nil {
+			return nil, NewErrDeviceBulkTemplateInvalid(req.Template, err)
+		}
+
+		apply = func(i int, device *models.Device) error {
+			var name strings.Builder
+			if err := tmpl.Execute(&name, deviceBulkTemplate{Index: i + 1, Device: *device}); err != nil {
+				return NewErrDeviceBulkTemplateInvalid(req.Template, err)
+			}
+
+			return s.RenameDevice(ctx, models.UID(device.UID), name.String(), tenant)
+		}
+	default:
+		return nil, NewErrDeviceBulkActionInvalid(req.Action, nil)
+	}
+
+	for i, device := range devices {
+		result := responses.DeviceBulkResult{UID: device.UID, Name: device.Name, Success: true}
+		if err := apply(i, device); err != nil {
+			result.Success, result.Error = false, deviceBulkError(err)
+		}
+
+		results = append(results, result)
+	}
+
+	res := &responses.DeviceBulk{Results: results}
+	for _, result := range results {
+		if result.Success {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+
+	return res, nil
+}
+
+// bulkDevicesSelect returns the devices selected by a bulk operation. When they are selected by UIDs, the UIDs that
+// are not found in the namespace are returned as failed results.
+func (s *service) bulkDevicesSelect(ctx context.Context, tenant string, req requests.DeviceBulk) ([]*models.Device, []responses.DeviceBulkResult, error) {
+	var filters []models.Filter
+	switch {
+	case len(req.UIDs) > 0:
+		if len(req.UIDs) > DeviceBulkMax {
+			return nil, nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+		}
+
+		devices := make([]*models.Device, 0, len(req.UIDs))
+		results := make([]responses.DeviceBulkResult, 0)
+		for _, uid := range req.UIDs {
+			device, err := s.store.DeviceGetByUID(ctx, models.UID(uid), tenant)
+			if err != nil || device == nil {
+				results = append(results, responses.DeviceBulkResult{
+					UID:   uid,
+					Error: deviceBulkError(NewErrDeviceNotFound(models.UID(uid), err)),
+				})
+
+				continue
+			}
+
+			devices = append(devices, device)
+		}
+
+		return devices, results, nil
+	case req.Group != "":
+		group, err := s.GetDeviceGroup(ctx, tenant, req.Group)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if filters, err = deviceGroupFilter(group); err != nil {
+			return nil, nil, err
+		}
+	default:
+		decoded, err := models.DecodeFilter(req.Filter)
+		if err != nil {
+			return nil, nil, NewErrDeviceFilterInvalid(req.Filter, err)
+		}
+
+		filters = scopeDeviceFilter(decoded, tenant)
+	}
+
+	list, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: DeviceBulkMax}, filters, "", "name", "asc", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if count > DeviceBulkMax {
+		return nil, nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+	}
+
+	devices := make([]*models.Device, len(list))
+	for i := range list {
+		devices[i] = &list[i]
+	}
+
+	return devices, nil, nil
+}
+
+// bulkDevicesAccept checks, once for the whole batch, if the namespace can hold the devices to be accepted, returning
+// the function that accepts each one of them.
+//
+// It follows the rules of UpdatePendingStatus: a device that replaces an accepted device with the same MAC does not
+// take a new slot, and, in the cloud, a device that was removed from the namespace reuses its removed slot.
+func (s *service) bulkDevicesAccept(ctx context.Context, tenant string, devices []*models.Device) (func(i int, device *models.Device) error, error) {
+	ns, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	cloud := envs.IsCloud() && ns.HasMaxDevices()
+
+	replaced := make(map[string]*models.Device)
+	removed := make(map[string]bool)
+	failed := make(map[string]error)
+
+	var fresh, needed int
+	for _, device := range devices {
+		if device.Status == models.DeviceStatusAccepted {
+			failed[device.UID] = NewErrDeviceStatusAccepted(nil)
+
+			continue
+		}
+
+		if cloud {
+			slot, err := s.store.DeviceRemovedGet(ctx, tenant, models.UID(device.UID))
+			if err != nil && err != store.ErrNoDocuments {
+				return nil, NewErrDeviceRemovedGet(err)
+			}
+
+			if slot != nil {
+				removed[device.UID] = true
+			} else {
+				fresh++
+			}
+		}
+
+		if device.Identity != nil {
+			sameMacDev, err := s.store.DeviceGetByMac(ctx, device.Identity.MAC, tenant, models.DeviceStatusAccepted)
+			if err != nil && err != store.ErrNoDocuments {
+				return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
+			}
+
+			if sameMacDev != nil && sameMacDev.UID != device.UID {
+				replaced[device.UID] = sameMacDev
+
+				continue
+			}
+		}
+
+		needed++
+	}
+
+	if cloud && fresh > 0 {
+		count, err := s.store.DeviceRemovedCount(ctx, tenant)
+		if err != nil {
+			return nil, NewErrDeviceRemovedCount(err)
+		}
+
+		if ns.HasMaxDevicesReached(count + int64(fresh-1)) {
+			return nil, NewErrDeviceRemovedFull(ns.MaxDevices, nil)
+		}
+	}
+
+	if ns.MaxDevices > 0 && ns.DevicesCount+needed > ns.MaxDevices {
+		return nil, NewErrDeviceLimit(ns.MaxDevices, nil)
+	}
+
+	return func(_ int, device *models.Device) error {
+		if err, ok := failed[device.UID]; ok {
+			return err
+		}
+
+		uid := models.UID(device.UID)
+
+		if removed[device.UID] {
+			if err := s.store.DeviceRemovedDelete(ctx, tenant, uid); err != nil {
+				return NewErrDeviceRemovedDelete(err)
+			}
+		}
+
+		if sameMacDev, ok := replaced[device.UID]; ok {
+			if err := s.store.SessionUpdateDeviceUID(ctx, models.UID(sameMacDev.UID), uid); err != nil {
+				return err
+			}
+
+			if err := s.store.DeviceDelete(ctx, models.UID(sameMacDev.UID)); err != nil {
+				return err
+			}
+
+			if err := s.store.DeviceRename(ctx, uid, sameMacDev.Name); err != nil {
+				return err
+			}
+		} else if err := createReportUsage(s.client.(req.Client), ns, true, device); err != nil {
+			return err
+		}
+
+		if err := s.store.DeviceUpdateStatus(ctx, uid, models.DeviceStatusAccepted); err != nil {
+			return err
+		}
+
+		s.audit(ctx, tenant, models.AuditActionDeviceUpdateStatus, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]interface{}{"status": device.Status}, map[string]interface{}{"status": models.DeviceStatusAccepted})
+
+		accepted := *device
+		accepted.Status = models.DeviceStatusAccepted
+		s.dispatch(ctx, tenant, webhook.WebhookDeviceAcceptedEvent, &accepted)
+
+		return nil
+	}, nil
+}
+
+// deviceBulkError returns the message reported for a device whose action failed. Only the messages of the service's
+// errors are reported, as the other ones can contain details from the inner layers.
+func deviceBulkError(err error) string {
+	if e, ok := err.(errors.Error); ok && e.Layer == ErrLayer {
+		return e.Message
+	}
+
+	return "internal error"
+}
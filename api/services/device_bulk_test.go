@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"text/template"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkDevices(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	envs.DefaultBackend = envMock
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	query := paginator.Query{Page: 1, PerPage: DeviceBulkMax}
+
+	groupFilters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"prod"}}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}
+
+	_, parseErr := template.New("name").Option("missingkey=error").Parse("{{ .Index")
+
+	pending := func(uid, name, mac string) *models.Device {
+		return &models.Device{
+			UID:      uid,
+			Name:     name,
+			TenantID: "tenant",
+			Status:   models.DeviceStatusPending,
+			Identity: &models.DeviceIdentity{MAC: mac},
+		}
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceBulk
+		requiredMocks func()
+		expected      *responses.DeviceBulk
+		err           error
+	}{
+		{
+			description: "reports the UIDs that are not found in the namespace",
+			req:         requests.DeviceBulk{UIDs: []string{"missing", "uid"}, Action: "add_tag", Tag: "prod"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("missing"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Name: "name", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", Name: "name", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceCreateTag", ctx, models.UID("uid"), "prod").Return(nil).Once()
+			},
+			expected: &responses.DeviceBulk{
+				Succeeded: 1,
+				Failed:    1,
+				Results: []responses.DeviceBulkResult{
+					{UID: "missing", Error: ErrDeviceNotFound.(errors.Error).Message},
+					{UID: "uid", Name: "name", Success: true},
+				},
+			},
+		},
+		{
+			description: "fails when the filter is invalid",
+			req:         requests.DeviceBulk{Filter: "W3sidHlwZSI6InVua25vd24ifV0=", Action: "remove"},
+			requiredMocks: func() {
+			},
+			err: NewErrDeviceFilterInvalid("W3sidHlwZSI6InVua25vd24ifV0=", models.ErrFilterInvalid),
+		},
+		{
+			description: "fails when the selection is greater than the limit",
+			req:         requests.DeviceBulk{Group: "id", Action: "remove"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Filter: tagFilter}, nil).Once()
+				storeMock.On("DeviceList", ctx, query, groupFilters, models.DeviceStatus(""), "name", "asc", store.DeviceListModeDefault).
+					Return([]models.Device{}, DeviceBulkMax+1, nil).Once()
+			},
+			err: NewErrDeviceBulkLimit(DeviceBulkMax, nil),
+		},
+		{
+			description: "fails the whole batch when accepting it exceeds the namespace's limit",
+			req:         requests.DeviceBulk{UIDs: []string{"uid1", "uid2"}, Action: "accept"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid1"), "tenant").Return(pending("uid1", "one", "mac1"), nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid2"), "tenant").Return(pending("uid2", "two", "mac2"), nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", MaxDevices: 3, DevicesCount: 2}, nil).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				storeMock.On("DeviceGetByMac", ctx, "mac1", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGetByMac", ctx, "mac2", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrDeviceLimit(3, nil),
+		},
+		{
+			description: "accepts the batch when the devices replacing others with the same MAC fit in the limit",
+			req:         requests.DeviceBulk{UIDs: []string{"uid1", "uid2"}, Action: "accept"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid1"), "tenant").Return(pending("uid1", "one", "mac1"), nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid2"), "tenant").Return(pending("uid2", "two", "mac2"), nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", MaxDevices: 3, DevicesCount: 2}, nil).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				storeMock.On("DeviceGetByMac", ctx, "mac1", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGetByMac", ctx, "mac2", "tenant", models.DeviceStatusAccepted).Return(&models.Device{UID: "old", Name: "old"}, nil).Once()
+				storeMock.On("DeviceUpdateStatus", ctx, models.UID("uid1"), models.DeviceStatusAccepted).Return(nil).Once()
+				storeMock.On("SessionUpdateDeviceUID", ctx, models.UID("old"), models.UID("uid2")).Return(nil).Once()
+				storeMock.On("DeviceDelete", ctx, models.UID("old")).Return(nil).Once()
+				storeMock.On("DeviceRename", ctx, models.UID("uid2"), "old").Return(Err).Once()
+			},
+			expected: &responses.DeviceBulk{
+				Succeeded: 1,
+				Failed:    1,
+				Results: []responses.DeviceBulkResult{
+					{UID: "uid1", Name: "one", Success: true},
+					{UID: "uid2", Name: "two", Error: "internal error"},
+				},
+			},
+		},
+		{
+			description: "fails when the template is invalid",
+			req:         requests.DeviceBulk{UIDs: []string{"uid"}, Action: "rename", Template: "{{ .Index"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Name: "name", TenantID: "tenant"}, nil).Once()
+			},
+			err: NewErrDeviceBulkTemplateInvalid("{{ .Index", parseErr),
+		},
+		{
+			description: "renames the devices selected by a group from a template",
+			req:         requests.DeviceBulk{Group: "id", Action: "rename", Template: "web-{{ .Index }}-{{ .Device.Name }}"},
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Filter: tagFilter}, nil).Once()
+				storeMock.On("DeviceList", ctx, query, groupFilters, models.DeviceStatus(""), "name", "asc", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid1", Name: "a", TenantID: "tenant"}, {UID: "uid2", Name: "b", TenantID: "tenant"}}, 2, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid1"), "tenant").Return(&models.Device{UID: "uid1", Name: "a", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "web-1-a", "tenant").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceRename", ctx, models.UID("uid1"), "web-1-a").Return(nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid2"), "tenant").Return(&models.Device{UID: "uid2", Name: "b", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				storeMock.On("DeviceGetByName", ctx, "web-2-b", "tenant").Return(&models.Device{UID: "uid3", Name: "web-2-b"}, nil).Once()
+			},
+			expected: &responses.DeviceBulk{
+				Succeeded: 1,
+				Failed:    1,
+				Results: []responses.DeviceBulkResult{
+					{UID: "uid1", Name: "a", Success: true},
+					{UID: "uid2", Name: "b", Error: ErrDeviceDuplicated.(errors.Error).Message},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.BulkDevices(ctx, "tenant", tc.req)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, res)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
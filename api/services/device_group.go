@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupService interface {
+	CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error)
+	ListDeviceGroups(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	UpdateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	DeleteDeviceGroup(ctx context.Context, tenant, id string) error
+	ListDeviceGroupDevices(ctx context.Context, tenant, id string, pagination paginator.Query, status models.DeviceStatus, sort, order string) ([]models.Device, int, error)
+}
+
+// CreateDeviceGroup saves a named device query in a namespace.
+func (s *service) CreateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if _, err := models.DecodeFilter(req.Filter); err != nil {
+		return nil, NewErrDeviceGroupFilterInvalid(req.Filter, err)
+	}
+
+	group := &models.DeviceGroup{
+		TenantID:  tenant,
+		Name:      req.Name,
+		Filter:    req.Filter,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.DeviceGroupCreate(ctx, group); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrDeviceGroupDuplicated(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceGroupCreate, models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: group.ID}, nil, deviceGroupAuditFields(group))
+
+	return group, nil
+}
+
+// ListDeviceGroups lists the device groups of a namespace, sorted by name.
+func (s *service) ListDeviceGroups(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	return s.store.DeviceGroupList(ctx, tenant, pagination)
+}
+
+func (s *service) GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+// UpdateDeviceGroup updates the name and the filter of a namespace's device group.
+func (s *service) UpdateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	group, err := s.GetDeviceGroup(ctx, tenant, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := models.DecodeFilter(req.Filter); err != nil {
+		return nil, NewErrDeviceGroupFilterInvalid(req.Filter, err)
+	}
+
+	before := deviceGroupAuditFields(group)
+
+	group.Name = req.Name
+	group.Filter = req.Filter
+
+	if err := s.store.DeviceGroupUpdate(ctx, group); err != nil {
+		switch err {
+		case store.ErrDuplicate:
+			return nil, NewErrDeviceGroupDuplicated(req.Name, err)
+		case store.ErrNoDocuments:
+			return nil, NewErrDeviceGroupNotFound(req.ID, err)
+		default:
+			return nil, err
+		}
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceGroupUpdate, models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: group.ID}, before, deviceGroupAuditFields(group))
+
+	return group, nil
+}
+
+// DeleteDeviceGroup deletes a namespace's device group.
+//
+// The public keys and the firewall rules filtered by the group are kept, but they stop matching any device.
+func (s *service) DeleteDeviceGroup(ctx context.Context, tenant, id string) error {
+	group, err := s.GetDeviceGroup(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.DeviceGroupDelete(ctx, tenant, id); err != nil {
+		return NewErrDeviceGroupNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionDeviceGroupDelete, models.AuditTarget{Type: models.AuditTargetDeviceGroup, ID: id}, deviceGroupAuditFields(group), nil)
+
+	return nil
+}
+
+// ListDeviceGroupDevices lists the devices of a namespace that currently match a device group's filter.
+func (s *service) ListDeviceGroupDevices(ctx context.Context, tenant, id string, pagination paginator.Query, status models.DeviceStatus, sort, order string) ([]models.Device, int, error) {
+	group, err := s.GetDeviceGroup(ctx, tenant, id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filters, err := deviceGroupFilter(group)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.DeviceList(ctx, pagination, filters, status, sort, order, store.DeviceListModeDefault)
+}
+
+// matchDeviceGroup checks if a device is currently matched by a namespace's device group.
+func (s *service) matchDeviceGroup(ctx context.Context, tenant, id string, device *models.Device) (bool, error) {
+	group, err := s.GetDeviceGroup(ctx, tenant, id)
+	if err != nil {
+		return false, err
+	}
+
+	filters, err := deviceGroupFilter(group, models.Filter{
+		Type:   "property",
+		Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: string(device.UID)},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	_, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: 1}, filters, "", "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// checkDeviceGroup checks if a device group used by a filter exists in the namespace.
+func (s *service) checkDeviceGroup(ctx context.Context, tenant, id string) error {
+	if id == "" {
+		return nil
+	}
+
+	_, err := s.GetDeviceGroup(ctx, tenant, id)
+
+	return err
+}
+
+// deviceGroupFilter returns the filters that select the devices of a group, restricted to the group's namespace and
+// to the extra properties.
+func deviceGroupFilter(group *models.DeviceGroup, extra ...models.Filter) ([]models.Filter, error) {
+	filters, err := models.DecodeFilter(group.Filter)
+	if err != nil {
+		return nil, NewErrDeviceGroupFilterInvalid(group.Filter, err)
+	}
+
+	return scopeDeviceFilter(filters, group.TenantID, extra...), nil
+}
+
+// closeDeviceFilter closes the group of the properties at the end of the filters, joining them by OR as the stores do
+// with the properties not followed by an operator, so the properties appended after it form their own group. The
+// filters ending with an unknown operator are closed too, as the stores skip it.
+func closeDeviceFilter(filters []models.Filter) []models.Filter {
+	if len(filters) > 0 && !filters[len(filters)-1].IsOperator() {
+		filters = append(filters, models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "or"}})
+	}
+
+	return filters
+}
+
+// scopeDeviceFilter restricts the devices selected by the filters to a namespace and to the extra properties. The
+// filters are closed before the namespace's and the extra properties, joined by AND, are appended.
+func scopeDeviceFilter(filters []models.Filter, tenant string, extra ...models.Filter) []models.Filter {
+	filters = closeDeviceFilter(filters)
+
+	filters = append(filters, models.Filter{
+		Type:   "property",
+		Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: tenant},
+	})
+	filters = append(filters, extra...)
+	filters = append(filters, models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "and"}})
+
+	return filters
+}
+
+func deviceGroupAuditFields(group *models.DeviceGroup) map[string]interface{} {
+	return map[string]interface{}{
+		"name":   group.Name,
+		"filter": group.Filter,
+	}
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// tagFilter is the encoded filter of the devices tagged with "prod".
+var tagFilter = base64.StdEncoding.EncodeToString([]byte(`[{"type":"property","params":{"name":"tags","operator":"contains","value":["prod"]}}]`))
+
+func TestCreateDeviceGroup(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	invalid := base64.StdEncoding.EncodeToString([]byte(`[{"type":"unknown"}]`))
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceGroupCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace does not exist",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: requests.DeviceGroupFields{Name: "prod", Filter: tagFilter}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the filter is invalid",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: requests.DeviceGroupFields{Name: "prod", Filter: invalid}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: NewErrDeviceGroupFilterInvalid(invalid, models.ErrFilterInvalid),
+		},
+		{
+			description: "fails when the name is already used in the namespace",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: requests.DeviceGroupFields{Name: "prod", Filter: tagFilter}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("DeviceGroupCreate", ctx, mock.Anything).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrDeviceGroupDuplicated("prod", store.ErrDuplicate),
+		},
+		{
+			description: "succeeds",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: requests.DeviceGroupFields{Name: "prod", Filter: tagFilter}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("DeviceGroupCreate", ctx, &models.DeviceGroup{TenantID: "tenant", Name: "prod", Filter: tagFilter, CreatedAt: now}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.CreateDeviceGroup(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceGroup(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	req := requests.DeviceGroupUpdate{
+		DeviceGroupParam:  requests.DeviceGroupParam{ID: "id"},
+		DeviceGroupFields: requests.DeviceGroupFields{Name: "production", Filter: tagFilter},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the group does not exist in the namespace",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("id", Err),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Name: "prod", Filter: "W10="}, nil).Once()
+				storeMock.On("DeviceGroupUpdate", ctx, &models.DeviceGroup{ID: "id", TenantID: "tenant", Name: "production", Filter: tagFilter}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.UpdateDeviceGroup(ctx, "tenant", req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceGroup(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the group does not exist in the namespace",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("id", Err),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceGroupDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.DeleteDeviceGroup(ctx, "tenant", "id"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListDeviceGroupDevices(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"prod"}}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}
+
+	storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Filter: tagFilter}, nil).Once()
+	storeMock.On("DeviceList", ctx, query, filters, models.DeviceStatusAccepted, "name", "asc", store.DeviceListModeDefault).
+		Return([]models.Device{{UID: "uid"}}, 1, nil).Once()
+
+	devices, count, err := s.ListDeviceGroupDevices(ctx, "tenant", "id", query, models.DeviceStatusAccepted, "name", "asc")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.Device{{UID: "uid"}}, devices)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEvaluateKeyFilterDeviceGroup(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	key := &models.PublicKey{
+		TenantID:        "tenant",
+		PublicKeyFields: models.PublicKeyFields{Filter: models.PublicKeyFilter{Group: "id"}},
+	}
+
+	device := models.Device{UID: "uid", TenantID: "tenant"}
+
+	matching := mock.MatchedBy(func(filters []models.Filter) bool {
+		params, ok := filters[len(filters)-2].Params.(*models.PropertyParams)
+
+		return ok && params.Name == "uid" && params.Value == "uid"
+	})
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      bool
+		err           error
+	}{
+		{
+			description: "fails when the group does not exist",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: false,
+			err:      NewErrDeviceGroupNotFound("id", Err),
+		},
+		{
+			description: "does not match when the device is not in the group",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Filter: tagFilter}, nil).Once()
+				storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, matching, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: false,
+			err:      nil,
+		},
+		{
+			description: "matches when the device is in the group",
+			requiredMocks: func() {
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "id").Return(&models.DeviceGroup{ID: "id", TenantID: "tenant", Filter: tagFilter}, nil).Once()
+				storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, matching, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{device}, 1, nil).Once()
+			},
+			expected: true,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			ok, err := s.EvaluateKeyFilter(ctx, key, device)
+			assert.Equal(t, tc.expected, ok)
+			assert.Equal(t, tc.err, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
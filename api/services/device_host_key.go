@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+// DeviceHostKey contains the service's function to manage the SSH host keys pinned to the devices.
+type DeviceHostKey interface {
+	RotateDeviceHostKey(ctx context.Context, tenant string, uid models.UID, hostKey string) error
+	ReportDeviceHostKeyMismatch(ctx context.Context, uid models.UID, hostKey string) error
+}
+
+// RotateDeviceHostKey pins a new host key to a device, replacing the one pinned at its registration, e.g. when its
+// agent was reinstalled with another key.
+//
+// If the host key is not a valid SSH public key, a NewErrDeviceHostKeyInvalid error will be returned.
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+func (s *service) RotateDeviceHostKey(ctx context.Context, tenant string, uid models.UID, hostKey string) error {
+	key, err := normalizeHostKey(hostKey)
+	if err != nil {
+		return NewErrDeviceHostKeyInvalid(err)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DeviceSetHostKey(ctx, uid, key); err != nil {
+		return err
+	}
+
+	var before map[string]interface{}
+	if device.HostKey != "" {
+		before = map[string]interface{}{"host_key": hostKeyFingerprint(device.HostKey)}
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceHostKeyRotate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, before, map[string]interface{}{"host_key": hostKeyFingerprint(key)})
+
+	return nil
+}
+
+// ReportDeviceHostKeyMismatch alerts the device's namespace that a host key other than the pinned one was presented
+// on the device's behalf.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) ReportDeviceHostKeyMismatch(ctx context.Context, uid models.UID, hostKey string) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	s.alertHostKeyMismatch(ctx, device, hostKey)
+
+	return nil
+}
+
+// alertHostKeyMismatch logs and dispatches to the namespace's webhooks that the device presented a host key other than
+// the one pinned to it.
+func (s *service) alertHostKeyMismatch(ctx context.Context, device *models.Device, hostKey string) {
+	logrus.WithFields(logrus.Fields{
+		"tenant":      device.TenantID,
+		"uid":         device.UID,
+		"fingerprint": hostKeyFingerprint(hostKey),
+		"pinned":      hostKeyFingerprint(device.HostKey),
+	}).Error("The device presented a host key other than the one pinned to it")
+
+	s.dispatch(ctx, device.TenantID, webhook.WebhookDeviceHostKeyMismatchEvent, &models.DeviceHostKeyMismatch{
+		UID:           device.UID,
+		Name:          device.Name,
+		HostKey:       hostKey,
+		PinnedHostKey: device.HostKey,
+	})
+}
+
+// normalizeHostKey parses a host key in the authorized keys format, returning it without its comment so the keys can
+// be compared as strings.
+func normalizeHostKey(hostKey string) (string, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey)) //nolint:dogsled
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(key)), "\n"), nil
+}
+
+// hostKeyFromPublicKey returns, in the authorized keys format, the host key of the agents that do not send theirs,
+// which is the RSA public key, PEM encoded, they register with.
+func hostKeyFromPublicKey(publicKey string) (string, error) {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return "", errors.New("public key is not PEM encoded")
+	}
+
+	rsaKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := ssh.NewPublicKey(rsaKey)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(key)), "\n"), nil
+}
+
+// hostKeyFingerprint returns the SHA256 fingerprint of a host key, or the host key itself when it cannot be parsed.
+func hostKeyFingerprint(hostKey string) string {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey)) //nolint:dogsled
+	if err != nil {
+		return hostKey
+	}
+
+	return ssh.FingerprintSHA256(key)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestRotateDeviceHostKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(public)
+	assert.NoError(t, err)
+
+	hostKey := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(key)), "\n")
+
+	_, _, _, _, parseErr := ssh.ParseAuthorizedKey([]byte("invalid")) //nolint:dogsled
+
+	cases := []struct {
+		description   string
+		tenant        string
+		hostKey       string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the host key is invalid",
+			tenant:        "tenant",
+			hostKey:       "invalid",
+			requiredMocks: func() {},
+			expected:      NewErrDeviceHostKeyInvalid(parseErr),
+		},
+		{
+			description: "fails when the device does not exist",
+			tenant:      "tenant",
+			hostKey:     hostKey,
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), Err),
+		},
+		{
+			description: "fails when the device is in another namespace",
+			tenant:      "other",
+			hostKey:     hostKey,
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "other").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the host key cannot be pinned",
+			tenant:      "tenant",
+			hostKey:     hostKey,
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceSetHostKey", ctx, models.UID("uid"), hostKey).Return(Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds pinning the host key without its comment",
+			tenant:      "tenant",
+			hostKey:     hostKey + " root@device\n",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", TenantID: "tenant", HostKey: "ssh-rsa pinned"}, nil).Once()
+				storeMock.On("DeviceSetHostKey", ctx, models.UID("uid"), hostKey).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.RotateDeviceHostKey(ctx, tc.tenant, models.UID("uid"), tc.hostKey))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestReportDeviceHostKeyMismatch(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device does not exist",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), Err),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", HostKey: "ssh-rsa pinned"}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.ReportDeviceHostKeyMismatch(ctx, models.UID("uid"), "ssh-ed25519 presented"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -36,7 +36,13 @@ func (s *service) CreateDeviceTag(ctx context.Context, uid models.UID, tag strin
 		return NewErrTagDuplicated(tag, nil)
 	}
 
-	return s.store.DeviceCreateTag(ctx, uid, tag)
+	if err := s.store.DeviceCreateTag(ctx, uid, tag); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceTagCreate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, nil, map[string]interface{}{"tag": tag})
+
+	return nil
 }
 
 // RemoveDeviceTag removes a tag from a device. UID is the device's UID and tag is the tag's name.
@@ -54,7 +60,13 @@ func (s *service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag strin
 		return NewErrTagNotFound(tag, nil)
 	}
 
-	return s.store.DeviceRemoveTag(ctx, uid, tag)
+	if err := s.store.DeviceRemoveTag(ctx, uid, tag); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceTagRemove, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"tag": tag}, nil)
+
+	return nil
 }
 
 // UpdateDeviceTag updates a device's tags. UID is the device's UID and tags is the new tags.
@@ -83,5 +95,11 @@ func (s *service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []st
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	return s.store.DeviceUpdateTag(ctx, uid, set)
+	if err := s.store.DeviceUpdateTag(ctx, uid, set); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditActionDeviceTagUpdate, models.AuditTarget{Type: models.AuditTargetDevice, ID: string(uid)}, map[string]interface{}{"tags": device.Tags}, map[string]interface{}{"tags": set})
+
+	return nil
 }
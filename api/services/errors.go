@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -29,6 +30,8 @@ const (
 	// ErrCodeStore is the error code for when the store function fails. The store function is responsible for execute
 	// the main service action.
 	ErrCodeStore
+	// ErrCodeTooManyRequests is the error code for when the access to a resource is locked out after too many attempts.
+	ErrCodeTooManyRequests
 )
 
 // ErrDataNotFound structure should be used to add errors.Data to an error when the resource is not found.
@@ -49,6 +52,12 @@ type ErrDataLimit struct {
 	Limit int
 }
 
+// ErrDataLocked structure should be used to add errors.Data to an error when the access is locked out.
+type ErrDataLocked struct {
+	// RetryAfter is the number of seconds until the lockout ends.
+	RetryAfter int
+}
+
 // ErrDataInvalid structure should be used to add errors.Data to an error when the resource is invalid.
 type ErrDataInvalid struct {
 	// Data is a key-value map of the invalid fields. key must be the field name what is invalid and value must be the
@@ -106,6 +115,7 @@ var (
 	ErrTokenSigned               = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion             = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound           = errors.New("session not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionRecordNotFound     = errors.New("session record not found", ErrLayer, ErrCodeNotFound)
 	ErrAuthInvalid               = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized           = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
 	ErrNamespaceLimitReached     = errors.New("namespace limit reached", ErrLayer, ErrCodeLimit)
@@ -114,6 +124,49 @@ var (
 	ErrDeviceRemovedFull         = errors.New("device removed full", ErrLayer, ErrCodePayment)
 	ErrDeviceRemovedDelete       = errors.New("device removed delete", ErrLayer, ErrCodeStore)
 	ErrDeviceRemovedGet          = errors.New("device removed get", ErrLayer, ErrCodeNotFound)
+	ErrFirewallRuleNotFound      = errors.New("firewall rule not found", ErrLayer, ErrCodeNotFound)
+	ErrFirewallRuleInvalid       = errors.New("firewall rule invalid", ErrLayer, ErrCodeInvalid)
+	ErrFirewallRuleFilter        = errors.New("firewall rule cannot have more than one filter at same time", ErrLayer, ErrCodeInvalid)
+	ErrFirewallBlock             = errors.New("a firewall rule blocks the connection", ErrLayer, ErrCodeForbidden)
+	ErrAPIKeyNotFound            = errors.New("api key not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookNotFound           = errors.New("webhook not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookDeliveryNotFound   = errors.New("webhook delivery not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookUnavailable        = errors.New("webhook deliveries are unavailable", ErrLayer, ErrCodeInvalid)
+	ErrDeviceGroupNotFound       = errors.New("device group not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupDuplicated     = errors.New("device group duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceGroupFilterInvalid  = errors.New("device group filter invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceAttributeNotFound   = errors.New("device attribute not found", ErrLayer, ErrCodeNotFound)
+	ErrMaxDeviceAttributeReached = errors.New("device attribute limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceFilterInvalid       = errors.New("device filter invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceBulkLimit           = errors.New("device bulk limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceBulkTemplateInvalid = errors.New("device bulk template invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceBulkActionInvalid   = errors.New("device bulk action invalid", ErrLayer, ErrCodeInvalid)
+	ErrInvitationNotFound        = errors.New("invitation not found", ErrLayer, ErrCodeNotFound)
+	ErrInvitationDuplicated      = errors.New("invitation duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrInvitationExpired         = errors.New("invitation expired", ErrLayer, ErrCodeInvalid)
+	ErrInvitationEmailMismatch   = errors.New("invitation was sent to another email", ErrLayer, ErrCodeForbidden)
+	ErrInvitationUnavailable     = errors.New("invitations are unavailable", ErrLayer, ErrCodeInvalid)
+	ErrMFAAlreadyEnabled         = errors.New("mfa already enabled", ErrLayer, ErrCodeInvalid)
+	ErrMFANotEnabled             = errors.New("mfa not enabled", ErrLayer, ErrCodeInvalid)
+	ErrMFANotGenerated           = errors.New("mfa secret not generated", ErrLayer, ErrCodeInvalid)
+	ErrMFACodeInvalid            = errors.New("mfa code invalid", ErrLayer, ErrCodeUnauthorized)
+	ErrMFATokenInvalid           = errors.New("mfa token invalid or expired", ErrLayer, ErrCodeUnauthorized)
+	ErrMFARequired               = errors.New("namespace requires mfa", ErrLayer, ErrCodeForbidden)
+	ErrOIDCUnavailable           = errors.New("oidc login is unavailable", ErrLayer, ErrCodeInvalid)
+	ErrOIDCStateInvalid          = errors.New("oidc state invalid or expired", ErrLayer, ErrCodeUnauthorized)
+	ErrOIDCAuthFailed            = errors.New("oidc authentication failed", ErrLayer, ErrCodeUnauthorized)
+	ErrOIDCEmailNotVerified      = errors.New("oidc email not verified", ErrLayer, ErrCodeForbidden)
+	ErrLDAPEmailMissing          = errors.New("ldap user has no email", ErrLayer, ErrCodeForbidden)
+	ErrAuthLocked                = errors.New("too many failed login attempts", ErrLayer, ErrCodeTooManyRequests)
+	ErrRoleNotFound              = errors.New("role not found", ErrLayer, ErrCodeNotFound)
+	ErrRoleDuplicated            = errors.New("role duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrRoleInvalid               = errors.New("role invalid", ErrLayer, ErrCodeInvalid)
+	ErrRoleInUse                 = errors.New("role is assigned to members", ErrLayer, ErrCodeInvalid)
+	ErrAccessGrantNotFound       = errors.New("access grant not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessRequestNotFound     = errors.New("access request not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessRequestDecided      = errors.New("access request already decided", ErrLayer, ErrCodeInvalid)
+	ErrAccessRequestOwn          = errors.New("access request cannot be decided by its requester", ErrLayer, ErrCodeForbidden)
+	ErrDeviceHostKeyInvalid      = errors.New("device host key invalid", ErrLayer, ErrCodeInvalid)
 )
 
 // NewErrNotFound returns an error with the ErrDataNotFound and wrap an error.
@@ -136,6 +189,12 @@ func NewErrLimit(err error, limit int, next error) error {
 	return errors.Wrap(errors.WithData(err, ErrDataLimit{Limit: limit}), next)
 }
 
+// NewErrLocked returns an error with the ErrDataLocked and wrap an error.
+func NewErrLocked(err error, retryAfter time.Duration, next error) error {
+	// The seconds are rounded up, so the lockout has ended when they pass.
+	return errors.Wrap(errors.WithData(err, ErrDataLocked{RetryAfter: int((retryAfter + time.Second - 1) / time.Second)}), next)
+}
+
 // NewErrStore return an error to be used when the main store function fails.
 //
 // A service can make n calls to store's function, but each service has your main action; what it was made to do. For
@@ -265,6 +324,11 @@ func NewErrSessionNotFound(id models.UID, next error) error {
 	return NewErrNotFound(ErrSessionNotFound, string(id), next)
 }
 
+// NewErrSessionRecordNotFound returns an error when the session has no recorded frames.
+func NewErrSessionRecordNotFound(id models.UID, next error) error {
+	return NewErrNotFound(ErrSessionRecordNotFound, string(id), next)
+}
+
 // NewErrNamespaceList return an error to be used when cannot list namespaces.
 func NewErrNamespaceList(next error) error {
 	return NewErrInvalid(ErrNamespaceList, nil, next)
@@ -397,3 +461,219 @@ func NewErrDeviceRemovedDelete(next error) error {
 func NewErrDeviceRemovedGet(next error) error {
 	return NewErrInvalid(ErrDeviceRemovedGet, nil, next)
 }
+
+// NewErrFirewallRuleNotFound returns an error when the firewall rule is not found.
+func NewErrFirewallRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrFirewallRuleNotFound, id, next)
+}
+
+// NewErrFirewallRuleInvalid returns an error when the firewall rule's fields are invalid.
+func NewErrFirewallRuleInvalid(next error) error {
+	return NewErrInvalid(ErrFirewallRuleInvalid, nil, next)
+}
+
+// NewErrFirewallRuleFilter returns an error when a tag operation is done over a firewall rule that cannot receive it.
+func NewErrFirewallRuleFilter(next error) error {
+	return NewErrInvalid(ErrFirewallRuleFilter, nil, next)
+}
+
+// NewErrFirewallBlock returns an error when a firewall rule blocks a connection.
+func NewErrFirewallBlock(next error) error {
+	return NewErrForbidden(ErrFirewallBlock, next)
+}
+
+// NewErrAPIKeyNotFound returns an error when the API key is not found.
+func NewErrAPIKeyNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAPIKeyNotFound, id, next)
+}
+
+// NewErrWebhookNotFound returns an error when the webhook is not found.
+func NewErrWebhookNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookNotFound, id, next)
+}
+
+// NewErrWebhookDeliveryNotFound returns an error when the webhook's delivery is not found.
+func NewErrWebhookDeliveryNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookDeliveryNotFound, id, next)
+}
+
+// NewErrWebhookUnavailable returns an error when the webhook's deliveries cannot be enqueued.
+func NewErrWebhookUnavailable(next error) error {
+	return NewErrInvalid(ErrWebhookUnavailable, nil, next)
+}
+
+// NewErrDeviceGroupNotFound returns an error when the device group is not found.
+func NewErrDeviceGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceGroupNotFound, id, next)
+}
+
+// NewErrDeviceGroupDuplicated returns an error when the namespace already has a device group with the name.
+func NewErrDeviceGroupDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrDeviceGroupDuplicated, []string{name}, next)
+}
+
+// NewErrDeviceGroupFilterInvalid returns an error when the device group's filter cannot be decoded.
+func NewErrDeviceGroupFilterInvalid(filter string, next error) error {
+	return NewErrInvalid(ErrDeviceGroupFilterInvalid, map[string]interface{}{"filter": filter}, next)
+}
+
+// NewErrDeviceAttributeNotFound returns an error when the device does not have the attribute.
+func NewErrDeviceAttributeNotFound(key string, next error) error {
+	return NewErrNotFound(ErrDeviceAttributeNotFound, key, next)
+}
+
+// NewErrDeviceAttributeLimit returns an error when the device's attribute limit is reached.
+func NewErrDeviceAttributeLimit(limit int, next error) error {
+	return NewErrLimit(ErrMaxDeviceAttributeReached, limit, next)
+}
+
+// NewErrDeviceFilterInvalid returns an error when the filter of devices cannot be decoded.
+func NewErrDeviceFilterInvalid(filter string, next error) error {
+	return NewErrInvalid(ErrDeviceFilterInvalid, map[string]interface{}{"filter": filter}, next)
+}
+
+// NewErrDeviceBulkLimit returns an error when a bulk operation selects more devices than the limit.
+func NewErrDeviceBulkLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceBulkLimit, limit, next)
+}
+
+// NewErrDeviceBulkTemplateInvalid returns an error when the template of the devices' names cannot be parsed.
+func NewErrDeviceBulkTemplateInvalid(template string, next error) error {
+	return NewErrInvalid(ErrDeviceBulkTemplateInvalid, map[string]interface{}{"template": template}, next)
+}
+
+// NewErrDeviceBulkActionInvalid returns an error when the action of a bulk operation on devices is not known.
+func NewErrDeviceBulkActionInvalid(action string, next error) error {
+	return NewErrInvalid(ErrDeviceBulkActionInvalid, map[string]interface{}{"action": action}, next)
+}
+
+// NewErrInvitationNotFound returns an error when the invitation is not found.
+func NewErrInvitationNotFound(id string, next error) error {
+	return NewErrNotFound(ErrInvitationNotFound, id, next)
+}
+
+// NewErrInvitationDuplicated returns an error when the email already has a pending invitation to the namespace.
+func NewErrInvitationDuplicated(email string, next error) error {
+	return NewErrDuplicated(ErrInvitationDuplicated, []string{email}, next)
+}
+
+// NewErrInvitationExpired returns an error when the invitation is expired.
+func NewErrInvitationExpired(next error) error {
+	return NewErrInvalid(ErrInvitationExpired, nil, next)
+}
+
+// NewErrInvitationEmailMismatch returns an error when the user who accepts the invitation is not the invited one.
+func NewErrInvitationEmailMismatch(next error) error {
+	return NewErrForbidden(ErrInvitationEmailMismatch, next)
+}
+
+// NewErrInvitationUnavailable returns an error when the invitation cannot be delivered.
+func NewErrInvitationUnavailable(next error) error {
+	return NewErrInvalid(ErrInvitationUnavailable, nil, next)
+}
+
+// NewErrMFAAlreadyEnabled returns an error when the user already has MFA enabled.
+func NewErrMFAAlreadyEnabled(next error) error {
+	return NewErrInvalid(ErrMFAAlreadyEnabled, nil, next)
+}
+
+// NewErrMFANotEnabled returns an error when the user does not have MFA enabled.
+func NewErrMFANotEnabled(next error) error {
+	return NewErrInvalid(ErrMFANotEnabled, nil, next)
+}
+
+// NewErrMFANotGenerated returns an error when MFA is enabled before its secret is generated.
+func NewErrMFANotGenerated(next error) error {
+	return NewErrInvalid(ErrMFANotGenerated, nil, next)
+}
+
+// NewErrMFACodeInvalid returns an error when the TOTP code, or the recovery code, is not valid.
+func NewErrMFACodeInvalid(next error) error {
+	return NewErrUnathorized(ErrMFACodeInvalid, next)
+}
+
+// NewErrMFATokenInvalid returns an error when the MFA-pending token is not valid or is expired.
+func NewErrMFATokenInvalid(next error) error {
+	return NewErrUnathorized(ErrMFATokenInvalid, next)
+}
+
+// NewErrMFARequired returns an error when the namespace requires MFA from a user who does not have it enabled.
+func NewErrMFARequired(next error) error {
+	return NewErrForbidden(ErrMFARequired, next)
+}
+
+// NewErrOIDCUnavailable returns an error when the OpenID Connect login is disabled or the provider is unreachable.
+func NewErrOIDCUnavailable(next error) error {
+	return NewErrInvalid(ErrOIDCUnavailable, nil, next)
+}
+
+// NewErrOIDCStateInvalid returns an error when the OpenID Connect login's state is not valid or is expired.
+func NewErrOIDCStateInvalid(next error) error {
+	return NewErrUnathorized(ErrOIDCStateInvalid, next)
+}
+
+// NewErrOIDCAuthFailed returns an error when the authorization code cannot be exchanged for a valid ID token.
+func NewErrOIDCAuthFailed(next error) error {
+	return NewErrUnathorized(ErrOIDCAuthFailed, next)
+}
+
+// NewErrOIDCEmailNotVerified returns an error when the OpenID Connect provider does not give a verified email.
+func NewErrOIDCEmailNotVerified(next error) error {
+	return NewErrForbidden(ErrOIDCEmailNotVerified, next)
+}
+
+// NewErrLDAPEmailMissing returns an error when the user authenticated by the LDAP directory has no email.
+func NewErrLDAPEmailMissing(next error) error {
+	return NewErrForbidden(ErrLDAPEmailMissing, next)
+}
+
+// NewErrAuthLocked returns an error when the login is locked out after too many failed attempts.
+func NewErrAuthLocked(retryAfter time.Duration, next error) error {
+	return NewErrLocked(ErrAuthLocked, retryAfter, next)
+}
+
+// NewErrRoleNotFound returns an error when the namespace has no custom role with the name.
+func NewErrRoleNotFound(name string, next error) error {
+	return NewErrNotFound(ErrRoleNotFound, name, next)
+}
+
+// NewErrRoleDuplicated returns an error when the namespace already has a custom role with the name.
+func NewErrRoleDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrRoleDuplicated, []string{name}, next)
+}
+
+// NewErrRoleInvalid returns an error when a custom role uses a builtin role's name or a permission that cannot be
+// granted.
+func NewErrRoleInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrRoleInvalid, data, next)
+}
+
+// NewErrRoleInUse returns an error when a custom role is deleted while members still have it.
+func NewErrRoleInUse(name string, next error) error {
+	return NewErrInvalid(ErrRoleInUse, map[string]interface{}{"name": name}, next)
+}
+
+// NewErrAccessGrantNotFound returns an error when the namespace has no access grant with the ID.
+func NewErrAccessGrantNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAccessGrantNotFound, id, next)
+}
+
+// NewErrAccessRequestNotFound returns an error when the namespace has no access request with the ID.
+func NewErrAccessRequestNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAccessRequestNotFound, id, next)
+}
+
+// NewErrAccessRequestDecided returns an error when an access request was already approved or denied.
+func NewErrAccessRequestDecided(id string, next error) error {
+	return NewErrInvalid(ErrAccessRequestDecided, map[string]interface{}{"id": id}, next)
+}
+
+// NewErrAccessRequestOwn returns an error when a member tries to approve or deny their own access request.
+func NewErrAccessRequestOwn(next error) error {
+	return NewErrForbidden(ErrAccessRequestOwn, next)
+}
+
+// NewErrDeviceHostKeyInvalid returns an error when the host key to pin to a device is not a valid SSH public key.
+func NewErrDeviceHostKeyInvalid(next error) error {
+	return NewErrInvalid(ErrDeviceHostKeyInvalid, nil, next)
+}
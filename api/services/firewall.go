@@ -0,0 +1,262 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// FirewallRuleActionAllow is the action of a firewall rule that allows the connection.
+	FirewallRuleActionAllow = "allow"
+	// FirewallRuleActionDeny is the action of a firewall rule that denies the connection.
+	FirewallRuleActionDeny = "deny"
+)
+
+type FirewallService interface {
+	ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error)
+	GetFirewallRule(ctx context.Context, tenant, id string) (*models.FirewallRule, error)
+	CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error)
+	UpdateFirewallRule(ctx context.Context, tenant, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error)
+	DeleteFirewallRule(ctx context.Context, tenant, id string) error
+	EvaluateFirewall(ctx context.Context, req requests.FirewallEvaluate) error
+}
+
+func (s *service) ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	return s.store.FirewallRuleList(ctx, pagination)
+}
+
+// GetFirewallRule gets a firewall rule from a namespace.
+//
+// It returns NewErrFirewallRuleNotFound when the rule does not exist or when it belongs to other namespace.
+func (s *service) GetFirewallRule(ctx context.Context, tenant, id string) (*models.FirewallRule, error) {
+	rule, err := s.store.FirewallRuleGet(ctx, id)
+	if err != nil || rule == nil {
+		return nil, NewErrFirewallRuleNotFound(id, err)
+	}
+
+	if rule.TenantID != tenant {
+		return nil, NewErrFirewallRuleNotFound(id, nil)
+	}
+
+	return rule, nil
+}
+
+// CreateFirewallRule creates a new firewall rule to a namespace.
+//
+// When the rule's filter is from tags type, all tags must exist in the namespace.
+func (s *service) CreateFirewallRule(ctx context.Context, tenant string, req requests.FirewallRuleCreate) (*models.FirewallRule, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if err := s.checkFirewallFilterTags(ctx, tenant, req.Filter.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+		return nil, err
+	}
+
+	rule := &models.FirewallRule{
+		TenantID:           tenant,
+		FirewallRuleFields: firewallRuleFieldsFromRequest(req.FirewallRuleFields),
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, NewErrFirewallRuleInvalid(err)
+	}
+
+	if err := s.store.FirewallRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleCreate, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: rule.ID}, nil, firewallRuleAuditFields(rule.FirewallRuleFields))
+
+	return rule, nil
+}
+
+// UpdateFirewallRule updates all fields of a namespace's firewall rule.
+func (s *service) UpdateFirewallRule(ctx context.Context, tenant, id string, req requests.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	current, err := s.GetFirewallRule(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkFirewallFilterTags(ctx, tenant, req.Filter.Tags); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+		return nil, err
+	}
+
+	model := models.FirewallRuleUpdate{
+		FirewallRuleFields: firewallRuleFieldsFromRequest(req.FirewallRuleFields),
+	}
+
+	if err := model.Validate(); err != nil {
+		return nil, NewErrFirewallRuleInvalid(err)
+	}
+
+	rule, err := s.store.FirewallRuleUpdate(ctx, id, model)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleUpdate, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}, firewallRuleAuditFields(current.FirewallRuleFields), firewallRuleAuditFields(model.FirewallRuleFields))
+
+	return rule, nil
+}
+
+// DeleteFirewallRule deletes a firewall rule from a namespace.
+func (s *service) DeleteFirewallRule(ctx context.Context, tenant, id string) error {
+	rule, err := s.GetFirewallRule(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.FirewallRuleDelete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleDelete, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}, firewallRuleAuditFields(rule.FirewallRuleFields), nil)
+
+	return nil
+}
+
+// EvaluateFirewall checks if a connection to a device is allowed by the namespace's firewall rules.
+//
+// The active rules of the device's namespace are evaluated in ascending priority order, and the first rule that
+// matches the client IP address, the username and the device determines the result. When the matched rule has the
+// deny action, or when a rule cannot be evaluated, it returns ErrFirewallBlock. A connection that does not match any
+// rule is allowed.
+func (s *service) EvaluateFirewall(ctx context.Context, req requests.FirewallEvaluate) error {
+	device, err := s.store.DeviceLookup(ctx, req.Domain, req.Name)
+	if err != nil || device == nil {
+		return NewErrDeviceLookupNotFound(req.Domain, req.Name, err)
+	}
+
+	rules, err := s.store.FirewallRuleListActive(ctx, device.TenantID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		ok, err := s.matchFirewallRule(ctx, &rule, device, req.Username, req.IPAddress)
+		if err != nil {
+			return NewErrFirewallBlock(err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if rule.Action != FirewallRuleActionAllow {
+			return NewErrFirewallBlock(nil)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// checkFirewallFilterTags checks if all tags used by a firewall rule's filter exist in the namespace.
+func (s *service) checkFirewallFilterTags(ctx context.Context, tenant string, tags []string) error {
+	if tags == nil {
+		return nil
+	}
+
+	existing, _, err := s.store.TagsGet(ctx, tenant)
+	if err != nil {
+		return NewErrTagEmpty(tenant, err)
+	}
+
+	for _, tag := range tags {
+		if !contains(existing, tag) {
+			return NewErrTagNotFound(tag, nil)
+		}
+	}
+
+	return nil
+}
+
+// matchFirewallRule checks if a firewall rule matches a connection from ip, as username, to device.
+func (s *service) matchFirewallRule(ctx context.Context, rule *models.FirewallRule, device *models.Device, username, ip string) (bool, error) {
+	ok, err := s.matchFirewallPattern(rule.SourceIP, ip)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	ok, err = s.matchFirewallPattern(rule.Username, username)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	switch {
+	case rule.Filter.Hostname != "":
+		return s.matchFirewallPattern(rule.Filter.Hostname, device.Name)
+	case len(rule.Filter.Tags) > 0:
+		for _, tag := range device.Tags {
+			if contains(rule.Filter.Tags, tag) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	case rule.Filter.Group != "":
+		return s.matchDeviceGroup(ctx, rule.TenantID, rule.Filter.Group, device)
+	}
+
+	return true, nil
+}
+
+// matchFirewallPattern checks if a firewall rule's pattern matches the whole value, compiling the pattern only the first
+// time it is matched.
+func (s *service) matchFirewallPattern(pattern, value string) (bool, error) {
+	if compiled, ok := s.firewallPatterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp).MatchString(value), nil
+	}
+
+	compiled, err := models.CompileFirewallPattern(pattern)
+	if err != nil {
+		return false, err
+	}
+
+	s.firewallPatterns.Store(pattern, compiled)
+
+	return compiled.MatchString(value), nil
+}
+
+func firewallRuleFieldsFromRequest(fields requests.FirewallRuleFields) models.FirewallRuleFields {
+	return models.FirewallRuleFields{
+		Priority: fields.Priority,
+		Action:   fields.Action,
+		Active:   fields.Active,
+		SourceIP: fields.SourceIP,
+		Username: fields.Username,
+		Filter: models.FirewallFilter{
+			Hostname: fields.Filter.Hostname,
+			Tags:     fields.Filter.Tags,
+			Group:    fields.Filter.Group,
+		},
+	}
+}
+
+// firewallRuleAuditFields returns the fields of a firewall rule recorded on the audit log.
+func firewallRuleAuditFields(fields models.FirewallRuleFields) map[string]interface{} {
+	return map[string]interface{}{
+		"priority":  fields.Priority,
+		"action":    fields.Action,
+		"active":    fields.Active,
+		"source_ip": fields.SourceIP,
+		"username":  fields.Username,
+		"hostname":  fields.Filter.Hostname,
+		"tags":      fields.Filter.Tags,
+		"group":     fields.Filter.Group,
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type FirewallTagsService interface {
+	AddFirewallRuleTag(ctx context.Context, tenant, id, tag string) error
+	RemoveFirewallRuleTag(ctx context.Context, tenant, id, tag string) error
+	UpdateFirewallRuleTags(ctx context.Context, tenant, id string, tags []string) error
+}
+
+// AddFirewallRuleTag trys to add a tag to the models.FirewallRule, when its filter is from Tags type.
+//
+// It checks if the models.FirewallRule exists in the namespace and if the tag exists before to perform the addition.
+func (s *service) AddFirewallRuleTag(ctx context.Context, tenant, id, tag string) error {
+	rule, err := s.GetFirewallRule(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if rule.Filter.Hostname != "" || rule.Filter.Group != "" {
+		return NewErrFirewallRuleFilter(nil)
+	}
+
+	if len(rule.Filter.Tags) == DeviceMaxTags {
+		return NewErrTagLimit(DeviceMaxTags, nil)
+	}
+
+	if contains(rule.Filter.Tags, tag) {
+		return NewErrTagDuplicated(tag, nil)
+	}
+
+	if err := s.checkFirewallFilterTags(ctx, tenant, []string{tag}); err != nil {
+		return err
+	}
+
+	if err := s.store.FirewallRuleAddTag(ctx, id, tag); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return ErrDuplicateTagName
+		default:
+			return err
+		}
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleTagAdd, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}, nil, map[string]interface{}{"tag": tag})
+
+	return nil
+}
+
+// RemoveFirewallRuleTag trys to remove a tag from the models.FirewallRule, when its filter is from Tags type.
+func (s *service) RemoveFirewallRuleTag(ctx context.Context, tenant, id, tag string) error {
+	rule, err := s.GetFirewallRule(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if rule.Filter.Hostname != "" || rule.Filter.Group != "" {
+		return NewErrFirewallRuleFilter(nil)
+	}
+
+	if !contains(rule.Filter.Tags, tag) {
+		return NewErrTagNotFound(tag, nil)
+	}
+
+	// A rule filtered by tags must keep, at least, one tag.
+	if len(rule.Filter.Tags) == 1 {
+		return NewErrFirewallRuleFilter(nil)
+	}
+
+	if err := s.store.FirewallRuleRemoveTag(ctx, id, tag); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleTagRemove, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}, map[string]interface{}{"tag": tag}, nil)
+
+	return nil
+}
+
+// UpdateFirewallRuleTags trys to update the tags of the models.FirewallRule, when its filter is from Tags type.
+//
+// Duplicated tags are removed before the update.
+func (s *service) UpdateFirewallRuleTags(ctx context.Context, tenant, id string, tags []string) error {
+	set := func(list []string) []string {
+		state := make(map[string]bool)
+		helper := make([]string, 0)
+		for _, item := range list {
+			if _, ok := state[item]; !ok {
+				state[item] = true
+				helper = append(helper, item)
+			}
+		}
+
+		return helper
+	}
+
+	rule, err := s.GetFirewallRule(ctx, tenant, id)
+	if err != nil {
+		return err
+	}
+
+	if rule.Filter.Hostname != "" || rule.Filter.Group != "" {
+		return NewErrFirewallRuleFilter(nil)
+	}
+
+	tags = set(tags)
+	if len(tags) > DeviceMaxTags {
+		return NewErrTagLimit(DeviceMaxTags, nil)
+	}
+
+	if err := s.checkFirewallFilterTags(ctx, tenant, tags); err != nil {
+		return err
+	}
+
+	if err := s.store.FirewallRuleUpdateTags(ctx, id, tags); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return ErrDuplicateTagName
+		default:
+			return err
+		}
+	}
+
+	s.audit(ctx, tenant, models.AuditActionFirewallRuleTagUpdate, models.AuditTarget{Type: models.AuditTargetFirewallRule, ID: id}, map[string]interface{}{"tags": rule.Filter.Tags}, map[string]interface{}{"tags": tags})
+
+	return nil
+}
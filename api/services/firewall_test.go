@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"regexp/syntax"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFirewallRule(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	rule := &models.FirewallRule{ID: "id", TenantID: "tenant"}
+
+	type Expected struct {
+		rule *models.FirewallRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the rule is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("id", Err)},
+		},
+		{
+			description: "fails when the rule is from other namespace",
+			tenant:      "other",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+			},
+			expected: Expected{nil, NewErrFirewallRuleNotFound("id", nil)},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FirewallRuleGet", ctx, "id").Return(rule, nil).Once()
+			},
+			expected: Expected{rule, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			rule, err := s.GetFirewallRule(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateFirewallRule(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{TenantID: "tenant"}
+
+	reqHostname := requests.FirewallRuleCreate{
+		FirewallRuleFields: requests.FirewallRuleFields{
+			Priority: 1,
+			Action:   "deny",
+			Active:   true,
+			SourceIP: ".*",
+			Username: ".*",
+			Filter: requests.FirewallFilter{
+				Hostname: ".*",
+			},
+		},
+	}
+
+	reqTags := requests.FirewallRuleCreate{
+		FirewallRuleFields: requests.FirewallRuleFields{
+			Priority: 1,
+			Action:   "allow",
+			Active:   true,
+			SourceIP: ".*",
+			Username: ".*",
+			Filter: requests.FirewallFilter{
+				Tags: []string{"production"},
+			},
+		},
+	}
+
+	// A pattern cannot close the group that anchors it.
+	reqInvalid := reqHostname
+	reqInvalid.SourceIP = ".*)|(.*"
+
+	invalid := firewallRuleFieldsFromRequest(reqInvalid.FirewallRuleFields)
+
+	cases := []struct {
+		description   string
+		req           requests.FirewallRuleCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when a pattern is not valid",
+			req:         reqInvalid,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrFirewallRuleInvalid(invalid.Validate()),
+		},
+		{
+			description: "fails when the namespace is not found",
+			req:         reqHostname,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when a tag does not exist",
+			req:         reqTags,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"development"}, 1, nil).Once()
+			},
+			expected: NewErrTagNotFound("production", nil),
+		},
+		{
+			description: "succeeds when the filter is from tags type",
+			req:         reqTags,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("TagsGet", ctx, "tenant").Return([]string{"production"}, 1, nil).Once()
+				mock.On("FirewallRuleCreate", ctx, &models.FirewallRule{
+					TenantID:           "tenant",
+					FirewallRuleFields: firewallRuleFieldsFromRequest(reqTags.FirewallRuleFields),
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the filter is from hostname type",
+			req:         reqHostname,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("FirewallRuleCreate", ctx, &models.FirewallRule{
+					TenantID:           "tenant",
+					FirewallRuleFields: firewallRuleFieldsFromRequest(reqHostname.FirewallRuleFields),
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.CreateFirewallRule(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateFirewall(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	device := &models.Device{
+		UID:      "uid",
+		Name:     "device",
+		TenantID: "tenant",
+		Tags:     []string{"production"},
+	}
+
+	rule := func(tenant string, priority int, action string, active bool, ip, username string, filter models.FirewallFilter) models.FirewallRule {
+		return models.FirewallRule{
+			TenantID: tenant,
+			FirewallRuleFields: models.FirewallRuleFields{
+				Priority: priority,
+				Action:   action,
+				Active:   active,
+				SourceIP: ip,
+				Username: username,
+				Filter:   filter,
+			},
+		}
+	}
+
+	req := requests.FirewallEvaluate{
+		Domain:    "namespace",
+		Name:      "device",
+		Username:  "root",
+		IPAddress: "192.168.0.10",
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceLookupNotFound("namespace", "device", Err),
+		},
+		{
+			description: "allows when there are no rules",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "blocks when the first matched rule denies",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule("tenant", 1, "deny", true, "192.168.*", ".*", models.FirewallFilter{Hostname: ".*"}),
+					rule("tenant", 2, "allow", true, ".*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: NewErrFirewallBlock(nil),
+		},
+		{
+			description: "allows when the first matched rule allows",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule("tenant", 1, "allow", true, ".*", "root", models.FirewallFilter{Tags: []string{"production"}}),
+					rule("tenant", 2, "deny", true, ".*", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "matches the patterns against the whole values",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule("tenant", 1, "deny", true, "192.168.0.1", ".*", models.FirewallFilter{Hostname: ".*"}),
+					rule("tenant", 2, "deny", true, ".*", "roo", models.FirewallFilter{Hostname: ".*"}),
+					rule("tenant", 3, "deny", true, ".*", ".*", models.FirewallFilter{Hostname: "dev"}),
+				}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "blocks when a rule cannot be evaluated",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule("tenant", 1, "allow", true, "(", ".*", models.FirewallFilter{Hostname: ".*"}),
+				}, nil).Once()
+			},
+			expected: NewErrFirewallBlock(&syntax.Error{Code: syntax.ErrMissingParen, Expr: "("}),
+		},
+		{
+			description: "skips rules that do not match the username or the device",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", ctx, "namespace", "device").Return(device, nil).Once()
+				mock.On("FirewallRuleListActive", ctx, "tenant").Return([]models.FirewallRule{
+					rule("tenant", 1, "allow", true, ".*", "admin", models.FirewallFilter{Hostname: ".*"}),
+					rule("tenant", 2, "allow", true, ".*", ".*", models.FirewallFilter{Tags: []string{"development"}}),
+					rule("tenant", 3, "deny", true, ".*", ".*", models.FirewallFilter{Hostname: "dev.*"}),
+				}, nil).Once()
+			},
+			expected: NewErrFirewallBlock(nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateFirewall(ctx, req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// InvitationDefaultExpiration is the number of days until an invitation expires when it is not defined.
+const InvitationDefaultExpiration = 7
+
+// Mailer delivers the emails sent by the service.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// WithMailer sets the mailer used to deliver the namespaces' invitations. The link sent on each invitation is the URL
+// with the invitation's token as the "token" query parameter.
+func WithMailer(mailer Mailer, url string) Option {
+	return func(s *service) {
+		s.mailer = mailer
+		s.invitationURL = url
+	}
+}
+
+type InvitationService interface {
+	CreateInvitation(ctx context.Context, tenant, userID string, req requests.InvitationCreate) (*models.Invitation, error)
+	ListInvitations(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error)
+	RevokeInvitation(ctx context.Context, tenant, id string) error
+	AcceptInvitation(ctx context.Context, userID, token string) (*models.Namespace, error)
+	DeclineInvitation(ctx context.Context, token string) error
+}
+
+// CreateInvitation invites an email address to join a namespace with a role, sending the invitation's link to it.
+//
+// The invitation's role cannot be greater than the role of the member who creates it. As an address can have a single
+// pending invitation to a namespace, an expired invitation to the same address is replaced.
+func (s *service) CreateInvitation(ctx context.Context, tenant, userID string, req requests.InvitationCreate) (*models.Invitation, error) {
+	if s.mailer == nil {
+		return nil, NewErrInvitationUnavailable(nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	active, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	if !guard.CheckRole(active.Role, req.Role) {
+		return nil, guard.ErrForbidden
+	}
+
+	if err := s.checkMemberRole(ctx, tenant, req.Role); err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(req.Email)
+
+	if user, err := s.store.UserGetByEmail(ctx, email); err == nil && user != nil {
+		if _, ok := guard.CheckMember(namespace, user.ID); ok {
+			return nil, NewErrNamespaceMemberDuplicated(user.ID, nil)
+		}
+	}
+
+	now := clock.Now()
+
+	pending, err := s.store.InvitationGetByEmail(ctx, tenant, email)
+	switch {
+	case err == nil && !pending.IsExpired(now):
+		return nil, NewErrInvitationDuplicated(email, nil)
+	case err == nil:
+		if err := s.store.InvitationDelete(ctx, tenant, pending.ID); err != nil && err != store.ErrNoDocuments {
+			return nil, err
+		}
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	expiresIn := req.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = InvitationDefaultExpiration
+	}
+
+	invitation := &models.Invitation{
+		TenantID:  tenant,
+		Email:     email,
+		Role:      req.Role,
+		InvitedBy: userID,
+		Digest:    tokenDigest(token),
+		CreatedAt: now,
+		ExpiresAt: now.AddDate(0, 0, expiresIn),
+	}
+
+	if err := s.store.InvitationCreate(ctx, invitation); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrInvitationDuplicated(email, err)
+		}
+
+		return nil, err
+	}
+
+	subject := fmt.Sprintf("You have been invited to join the %s namespace on ShellHub", namespace.Name)
+	body := fmt.Sprintf("You have been invited to join the %s namespace on ShellHub as %s.\n\nTo accept or decline the invitation, open the link below. If you do not have an account yet, create one with this email address first.\n\n%s\n\nThe invitation expires on %s.\n",
+		namespace.Name, invitation.Role, s.invitationLink(token), invitation.ExpiresAt.UTC().Format("January 2, 2006 15:04 MST"))
+
+	if err := s.mailer.Send(ctx, email, subject, body); err != nil {
+		if err := s.store.InvitationDelete(ctx, tenant, invitation.ID); err != nil {
+			logrus.WithError(err).WithField("id", invitation.ID).Error("Failed to delete the undelivered invitation")
+		}
+
+		return nil, NewErrInvitationUnavailable(err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionNamespaceInvitationCreate, models.AuditTarget{Type: models.AuditTargetInvitation, ID: invitation.ID}, nil, invitationAuditFields(invitation))
+
+	return invitation, nil
+}
+
+// ListInvitations lists the pending invitations of a namespace, from the newest to the oldest.
+func (s *service) ListInvitations(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	return s.store.InvitationList(ctx, tenant, pagination)
+}
+
+// RevokeInvitation deletes a pending invitation from a namespace, invalidating its link.
+func (s *service) RevokeInvitation(ctx context.Context, tenant, id string) error {
+	invitation, err := s.store.InvitationGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrInvitationNotFound(id, err)
+	}
+
+	if err := s.store.InvitationDelete(ctx, tenant, id); err != nil {
+		return NewErrInvitationNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionNamespaceInvitationRevoke, models.AuditTarget{Type: models.AuditTargetInvitation, ID: id}, invitationAuditFields(invitation), nil)
+
+	return nil
+}
+
+// AcceptInvitation adds the user to the namespace of an invitation, with the invitation's role.
+//
+// The invitation is accepted only by the user whose email address is the invited one. Once accepted, the invitation
+// is deleted.
+func (s *service) AcceptInvitation(ctx context.Context, userID, token string) (*models.Namespace, error) {
+	invitation, err := s.invitationByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, NewErrInvitationEmailMismatch(nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, invitation.TenantID)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(invitation.TenantID, err)
+	}
+
+	if _, ok := guard.CheckMember(namespace, user.ID); ok {
+		return nil, NewErrNamespaceMemberDuplicated(user.ID, nil)
+	}
+
+	// The invitation's custom role may have been deleted since it was sent.
+	if err := s.checkMemberRole(ctx, invitation.TenantID, invitation.Role); err != nil {
+		return nil, err
+	}
+
+	added, err := s.store.NamespaceAddMember(ctx, invitation.TenantID, user.ID, invitation.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.InvitationDelete(ctx, invitation.TenantID, invitation.ID); err != nil && err != store.ErrNoDocuments {
+		logrus.WithError(err).WithField("id", invitation.ID).Error("Failed to delete the accepted invitation")
+	}
+
+	s.audit(ctx, invitation.TenantID, models.AuditActionNamespaceInvitationAccept, models.AuditTarget{Type: models.AuditTargetInvitation, ID: invitation.ID}, invitationAuditFields(invitation), map[string]interface{}{"username": user.Username, "role": invitation.Role})
+
+	return added, nil
+}
+
+// DeclineInvitation deletes the invitation of a token. As the token is only known by the invited address, it does not
+// require an account.
+func (s *service) DeclineInvitation(ctx context.Context, token string) error {
+	invitation, err := s.invitationByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.InvitationDelete(ctx, invitation.TenantID, invitation.ID); err != nil {
+		return NewErrInvitationNotFound(invitation.ID, err)
+	}
+
+	s.audit(ctx, invitation.TenantID, models.AuditActionNamespaceInvitationDecline, models.AuditTarget{Type: models.AuditTargetInvitation, ID: invitation.ID}, invitationAuditFields(invitation), nil)
+
+	return nil
+}
+
+// invitationByToken returns the pending invitation of a token. An expired invitation is deleted and reported as
+// expired.
+func (s *service) invitationByToken(ctx context.Context, token string) (*models.Invitation, error) {
+	invitation, err := s.store.InvitationGetByDigest(ctx, tokenDigest(token))
+	if err != nil {
+		return nil, NewErrInvitationNotFound("", err)
+	}
+
+	if invitation.IsExpired(clock.Now()) {
+		if err := s.store.InvitationDelete(ctx, invitation.TenantID, invitation.ID); err != nil && err != store.ErrNoDocuments {
+			logrus.WithError(err).WithField("id", invitation.ID).Error("Failed to delete the expired invitation")
+		}
+
+		return nil, NewErrInvitationExpired(nil)
+	}
+
+	return invitation, nil
+}
+
+// invitationLink returns the link, sent by email, to accept or decline an invitation.
+func (s *service) invitationLink(token string) string {
+	link, err := url.Parse(s.invitationURL)
+	if err != nil {
+		return s.invitationURL + "?token=" + url.QueryEscape(token)
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String()
+}
+
+// invitationAuditFields returns the fields of an invitation recorded on the audit log.
+func invitationAuditFields(invitation *models.Invitation) map[string]interface{} {
+	return map[string]interface{}{
+		"email":      invitation.Email,
+		"role":       invitation.Role,
+		"expires_at": invitation.ExpiresAt,
+	}
+}
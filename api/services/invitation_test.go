@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mailerMock records the emails sent.
+type mailerMock struct {
+	sent []string
+	err  error
+}
+
+func (m *mailerMock) Send(_ context.Context, to, _, _ string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	m.sent = append(m.sent, to)
+
+	return nil
+}
+
+func TestCreateInvitation(t *testing.T) {
+	storeMock := &mocks.Store{}
+	mailer := &mailerMock{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithMailer(mailer, "http://localhost/accept-invite"))
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: "owner"},
+			{ID: "operator", Role: "operator"},
+			{ID: "member", Role: "observer"},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		req           requests.InvitationCreate
+		mailerErr     error
+		requiredMocks func()
+		sent          []string
+		err           error
+	}{
+		{
+			description: "fails when the invited role is greater than the member's role",
+			userID:      "operator",
+			req:         requests.InvitationCreate{Email: "john@example.com", RoleBody: requests.RoleBody{Role: "administrator"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			err: guard.ErrForbidden,
+		},
+		{
+			description: "fails when the email belongs to a member of the namespace",
+			userID:      "owner",
+			req:         requests.InvitationCreate{Email: "John@example.com", RoleBody: requests.RoleBody{Role: "observer"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(&models.User{ID: "member"}, nil).Once()
+			},
+			err: NewErrNamespaceMemberDuplicated("member", nil),
+		},
+		{
+			description: "fails when the email has a pending invitation",
+			userID:      "owner",
+			req:         requests.InvitationCreate{Email: "john@example.com", RoleBody: requests.RoleBody{Role: "observer"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("InvitationGetByEmail", ctx, "tenant", "john@example.com").
+					Return(&models.Invitation{ID: "id", TenantID: "tenant", Email: "john@example.com", ExpiresAt: now.Add(1)}, nil).Once()
+			},
+			err: NewErrInvitationDuplicated("john@example.com", nil),
+		},
+		{
+			description: "deletes the invitation when it cannot be delivered",
+			userID:      "owner",
+			req:         requests.InvitationCreate{Email: "john@example.com", RoleBody: requests.RoleBody{Role: "observer"}},
+			mailerErr:   Err,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("InvitationGetByEmail", ctx, "tenant", "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("InvitationCreate", ctx, mock.AnythingOfType("*models.Invitation")).
+					Run(func(args mock.Arguments) { args.Get(1).(*models.Invitation).ID = "id" }).Return(nil).Once()
+				storeMock.On("InvitationDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			err: NewErrInvitationUnavailable(Err),
+		},
+		{
+			description: "replaces an expired invitation and sends the new one",
+			userID:      "owner",
+			req:         requests.InvitationCreate{Email: "john@example.com", RoleBody: requests.RoleBody{Role: "observer"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("InvitationGetByEmail", ctx, "tenant", "john@example.com").
+					Return(&models.Invitation{ID: "expired", TenantID: "tenant", Email: "john@example.com", ExpiresAt: now}, nil).Once()
+				storeMock.On("InvitationDelete", ctx, "tenant", "expired").Return(nil).Once()
+				storeMock.On("InvitationCreate", ctx, mock.MatchedBy(func(invitation *models.Invitation) bool {
+					return invitation.Email == "john@example.com" && invitation.Role == "observer" && invitation.InvitedBy == "owner" &&
+						invitation.Digest != "" && invitation.ExpiresAt.Equal(now.AddDate(0, 0, InvitationDefaultExpiration))
+				})).Return(nil).Once()
+			},
+			sent: []string{"john@example.com"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			mailer.sent, mailer.err = nil, tc.mailerErr
+
+			invitation, err := s.CreateInvitation(ctx, "tenant", tc.userID, tc.req)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.sent, mailer.sent)
+			if tc.err == nil {
+				assert.Equal(t, "john@example.com", invitation.Email)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestCreateInvitationWithoutMailer(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	invitation, err := s.CreateInvitation(context.TODO(), "tenant", "owner", requests.InvitationCreate{Email: "john@example.com"})
+	assert.Equal(t, NewErrInvitationUnavailable(nil), err)
+	assert.Nil(t, invitation)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	invitation := &models.Invitation{ID: "id", TenantID: "tenant", Email: "john@example.com", Role: "operator", ExpiresAt: now.Add(1)}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      *models.Namespace
+		err           error
+	}{
+		{
+			description: "fails when the invitation is not found",
+			requiredMocks: func() {
+				storeMock.On("InvitationGetByDigest", ctx, tokenDigest("token")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrInvitationNotFound("", store.ErrNoDocuments),
+		},
+		{
+			description: "deletes the invitation when it is expired",
+			requiredMocks: func() {
+				storeMock.On("InvitationGetByDigest", ctx, tokenDigest("token")).
+					Return(&models.Invitation{ID: "id", TenantID: "tenant", Email: "john@example.com", ExpiresAt: now}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("InvitationDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			err: NewErrInvitationExpired(nil),
+		},
+		{
+			description: "fails when the user is not the invited one",
+			requiredMocks: func() {
+				storeMock.On("InvitationGetByDigest", ctx, tokenDigest("token")).Return(invitation, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("UserGetByID", ctx, "user", false).Return(&models.User{ID: "user", UserData: models.UserData{Email: "jane@example.com"}}, 0, nil).Once()
+			},
+			err: NewErrInvitationEmailMismatch(nil),
+		},
+		{
+			description: "adds the user to the namespace with the invitation's role",
+			requiredMocks: func() {
+				storeMock.On("InvitationGetByDigest", ctx, tokenDigest("token")).Return(invitation, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("UserGetByID", ctx, "user", false).Return(&models.User{ID: "user", UserData: models.UserData{Email: "John@example.com"}}, 0, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}}}, nil).Once()
+				storeMock.On("NamespaceAddMember", ctx, "tenant", "user", "operator").
+					Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}, {ID: "user", Role: "operator"}}}, nil).Once()
+				storeMock.On("InvitationDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: &models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}, {ID: "user", Role: "operator"}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			namespace, err := s.AcceptInvitation(ctx, "user", "token")
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, namespace)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeclineInvitation(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	storeMock.On("InvitationGetByDigest", ctx, tokenDigest("token")).
+		Return(&models.Invitation{ID: "id", TenantID: "tenant", Email: "john@example.com", ExpiresAt: now.Add(1)}, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("InvitationDelete", ctx, "tenant", "id").Return(nil).Once()
+
+	assert.NoError(t, s.DeclineInvitation(ctx, "token"))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	storeMock.On("InvitationGet", ctx, "tenant", "missing").Return(nil, store.ErrNoDocuments).Once()
+	assert.Equal(t, NewErrInvitationNotFound("missing", store.ErrNoDocuments), s.RevokeInvitation(ctx, "tenant", "missing"))
+
+	storeMock.On("InvitationGet", ctx, "tenant", "id").Return(&models.Invitation{ID: "id", TenantID: "tenant"}, nil).Once()
+	storeMock.On("InvitationDelete", ctx, "tenant", "id").Return(nil).Once()
+	assert.NoError(t, s.RevokeInvitation(ctx, "tenant", "id"))
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/ldap"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// LDAPAuthenticator authenticates the users against an LDAP directory.
+type LDAPAuthenticator interface {
+	Authenticate(ctx context.Context, username, password string) (*ldap.Identity, error)
+}
+
+// WithLDAP sets the LDAP directory used to authenticate the users before their local accounts. The members of the
+// directory's groups become members of the namespaces mapped to them when they log in.
+func WithLDAP(authenticator LDAPAuthenticator, roles []guard.GroupRole) Option {
+	return func(s *service) {
+		s.ldap = authenticator
+		s.ldapRoles = roles
+	}
+}
+
+// authLDAPUser returns the JWT of a user authenticated by the LDAP directory with the login's username. The user is
+// found by the email on the directory, being created on its first login.
+func (s *service) authLDAPUser(ctx context.Context, identity *ldap.Identity, login string) (*models.UserAuthResponse, error) {
+	if identity.Email == "" {
+		return nil, NewErrLDAPEmailMissing(nil)
+	}
+
+	user, err := s.provisionUser(ctx, identity.Email, identity.Username, identity.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.provisionMembership(ctx, user, s.ldapRoles, identity.Groups)
+
+	if user.MFA.Enabled {
+		return s.authMFAPending(ctx, user, login)
+	}
+
+	s.loginSucceeded(ctx, login)
+
+	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
+
+	return s.authUserToken(ctx, user, namespace)
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/ldap"
+	"github.com/shellhub-io/shellhub/api/pkg/ldap/ldaptest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUserLDAP(t *testing.T) {
+	storeMock := &mocks.Store{}
+
+	directory := ldaptest.NewServer(
+		ldaptest.Entry{
+			DN:       "uid=john,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"uid":      {"john"},
+				"mail":     {"John@example.com"},
+				"cn":       {"John Doe"},
+				"memberOf": {"cn=devs,ou=groups,dc=example,dc=com"},
+			},
+		},
+		ldaptest.Entry{DN: "uid=nomail,ou=people,dc=example,dc=com", Password: "secret", Attributes: map[string][]string{"uid": {"nomail"}}},
+	)
+	defer directory.Close()
+
+	newAuthenticator := func(url string) *ldap.Authenticator {
+		authenticator, err := ldap.New(ldap.Config{
+			LDAPURL:               url,
+			LDAPBaseDN:            "dc=example,dc=com",
+			LDAPUserFilter:        "(uid=%s)",
+			LDAPUsernameAttribute: "uid",
+			LDAPEmailAttribute:    "mail",
+			LDAPNameAttribute:     "cn",
+			LDAPGroupAttribute:    "memberOf",
+		})
+		assert.NoError(t, err)
+
+		return authenticator
+	}
+
+	roles := []guard.GroupRole{{Group: "devs", Tenant: "tenant", Role: "operator"}}
+
+	ctx := context.TODO()
+
+	passwd := sha256.Sum256([]byte("passwd"))
+	local := &models.User{
+		ID:           "local",
+		Confirmed:    true,
+		UserData:     models.UserData{Username: "jane", Email: "jane@example.com"},
+		UserPassword: models.UserPassword{Password: hex.EncodeToString(passwd[:])},
+	}
+
+	cases := []struct {
+		description   string
+		url           string
+		req           requests.UserAuth
+		requiredMocks func()
+		expected      func(t *testing.T, res *models.UserAuthResponse)
+		err           error
+	}{
+		{
+			description: "creates the user authenticated by the directory and adds it to the namespace mapped to its groups",
+			url:         directory.URL,
+			req:         requests.UserAuth{Username: "john", Password: "secret"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("UserGetByUsername", ctx, "john").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("UserCreate", ctx, mock.MatchedBy(func(user *models.User) bool {
+					return user.Username == "john" && user.Name == "John Doe" && user.Email == "john@example.com" && user.Confirmed
+				})).Run(func(args mock.Arguments) { args.Get(1).(*models.User).ID = "id" }).Return(nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}}}, nil).Once()
+				storeMock.On("NamespaceAddMember", ctx, "tenant", "id", "operator").Return(&models.Namespace{}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID:  "tenant",
+					Actor:     models.AuditActor{ID: "id", Username: "john"},
+					Action:    models.AuditActionNamespaceMemberAdd,
+					Target:    models.AuditTarget{Type: models.AuditTargetMember, ID: "id"},
+					Diff:      models.AuditDiff{After: map[string]interface{}{"username": "john", "role": "operator"}},
+					CreatedAt: now,
+				}).Return(nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "id", Role: "operator"}}}, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "id", mock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.NotEmpty(t, res.Token)
+				assert.Equal(t, "john", res.User)
+				assert.Equal(t, "tenant", res.Tenant)
+				assert.Equal(t, "operator", res.Role)
+			},
+		},
+		{
+			description: "fails when the local user with the directory's email is not confirmed",
+			url:         directory.URL,
+			req:         requests.UserAuth{Username: "john", Password: "secret"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").
+					Return(&models.User{ID: "id", UserData: models.UserData{Username: "john", Email: "john@example.com"}}, nil).Once()
+			},
+			err: NewErrUserNotConfirmed(nil),
+		},
+		{
+			description:   "fails when the user authenticated by the directory has no email",
+			url:           directory.URL,
+			req:           requests.UserAuth{Username: "nomail", Password: "secret"},
+			requiredMocks: func() {},
+			err:           NewErrLDAPEmailMissing(nil),
+		},
+		{
+			description: "falls back to the local account when the user is not on the directory",
+			url:         directory.URL,
+			req:         requests.UserAuth{Username: "jane", Password: "passwd"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "jane").Return(local, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "local").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "local", mock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.NotEmpty(t, res.Token)
+				assert.Equal(t, "jane", res.User)
+			},
+		},
+		{
+			description: "falls back to the local account when the directory is unavailable",
+			url:         "ldap://127.0.0.1:1",
+			req:         requests.UserAuth{Username: "jane", Password: "passwd"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "jane").Return(local, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "local").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "local", mock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.NotEmpty(t, res.Token)
+			},
+		},
+		{
+			description: "fails when the password is wrong on the directory and on the local account",
+			url:         directory.URL,
+			req:         requests.UserAuth{Username: "john", Password: "wrong"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "john").Return(&models.User{ID: "id", Confirmed: true}, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrAuthUnathorized(nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithLDAP(newAuthenticator(tc.url), roles))
+
+			res, err := s.AuthUser(ctx, tc.req)
+			assert.Equal(t, tc.err, err)
+			if tc.expected != nil {
+				tc.expected(t, res)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/lockout"
+	"github.com/sirupsen/logrus"
+)
+
+// WithLoginLockout protects the users' logins against brute force, locking out the usernames and the source IPs after
+// too many failed attempts.
+func WithLoginLockout(user, ip lockout.Config) Option {
+	return func(s *service) {
+		s.userLockout = lockout.New(s.cache, "login_user", user)
+		s.ipLockout = lockout.New(s.cache, "login_ip", ip)
+	}
+}
+
+// loginLocked returns how long the login of the username, from the IP, is still locked out. The logins are never locked
+// out when the cache fails.
+func (s *service) loginLocked(ctx context.Context, username, ip string) time.Duration {
+	if s.userLockout == nil {
+		return 0
+	}
+
+	left, err := s.userLockout.Check(ctx, username)
+	if err != nil {
+		logrus.WithError(err).WithField("username", username).Warn("Failed to check the login lockout of the username")
+	}
+
+	if ip != "" {
+		byIP, err := s.ipLockout.Check(ctx, ip)
+		if err != nil {
+			logrus.WithError(err).WithField("ip", ip).Warn("Failed to check the login lockout of the IP")
+		}
+
+		if byIP > left {
+			left = byIP
+		}
+	}
+
+	return left
+}
+
+// loginFailed records a failed login of the username, from the IP, returning the error of the failure or, when the
+// attempt locks the login out, the error of the lockout.
+func (s *service) loginFailed(ctx context.Context, username, ip string, failure error) error {
+	if s.userLockout == nil {
+		return failure
+	}
+
+	locked, err := s.userLockout.Fail(ctx, username)
+	if err != nil {
+		logrus.WithError(err).WithField("username", username).Warn("Failed to record the failed login of the username")
+	}
+
+	if ip != "" {
+		byIP, err := s.ipLockout.Fail(ctx, ip)
+		if err != nil {
+			logrus.WithError(err).WithField("ip", ip).Warn("Failed to record the failed login from the IP")
+		}
+
+		if byIP > locked {
+			locked = byIP
+		}
+	}
+
+	if locked > 0 {
+		logrus.WithFields(logrus.Fields{"username": username, "ip": ip, "duration": locked}).Warn("Login locked out after too many failed attempts")
+
+		return NewErrAuthLocked(locked, nil)
+	}
+
+	return failure
+}
+
+// loginSucceeded forgets the failed logins of the username. The IP's failures are kept, so a valid account cannot be
+// used to keep guessing the passwords of others.
+func (s *service) loginSucceeded(ctx context.Context, username string) {
+	if s.userLockout == nil {
+		return
+	}
+
+	if err := s.userLockout.Reset(ctx, username); err != nil {
+		logrus.WithError(err).WithField("username", username).Warn("Failed to reset the failed logins of the username")
+	}
+}
+
+func (s *service) AuthUnlock(ctx context.Context, username, ip string) error {
+	if s.userLockout == nil {
+		return nil
+	}
+
+	if username != "" {
+		if err := s.userLockout.Reset(ctx, strings.ToLower(username)); err != nil {
+			return err
+		}
+	}
+
+	if ip != "" {
+		if err := s.ipLockout.Reset(ctx, ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
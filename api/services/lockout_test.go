@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/totp"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/lockout"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthUserLockout(t *testing.T) {
+	storeMock := &mocks.Store{}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMap{}, clientMock, nil, WithLoginLockout(
+		lockout.Config{Attempts: 2, Duration: time.Minute, MaxDuration: time.Hour, Window: 15 * time.Minute},
+		lockout.Config{Attempts: 10, Duration: time.Minute, MaxDuration: time.Hour, Window: 15 * time.Minute},
+	))
+
+	ctx := context.TODO()
+
+	passwd := sha256.Sum256([]byte("passwd"))
+	user := &models.User{
+		ID:           "id",
+		Confirmed:    true,
+		UserData:     models.UserData{Username: "john", Email: "john@example.com"},
+		UserPassword: models.UserPassword{Password: hex.EncodeToString(passwd[:])},
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.UserAuth
+		unlock        bool
+		requiredMocks func()
+		err           error
+	}{
+		{
+			description: "fails when the password is wrong",
+			req:         requests.UserAuth{Username: "john", Password: "wrong"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "john").Return(user, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrAuthUnathorized(nil),
+		},
+		{
+			description: "locks the username out when the password is wrong again",
+			req:         requests.UserAuth{Username: "John", Password: "wrong"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "john").Return(user, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			err: NewErrAuthLocked(time.Minute, nil),
+		},
+		{
+			description: "fails while the username is locked out, even with the right password",
+			req:         requests.UserAuth{Username: "john", Password: "passwd"},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+			},
+			err: NewErrAuthLocked(time.Minute, nil),
+		},
+		{
+			description: "authenticates the user after the username is unlocked",
+			req:         requests.UserAuth{Username: "john", Password: "passwd"},
+			unlock:      true,
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "john").Return(user, nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "id", mock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+		},
+		{
+			description: "fails when the user does not exist",
+			req:         requests.UserAuth{Username: "jane", Password: "passwd"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "jane").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("UserGetByEmail", ctx, "jane").Return(nil, store.ErrNoDocuments).Once()
+			},
+			err: NewErrUserNotFound("jane", store.ErrNoDocuments),
+		},
+		{
+			description: "locks the username out when the user does not exist again",
+			req:         requests.UserAuth{Username: "jane", Password: "passwd"},
+			requiredMocks: func() {
+				storeMock.On("UserGetByUsername", ctx, "jane").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("UserGetByEmail", ctx, "jane").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			err: NewErrAuthLocked(time.Minute, nil),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.unlock {
+				assert.NoError(t, s.AuthUnlock(ctx, "John", ""))
+			}
+
+			tc.requiredMocks()
+
+			res, err := s.AuthUser(ctx, tc.req)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.NotEmpty(t, res.Token)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthMFALockout(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cache := cacheMap{}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil, WithLoginLockout(
+		lockout.Config{Attempts: 2, Duration: time.Minute, MaxDuration: time.Hour, Window: 15 * time.Minute},
+		lockout.Config{Attempts: 10, Duration: time.Minute, MaxDuration: time.Hour, Window: 15 * time.Minute},
+	))
+
+	ctx := context.TODO()
+
+	passwd := sha256.Sum256([]byte("passwd"))
+	user := &models.User{
+		ID:           "id",
+		Confirmed:    true,
+		UserData:     models.UserData{Username: "john", Email: "john@example.com"},
+		UserPassword: models.UserPassword{Password: hex.EncodeToString(passwd[:])},
+		MFA:          models.UserMFA{Enabled: true, Secret: mfaSecret},
+	}
+
+	login := func(password string) (*models.UserAuthResponse, error) {
+		storeMock.On("UserGetByUsername", ctx, "john").Return(user, nil).Once()
+		storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+
+		return s.AuthUser(ctx, requests.UserAuth{Username: "john", Password: password})
+	}
+
+	_, err := login("wrong")
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	// The failed logins are kept while the second factor is not verified.
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err := login("passwd")
+	assert.NoError(t, err)
+	assert.Contains(t, cache, "login_user/john/failures")
+
+	code, err := totp.Code(mfaSecret, now)
+	assert.NoError(t, err)
+
+	counter, _ := totp.Counter(mfaSecret, code, now)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+	storeMock.On("UserSetMFACounter", ctx, "id", counter).Return(nil).Once()
+	storeMock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+	storeMock.On("UserUpdateData", ctx, "id", mock.AnythingOfType("models.User")).Return(nil).Once()
+	clockMock.On("Now").Return(now).Times(4)
+
+	res, err := s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+	assert.NotContains(t, cache, "login_user/john/failures")
+
+	// The invalid codes are failed logins, locking the username out and invalidating the token.
+	_, err = login("wrong")
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err = login("passwd")
+	assert.NoError(t, err)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+	clockMock.On("Now").Return(now).Times(3)
+
+	_, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: mfaInvalidCode()})
+	assert.Equal(t, NewErrAuthLocked(time.Minute, nil), err)
+
+	clockMock.On("Now").Return(now).Once()
+
+	_, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.Equal(t, NewErrMFATokenInvalid(nil), err)
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type MemberScopeService interface {
+	EditNamespaceUserScope(ctx context.Context, tenantID, userID, memberID string, scope *models.MemberScope) error
+	CheckMemberScope(ctx context.Context, memberID string, device *models.Device) error
+	EvaluateConnectionScope(ctx context.Context, uid models.UID, member, fingerprint string) error
+}
+
+// EditNamespaceUserScope restricts a namespace's member to the devices with one of the scope's tags or in one of its
+// device groups. An empty scope removes the restriction.
+func (s *service) EditNamespaceUserScope(ctx context.Context, tenantID, userID, memberID string, scope *models.MemberScope) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	// checks if the active member is in the namespace. user is the active member.
+	active, ok := guard.CheckMember(namespace, userID)
+	if !ok {
+		return NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	// checks if the passive member is in the namespace. member is the passive member.
+	passive, ok := guard.CheckMember(namespace, memberID)
+	if !ok {
+		return NewErrNamespaceMemberNotFound(memberID, nil)
+	}
+
+	// checks if the active member can act over the passive member.
+	if !guard.CheckRole(active.Role, passive.Role) {
+		return guard.ErrForbidden
+	}
+
+	if scope.IsEmpty() {
+		scope = nil
+	}
+
+	if scope != nil {
+		for _, id := range scope.Groups {
+			if err := s.checkDeviceGroup(ctx, tenantID, id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.store.NamespaceSetMemberScope(ctx, tenantID, memberID, scope); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditActionNamespaceMemberScope, models.AuditTarget{Type: models.AuditTargetMember, ID: memberID}, map[string]interface{}{"scope": passive.Scope}, map[string]interface{}{"scope": scope})
+
+	return nil
+}
+
+// CheckMemberScope checks if a member of the device's namespace can connect to the device, because it is in the
+// member's scope or an access grant gives the member access to it. It returns NewErrDeviceNotFound when the member
+// cannot connect, so the device is not disclosed to the member.
+func (s *service) CheckMemberScope(ctx context.Context, memberID string, device *models.Device) error {
+	scope, err := s.memberScope(ctx, device.TenantID, memberID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.inScope(ctx, device.TenantID, scope, device)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		return nil
+	}
+
+	grant, err := s.EvaluateAccessGrant(ctx, device.TenantID, memberID, "", device)
+	if err != nil {
+		return err
+	}
+
+	if grant == nil {
+		return NewErrDeviceNotFound(models.UID(device.UID), nil)
+	}
+
+	return nil
+}
+
+// EvaluateConnectionScope checks if a connection to the device can be opened, because the device is in the scope of
+// the member who opens it. When the connection has no member, as the ones authenticated on the SSH server, the member
+// is the one who added the public key with the fingerprint. A connection that resolves to no member, as the ones
+// authenticated by password or by a public key added before the keys recorded their owners, is not restricted.
+//
+// It returns NewErrDeviceNotFound when the connection cannot be opened, so the device is not disclosed.
+func (s *service) EvaluateConnectionScope(ctx context.Context, uid models.UID, member, fingerprint string) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if member == "" && fingerprint != "" {
+		key, err := s.store.PublicKeyGet(ctx, fingerprint, device.TenantID)
+		if err != nil {
+			return NewErrPublicKeyNotFound(fingerprint, err)
+		}
+
+		member = key.CreatedBy
+	}
+
+	if member == "" {
+		return nil
+	}
+
+	return s.CheckMemberScope(ctx, member, device)
+}
+
+// memberScope returns the device scope of a namespace's member, or nil when the member is not restricted.
+func (s *service) memberScope(ctx context.Context, tenant, memberID string) (*models.MemberScope, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	member, ok := guard.CheckMember(namespace, memberID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(memberID, nil)
+	}
+
+	if member.Scope.IsEmpty() {
+		return nil, nil
+	}
+
+	return member.Scope, nil
+}
+
+// requestScope returns the device scope of the user who made the request in the namespace, or nil when the user is
+// not restricted or the request was not made by a user, as the internal ones.
+func (s *service) requestScope(ctx context.Context, tenant string) (*models.MemberScope, error) {
+	id := gateway.IDFromContext(ctx)
+	if id == nil || id.ID == "" || tenant == "" {
+		return nil, nil
+	}
+
+	return s.memberScope(ctx, tenant, id.ID)
+}
+
+// checkRequestScope checks if the device is in the scope of the user who made the request, returning
+// NewErrDeviceNotFound when it is not.
+func (s *service) checkRequestScope(ctx context.Context, device *models.Device) error {
+	tenant := gateway.TenantFromContext(ctx)
+	if tenant == nil {
+		return nil
+	}
+
+	scope, err := s.requestScope(ctx, tenant.ID)
+	if err != nil {
+		return err
+	}
+
+	ok, err := s.inScope(ctx, tenant.ID, scope, device)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return NewErrDeviceNotFound(models.UID(device.UID), nil)
+	}
+
+	return nil
+}
+
+// inScope checks if a device has one of the scope's tags or is in one of its device groups. A nil scope has all the
+// devices.
+func (s *service) inScope(ctx context.Context, tenant string, scope *models.MemberScope, device *models.Device) (bool, error) {
+	if scope == nil {
+		return true, nil
+	}
+
+	for _, tag := range scope.Tags {
+		for _, t := range device.Tags {
+			if t == tag {
+				return true, nil
+			}
+		}
+	}
+
+	for _, id := range scope.Groups {
+		group, err := s.scopeGroup(ctx, tenant, id)
+		if err != nil {
+			return false, err
+		}
+
+		if group == nil {
+			continue
+		}
+
+		filters, err := deviceGroupFilter(group, models.Filter{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: string(device.UID)},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		_, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: 1}, filters, "", "", "", store.DeviceListModeDefault)
+		if err != nil {
+			return false, err
+		}
+
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// scopeGroup returns a device group of a scope, or nil when the group was removed after the scope was set, as the
+// removed groups do not grant any device.
+func (s *service) scopeGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err == store.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+// scopeFilter returns the properties, joined by OR, that select the devices with one of the scope's tags or in one of
+// its device groups. As the groups have their own filters, their devices are selected by UID.
+func (s *service) scopeFilter(ctx context.Context, tenant string, scope *models.MemberScope) ([]models.Filter, error) {
+	properties := make([]models.Filter, 0)
+	for _, tag := range scope.Tags {
+		properties = append(properties, models.Filter{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: tag},
+		})
+	}
+
+	for _, id := range scope.Groups {
+		group, err := s.scopeGroup(ctx, tenant, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if group == nil {
+			continue
+		}
+
+		filters, err := deviceGroupFilter(group)
+		if err != nil {
+			return nil, err
+		}
+
+		devices, _, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: -1}, filters, "", "", "", store.DeviceListModeDefault)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, device := range devices {
+			properties = append(properties, models.Filter{
+				Type:   "property",
+				Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: string(device.UID)},
+			})
+		}
+	}
+
+	// A scope without any device must not select all of them.
+	if len(properties) == 0 {
+		properties = append(properties, models.Filter{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: ""},
+		})
+	}
+
+	return properties, nil
+}
+
+// restrictDeviceFilter restricts the devices selected by the filters to the devices in the member's scope. The filters
+// are closed before the scope's properties are appended as their own group, which the stores join to the others by
+// AND.
+func (s *service) restrictDeviceFilter(ctx context.Context, tenant string, scope *models.MemberScope, filters []models.Filter) ([]models.Filter, error) {
+	properties, err := s.scopeFilter(ctx, tenant, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	filters = closeDeviceFilter(filters)
+	filters = append(filters, properties...)
+	filters = append(filters, models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "or"}})
+
+	return filters, nil
+}
+
+// scopeDevices returns the UIDs of the namespace's devices in the member's scope.
+func (s *service) scopeDevices(ctx context.Context, tenant string, scope *models.MemberScope) ([]models.UID, error) {
+	filters, err := s.restrictDeviceFilter(ctx, tenant, scope, scopeDeviceFilter(nil, tenant))
+	if err != nil {
+		return nil, err
+	}
+
+	devices, _, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: -1}, filters, "", "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make([]models.UID, 0, len(devices))
+	for _, device := range devices {
+		uids = append(uids, models.UID(device.UID))
+	}
+
+	return uids, nil
+}
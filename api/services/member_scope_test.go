@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEditNamespaceUserScope(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "admin", Role: guard.RoleAdministrator},
+			{ID: "contractor", Role: guard.RoleOperator},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		userID        string
+		memberID      string
+		scope         *models.MemberScope
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace does not exist",
+			userID:      "admin",
+			memberID:    "contractor",
+			scope:       &models.MemberScope{Tags: []string{"customer-a"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the member is not in the namespace",
+			userID:      "admin",
+			memberID:    "unknown",
+			scope:       &models.MemberScope{Tags: []string{"customer-a"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrNamespaceMemberNotFound("unknown", nil),
+		},
+		{
+			description: "fails when the user cannot act over the member",
+			userID:      "admin",
+			memberID:    "owner",
+			scope:       &models.MemberScope{Tags: []string{"customer-a"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			description: "fails when a device group does not exist",
+			userID:      "admin",
+			memberID:    "contractor",
+			scope:       &models.MemberScope{Groups: []string{"group"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "group").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("group", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds restricting the member to the tags and the device groups",
+			userID:      "admin",
+			memberID:    "contractor",
+			scope:       &models.MemberScope{Tags: []string{"customer-a"}, Groups: []string{"group"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "group").Return(&models.DeviceGroup{ID: "group", TenantID: "tenant"}, nil).Once()
+				storeMock.On("NamespaceSetMemberScope", ctx, "tenant", "contractor", &models.MemberScope{Tags: []string{"customer-a"}, Groups: []string{"group"}}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds removing the restriction when the scope is empty",
+			userID:      "admin",
+			memberID:    "contractor",
+			scope:       &models.MemberScope{},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("NamespaceSetMemberScope", ctx, "tenant", "contractor", (*models.MemberScope)(nil)).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditNamespaceUserScope(ctx, "tenant", tc.userID, tc.memberID, tc.scope)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestCheckMemberScope(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "contractor", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}, Groups: []string{"removed", "group"}}},
+		},
+	}
+
+	group := &models.DeviceGroup{ID: "group", TenantID: "tenant", Filter: "W10="}
+
+	cases := []struct {
+		description   string
+		memberID      string
+		device        *models.Device
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the member is not in the namespace",
+			memberID:    "unknown",
+			device:      &models.Device{UID: "uid", TenantID: "tenant"},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: NewErrNamespaceMemberNotFound("unknown", nil),
+		},
+		{
+			description: "succeeds when the member is not restricted",
+			memberID:    "owner",
+			device:      &models.Device{UID: "uid", TenantID: "tenant"},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the device has one of the scope's tags",
+			memberID:    "contractor",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"customer-a"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the device is in one of the scope's groups",
+			memberID:    "contractor",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"customer-b"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "removed").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, mock.Anything, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid"}}, 1, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the device is out of the scope",
+			memberID:    "contractor",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"customer-b"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "removed").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, mock.Anything, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "contractor", "").Return([]models.AccessGrant{}, nil).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", nil),
+		},
+		{
+			description: "succeeds when an access grant gives the member access to the device out of the scope",
+			memberID:    "contractor",
+			device:      &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"customer-b"}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "removed").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, mock.Anything, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "contractor", "").
+					Return([]models.AccessGrant{{ID: "grant", TenantID: "tenant", Member: "contractor", Device: "uid"}}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.CheckMemberScope(ctx, tc.memberID, tc.device)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEvaluateConnectionScope(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	restricted := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "contractor", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}}},
+		},
+	}
+
+	device := &models.Device{UID: "uid", TenantID: "tenant", Tags: []string{"customer-b"}}
+
+	cases := []struct {
+		description   string
+		member        string
+		fingerprint   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device does not exist",
+			member:      "contractor",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", Err),
+		},
+		{
+			description: "fails when the device is out of the member's scope",
+			member:      "contractor",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(restricted, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "contractor", "").Return([]models.AccessGrant{}, nil).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", nil),
+		},
+		{
+			description: "fails when the public key does not exist",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrPublicKeyNotFound("fingerprint", Err),
+		},
+		{
+			description: "fails when the device is out of the scope of the member who added the public key",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(&models.PublicKey{Fingerprint: "fingerprint", CreatedBy: "contractor"}, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(restricted, nil).Once()
+				storeMock.On("AccessGrantListActive", ctx, "tenant", "contractor", "").Return([]models.AccessGrant{}, nil).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", nil),
+		},
+		{
+			description: "succeeds when the device is in the scope of the member who added the public key",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(&models.PublicKey{Fingerprint: "fingerprint", CreatedBy: "owner"}, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(restricted, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the connection has no member though a member of the namespace is restricted",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the public key has no member though a member of the namespace is restricted",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				storeMock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(&models.PublicKey{Fingerprint: "fingerprint"}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EvaluateConnectionScope(ctx, models.UID("uid"), tc.member, tc.fingerprint)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestListDevicesMemberScope(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	// The request is made by the member "id".
+	ctx := newAuditContext()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "owner", Role: guard.RoleOwner},
+			{ID: "id", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}}},
+		},
+	}
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	devices := []models.Device{{UID: "uid", TenantID: "tenant", Tags: []string{"customer-a"}}}
+
+	cases := []struct {
+		description string
+		filters     []models.Filter
+		restricted  []models.Filter
+	}{
+		{
+			description: "closes the member's filters before the scope's tags are required",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: "web"}},
+			},
+			restricted: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: "web"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+				{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "customer-a"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+			},
+		},
+		{
+			description: "closes the member's filters ending with an unknown operator",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: ""}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "xor"}},
+			},
+			restricted: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: ""}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "xor"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+				{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "customer-a"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+			},
+		},
+		{
+			description: "keeps the member's filters ending with a known operator",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: "web"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+			restricted: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: "web"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+				{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "customer-a"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "or"}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			storeMock.On("DeviceList", ctx, query, tc.restricted, models.DeviceStatusAccepted, "name", "asc", store.DeviceListModeDefault).Return(devices, 1, nil).Once()
+
+			list, count, err := s.ListDevices(ctx, "tenant", query, tc.filters, models.DeviceStatusAccepted, "name", "asc")
+			assert.NoError(t, err)
+			assert.Equal(t, devices, list)
+			assert.Equal(t, 1, count)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
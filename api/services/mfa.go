@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/totp"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MFAIssuer is the issuer shown by the authenticator apps next to the user's account.
+	MFAIssuer = "ShellHub"
+	// MFATokenExpiration is the time that an MFA-pending token can be exchanged for the user's JWT.
+	MFATokenExpiration = 5 * time.Minute
+	// MFATokenAttempts is the number of invalid codes accepted for an MFA-pending token before it is invalidated.
+	MFATokenAttempts = 5
+	// MFARecoveryCodes is the number of recovery codes generated when MFA is enabled.
+	MFARecoveryCodes = 10
+)
+
+type MFAService interface {
+	GenerateMFA(ctx context.Context, userID string) (*responses.MFAGenerate, error)
+	EnableMFA(ctx context.Context, userID string, req requests.MFAEnable) (*responses.MFARecoveryCodes, error)
+	DisableMFA(ctx context.Context, userID string, req requests.MFADisable) error
+	AuthMFA(ctx context.Context, req requests.MFAAuth) (*models.UserAuthResponse, error)
+	EditNamespaceRequireMFA(ctx context.Context, tenant, userID string, requireMFA bool) error
+}
+
+// mfaPending is the cached state of an MFA-pending token.
+type mfaPending struct {
+	UserID string `json:"user_id"`
+	// Login is the username whose failed logins are counted for the token.
+	Login     string    `json:"login"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GenerateMFA generates a new TOTP secret for a user, returning it with its provisioning URI. The secret is only used
+// after it is confirmed by EnableMFA.
+func (s *service) GenerateMFA(ctx context.Context, userID string) (*responses.MFAGenerate, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	if user.MFA.Enabled {
+		return nil, NewErrMFAAlreadyEnabled(nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.UserUpdateMFA(ctx, user.ID, models.UserMFA{Secret: secret}); err != nil {
+		return nil, NewErrUserUpdate(user, err)
+	}
+
+	return &responses.MFAGenerate{
+		Secret: secret,
+		URI:    totp.URI(MFAIssuer, user.Username, secret),
+	}, nil
+}
+
+// EnableMFA enables MFA for a user when the code is valid for the secret generated by GenerateMFA. The recovery codes
+// are returned only here, as just their digests are stored.
+func (s *service) EnableMFA(ctx context.Context, userID string, req requests.MFAEnable) (*responses.MFARecoveryCodes, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	if user.MFA.Enabled {
+		return nil, NewErrMFAAlreadyEnabled(nil)
+	}
+
+	if user.MFA.Secret == "" {
+		return nil, NewErrMFANotGenerated(nil)
+	}
+
+	counter, ok := totp.Counter(user.MFA.Secret, req.Code, clock.Now())
+	if !ok {
+		return nil, NewErrMFACodeInvalid(nil)
+	}
+
+	if err := s.mfaAcceptCounter(ctx, user, counter); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, MFARecoveryCodes)
+	digests := make([]string, MFARecoveryCodes)
+	for i := range codes {
+		code, err := mfaRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes[i], digests[i] = code, tokenDigest(mfaNormalizeCode(code))
+	}
+
+	if err := s.store.UserUpdateMFA(ctx, user.ID, models.UserMFA{Enabled: true, Secret: user.MFA.Secret, RecoveryCodes: digests}); err != nil {
+		return nil, NewErrUserUpdate(user, err)
+	}
+
+	return &responses.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// DisableMFA disables MFA for a user, which must confirm it with a TOTP code or a recovery code.
+func (s *service) DisableMFA(ctx context.Context, userID string, req requests.MFADisable) error {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return NewErrUserNotFound(userID, err)
+	}
+
+	if !user.MFA.Enabled {
+		return NewErrMFANotEnabled(nil)
+	}
+
+	if err := s.mfaVerify(ctx, user, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.store.UserUpdateMFA(ctx, user.ID, models.UserMFA{}); err != nil {
+		return NewErrUserUpdate(user, err)
+	}
+
+	return nil
+}
+
+// AuthMFA exchanges an MFA-pending token, returned by AuthUser, for the user's JWT when the code is valid.
+//
+// The token is invalidated once it is exchanged, when it expires or after MFATokenAttempts invalid codes. The attempts
+// are counted atomically before the code is verified, so concurrent requests cannot verify more codes than that. The
+// invalid codes are also failed logins of the username and the IP, whose failures are reset only when the code is
+// valid.
+func (s *service) AuthMFA(ctx context.Context, req requests.MFAAuth) (*models.UserAuthResponse, error) {
+	digest := tokenDigest(req.Token)
+	key, attemptsKey := "mfa_token_"+digest, "mfa_attempts_"+digest
+
+	invalidate := func() {
+		s.cache.Delete(ctx, key)         // nolint: errcheck
+		s.cache.Delete(ctx, attemptsKey) // nolint: errcheck
+	}
+
+	var pending mfaPending
+	if err := s.cache.Get(ctx, key, &pending); err != nil || pending.UserID == "" {
+		return nil, NewErrMFATokenInvalid(err)
+	}
+
+	ip := gateway.IPFromContext(ctx)
+	if left := s.loginLocked(ctx, pending.Login, ip); left > 0 {
+		return nil, NewErrAuthLocked(left, nil)
+	}
+
+	ttl := pending.ExpiresAt.Sub(clock.Now())
+	if ttl <= 0 {
+		invalidate()
+
+		return nil, NewErrMFATokenInvalid(nil)
+	}
+
+	attempts, err := s.cache.Incr(ctx, attemptsKey, ttl)
+	if err != nil || attempts > MFATokenAttempts {
+		invalidate()
+
+		return nil, NewErrMFATokenInvalid(err)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, pending.UserID, false)
+	if err != nil || user == nil || !user.MFA.Enabled {
+		invalidate()
+
+		return nil, NewErrMFATokenInvalid(err)
+	}
+
+	if err := s.mfaVerify(ctx, user, req.Code); err != nil {
+		failure := s.loginFailed(ctx, pending.Login, ip, err)
+		if failure != err || attempts >= MFATokenAttempts {
+			invalidate()
+		}
+
+		return nil, failure
+	}
+
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, NewErrMFATokenInvalid(err)
+	}
+
+	s.cache.Delete(ctx, attemptsKey) // nolint: errcheck
+	s.loginSucceeded(ctx, pending.Login)
+
+	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
+
+	return s.authUserToken(ctx, user, namespace)
+}
+
+// EditNamespaceRequireMFA defines if the members of a namespace must have MFA enabled to access it.
+//
+// To avoid being locked out, the member who requires MFA must have it enabled. The cached tokens of the members
+// without MFA are invalidated, so they lose the access to the namespace immediately.
+func (s *service) EditNamespaceRequireMFA(ctx context.Context, tenant, userID string, requireMFA bool) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil || namespace == nil {
+		return NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if requireMFA {
+		user, _, err := s.store.UserGetByID(ctx, userID, false)
+		if err != nil || user == nil {
+			return NewErrUserNotFound(userID, err)
+		}
+
+		if !user.MFA.Enabled {
+			return NewErrMFANotEnabled(nil)
+		}
+	}
+
+	if err := s.store.NamespaceSetRequireMFA(ctx, requireMFA, tenant); err != nil {
+		return err
+	}
+
+	if requireMFA {
+		for _, member := range namespace.Members {
+			if member.ID == userID {
+				continue
+			}
+
+			user, _, err := s.store.UserGetByID(ctx, member.ID, false)
+			if err != nil || user == nil || user.MFA.Enabled {
+				continue
+			}
+
+			if err := s.AuthUncacheToken(ctx, tenant, member.ID); err != nil {
+				logrus.WithError(err).WithFields(logrus.Fields{"tenant": tenant, "id": member.ID}).Error("Failed to uncache the token of a member without MFA")
+			}
+		}
+	}
+
+	s.audit(ctx, tenant, models.AuditActionNamespaceRequireMFA, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenant}, nil, map[string]interface{}{"require_mfa": requireMFA})
+
+	return nil
+}
+
+// authMFAPending returns the MFA-pending token of a user whose password was verified. The failed logins of the login's
+// username are kept until the token is exchanged.
+func (s *service) authMFAPending(ctx context.Context, user *models.User, login string) (*models.UserAuthResponse, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	pending := mfaPending{UserID: user.ID, Login: login, ExpiresAt: clock.Now().Add(MFATokenExpiration)}
+	if err := s.cache.Set(ctx, "mfa_token_"+tokenDigest(token), pending, MFATokenExpiration); err != nil {
+		return nil, err
+	}
+
+	return &models.UserAuthResponse{MFAToken: token}, nil
+}
+
+// mfaVerify checks a TOTP code, or a recovery code, of a user with MFA enabled. A TOTP code is accepted only once and a
+// recovery code is removed once used.
+func (s *service) mfaVerify(ctx context.Context, user *models.User, code string) error {
+	if counter, ok := totp.Counter(user.MFA.Secret, code, clock.Now()); ok {
+		return s.mfaAcceptCounter(ctx, user, counter)
+	}
+
+	digest := tokenDigest(mfaNormalizeCode(code))
+	for i, recovery := range user.MFA.RecoveryCodes {
+		if recovery != digest {
+			continue
+		}
+
+		if err := s.store.UserRemoveMFARecoveryCode(ctx, user.ID, digest); err != nil {
+			if err == store.ErrNoDocuments {
+				return NewErrMFACodeInvalid(nil)
+			}
+
+			return NewErrUserUpdate(user, err)
+		}
+
+		user.MFA.RecoveryCodes = append(append([]string{}, user.MFA.RecoveryCodes[:i]...), user.MFA.RecoveryCodes[i+1:]...)
+
+		return nil
+	}
+
+	return NewErrMFACodeInvalid(nil)
+}
+
+// mfaAcceptCounter records the time step of an accepted TOTP code, rejecting the code when it, or a later one, was
+// already accepted for the user.
+func (s *service) mfaAcceptCounter(ctx context.Context, user *models.User, counter uint64) error {
+	if counter <= user.MFA.LastCounter {
+		return NewErrMFACodeInvalid(nil)
+	}
+
+	if err := s.store.UserSetMFACounter(ctx, user.ID, counter); err != nil {
+		if err == store.ErrNoDocuments {
+			return NewErrMFACodeInvalid(nil)
+		}
+
+		return NewErrUserUpdate(user, err)
+	}
+
+	user.MFA.LastCounter = counter
+
+	return nil
+}
+
+// mfaRequired checks if a namespace requires MFA from a user who does not have it enabled.
+func mfaRequired(namespace *models.Namespace, user *models.User) bool {
+	return namespace.Settings != nil && namespace.Settings.RequireMFA && !user.MFA.Enabled
+}
+
+// mfaRecoveryCode generates a recovery code formatted as two groups of five characters.
+func mfaRecoveryCode() (string, error) {
+	secret := make([]byte, 10)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	code := strings.ToLower(base32.StdEncoding.EncodeToString(secret))[:10]
+
+	return code[:5] + "-" + code[5:], nil
+}
+
+// mfaNormalizeCode normalizes a recovery code as typed by the user, ignoring its case, spaces and dashes.
+func mfaNormalizeCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+}
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/totp"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// cacheMap is an in-memory cache, ignoring the TTL, to follow the values cached by the service.
+type cacheMap map[string][]byte
+
+var _ storecache.Cache = cacheMap{}
+
+func (c cacheMap) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := c[key]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func (c cacheMap) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c[key] = data
+
+	return nil
+}
+
+func (c cacheMap) Delete(_ context.Context, key string) error {
+	delete(c, key)
+
+	return nil
+}
+
+func (c cacheMap) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var value int64
+	if err := c.Get(ctx, key, &value); err != nil {
+		return 0, err
+	}
+
+	value++
+
+	return value, c.Set(ctx, key, value, ttl)
+}
+
+const mfaSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// mfaInvalidCode returns a code that is not valid for the mfaSecret at the tests' current time.
+func mfaInvalidCode() string {
+	for _, code := range []string{"000000", "111111", "222222", "333333"} {
+		if !totp.Validate(mfaSecret, code, now) {
+			return code
+		}
+	}
+
+	return "444444"
+}
+
+func TestGenerateMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Enabled: true, Secret: mfaSecret}}, 0, nil).Once()
+
+	res, err := s.GenerateMFA(ctx, "id")
+	assert.Equal(t, NewErrMFAAlreadyEnabled(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", UserData: models.UserData{Username: "john"}}, 0, nil).Once()
+	storeMock.On("UserUpdateMFA", ctx, "id", mock.MatchedBy(func(mfa models.UserMFA) bool {
+		return !mfa.Enabled && len(mfa.Secret) == 32 && len(mfa.RecoveryCodes) == 0
+	})).Return(nil).Once()
+
+	res, err = s.GenerateMFA(ctx, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, totp.URI(MFAIssuer, "john", res.Secret), res.URI)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEnableMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	code, err := totp.Code(mfaSecret, now)
+	assert.NoError(t, err)
+
+	counter, _ := totp.Counter(mfaSecret, code, now)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id"}, 0, nil).Once()
+
+	res, err := s.EnableMFA(ctx, "id", requests.MFAEnable{Code: code})
+	assert.Equal(t, NewErrMFANotGenerated(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Secret: mfaSecret}}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	res, err = s.EnableMFA(ctx, "id", requests.MFAEnable{Code: mfaInvalidCode()})
+	assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Secret: mfaSecret, LastCounter: counter}}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	res, err = s.EnableMFA(ctx, "id", requests.MFAEnable{Code: code})
+	assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Secret: mfaSecret}}, 0, nil).Once()
+	storeMock.On("UserSetMFACounter", ctx, "id", counter).Return(nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	var stored models.UserMFA
+	storeMock.On("UserUpdateMFA", ctx, "id", mock.AnythingOfType("models.UserMFA")).
+		Run(func(args mock.Arguments) { stored = args.Get(2).(models.UserMFA) }).Return(nil).Once()
+
+	res, err = s.EnableMFA(ctx, "id", requests.MFAEnable{Code: code})
+	assert.NoError(t, err)
+	assert.Len(t, res.RecoveryCodes, MFARecoveryCodes)
+	assert.True(t, stored.Enabled)
+	assert.Equal(t, mfaSecret, stored.Secret)
+	assert.Len(t, stored.RecoveryCodes, MFARecoveryCodes)
+	for i, code := range res.RecoveryCodes {
+		assert.Regexp(t, "^[a-z2-7]{5}-[a-z2-7]{5}$", code)
+		assert.Equal(t, tokenDigest(mfaNormalizeCode(code)), stored.RecoveryCodes[i])
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDisableMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id"}, 0, nil).Once()
+	assert.Equal(t, NewErrMFANotEnabled(nil), s.DisableMFA(ctx, "id", requests.MFADisable{Code: mfaInvalidCode()}))
+
+	mfa := models.UserMFA{Enabled: true, Secret: mfaSecret, RecoveryCodes: []string{tokenDigest("abcdefghij"), tokenDigest("klmnopqrst")}}
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: mfa}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	assert.Equal(t, NewErrMFACodeInvalid(nil), s.DisableMFA(ctx, "id", requests.MFADisable{Code: "abcde-fghik"}))
+
+	// The recovery code was used by a concurrent request after the user was read.
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: mfa}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("UserRemoveMFARecoveryCode", ctx, "id", tokenDigest("abcdefghij")).Return(store.ErrNoDocuments).Once()
+	assert.Equal(t, NewErrMFACodeInvalid(nil), s.DisableMFA(ctx, "id", requests.MFADisable{Code: "ABCDE-FGHIJ"}))
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: mfa}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("UserRemoveMFARecoveryCode", ctx, "id", tokenDigest("abcdefghij")).Return(nil).Once()
+	storeMock.On("UserUpdateMFA", ctx, "id", models.UserMFA{}).Return(nil).Once()
+	assert.NoError(t, s.DisableMFA(ctx, "id", requests.MFADisable{Code: "ABCDE-FGHIJ"}))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cache := cacheMap{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	passwd := sha256.Sum256([]byte("passwd"))
+	user := &models.User{
+		ID:           "id",
+		Confirmed:    true,
+		UserData:     models.UserData{Username: "john", Email: "john@example.com"},
+		UserPassword: models.UserPassword{Password: hex.EncodeToString(passwd[:])},
+		MFA:          models.UserMFA{Enabled: true, Secret: mfaSecret},
+	}
+
+	namespace := &models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "id", Role: "owner"}}}
+
+	storeMock.On("UserGetByUsername", ctx, "john").Return(user, nil).Once()
+	storeMock.On("NamespaceGetFirst", ctx, "id").Return(namespace, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err := s.AuthUser(ctx, requests.UserAuth{Username: "john", Password: "passwd"})
+	assert.NoError(t, err)
+	assert.Empty(t, pending.Token)
+	assert.NotEmpty(t, pending.MFAToken)
+
+	res, err := s.AuthMFA(ctx, requests.MFAAuth{Token: "invalid", Code: mfaInvalidCode()})
+	assert.Equal(t, NewErrMFATokenInvalid(nil), err)
+	assert.Nil(t, res)
+
+	for i := 1; i < MFATokenAttempts; i++ {
+		storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+		clockMock.On("Now").Return(now).Twice()
+
+		res, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: mfaInvalidCode()})
+		assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+		assert.Nil(t, res)
+	}
+
+	code, err := totp.Code(mfaSecret, now)
+	assert.NoError(t, err)
+
+	counter, _ := totp.Counter(mfaSecret, code, now)
+
+	storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+	storeMock.On("UserSetMFACounter", ctx, "id", counter).Return(nil).Once()
+	storeMock.On("NamespaceGetFirst", ctx, "id").Return(namespace, nil).Once()
+	storeMock.On("UserUpdateData", ctx, "id", mock.AnythingOfType("models.User")).Return(nil).Once()
+	clockMock.On("Now").Return(now).Times(4)
+
+	res, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Token)
+	assert.Equal(t, "tenant", res.Tenant)
+	assert.Equal(t, "owner", res.Role)
+
+	res, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.Equal(t, NewErrMFATokenInvalid(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthMFAReplay(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cache := cacheMap{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	code, err := totp.Code(mfaSecret, now)
+	assert.NoError(t, err)
+
+	counter, _ := totp.Counter(mfaSecret, code, now)
+
+	user := &models.User{ID: "id", MFA: models.UserMFA{Enabled: true, Secret: mfaSecret}}
+
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err := s.authMFAPending(ctx, user, "john")
+	assert.NoError(t, err)
+
+	// The code was accepted by a concurrent request after the user was read.
+	storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+	storeMock.On("UserSetMFACounter", ctx, "id", counter).Return(store.ErrNoDocuments).Once()
+	clockMock.On("Now").Return(now).Twice()
+
+	res, err := s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+	assert.Nil(t, res)
+
+	// The code was accepted before the user was read.
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Enabled: true, Secret: mfaSecret, LastCounter: counter}}, 0, nil).Once()
+	clockMock.On("Now").Return(now).Twice()
+
+	res, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: code})
+	assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthMFAAttempts(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cache := cacheMap{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	user := &models.User{ID: "id", MFA: models.UserMFA{Enabled: true, Secret: mfaSecret}}
+
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err := s.authMFAPending(ctx, user, "john")
+	assert.NoError(t, err)
+
+	for i := 0; i < MFATokenAttempts; i++ {
+		storeMock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+		clockMock.On("Now").Return(now).Twice()
+
+		_, err := s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: mfaInvalidCode()})
+		assert.Equal(t, NewErrMFACodeInvalid(nil), err)
+	}
+
+	assert.Empty(t, cache)
+
+	// The attempts made concurrently with the last one are counted, invalidating the token before a code is verified.
+	clockMock.On("Now").Return(now).Once()
+
+	pending, err = s.authMFAPending(ctx, user, "john")
+	assert.NoError(t, err)
+
+	cache["mfa_attempts_"+tokenDigest(pending.MFAToken)] = []byte("5")
+	clockMock.On("Now").Return(now).Once()
+
+	_, err = s.AuthMFA(ctx, requests.MFAAuth{Token: pending.MFAToken, Code: mfaInvalidCode()})
+	assert.Equal(t, NewErrMFATokenInvalid(nil), err)
+	assert.Empty(t, cache)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEditNamespaceRequireMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cache := cacheMap{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "owner", Role: "owner"}, {ID: "with", Role: "operator"}, {ID: "without", Role: "observer"}},
+	}
+
+	storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+	storeMock.On("UserGetByID", ctx, "owner", false).Return(&models.User{ID: "owner"}, 0, nil).Once()
+	assert.Equal(t, NewErrMFANotEnabled(nil), s.EditNamespaceRequireMFA(ctx, "tenant", "owner", true))
+
+	assert.NoError(t, s.AuthCacheToken(ctx, "tenant", "with", "token"))
+	assert.NoError(t, s.AuthCacheToken(ctx, "tenant", "without", "token"))
+
+	storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+	storeMock.On("UserGetByID", ctx, "owner", false).Return(&models.User{ID: "owner", MFA: models.UserMFA{Enabled: true}}, 0, nil).Once()
+	storeMock.On("NamespaceSetRequireMFA", ctx, true, "tenant").Return(nil).Once()
+	storeMock.On("UserGetByID", ctx, "with", false).Return(&models.User{ID: "with", MFA: models.UserMFA{Enabled: true}}, 0, nil).Once()
+	storeMock.On("UserGetByID", ctx, "without", false).Return(&models.User{ID: "without"}, 0, nil).Once()
+	assert.NoError(t, s.EditNamespaceRequireMFA(ctx, "tenant", "owner", true))
+
+	cached, err := s.AuthIsCacheToken(ctx, "tenant", "with")
+	assert.NoError(t, err)
+	assert.True(t, cached)
+
+	cached, err = s.AuthIsCacheToken(ctx, "tenant", "without")
+	assert.NoError(t, err)
+	assert.False(t, cached)
+
+	storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+	storeMock.On("NamespaceSetRequireMFA", ctx, false, "tenant").Return(nil).Once()
+	assert.NoError(t, s.EditNamespaceRequireMFA(ctx, "tenant", "without", false))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthSwapTokenRequireMFA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "id", Role: "observer"}},
+		Settings: &models.NamespaceSettings{RequireMFA: true},
+	}
+
+	storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+	storeMock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id"}, 0, nil).Once()
+
+	res, err := s.AuthSwapToken(ctx, "id", "tenant")
+	assert.Equal(t, NewErrMFARequired(nil), err)
+	assert.Nil(t, res)
+
+	storeMock.AssertExpectations(t)
+}
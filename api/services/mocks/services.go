@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	asciicast "github.com/shellhub-io/shellhub/pkg/asciicast"
+
 	context "context"
 
 	models "github.com/shellhub-io/shellhub/pkg/models"
@@ -22,6 +24,46 @@ type Service struct {
 	mock.Mock
 }
 
+// AcceptInvitation provides a mock function with given fields: ctx, userID, token
+func (_m *Service) AcceptInvitation(ctx context.Context, userID string, token string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, userID, token)
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Namespace, error)); ok {
+		return rf(ctx, userID, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Namespace); ok {
+		r0 = rf(ctx, userID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddFirewallRuleTag provides a mock function with given fields: ctx, tenant, id, tag
+func (_m *Service) AddFirewallRuleTag(ctx context.Context, tenant string, id string, tag string) error {
+	ret := _m.Called(ctx, tenant, id, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddNamespaceUser provides a mock function with given fields: ctx, memberUsername, memberRole, tenantID, userID
 func (_m *Service) AddNamespaceUser(ctx context.Context, memberUsername string, memberRole string, tenantID string, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, memberUsername, memberRole, tenantID, userID)
@@ -62,6 +104,58 @@ func (_m *Service) AddPublicKeyTag(ctx context.Context, tenant string, fingerpri
 	return r0
 }
 
+// ApproveAccessRequest provides a mock function with given fields: ctx, tenant, id, userID
+func (_m *Service) ApproveAccessRequest(ctx context.Context, tenant string, id string, userID string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, id, userID)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthAPIKey provides a mock function with given fields: ctx, key
+func (_m *Service) AuthAPIKey(ctx context.Context, key string) (*models.UserAuthClaims, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *models.UserAuthClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserAuthClaims, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserAuthClaims); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthCacheToken provides a mock function with given fields: ctx, tenant, id, token
 func (_m *Service) AuthCacheToken(ctx context.Context, tenant string, id string, token string) error {
 	ret := _m.Called(ctx, tenant, id, token)
@@ -152,6 +246,32 @@ func (_m *Service) AuthIsCacheToken(ctx context.Context, tenant string, id strin
 	return r0, r1
 }
 
+// AuthMFA provides a mock function with given fields: ctx, req
+func (_m *Service) AuthMFA(ctx context.Context, req request.MFAAuth) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.MFAAuth) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.MFAAuth) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.MFAAuth) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthPublicKey provides a mock function with given fields: ctx, req
 func (_m *Service) AuthPublicKey(ctx context.Context, req request.PublicKeyAuth) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -218,6 +338,20 @@ func (_m *Service) AuthUncacheToken(ctx context.Context, tenant string, id strin
 	return r0
 }
 
+// AuthUnlock provides a mock function with given fields: ctx, username, ip
+func (_m *Service) AuthUnlock(ctx context.Context, username string, ip string) error {
+	ret := _m.Called(ctx, username, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AuthUser provides a mock function with given fields: ctx, req
 func (_m *Service) AuthUser(ctx context.Context, req request.UserAuth) (*models.UserAuthResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -270,6 +404,150 @@ func (_m *Service) AuthUserInfo(ctx context.Context, username string, tenant str
 	return r0, r1
 }
 
+// BulkDevices provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) BulkDevices(ctx context.Context, tenant string, req request.DeviceBulk) (*response.DeviceBulk, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *response.DeviceBulk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceBulk) (*response.DeviceBulk, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceBulk) *response.DeviceBulk); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.DeviceBulk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.DeviceBulk) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckMemberScope provides a mock function with given fields: ctx, memberID, device
+func (_m *Service) CheckMemberScope(ctx context.Context, memberID string, device *models.Device) error {
+	ret := _m.Called(ctx, memberID, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Device) error); ok {
+		r0 = rf(ctx, memberID, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, tenant, userID, req
+func (_m *Service) CreateAPIKey(ctx context.Context, tenant string, userID string, req request.APIKeyCreate) (*response.APIKeyCreate, error) {
+	ret := _m.Called(ctx, tenant, userID, req)
+
+	var r0 *response.APIKeyCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.APIKeyCreate) (*response.APIKeyCreate, error)); ok {
+		return rf(ctx, tenant, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.APIKeyCreate) *response.APIKeyCreate); ok {
+		r0 = rf(ctx, tenant, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.APIKeyCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.APIKeyCreate) error); ok {
+		r1 = rf(ctx, tenant, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessGrant provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateAccessGrant(ctx context.Context, tenant string, req request.AccessGrantCreate) (*models.AccessGrant, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.AccessGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.AccessGrantCreate) (*models.AccessGrant, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.AccessGrantCreate) *models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.AccessGrantCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessRequest provides a mock function with given fields: ctx, tenant, member, req
+func (_m *Service) CreateAccessRequest(ctx context.Context, tenant string, member string, req request.AccessRequestCreate) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, member, req)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.AccessRequestCreate) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, member, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.AccessRequestCreate) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, member, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.AccessRequestCreate) error); ok {
+		r1 = rf(ctx, tenant, member, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeviceGroup provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateDeviceGroup(ctx context.Context, tenant string, req request.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceGroupCreate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceGroupCreate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.DeviceGroupCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -284,6 +562,58 @@ func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
+// CreateFirewallRule provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateFirewallRule(ctx context.Context, tenant string, req request.FirewallRuleCreate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.FirewallRuleCreate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.FirewallRuleCreate) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.FirewallRuleCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, tenant, userID, req
+func (_m *Service) CreateInvitation(ctx context.Context, tenant string, userID string, req request.InvitationCreate) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, userID, req)
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.InvitationCreate) (*models.Invitation, error)); ok {
+		return rf(ctx, tenant, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.InvitationCreate) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.InvitationCreate) error); ok {
+		r1 = rf(ctx, tenant, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNamespace provides a mock function with given fields: ctx, namespace, userID
 func (_m *Service) CreateNamespace(ctx context.Context, namespace request.NamespaceCreate, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, namespace, userID)
@@ -362,6 +692,32 @@ func (_m *Service) CreatePublicKey(ctx context.Context, req request.PublicKeyCre
 	return r0, r1
 }
 
+// CreateRole provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateRole(ctx context.Context, tenant string, req request.RoleCreate) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.RoleCreate) (*models.Role, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.RoleCreate) *models.Role); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.RoleCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSession provides a mock function with given fields: ctx, session
 func (_m *Service) CreateSession(ctx context.Context, session request.SessionCreate) (*models.Session, error) {
 	ret := _m.Called(ctx, session)
@@ -388,6 +744,32 @@ func (_m *Service) CreateSession(ctx context.Context, session request.SessionCre
 	return r0, r1
 }
 
+// CreateWebhook provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateWebhook(ctx context.Context, tenant string, req request.WebhookCreate) (*response.WebhookCreate, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *response.WebhookCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.WebhookCreate) (*response.WebhookCreate, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.WebhookCreate) *response.WebhookCreate); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.WebhookCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.WebhookCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateSession provides a mock function with given fields: ctx, uid
 func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -402,13 +784,13 @@ func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error
 	return r0
 }
 
-// DeleteDevice provides a mock function with given fields: ctx, uid, tenant
-func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
-	ret := _m.Called(ctx, uid, tenant)
+// DeclineInvitation provides a mock function with given fields: ctx, token
+func (_m *Service) DeclineInvitation(ctx context.Context, token string) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
-		r0 = rf(ctx, uid, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -416,13 +798,13 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
-// DeleteNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
-	ret := _m.Called(ctx, tenantID)
+// DeleteAPIKey provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteAPIKey(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -430,10 +812,66 @@ func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	return r0
 }
 
-// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
-	ret := _m.Called(ctx, fingerprint, tenant)
-
+// DeleteDevice provides a mock function with given fields: ctx, uid, tenant
+func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
+	ret := _m.Called(ctx, uid, tenant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteDeviceGroup(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteFirewallRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteFirewallRule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeletePublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tenant string) error {
+	ret := _m.Called(ctx, fingerprint, tenant)
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
 		r0 = rf(ctx, fingerprint, tenant)
@@ -444,6 +882,20 @@ func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tena
 	return r0
 }
 
+// DeleteRole provides a mock function with given fields: ctx, tenant, name
+func (_m *Service) DeleteRole(ctx context.Context, tenant string, name string) error {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTag provides a mock function with given fields: ctx, tenant, tag
 func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
 	ret := _m.Called(ctx, tenant, tag)
@@ -458,6 +910,60 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
+// DeleteWebhook provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteWebhook(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliverWebhook provides a mock function with given fields: ctx, deliveryID
+func (_m *Service) DeliverWebhook(ctx context.Context, deliveryID string) error {
+	ret := _m.Called(ctx, deliveryID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DenyAccessRequest provides a mock function with given fields: ctx, tenant, id, userID
+func (_m *Service) DenyAccessRequest(ctx context.Context, tenant string, id string, userID string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, id, userID)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceHeartbeat provides a mock function with given fields: ctx, uid
 func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -472,6 +978,20 @@ func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// DisableMFA provides a mock function with given fields: ctx, userID, req
+func (_m *Service) DisableMFA(ctx context.Context, userID string, req request.MFADisable) error {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.MFADisable) error); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespace provides a mock function with given fields: ctx, tenantID, name
 func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, name)
@@ -498,6 +1018,34 @@ func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// EditNamespaceApprovalTags provides a mock function with given fields: ctx, tenant, tags
+func (_m *Service) EditNamespaceApprovalTags(ctx context.Context, tenant string, tags []string) error {
+	ret := _m.Called(ctx, tenant, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, tenant, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditNamespaceRequireMFA provides a mock function with given fields: ctx, tenant, userID, requireMFA
+func (_m *Service) EditNamespaceRequireMFA(ctx context.Context, tenant string, userID string, requireMFA bool) error {
+	ret := _m.Called(ctx, tenant, userID, requireMFA)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
+		r0 = rf(ctx, tenant, userID, requireMFA)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespaceUser provides a mock function with given fields: ctx, tenantID, userID, memberID, memberNewRole
 func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userID string, memberID string, memberNewRole string) error {
 	ret := _m.Called(ctx, tenantID, userID, memberID, memberNewRole)
@@ -512,6 +1060,34 @@ func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userI
 	return r0
 }
 
+// EditNamespaceUserScope provides a mock function with given fields: ctx, tenantID, userID, memberID, scope
+func (_m *Service) EditNamespaceUserScope(ctx context.Context, tenantID string, userID string, memberID string, scope *models.MemberScope) error {
+	ret := _m.Called(ctx, tenantID, userID, memberID, scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.MemberScope) error); ok {
+		r0 = rf(ctx, tenantID, userID, memberID, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditSessionRecordInput provides a mock function with given fields: ctx, recordInput, tenantID
+func (_m *Service) EditSessionRecordInput(ctx context.Context, recordInput bool, tenantID string) error {
+	ret := _m.Called(ctx, recordInput, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, recordInput, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditSessionRecordStatus provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -526,6 +1102,112 @@ func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bo
 	return r0
 }
 
+// EnableMFA provides a mock function with given fields: ctx, userID, req
+func (_m *Service) EnableMFA(ctx context.Context, userID string, req request.MFAEnable) (*response.MFARecoveryCodes, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *response.MFARecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.MFAEnable) (*response.MFARecoveryCodes, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.MFAEnable) *response.MFARecoveryCodes); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.MFARecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.MFAEnable) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateAccessGrant provides a mock function with given fields: ctx, tenant, member, fingerprint, device
+func (_m *Service) EvaluateAccessGrant(ctx context.Context, tenant string, member string, fingerprint string, device *models.Device) (*models.AccessGrant, error) {
+	ret := _m.Called(ctx, tenant, member, fingerprint, device)
+
+	var r0 *models.AccessGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.Device) (*models.AccessGrant, error)); ok {
+		return rf(ctx, tenant, member, fingerprint, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *models.Device) *models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, member, fingerprint, device)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *models.Device) error); ok {
+		r1 = rf(ctx, tenant, member, fingerprint, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateAccessRequest provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateAccessRequest(ctx context.Context, req request.AccessRequestEvaluate) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AccessRequestEvaluate) (*models.AccessRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AccessRequestEvaluate) *models.AccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AccessRequestEvaluate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateConnectionScope provides a mock function with given fields: ctx, uid, member, fingerprint
+func (_m *Service) EvaluateConnectionScope(ctx context.Context, uid models.UID, member string, fingerprint string) error {
+	ret := _m.Called(ctx, uid, member, fingerprint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
+		r0 = rf(ctx, uid, member, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateFirewall provides a mock function with given fields: ctx, req
+func (_m *Service) EvaluateFirewall(ctx context.Context, req request.FirewallEvaluate) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.FirewallEvaluate) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
 func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	ret := _m.Called(ctx, key, dev)
@@ -574,20 +1256,34 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
-// GetDevice provides a mock function with given fields: ctx, uid
-func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
+// ExpireAccessGrants provides a mock function with given fields: ctx
+func (_m *Service) ExpireAccessGrants(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportSessionRecord provides a mock function with given fields: ctx, uid
+func (_m *Service) ExportSessionRecord(ctx context.Context, uid models.UID) (*asciicast.Cast, error) {
 	ret := _m.Called(ctx, uid)
 
-	var r0 *models.Device
+	var r0 *asciicast.Cast
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*asciicast.Cast, error)); ok {
 		return rf(ctx, uid)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *asciicast.Cast); ok {
 		r0 = rf(ctx, uid)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).(*asciicast.Cast)
 		}
 	}
 
@@ -600,25 +1296,25 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
-// GetNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
-	ret := _m.Called(ctx, tenantID)
+// GenerateMFA provides a mock function with given fields: ctx, userID
+func (_m *Service) GenerateMFA(ctx context.Context, userID string) (*response.MFAGenerate, error) {
+	ret := _m.Called(ctx, userID)
 
-	var r0 *models.Namespace
+	var r0 *response.MFAGenerate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
-		return rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.MFAGenerate, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
-		r0 = rf(ctx, tenantID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.MFAGenerate); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Namespace)
+			r0 = ret.Get(0).(*response.MFAGenerate)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, tenantID)
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -626,16 +1322,172 @@ func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.N
 	return r0, r1
 }
 
-// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
-func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenant)
+// GetAccessGrant provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetAccessGrant(ctx context.Context, tenant string, id string) (*models.AccessGrant, error) {
+	ret := _m.Called(ctx, tenant, id)
 
-	var r0 *models.PublicKey
+	var r0 *models.AccessGrant
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PublicKey, error)); ok {
-		return rf(ctx, fingerprint, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AccessGrant, error)); ok {
+		return rf(ctx, tenant, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessRequest provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetAccessRequest(ctx context.Context, tenant string, id string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDevice provides a mock function with given fields: ctx, uid
+func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.Device, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.Device); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetDeviceGroup(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirewallRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetFirewallRule(ctx context.Context, tenant string, id string) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicKey provides a mock function with given fields: ctx, fingerprint, tenant
+func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant string) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenant)
+
+	var r0 *models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.PublicKey, error)); ok {
+		return rf(ctx, fingerprint, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.PublicKey); ok {
 		r0 = rf(ctx, fingerprint, tenant)
 	} else {
 		if ret.Get(0) != nil {
@@ -652,6 +1504,32 @@ func (_m *Service) GetPublicKey(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
+// GetRole provides a mock function with given fields: ctx, tenant, name
+func (_m *Service) GetRole(ctx context.Context, tenant string, name string) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Role, error)); ok {
+		return rf(ctx, tenant, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Role); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSession provides a mock function with given fields: ctx, uid
 func (_m *Service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
 	ret := _m.Called(ctx, uid)
@@ -761,6 +1639,32 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 	return r0, r1, r2
 }
 
+// IssueUserCertificate provides a mock function with given fields: ctx, req
+func (_m *Service) IssueUserCertificate(ctx context.Context, req request.UserCertificateIssue) (*models.UserCertificate, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.UserCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.UserCertificateIssue) (*models.UserCertificate, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.UserCertificateIssue) *models.UserCertificate); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.UserCertificateIssue) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // KeepAliveSession provides a mock function with given fields: ctx, uid
 func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -775,6 +1679,204 @@ func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// ListAPIKeys provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.APIKey, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.APIKey); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAccessGrants provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListAccessGrants(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.AccessGrant
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.AccessGrant, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAccessRequests provides a mock function with given fields: ctx, tenant, status, pagination
+func (_m *Service) ListAccessRequests(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	ret := _m.Called(ctx, tenant, status, pagination)
+
+	var r0 []models.AccessRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) ([]models.AccessRequest, int, error)); ok {
+		return rf(ctx, tenant, status, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) []models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, status, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, status, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, tenant, pagination, filters
+func (_m *Service) ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filters)
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, tenant, pagination, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter) []models.AuditLog); ok {
+		r0 = rf(ctx, tenant, pagination, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, []models.Filter) int); ok {
+		r1 = rf(ctx, tenant, pagination, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, []models.Filter) error); ok {
+		r2 = rf(ctx, tenant, pagination, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroupDevices provides a mock function with given fields: ctx, tenant, id, pagination, status, sort, _a6
+func (_m *Service) ListDeviceGroupDevices(ctx context.Context, tenant string, id string, pagination paginator.Query, status models.DeviceStatus, sort string, _a6 string) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination, status, sort, _a6)
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query, models.DeviceStatus, string, string) ([]models.Device, int, error)); ok {
+		return rf(ctx, tenant, id, pagination, status, sort, _a6)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query, models.DeviceStatus, string, string) []models.Device); ok {
+		r0 = rf(ctx, tenant, id, pagination, status, sort, _a6)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query, models.DeviceStatus, string, string) int); ok {
+		r1 = rf(ctx, tenant, id, pagination, status, sort, _a6)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query, models.DeviceStatus, string, string) error); ok {
+		r2 = rf(ctx, tenant, id, pagination, status, sort, _a6)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroups provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListDeviceGroups(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListDevices provides a mock function with given fields: ctx, tenant, pagination, filter, status, sort, order
 func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort string, order string) ([]models.Device, int, error) {
 	ret := _m.Called(ctx, tenant, pagination, filter, status, sort, order)
@@ -808,6 +1910,72 @@ func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination pa
 	return r0, r1, r2
 }
 
+// ListFirewallRules provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListFirewallRules(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.FirewallRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.FirewallRule, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.FirewallRule); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListInvitations provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListInvitations(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Invitation
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Invitation, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Invitation); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListNamespaces provides a mock function with given fields: ctx, pagination, filter, export
 func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Query, filter []models.Filter, export bool) ([]models.Namespace, int, error) {
 	ret := _m.Called(ctx, pagination, filter, export)
@@ -826,14 +1994,146 @@ func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Quer
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, bool) int); ok {
-		r1 = rf(ctx, pagination, filter, export)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, bool) int); ok {
+		r1 = rf(ctx, pagination, filter, export)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, bool) error); ok {
+		r2 = rf(ctx, pagination, filter, export)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPublicKeys provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.PublicKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.PublicKey, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListRoles provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListRoles(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Role
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Role, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Role); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessionRecordFrames provides a mock function with given fields: ctx, uid
+func (_m *Service) ListSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []models.RecordedSession
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.RecordedSession, int, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.RecordedSession); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecordedSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) int); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID) error); ok {
+		r2 = rf(ctx, uid)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListSessions provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.Session
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.Session, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, bool) error); ok {
-		r2 = rf(ctx, pagination, filter, export)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -841,32 +2141,32 @@ func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
-// ListPublicKeys provides a mock function with given fields: ctx, pagination
-func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
-	ret := _m.Called(ctx, pagination)
+// ListWebhookDeliveries provides a mock function with given fields: ctx, tenant, id, pagination
+func (_m *Service) ListWebhookDeliveries(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination)
 
-	var r0 []models.PublicKey
+	var r0 []models.WebhookDelivery
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.PublicKey, int, error)); ok {
-		return rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, id, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.PublicKey); ok {
-		r0 = rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.PublicKey)
+			r0 = ret.Get(0).([]models.WebhookDelivery)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, id, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, id, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -874,32 +2174,32 @@ func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
-// ListSessions provides a mock function with given fields: ctx, pagination
-func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, pagination)
+// ListWebhooks provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
 
-	var r0 []models.Session
+	var r0 []models.Webhook
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.Session, int, error)); ok {
-		return rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
-		r0 = rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Session)
+			r0 = ret.Get(0).([]models.Webhook)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -933,6 +2233,63 @@ func (_m *Service) LookupDevice(ctx context.Context, namespace string, name stri
 	return r0, r1
 }
 
+// OIDCAuth provides a mock function with given fields: ctx, req
+func (_m *Service) OIDCAuth(ctx context.Context, req request.OIDCAuth) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OIDCAuth) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OIDCAuth) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OIDCAuth) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCLogin provides a mock function with given fields: ctx
+func (_m *Service) OIDCLogin(ctx context.Context) (string, string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) string); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PublicKey provides a mock function with given fields:
 func (_m *Service) PublicKey() *rsa.PublicKey {
 	ret := _m.Called()
@@ -949,6 +2306,60 @@ func (_m *Service) PublicKey() *rsa.PublicKey {
 	return r0
 }
 
+// RecordSession provides a mock function with given fields: ctx, uid, frame
+func (_m *Service) RecordSession(ctx context.Context, uid models.UID, frame request.SessionRecord) error {
+	ret := _m.Called(ctx, uid, frame)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, request.SessionRecord) error); ok {
+		r0 = rf(ctx, uid, frame)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedeliverWebhook provides a mock function with given fields: ctx, tenant, id, deliveryID
+func (_m *Service) RedeliverWebhook(ctx context.Context, tenant string, id string, deliveryID string) (*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, tenant, id, deliveryID)
+
+	var r0 *models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.WebhookDelivery, error)); ok {
+		return rf(ctx, tenant, id, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, id, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDeviceAttribute provides a mock function with given fields: ctx, tenant, uid, key
+func (_m *Service) RemoveDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string) error {
+	ret := _m.Called(ctx, tenant, uid, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -963,6 +2374,20 @@ func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
+// RemoveFirewallRuleTag provides a mock function with given fields: ctx, tenant, id, tag
+func (_m *Service) RemoveFirewallRuleTag(ctx context.Context, tenant string, id string, tag string) error {
+	ret := _m.Called(ctx, tenant, id, tag)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveNamespaceUser provides a mock function with given fields: ctx, tenantID, memberID, userID
 func (_m *Service) RemoveNamespaceUser(ctx context.Context, tenantID string, memberID string, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, memberID, userID)
@@ -1031,6 +2456,109 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
+// ReportDeviceHostKeyMismatch provides a mock function with given fields: ctx, uid, hostKey
+func (_m *Service) ReportDeviceHostKeyMismatch(ctx context.Context, uid models.UID, hostKey string) error {
+	ret := _m.Called(ctx, uid, hostKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, hostKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAccessGrant provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) RevokeAccessGrant(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeInvitation provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) RevokeInvitation(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateDeviceHostKey provides a mock function with given fields: ctx, tenant, uid, hostKey
+func (_m *Service) RotateDeviceHostKey(ctx context.Context, tenant string, uid models.UID, hostKey string) error {
+	ret := _m.Called(ctx, tenant, uid, hostKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, hostKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchSessionRecords provides a mock function with given fields: ctx, tenant, query, pagination
+func (_m *Service) SearchSessionRecords(ctx context.Context, tenant string, query string, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	ret := _m.Called(ctx, tenant, query, pagination)
+
+	var r0 []models.SessionRecordLine
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.SessionRecordLine, int, error)); ok {
+		return rf(ctx, tenant, query, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.SessionRecordLine); ok {
+		r0 = rf(ctx, tenant, query, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionRecordLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, query, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, query, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetDeviceAttribute provides a mock function with given fields: ctx, tenant, uid, key, value
+func (_m *Service) SetDeviceAttribute(ctx context.Context, tenant string, uid models.UID, key string, value string) error {
+	ret := _m.Called(ctx, tenant, uid, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, string) error); ok {
+		r0 = rf(ctx, tenant, uid, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDevicePosition provides a mock function with given fields: ctx, uid, ip
 func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip string) error {
 	ret := _m.Called(ctx, uid, ip)
@@ -1113,6 +2641,46 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceAttributes provides a mock function with given fields: ctx, tenant, uid, attributes
+func (_m *Service) UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, tenant, uid, attributes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, tenant, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeviceGroup provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) UpdateDeviceGroup(ctx context.Context, tenant string, req request.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceGroupUpdate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, uid, online
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1141,6 +2709,46 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
+// UpdateFirewallRule provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) UpdateFirewallRule(ctx context.Context, tenant string, id string, req request.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, request.FirewallRuleUpdate) *models.FirewallRule); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, request.FirewallRuleUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateFirewallRuleTags provides a mock function with given fields: ctx, tenant, id, tags
+func (_m *Service) UpdateFirewallRuleTags(ctx context.Context, tenant string, id string, tags []string) error {
+	ret := _m.Called(ctx, tenant, id, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, tenant, id, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
 func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
 	ret := _m.Called(ctx, id, currentPassword, newPassword)
@@ -1209,6 +2817,32 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// UpdateRole provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) UpdateRole(ctx context.Context, tenant string, req request.RoleUpdate) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.RoleUpdate) (*models.Role, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, request.RoleUpdate) *models.Role); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, request.RoleUpdate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
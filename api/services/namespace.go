@@ -25,6 +25,7 @@ type NamespaceService interface {
 	RemoveNamespaceUser(ctx context.Context, tenantID, memberID, userID string) (*models.Namespace, error)
 	EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error
 	EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error
+	EditSessionRecordInput(ctx context.Context, recordInput bool, tenantID string) error
 	GetSessionRecord(ctx context.Context, tenantID string) (bool, error)
 }
 
@@ -109,6 +110,8 @@ func (s *service) CreateNamespace(ctx context.Context, namespace requests.Namesp
 		return nil, NewErrNamespaceCreateStore(err)
 	}
 
+	s.audit(ctx, ns.TenantID, models.AuditActionNamespaceCreate, models.AuditTarget{Type: models.AuditTargetNamespace, ID: ns.TenantID}, nil, map[string]interface{}{"name": ns.Name})
+
 	return ns, nil
 }
 
@@ -148,7 +151,13 @@ func (s *service) DeleteNamespace(ctx context.Context, tenantID string) error {
 		return err
 	}
 
-	return s.store.NamespaceDelete(ctx, tenantID)
+	if err := s.store.NamespaceDelete(ctx, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditActionNamespaceDelete, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, map[string]interface{}{"name": ns.Name}, nil)
+
+	return nil
 }
 
 // fillMembersData fill the member data with the user data.
@@ -195,7 +204,14 @@ func (s *service) EditNamespace(ctx context.Context, tenantID, name string) (*mo
 		return nil, NewErrNamespaceDuplicated(nil)
 	}
 
-	return s.store.NamespaceRename(ctx, namespace.TenantID, name)
+	renamed, err := s.store.NamespaceRename(ctx, namespace.TenantID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, namespace.TenantID, models.AuditActionNamespaceRename, models.AuditTarget{Type: models.AuditTargetNamespace, ID: namespace.TenantID}, map[string]interface{}{"name": namespace.Name}, map[string]interface{}{"name": name})
+
+	return renamed, nil
 }
 
 // AddNamespaceUser adds a member to a namespace.
@@ -244,7 +260,24 @@ func (s *service) AddNamespaceUser(ctx context.Context, memberUsername, memberRo
 		return nil, guard.ErrForbidden
 	}
 
-	return s.store.NamespaceAddMember(ctx, tenantID, passive.ID, memberRole)
+	if err := s.checkMemberRole(ctx, tenantID, memberRole); err != nil {
+		return nil, err
+	}
+
+	return s.addNamespaceMember(ctx, requestActor(ctx), tenantID, passive, memberRole)
+}
+
+// addNamespaceMember adds the user to the namespace with the role, recording it on the audit log as performed by the
+// actor.
+func (s *service) addNamespaceMember(ctx context.Context, actor *models.AuditActor, tenant string, user *models.User, role string) (*models.Namespace, error) {
+	added, err := s.store.NamespaceAddMember(ctx, tenant, user.ID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditBy(ctx, actor, tenant, models.AuditActionNamespaceMemberAdd, models.AuditTarget{Type: models.AuditTargetMember, ID: user.ID}, nil, map[string]interface{}{"username": user.Username, "role": role})
+
+	return added, nil
 }
 
 // RemoveNamespaceUser removes member from a namespace.
@@ -300,6 +333,8 @@ func (s *service) RemoveNamespaceUser(ctx context.Context, tenantID, memberID, u
 
 	s.AuthUncacheToken(ctx, namespace.TenantID, member.ID) // nolint: errcheck
 
+	s.audit(ctx, tenantID, models.AuditActionNamespaceMemberRemove, models.AuditTarget{Type: models.AuditTargetMember, ID: member.ID}, map[string]interface{}{"username": member.Username, "role": passive.Role}, nil)
+
 	return removed, nil
 }
 
@@ -350,11 +385,23 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 		return guard.ErrForbidden
 	}
 
-	if err := s.store.NamespaceEditMember(ctx, tenantID, member.ID, memberNewRole); err != nil {
+	if err := s.checkMemberRole(ctx, tenantID, memberNewRole); err != nil {
 		return err
 	}
 
-	s.AuthUncacheToken(ctx, namespace.TenantID, member.ID) // nolint: errcheck
+	return s.editNamespaceMember(ctx, requestActor(ctx), namespace.TenantID, passive, memberNewRole)
+}
+
+// editNamespaceMember changes the role of a namespace's member, recording it on the audit log as performed by the actor.
+// The member's cached token is invalidated, so the new role is used from its next request.
+func (s *service) editNamespaceMember(ctx context.Context, actor *models.AuditActor, tenant string, member *models.Member, role string) error {
+	if err := s.store.NamespaceEditMember(ctx, tenant, member.ID, role); err != nil {
+		return err
+	}
+
+	s.AuthUncacheToken(ctx, tenant, member.ID) // nolint: errcheck
+
+	s.auditBy(ctx, actor, tenant, models.AuditActionNamespaceMemberEdit, models.AuditTarget{Type: models.AuditTargetMember, ID: member.ID}, map[string]interface{}{"role": member.Role}, map[string]interface{}{"role": role})
 
 	return nil
 }
@@ -364,7 +411,27 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 // It receives a context, used to "control" the request flow, a boolean to define if the sessions will be recorded and
 // the tenant ID from models.Namespace.
 func (s *service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	return s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID)
+	if err := s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditActionNamespaceSessionRecord, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, nil, map[string]interface{}{"session_record": sessionRecord})
+
+	return nil
+}
+
+// EditSessionRecordInput defines if the input of the recorded sessions, as the keystrokes, will also be recorded.
+//
+// It receives a context, used to "control" the request flow, a boolean to define if the input will be recorded and
+// the tenant ID from models.Namespace.
+func (s *service) EditSessionRecordInput(ctx context.Context, recordInput bool, tenantID string) error {
+	if err := s.store.NamespaceSetRecordInput(ctx, recordInput, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditActionNamespaceSessionRecord, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, nil, map[string]interface{}{"record_input": recordInput})
+
+	return nil
 }
 
 // GetSessionRecord gets the session record data.
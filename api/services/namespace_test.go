@@ -785,7 +785,7 @@ func TestAddNamespaceUser(t *testing.T) {
 		{
 			Name:     "AddNamespaceUser fails when Role is not valid",
 			Username: user2.Username,
-			Role:     "invalid",
+			Role:     "invalid role",
 			ID:       user1.ID,
 			TenantID: namespace.TenantID,
 			RequiredMocks: func() {
@@ -875,6 +875,45 @@ func TestAddNamespaceUser(t *testing.T) {
 				err:       NewErrNamespaceMemberDuplicated(user2.ID, nil),
 			},
 		},
+		{
+			Name:     "AddNamespaceUser fails when the custom role does not exist",
+			Username: user2.Username,
+			Role:     "support",
+			ID:       user1.ID,
+			TenantID: namespace.TenantID,
+			RequiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+
+				mock.On("UserGetByID", ctx, user1.ID, false).Return(user1, 0, nil).Once()
+				mock.On("UserGetByUsername", ctx, user2.Username).Return(user2, nil).Once()
+
+				mock.On("RoleGet", ctx, namespace.TenantID, "support").Return(nil, store.ErrNoDocuments).Once()
+			},
+			Expected: Expected{
+				namespace: nil,
+				err:       NewErrRoleNotFound("support", store.ErrNoDocuments),
+			},
+		},
+		{
+			Name:     "AddNamespaceUser succeeds with a custom role",
+			Username: user2.Username,
+			Role:     "support",
+			ID:       user1.ID,
+			TenantID: namespace.TenantID,
+			RequiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+
+				mock.On("UserGetByID", ctx, user1.ID, false).Return(user1, 0, nil).Once()
+				mock.On("UserGetByUsername", ctx, user2.Username).Return(user2, nil).Once()
+
+				mock.On("RoleGet", ctx, namespace.TenantID, "support").Return(&models.Role{TenantID: namespace.TenantID, Name: "support"}, nil).Once()
+				mock.On("NamespaceAddMember", ctx, namespace.TenantID, user2.ID, "support").Return(namespaceTwoMembers, nil).Once()
+			},
+			Expected: Expected{
+				namespace: namespaceTwoMembers,
+				err:       nil,
+			},
+		},
 		{
 			Name:     "AddNamespaceUser succeeds",
 			Username: user2.Username,
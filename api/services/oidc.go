@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/oidc"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// OIDCStateExpiration is the time that the user has to authenticate on the OpenID Connect provider.
+const OIDCStateExpiration = 10 * time.Minute
+
+// OIDCProvider authenticates the users through the authorization code flow of an OpenID Connect provider.
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce string) (string, error)
+	Exchange(ctx context.Context, code, nonce string) (*oidc.Identity, error)
+}
+
+// WithOIDC sets the OpenID Connect provider used to authenticate the users. The members of the provider's groups
+// become members of the namespaces mapped to them when they log in.
+func WithOIDC(provider OIDCProvider, roles []guard.GroupRole) Option {
+	return func(s *service) {
+		s.oidc = provider
+		s.oidcRoles = roles
+	}
+}
+
+type OIDCService interface {
+	OIDCLogin(ctx context.Context) (string, string, error)
+	OIDCAuth(ctx context.Context, req requests.OIDCAuth) (*models.UserAuthResponse, error)
+}
+
+// oidcState is the cached state of a login started on the OpenID Connect provider.
+type oidcState struct {
+	Nonce string `json:"nonce"`
+}
+
+// OIDCLogin starts a login on the OpenID Connect provider, returning the provider's URL where the user is
+// authenticated and the login's state, which must be sent back to OIDCAuth.
+func (s *service) OIDCLogin(ctx context.Context) (string, string, error) {
+	if s.oidc == nil {
+		return "", "", NewErrOIDCUnavailable(nil)
+	}
+
+	state, err := provisionSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := provisionSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	url, err := s.oidc.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		return "", "", NewErrOIDCUnavailable(err)
+	}
+
+	if err := s.cache.Set(ctx, "oidc_state_"+tokenDigest(state), oidcState{Nonce: nonce}, OIDCStateExpiration); err != nil {
+		return "", "", err
+	}
+
+	return url, state, nil
+}
+
+// OIDCAuth exchanges the authorization code sent by the OpenID Connect provider for the user's JWT.
+//
+// The user is found by the verified email given by the provider, being created on its first login. When the
+// provider's groups are mapped to namespaces, the user is added to them, or has the role updated, with the greatest
+// role mapped to its groups. Memberships are never removed, as they can be managed on ShellHub too.
+func (s *service) OIDCAuth(ctx context.Context, req requests.OIDCAuth) (*models.UserAuthResponse, error) {
+	if s.oidc == nil {
+		return nil, NewErrOIDCUnavailable(nil)
+	}
+
+	key := "oidc_state_" + tokenDigest(req.State)
+
+	var state oidcState
+	if err := s.cache.Get(ctx, key, &state); err != nil || state.Nonce == "" {
+		return nil, NewErrOIDCStateInvalid(err)
+	}
+
+	// The state is used once, even when the authentication fails.
+	if err := s.cache.Delete(ctx, key); err != nil {
+		return nil, NewErrOIDCStateInvalid(err)
+	}
+
+	identity, err := s.oidc.Exchange(ctx, req.Code, state.Nonce)
+	if err != nil {
+		return nil, NewErrOIDCAuthFailed(err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, NewErrOIDCEmailNotVerified(nil)
+	}
+
+	user, err := s.provisionUser(ctx, identity.Email, identity.Username, identity.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.provisionMembership(ctx, user, s.oidcRoles, identity.Groups)
+
+	if user.MFA.Enabled {
+		return s.authMFAPending(ctx, user, strings.ToLower(user.Username))
+	}
+
+	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
+
+	return s.authUserToken(ctx, user, namespace)
+}
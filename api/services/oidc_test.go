@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/oidc"
+	"github.com/shellhub-io/shellhub/api/pkg/oidc/oidctest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newOIDCProvider returns a provider authenticating through the mock provider.
+func newOIDCProvider(t *testing.T, server *oidctest.Server) *oidc.Provider {
+	t.Helper()
+
+	provider, err := oidc.New(oidc.Config{
+		OIDCIssuer:       server.URL,
+		OIDCClientID:     server.ClientID,
+		OIDCClientSecret: server.ClientSecret,
+		OIDCRedirectURL:  "http://localhost/login/oidc",
+		OIDCScopes:       []string{"openid", "email", "profile"},
+		OIDCGroupsClaim:  "groups",
+	})
+	assert.NoError(t, err)
+
+	return provider
+}
+
+// oidcAuthorize follows the URL returned by OIDCLogin, as the browser does, returning the request sent by the redirect
+// URL's page.
+func oidcAuthorize(t *testing.T, s *APIService) requests.OIDCAuth {
+	t.Helper()
+
+	authURL, state, err := s.OIDCLogin(context.TODO())
+	assert.NoError(t, err)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	res, err := client.Get(authURL)
+	assert.NoError(t, err)
+	res.Body.Close()
+
+	redirect, err := url.Parse(res.Header.Get("Location"))
+	assert.NoError(t, err)
+	assert.Equal(t, state, redirect.Query().Get("state"))
+
+	return requests.OIDCAuth{Code: redirect.Query().Get("code"), State: redirect.Query().Get("state")}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	storeMock := &mocks.Store{}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	_, _, err := s.OIDCLogin(context.TODO())
+	assert.Equal(t, NewErrOIDCUnavailable(nil), err)
+
+	server := oidctest.NewServer("shellhub", "secret")
+	defer server.Close()
+
+	cache := cacheMap{}
+	s = NewService(store.Store(storeMock), privateKey, publicKey, cache, clientMock, nil, WithOIDC(newOIDCProvider(t, server), nil))
+
+	authURL, state, err := s.OIDCLogin(context.TODO())
+	assert.NoError(t, err)
+	assert.Contains(t, cache, "oidc_state_"+tokenDigest(state))
+
+	parsed, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, state, parsed.Query().Get("state"))
+	assert.Equal(t, "http://localhost/login/oidc", parsed.Query().Get("redirect_uri"))
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestOIDCAuth(t *testing.T) {
+	storeMock := &mocks.Store{}
+
+	server := oidctest.NewServer("shellhub", "secret")
+	defer server.Close()
+
+	roles := []guard.GroupRole{
+		{Group: "devs", Tenant: "tenant", Role: "operator"},
+		{Group: "ops", Tenant: "tenant", Role: "observer"},
+		{Group: "admins", Tenant: "other", Role: "administrator"},
+	}
+
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMap{}, clientMock, nil, WithOIDC(newOIDCProvider(t, server), roles))
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		claims        map[string]interface{}
+		requiredMocks func()
+		state         string
+		expected      func(t *testing.T, res *models.UserAuthResponse)
+		err           error
+	}{
+		{
+			description:   "fails when the state is unknown",
+			state:         "unknown",
+			requiredMocks: func() {},
+			err:           NewErrOIDCStateInvalid(nil),
+		},
+		{
+			description:   "fails when the email is not verified",
+			claims:        map[string]interface{}{"email_verified": false},
+			requiredMocks: func() {},
+			err:           NewErrOIDCEmailNotVerified(nil),
+		},
+		{
+			description: "creates the user and adds it to the namespace mapped to its groups",
+			claims:      map[string]interface{}{"preferred_username": "John", "name": "John Doe", "groups": []string{"devs", "ops"}},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").Return(nil, store.ErrNoDocuments).Once()
+				storeMock.On("UserGetByUsername", ctx, "john").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("UserCreate", ctx, mock.MatchedBy(func(user *models.User) bool {
+					return user.Username == "john" && user.Name == "John Doe" && user.Email == "john@example.com" && user.Confirmed && user.Password != ""
+				})).Run(func(args mock.Arguments) { args.Get(1).(*models.User).ID = "id" }).Return(nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}}}, nil).Once()
+				storeMock.On("NamespaceAddMember", ctx, "tenant", "id", "operator").Return(&models.Namespace{}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID:  "tenant",
+					Actor:     models.AuditActor{ID: "id", Username: "john"},
+					Action:    models.AuditActionNamespaceMemberAdd,
+					Target:    models.AuditTarget{Type: models.AuditTargetMember, ID: "id"},
+					Diff:      models.AuditDiff{After: map[string]interface{}{"username": "john", "role": "operator"}},
+					CreatedAt: now,
+				}).Return(nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").
+					Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "owner", Role: "owner"}, {ID: "id", Role: "operator"}}}, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "id", mock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.NotEmpty(t, res.Token)
+				assert.Equal(t, "john", res.User)
+				assert.Equal(t, "tenant", res.Tenant)
+				assert.Equal(t, "operator", res.Role)
+			},
+		},
+		{
+			description: "fails when the user with the email is not confirmed",
+			claims:      map[string]interface{}{"groups": []string{"devs"}},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").
+					Return(&models.User{ID: "id", UserData: models.UserData{Username: "john", Email: "john@example.com"}}, nil).Once()
+			},
+			err: NewErrUserNotConfirmed(nil),
+		},
+		{
+			description: "links the confirmed user with the email and updates its role",
+			claims:      map[string]interface{}{"groups": []string{"devs"}},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").
+					Return(&models.User{ID: "id", Confirmed: true, UserData: models.UserData{Username: "john", Email: "john@example.com"}}, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "id", Role: "observer"}}}, nil).Once()
+				storeMock.On("NamespaceEditMember", ctx, "tenant", "id", "operator").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID:  "tenant",
+					Actor:     models.AuditActor{ID: "id", Username: "john"},
+					Action:    models.AuditActionNamespaceMemberEdit,
+					Target:    models.AuditTarget{Type: models.AuditTargetMember, ID: "id"},
+					Diff:      models.AuditDiff{Before: map[string]interface{}{"role": "observer"}, After: map[string]interface{}{"role": "operator"}},
+					CreatedAt: now,
+				}).Return(nil).Once()
+				storeMock.On("NamespaceGetFirst", ctx, "id").Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "id", Role: "operator"}}}, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				storeMock.On("UserUpdateData", ctx, "id", mock.MatchedBy(func(user models.User) bool { return user.Confirmed })).Return(nil).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.NotEmpty(t, res.Token)
+				assert.Equal(t, "operator", res.Role)
+			},
+		},
+		{
+			description: "requires the TOTP code of a user with MFA enabled",
+			claims:      map[string]interface{}{"groups": []string{}},
+			requiredMocks: func() {
+				storeMock.On("UserGetByEmail", ctx, "john@example.com").
+					Return(&models.User{ID: "id", Confirmed: true, MFA: models.UserMFA{Enabled: true, Secret: mfaSecret}}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: func(t *testing.T, res *models.UserAuthResponse) {
+				assert.Empty(t, res.Token)
+				assert.NotEmpty(t, res.MFAToken)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			server.Claims = map[string]interface{}{"sub": "user", "email": "John@example.com", "email_verified": true}
+			for key, value := range tc.claims {
+				server.Claims[key] = value
+			}
+
+			tc.requiredMocks()
+
+			req := oidcAuthorize(t, s)
+			if tc.state != "" {
+				req.State = tc.state
+			}
+
+			res, err := s.OIDCAuth(ctx, req)
+			assert.Equal(t, tc.err, err)
+			if tc.expected != nil {
+				tc.expected(t, res)
+			}
+
+			// The state cannot be used again.
+			_, err = s.OIDCAuth(ctx, req)
+			assert.Equal(t, NewErrOIDCStateInvalid(nil), err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// provisionUsernameInvalid matches the characters that cannot be used on a username.
+var provisionUsernameInvalid = regexp.MustCompile(`[^a-z0-9-_.@]`)
+
+// provisionUser returns the user with the email given by an external authentication, as OpenID Connect or LDAP,
+// creating it when it does not exist.
+//
+// An existing user is only linked to the external authentication when its email was already confirmed on ShellHub, as
+// an unconfirmed account may have been registered by someone else with the email, and it is never confirmed here.
+// Otherwise, a NewErrUserNotConfirmed error is returned.
+func (s *service) provisionUser(ctx context.Context, email, username, name string) (*models.User, error) {
+	email = strings.ToLower(email)
+
+	user, err := s.store.UserGetByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !user.Confirmed {
+			return nil, NewErrUserNotConfirmed(nil)
+		}
+
+		return user, nil
+	case err != store.ErrNoDocuments:
+		return nil, NewErrUserNotFound(email, err)
+	}
+
+	username, err = s.provisionUsername(ctx, username, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = username
+	}
+
+	// The user authenticates externally, so the password is a random one that nobody knows.
+	secret, err := provisionSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	password := sha256.Sum256([]byte(secret))
+
+	user = &models.User{
+		UserData:      models.UserData{Name: name, Email: email, Username: username},
+		UserPassword:  models.UserPassword{Password: hex.EncodeToString(password[:])},
+		Confirmed:     true,
+		CreatedAt:     clock.Now(),
+		MaxNamespaces: -1,
+	}
+
+	if err := s.store.UserCreate(ctx, user); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrUserDuplicated([]string{username}, err)
+		}
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// provisionUsername derives an unused username from the preferred one, or from the email, adding a random suffix when
+// it is already used.
+func (s *service) provisionUsername(ctx context.Context, preferred, email string) (string, error) {
+	username := provisionUsernameInvalid.ReplaceAllString(strings.ToLower(preferred), "")
+	if len(username) < 3 {
+		local, _, _ := strings.Cut(email, "@")
+		username = provisionUsernameInvalid.ReplaceAllString(local, "")
+	}
+
+	if len(username) > 30 {
+		username = username[:30]
+	}
+
+	if len(username) >= 3 {
+		if _, err := s.store.UserGetByUsername(ctx, username); err == store.ErrNoDocuments {
+			return username, nil
+		}
+	}
+
+	suffix, err := provisionSecret()
+	if err != nil {
+		return "", err
+	}
+
+	if len(username) > 25 {
+		username = username[:25]
+	}
+
+	return username + "-" + strings.ToLower(suffix[:4]), nil
+}
+
+// provisionMembership adds the user to the namespaces mapped to its external groups, or updates its role on them, with
+// the greatest role mapped to its groups. Memberships are never removed, as they can be managed on ShellHub too. A
+// failure is only logged, so the user can still log in.
+//
+// The changes are recorded on the namespaces' audit logs as performed by the user, whose login synchronized them.
+func (s *service) provisionMembership(ctx context.Context, user *models.User, roles []guard.GroupRole, groups []string) {
+	actor := &models.AuditActor{ID: user.ID, Username: user.Username}
+
+	for tenant, role := range guard.GroupsRoles(roles, groups) {
+		log := logrus.WithFields(logrus.Fields{"tenant": tenant, "id": user.ID, "role": role})
+
+		namespace, err := s.store.NamespaceGet(ctx, tenant)
+		if err != nil || namespace == nil {
+			log.WithError(err).Warn("Failed to get the namespace mapped to the user's groups")
+
+			continue
+		}
+
+		member, ok := guard.CheckMember(namespace, user.ID)
+		switch {
+		case !ok:
+			if _, err := s.addNamespaceMember(ctx, actor, tenant, user, role); err != nil {
+				log.WithError(err).Error("Failed to add the user to the namespace mapped to its groups")
+			}
+		case member.Role != role && member.Role != guard.RoleOwner:
+			if err := s.editNamespaceMember(ctx, actor, tenant, member, role); err != nil {
+				log.WithError(err).Error("Failed to update the user's role on the namespace mapped to its groups")
+			}
+		}
+	}
+}
+
+// provisionSecret generates a random secret, as the state and the nonce of an OpenID Connect login.
+func provisionSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
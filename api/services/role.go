@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type RoleService interface {
+	CreateRole(ctx context.Context, tenant string, req requests.RoleCreate) (*models.Role, error)
+	ListRoles(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error)
+	GetRole(ctx context.Context, tenant, name string) (*models.Role, error)
+	UpdateRole(ctx context.Context, tenant string, req requests.RoleUpdate) (*models.Role, error)
+	DeleteRole(ctx context.Context, tenant, name string) error
+}
+
+// CreateRole creates a namespace's custom role, granting the permissions listed by name. The role cannot have the name
+// of a builtin role.
+func (s *service) CreateRole(ctx context.Context, tenant string, req requests.RoleCreate) (*models.Role, error) {
+	if guard.IsBuiltinRole(req.Name) {
+		return nil, NewErrRoleInvalid(map[string]interface{}{"name": req.Name}, nil)
+	}
+
+	if _, err := guard.ParsePermissions(req.Permissions); err != nil {
+		return nil, NewErrRoleInvalid(map[string]interface{}{"permissions": req.Permissions}, err)
+	}
+
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	role := &models.Role{
+		TenantID:    tenant,
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+		CreatedAt:   clock.Now(),
+	}
+
+	if err := s.store.RoleCreate(ctx, role); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrRoleDuplicated(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionRoleCreate, models.AuditTarget{Type: models.AuditTargetRole, ID: role.Name}, nil, roleAuditFields(role))
+
+	return role, nil
+}
+
+// ListRoles lists the custom roles of a namespace, sorted by name.
+func (s *service) ListRoles(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	return s.store.RoleList(ctx, tenant, pagination)
+}
+
+func (s *service) GetRole(ctx context.Context, tenant, name string) (*models.Role, error) {
+	role, err := s.store.RoleGet(ctx, tenant, name)
+	if err != nil {
+		return nil, NewErrRoleNotFound(name, err)
+	}
+
+	return role, nil
+}
+
+// UpdateRole updates the description and the permissions of a namespace's custom role. As the permissions are resolved
+// on each request, the change applies to the role's members immediately.
+func (s *service) UpdateRole(ctx context.Context, tenant string, req requests.RoleUpdate) (*models.Role, error) {
+	role, err := s.GetRole(ctx, tenant, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := guard.ParsePermissions(req.Permissions); err != nil {
+		return nil, NewErrRoleInvalid(map[string]interface{}{"permissions": req.Permissions}, err)
+	}
+
+	before := roleAuditFields(role)
+
+	role.Description = req.Description
+	role.Permissions = req.Permissions
+
+	if err := s.store.RoleUpdate(ctx, role); err != nil {
+		if err == store.ErrNoDocuments {
+			return nil, NewErrRoleNotFound(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionRoleUpdate, models.AuditTarget{Type: models.AuditTargetRole, ID: role.Name}, before, roleAuditFields(role))
+
+	return role, nil
+}
+
+// DeleteRole deletes a namespace's custom role. A role still given to a member cannot be deleted.
+func (s *service) DeleteRole(ctx context.Context, tenant, name string) error {
+	role, err := s.GetRole(ctx, tenant, name)
+	if err != nil {
+		return err
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenant, err)
+	}
+
+	for _, member := range namespace.Members {
+		if member.Role == name {
+			return NewErrRoleInUse(name, nil)
+		}
+	}
+
+	if err := s.store.RoleDelete(ctx, tenant, name); err != nil {
+		return NewErrRoleNotFound(name, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionRoleDelete, models.AuditTarget{Type: models.AuditTargetRole, ID: name}, roleAuditFields(role), nil)
+
+	return nil
+}
+
+// checkMemberRole checks if a role can be given to a namespace's member: a builtin role or one of the namespace's
+// custom roles.
+func (s *service) checkMemberRole(ctx context.Context, tenant, role string) error {
+	if guard.IsBuiltinRole(role) {
+		return nil
+	}
+
+	_, err := s.GetRole(ctx, tenant, role)
+
+	return err
+}
+
+func roleAuditFields(role *models.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"description": role.Description,
+		"permissions": role.Permissions,
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRole(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	_, errPermission := guard.ParsePermissions([]string{"device:connect", "namespace:delete"})
+
+	cases := []struct {
+		description   string
+		req           requests.RoleCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the name is a builtin role",
+			req:         requests.RoleCreate{Name: guard.RoleOperator, RoleFields: requests.RoleFields{Permissions: []string{"device:connect"}}},
+			requiredMocks: func() {
+			},
+			expected: NewErrRoleInvalid(map[string]interface{}{"name": guard.RoleOperator}, nil),
+		},
+		{
+			description: "fails when a permission cannot be granted",
+			req:         requests.RoleCreate{Name: "support", RoleFields: requests.RoleFields{Permissions: []string{"device:connect", "namespace:delete"}}},
+			requiredMocks: func() {
+			},
+			expected: NewErrRoleInvalid(map[string]interface{}{"permissions": []string{"device:connect", "namespace:delete"}}, errPermission),
+		},
+		{
+			description: "fails when the namespace does not exist",
+			req:         requests.RoleCreate{Name: "support", RoleFields: requests.RoleFields{Permissions: []string{"device:connect"}}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "fails when the name is already used in the namespace",
+			req:         requests.RoleCreate{Name: "support", RoleFields: requests.RoleFields{Permissions: []string{"device:connect"}}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("RoleCreate", ctx, mock.Anything).Return(store.ErrDuplicate).Once()
+			},
+			expected: NewErrRoleDuplicated("support", store.ErrDuplicate),
+		},
+		{
+			description: "succeeds",
+			req:         requests.RoleCreate{Name: "support", RoleFields: requests.RoleFields{Description: "Support team", Permissions: []string{"device:connect", "session:play"}}},
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("RoleCreate", ctx, &models.Role{
+					TenantID:    "tenant",
+					Name:        "support",
+					Description: "Support team",
+					Permissions: []string{"device:connect", "session:play"},
+					CreatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.CreateRole(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestUpdateRole(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	_, errPermission := guard.ParsePermissions([]string{"role:edit"})
+
+	cases := []struct {
+		description   string
+		req           requests.RoleUpdate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the role does not exist",
+			req:         requests.RoleUpdate{RoleParam: requests.RoleParam{Name: "support"}, RoleFields: requests.RoleFields{Permissions: []string{"device:connect"}}},
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrRoleNotFound("support", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when a permission cannot be granted",
+			req:         requests.RoleUpdate{RoleParam: requests.RoleParam{Name: "support"}, RoleFields: requests.RoleFields{Permissions: []string{"role:edit"}}},
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(&models.Role{TenantID: "tenant", Name: "support"}, nil).Once()
+			},
+			expected: NewErrRoleInvalid(map[string]interface{}{"permissions": []string{"role:edit"}}, errPermission),
+		},
+		{
+			description: "succeeds",
+			req:         requests.RoleUpdate{RoleParam: requests.RoleParam{Name: "support"}, RoleFields: requests.RoleFields{Permissions: []string{"device:connect", "device:accept"}}},
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(&models.Role{TenantID: "tenant", Name: "support", Permissions: []string{"device:connect"}}, nil).Once()
+				storeMock.On("RoleUpdate", ctx, &models.Role{TenantID: "tenant", Name: "support", Permissions: []string{"device:connect", "device:accept"}}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.UpdateRole(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteRole(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	role := &models.Role{TenantID: "tenant", Name: "support"}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the role does not exist",
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrRoleNotFound("support", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when a member has the role",
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(role, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "owner", Role: guard.RoleOwner}, {ID: "member", Role: "support"}},
+				}, nil).Once()
+			},
+			expected: NewErrRoleInUse("support", nil),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("RoleGet", ctx, "tenant", "support").Return(role, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "owner", Role: guard.RoleOwner}},
+				}, nil).Once()
+				storeMock.On("RoleDelete", ctx, "tenant", "support").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.DeleteRole(ctx, "tenant", "support"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
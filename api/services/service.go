@@ -2,10 +2,13 @@ package services
 
 import (
 	"crypto/rsa"
+	"sync"
 
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
+	"github.com/shellhub-io/shellhub/pkg/lockout"
 )
 
 type APIService struct {
@@ -21,23 +24,61 @@ type service struct {
 	cache   cache.Cache
 	client  interface{}
 	locator geoip.Locator
+	// webhooks is where the deliveries of the webhook's events are enqueued. When it is nil, no event is delivered.
+	webhooks WebhookQueue
+	// mailer delivers the namespaces' invitations, linking to the invitationURL. When it is nil, no invitation is sent.
+	mailer        Mailer
+	invitationURL string
+	// oidc authenticates the users through an OpenID Connect provider. When it is nil, the OpenID Connect login is
+	// disabled.
+	oidc      OIDCProvider
+	oidcRoles []guard.GroupRole
+	// ldap authenticates the users against an LDAP directory. When it is nil, only the local accounts are used.
+	ldap      LDAPAuthenticator
+	ldapRoles []guard.GroupRole
+	// userLockout and ipLockout lock the users' logins out after too many failed attempts. When they are nil, the
+	// attempts are not limited.
+	userLockout *lockout.Lockout
+	ipLockout   *lockout.Lockout
+	// firewallPatterns caches the compiled patterns of the firewall rules, so they are not compiled on each connection.
+	firewallPatterns sync.Map
 }
 
+// Option sets an optional dependency of the service.
+type Option func(s *service)
+
 type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceAttributes
+	DeviceHostKey
+	DeviceBulkService
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
+	FirewallService
+	FirewallTagsService
 	SessionService
 	NamespaceService
 	AuthService
 	StatsService
 	SetupService
+	AuditService
+	APIKeyService
+	WebhookService
+	DeviceGroupService
+	InvitationService
+	MFAService
+	OIDCService
+	RoleService
+	MemberScopeService
+	AccessGrantService
+	AccessRequestService
+	CertificateService
 }
 
-func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
+func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator, opts ...Option) *APIService {
 	if privKey == nil || pubKey == nil {
 		var err error
 		privKey, pubKey, err = LoadKeys()
@@ -46,5 +87,10 @@ func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKe
 		}
 	}
 
-	return &APIService{service: &service{store, privKey, pubKey, cache, c, l}}
+	s := &service{store: store, privKey: privKey, pubKey: pubKey, cache: cache, client: c, locator: l}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return &APIService{service: s}
 }
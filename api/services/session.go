@@ -2,12 +2,19 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sort"
 
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/asciicast"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
 )
 
 type SessionService interface {
@@ -17,20 +24,50 @@ type SessionService interface {
 	DeactivateSession(ctx context.Context, uid models.UID) error
 	KeepAliveSession(ctx context.Context, uid models.UID) error
 	SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
+	RecordSession(ctx context.Context, uid models.UID, frame requests.SessionRecord) error
+	ListSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error)
+	ExportSessionRecord(ctx context.Context, uid models.UID) (*asciicast.Cast, error)
+	SearchSessionRecords(ctx context.Context, tenant, query string, pagination paginator.Query) ([]models.SessionRecordLine, int, error)
 }
 
 func (s *service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	return s.store.SessionList(ctx, pagination)
+	// The members restricted to a device scope only list the sessions of the devices in it.
+	var devices []models.UID
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		scope, err := s.requestScope(ctx, tenant.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if scope != nil {
+			if devices, err = s.scopeDevices(ctx, tenant.ID, scope); err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	return s.store.SessionList(ctx, pagination, devices)
 }
 
 func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
-	return s.store.SessionGet(ctx, uid)
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Device != nil {
+		if err := s.checkRequestScope(ctx, session.Device); err != nil {
+			return nil, NewErrSessionNotFound(uid, err)
+		}
+	}
+
+	return session, nil
 }
 
 func (s *service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
 	position, _ := s.locator.GetPosition(net.ParseIP(session.IPAddress))
 
-	return s.store.SessionCreate(ctx, models.Session{
+	created, err := s.store.SessionCreate(ctx, models.Session{
 		UID:       session.UID,
 		DeviceUID: models.UID(session.DeviceUID),
 		Username:  session.Username,
@@ -41,7 +78,15 @@ func (s *service) CreateSession(ctx context.Context, session requests.SessionCre
 			Longitude: position.Longitude,
 			Latitude:  position.Latitude,
 		},
+		Grant: s.sessionAccessGrant(ctx, session),
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.dispatch(ctx, created.TenantID, webhook.WebhookSessionStartedEvent, created)
+
+	return created, nil
 }
 
 func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
@@ -50,7 +95,27 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 		return NewErrSessionNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		logrus.WithError(err).WithField("uid", uid).Error("Failed to get the closed session")
+
+		return nil
+	}
+
+	s.dispatch(ctx, session.TenantID, webhook.WebhookSessionClosedEvent, session)
+
+	// As the session is closed, its record is complete and can be indexed to be searched.
+	if session.Recorded {
+		if err := s.indexSessionRecord(ctx, session); err != nil {
+			logrus.WithError(err).WithField("uid", uid).Error("Failed to index the session's record")
+		}
+	}
+
+	return nil
 }
 
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
@@ -60,3 +125,153 @@ func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 func (s *service) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
 	return s.store.SessionSetAuthenticated(ctx, uid, authenticated)
 }
+
+// RecordSession records a frame of a session, when the session's namespace records its sessions.
+//
+// An input frame is only recorded when the namespace also records the sessions' input, otherwise it is discarded.
+func (s *service) RecordSession(ctx context.Context, uid models.UID, frame requests.SessionRecord) error {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	ns, err := s.store.NamespaceGet(ctx, session.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(session.TenantID, err)
+	}
+
+	if ns.Settings == nil || !ns.Settings.SessionRecord {
+		return nil
+	}
+
+	record := &models.RecordedSession{
+		UID:      uid,
+		Message:  frame.Message,
+		TenantID: session.TenantID,
+		Time:     clock.Now(),
+		Width:    frame.Width,
+		Height:   frame.Height,
+		Type:     models.RecordedSessionTypeOutput,
+	}
+
+	if frame.Type == models.RecordedSessionTypeInput {
+		if !ns.Settings.RecordInput {
+			return nil
+		}
+
+		record.Type = models.RecordedSessionTypeInput
+	}
+
+	return s.store.SessionCreateRecordFrame(ctx, uid, record)
+}
+
+// ListSessionRecordFrames lists a session's recorded frames, the input and output ones interleaved by their time.
+//
+// If the session does not exist or its device is out of the requester's scope, a NewErrSessionNotFound error will be
+// returned.
+func (s *service) ListSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	if _, err := s.scopedSession(ctx, uid); err != nil {
+		return nil, 0, err
+	}
+
+	return s.sessionRecordFrames(ctx, uid)
+}
+
+// scopedSession gets a session whose device is in the scope of the user who made the request.
+func (s *service) scopedSession(ctx context.Context, uid models.UID) (*models.Session, error) {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrSessionNotFound(uid, err)
+	}
+
+	if session.Device != nil {
+		if err := s.checkRequestScope(ctx, session.Device); err != nil {
+			return nil, NewErrSessionNotFound(uid, err)
+		}
+	}
+
+	return session, nil
+}
+
+// sessionRecordFrames gets a session's recorded frames sorted by their time.
+func (s *service) sessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	records, count, err := s.store.SessionGetRecordFrame(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+
+	return records, count, nil
+}
+
+// ExportSessionRecord converts a session's recorded frames to the asciicast v2 format.
+//
+// The header is built from the terminal's size of the first frame and the session's term. Each frame is an output or
+// input event timed from the session's start, preceded by a resize event when the terminal's size changed since the
+// previous frame.
+//
+// If the session does not exist or its device is out of the requester's scope, a NewErrSessionNotFound error will be
+// returned.
+func (s *service) ExportSessionRecord(ctx context.Context, uid models.UID) (*asciicast.Cast, error) {
+	session, err := s.scopedSession(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	records, _, err := s.sessionRecordFrames(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, NewErrSessionRecordNotFound(uid, nil)
+	}
+
+	cast := &asciicast.Cast{
+		Header: asciicast.Header{
+			Version:   asciicast.Version,
+			Width:     records[0].Width,
+			Height:    records[0].Height,
+			Timestamp: session.StartedAt.Unix(),
+		},
+		Events: make([]asciicast.Event, 0, len(records)),
+	}
+
+	if session.Term != "" {
+		cast.Header.Env = map[string]string{"TERM": session.Term}
+	}
+
+	width, height := records[0].Width, records[0].Height
+	for _, record := range records {
+		elapsed := record.Time.Sub(session.StartedAt).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+
+		if record.Width != width || record.Height != height {
+			width, height = record.Width, record.Height
+
+			cast.Events = append(cast.Events, asciicast.Event{
+				Time: elapsed,
+				Type: asciicast.EventResize,
+				Data: fmt.Sprintf("%dx%d", width, height),
+			})
+		}
+
+		event := asciicast.EventOutput
+		if record.IsInput() {
+			event = asciicast.EventInput
+		}
+
+		cast.Events = append(cast.Events, asciicast.Event{
+			Time: elapsed,
+			Type: event,
+			Data: record.Message,
+		})
+	}
+
+	return cast, nil
+}
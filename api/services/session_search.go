@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/ansi"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// SearchSessionRecords lists the lines of the namespace's session records containing the query, with the session,
+// device and user where each one was recorded, and its offset from the session's start.
+//
+// The members restricted to a device scope only search the records of the devices in it.
+func (s *service) SearchSessionRecords(ctx context.Context, tenant, query string, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	scope, err := s.requestScope(ctx, tenant)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var devices []models.UID
+	if scope != nil {
+		if devices, err = s.scopeDevices(ctx, tenant, scope); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return s.store.SessionRecordSearch(ctx, tenant, query, devices, pagination)
+}
+
+// indexSessionRecord indexes the lines of a session's record, the output ones and, when recorded, the input ones,
+// replacing the lines indexed before.
+func (s *service) indexSessionRecord(ctx context.Context, session *models.Session) error {
+	frames, _, err := s.sessionRecordFrames(ctx, models.UID(session.UID))
+	if err != nil {
+		return err
+	}
+
+	output := &lineBuilder{session: session, kind: models.RecordedSessionTypeOutput}
+	input := &lineBuilder{session: session, kind: models.RecordedSessionTypeInput}
+
+	for _, frame := range frames {
+		offset := frame.Time.Sub(session.StartedAt).Seconds()
+		if offset < 0 {
+			offset = 0
+		}
+
+		if frame.IsInput() {
+			input.write(ansi.Strip(frame.Message), offset)
+		} else {
+			output.write(ansi.Strip(frame.Message), offset)
+		}
+	}
+
+	output.flush()
+	input.flush()
+
+	return s.store.SessionRecordIndex(ctx, models.UID(session.UID), append(output.lines, input.lines...))
+}
+
+// lineBuilder splits the data written to, or read from, a terminal into lines as seen by the user, erasing the
+// characters deleted by backspaces.
+type lineBuilder struct {
+	session *models.Session
+	kind    string
+	current []rune
+	offset  float64
+	lines   []models.SessionRecordLine
+}
+
+// write appends data, received at the offset in seconds since the session started, to the lines.
+func (b *lineBuilder) write(data string, offset float64) {
+	for len(data) > 0 {
+		r, size := utf8.DecodeRuneInString(data)
+		data = data[size:]
+
+		switch {
+		case r == '\r' || r == '\n':
+			b.flush()
+		case r == '\b' || r == 0x7f:
+			if len(b.current) > 0 {
+				b.current = b.current[:len(b.current)-1]
+			}
+		case r == '\t':
+			b.append(' ', offset)
+		case unicode.IsControl(r) || r == utf8.RuneError:
+			continue
+		default:
+			b.append(r, offset)
+		}
+	}
+}
+
+func (b *lineBuilder) append(r rune, offset float64) {
+	if len(b.current) == 0 {
+		b.offset = offset
+	}
+
+	b.current = append(b.current, r)
+}
+
+// flush ends the current line, discarding it when blank.
+func (b *lineBuilder) flush() {
+	text := strings.TrimSpace(string(b.current))
+	b.current = b.current[:0]
+
+	if text == "" {
+		return
+	}
+
+	b.lines = append(b.lines, models.SessionRecordLine{
+		UID:       models.UID(b.session.UID),
+		TenantID:  b.session.TenantID,
+		DeviceUID: b.session.DeviceUID,
+		Username:  b.session.Username,
+		StartedAt: b.session.StartedAt,
+		Type:      b.kind,
+		Offset:    b.offset,
+		Text:      text,
+	})
+}
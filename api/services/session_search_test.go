@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchSessionRecords(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	Err := errors.New("error")
+
+	lines := []models.SessionRecordLine{
+		{UID: "uid", TenantID: "tenant", DeviceUID: "device", Username: "root", Type: models.RecordedSessionTypeInput, Offset: 1.5, Text: "rm -rf /tmp/data"},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      []models.SessionRecordLine
+		count         int
+		err           error
+	}{
+		{
+			description: "fails when the search fails",
+			requiredMocks: func() {
+				mock.On("SessionRecordSearch", ctx, "tenant", "rm -rf", []models.UID(nil), query).Return(nil, 0, Err).Once()
+			},
+			expected: nil,
+			count:    0,
+			err:      Err,
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("SessionRecordSearch", ctx, "tenant", "rm -rf", []models.UID(nil), query).Return(lines, 1, nil).Once()
+			},
+			expected: lines,
+			count:    1,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			matches, count, err := s.SearchSessionRecords(ctx, "tenant", "rm -rf", query)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, matches)
+			assert.Equal(t, tc.count, count)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSearchSessionRecordsMemberScope(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	// The request is made by the member "id", restricted to the devices tagged "customer-a".
+	ctx := newAuditContext()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "id", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}}}},
+	}
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+	storeMock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: -1}, mock.Anything, models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+		Return([]models.Device{{UID: "device", TenantID: "tenant", Tags: []string{"customer-a"}}}, 1, nil).Once()
+	storeMock.On("SessionRecordSearch", ctx, "tenant", "rm -rf", []models.UID{"device"}, query).Return([]models.SessionRecordLine{}, 0, nil).Once()
+
+	_, _, err := s.SearchSessionRecords(ctx, "tenant", "rm -rf", query)
+	assert.NoError(t, err)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestIndexSessionRecord(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil).service
+
+	ctx := context.TODO()
+
+	startedAt := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	session := &models.Session{UID: "uid", TenantID: "tenant", DeviceUID: "device", Username: "root", StartedAt: startedAt, Recorded: true}
+
+	frames := []models.RecordedSession{
+		{UID: "uid", Time: startedAt.Add(2 * time.Second), Type: models.RecordedSessionTypeInput, Message: "rm -rf /tmp/dtaa\x7f\x7f\x7fata\r"},
+		{UID: "uid", Time: startedAt, Message: "\x1b]0;root@device: ~\x07\x1b[01;32mroot@device\x1b[00m:~# "},
+		{UID: "uid", Time: startedAt.Add(2 * time.Second), Message: "rm -rf /tmp/data\r\n\r\n"},
+		{UID: "uid", Time: startedAt.Add(3 * time.Second), Message: "\x1b[?2004hroot@device:~# "},
+	}
+
+	line := func(kind string, offset float64, text string) models.SessionRecordLine {
+		return models.SessionRecordLine{
+			UID:       "uid",
+			TenantID:  "tenant",
+			DeviceUID: "device",
+			Username:  "root",
+			StartedAt: startedAt,
+			Type:      kind,
+			Offset:    offset,
+			Text:      text,
+		}
+	}
+
+	mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return(frames, len(frames), nil).Once()
+	mock.On("SessionRecordIndex", ctx, models.UID("uid"), []models.SessionRecordLine{
+		line(models.RecordedSessionTypeOutput, 0, "root@device:~# rm -rf /tmp/data"),
+		line(models.RecordedSessionTypeOutput, 3, "root@device:~#"),
+		line(models.RecordedSessionTypeInput, 2, "rm -rf /tmp/data"),
+	}).Return(nil).Once()
+
+	assert.NoError(t, s.indexSessionRecord(ctx, session))
+
+	mock.AssertExpectations(t)
+}
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"net"
 	"testing"
+	"time"
 
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/asciicast"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
@@ -49,7 +52,7 @@ func TestListSessions(t *testing.T) {
 			name:       "ListSessions fails",
 			pagination: query,
 			requiredMocks: func() {
-				mock.On("SessionList", ctx, query).
+				mock.On("SessionList", ctx, query, []models.UID(nil)).
 					Return(nil, 0, Err).Once()
 			},
 			expected: Expected{
@@ -62,7 +65,7 @@ func TestListSessions(t *testing.T) {
 			name:       "ListSessions succeeds",
 			pagination: query,
 			requiredMocks: func() {
-				mock.On("SessionList", ctx, query).
+				mock.On("SessionList", ctx, query, []models.UID(nil)).
 					Return(sessions, len(sessions), nil).Once()
 			},
 			expected: Expected{
@@ -248,6 +251,24 @@ func TestDeactivateSession(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("SessionDeleteActives", ctx, models.UID("uid")).
 					Return(nil).Once()
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "DeactivateSession succeeds indexing the session's record",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionDeleteActives", ctx, models.UID("uid")).
+					Return(nil).Once()
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant", Recorded: true}, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).
+					Return([]models.RecordedSession{{UID: "uid", Message: "$ ls\r\n"}}, 1, nil).Once()
+				mock.On("SessionRecordIndex", ctx, models.UID("uid"), []models.SessionRecordLine{
+					{UID: "uid", TenantID: "tenant", Type: models.RecordedSessionTypeOutput, Text: "$ ls"},
+				}).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -308,3 +329,250 @@ func TestSetSessionAuthenticated(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestListSessionRecordFrames(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	// The request is made by the member "id", restricted to the devices tagged "customer-a".
+	ctx := newTenantContext()
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "id", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}}}},
+	}
+
+	frames := []models.RecordedSession{
+		{UID: "uid", Message: "second", Time: time.Unix(2, 0)},
+		{UID: "uid", Message: "first", Time: time.Unix(1, 0)},
+	}
+
+	Err := errors.New("error")
+
+	type Expected struct {
+		frames []models.RecordedSession
+		count  int
+		err    error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, 0, NewErrSessionNotFound("uid", Err)},
+		},
+		{
+			name: "fails when the session's device is out of the member's scope",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Device: &models.Device{UID: "device", Tags: []string{"customer-b"}}}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, 0, NewErrSessionNotFound("uid", NewErrDeviceNotFound("device", nil))},
+		},
+		{
+			name: "succeeds sorting the frames by their time",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Device: &models.Device{UID: "device", Tags: []string{"customer-a"}}}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return(frames, 2, nil).Once()
+			},
+			expected: Expected{[]models.RecordedSession{frames[1], frames[0]}, 2, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			list, count, err := s.ListSessionRecordFrames(ctx, "uid")
+			assert.Equal(t, tc.expected, Expected{list, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestExportSessionRecord(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	// The request is made by the member "id", restricted to the devices tagged "customer-a".
+	ctx := newTenantContext()
+
+	type Expected struct {
+		cast *asciicast.Cast
+		err  error
+	}
+
+	startedAt := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	session := &models.Session{UID: "uid", Term: "xterm", StartedAt: startedAt}
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("uid", Err)},
+		},
+		{
+			name: "fails when the session's device is out of the member's scope",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", Device: &models.Device{UID: "device", Tags: []string{"customer-b"}}}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "id", Role: guard.RoleOperator, Scope: &models.MemberScope{Tags: []string{"customer-a"}}}},
+				}, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("uid", NewErrDeviceNotFound("device", nil))},
+		},
+		{
+			name: "fails when the session has no recorded frames",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return([]models.RecordedSession{}, 0, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionRecordNotFound("uid", nil)},
+		},
+		{
+			name: "succeeds timing the frames from the session's start",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return([]models.RecordedSession{
+					{UID: "uid", Message: "third", Time: startedAt.Add(3 * time.Second), Width: 100, Height: 30},
+					{UID: "uid", Message: "first", Time: startedAt.Add(500 * time.Millisecond), Width: 80, Height: 24},
+					{UID: "uid", Message: "second", Time: startedAt.Add(time.Second), Width: 80, Height: 24},
+					{UID: "uid", Message: "input", Time: startedAt.Add(2 * time.Second), Width: 80, Height: 24, Type: models.RecordedSessionTypeInput},
+				}, 4, nil).Once()
+			},
+			expected: Expected{
+				cast: &asciicast.Cast{
+					Header: asciicast.Header{
+						Version:   2,
+						Width:     80,
+						Height:    24,
+						Timestamp: startedAt.Unix(),
+						Env:       map[string]string{"TERM": "xterm"},
+					},
+					Events: []asciicast.Event{
+						{Time: 0.5, Type: asciicast.EventOutput, Data: "first"},
+						{Time: 1, Type: asciicast.EventOutput, Data: "second"},
+						{Time: 2, Type: asciicast.EventInput, Data: "input"},
+						{Time: 3, Type: asciicast.EventResize, Data: "100x30"},
+						{Time: 3, Type: asciicast.EventOutput, Data: "third"},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			cast, err := s.ExportSessionRecord(ctx, "uid")
+			assert.Equal(t, tc.expected, Expected{cast, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRecordSession(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+
+	namespace := func(sessionRecord, recordInput bool) *models.Namespace {
+		return &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{SessionRecord: sessionRecord, RecordInput: recordInput}}
+	}
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		frame         requests.SessionRecord
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name:  "fails when the session is not found",
+			frame: requests.SessionRecord{Message: "output"},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name:  "discards the frame when the namespace does not record the sessions",
+			frame: requests.SessionRecord{Message: "output"},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(false, true), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name:  "discards the input frame when the namespace does not record the input",
+			frame: requests.SessionRecord{Message: "input", Type: models.RecordedSessionTypeInput},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(true, false), nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name:  "records the output frame",
+			frame: requests.SessionRecord{Message: "output", Width: 80, Height: 24},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(true, false), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionCreateRecordFrame", ctx, models.UID("uid"), &models.RecordedSession{
+					UID: "uid", Message: "output", TenantID: "tenant", Time: now, Width: 80, Height: 24, Type: models.RecordedSessionTypeOutput,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name:  "records the input frame when the namespace records the input",
+			frame: requests.SessionRecord{Message: "input", Width: 80, Height: 24, Type: models.RecordedSessionTypeInput},
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(true, true), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionCreateRecordFrame", ctx, models.UID("uid"), &models.RecordedSession{
+					UID: "uid", Message: "input", TenantID: "tenant", Time: now, Width: 80, Height: 24, Type: models.RecordedSessionTypeInput,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.RecordSession(ctx, "uid", tc.frame)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
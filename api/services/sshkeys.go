@@ -8,10 +8,12 @@ import (
 	"encoding/pem"
 	"regexp"
 
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"golang.org/x/crypto/ssh"
@@ -48,6 +50,8 @@ func (s *service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey,
 		}
 
 		return false, nil
+	} else if key.Filter.Group != "" {
+		return s.matchDeviceGroup(ctx, key.TenantID, key.Filter.Group, &dev)
 	}
 
 	return true, nil
@@ -91,20 +95,27 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		}
 	}
 
+	if err := s.checkDeviceGroup(ctx, tenant, req.Filter.Group); err != nil {
+		return nil, err
+	}
+
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(req.Data) //nolint:dogsled
 	if err != nil {
 		return nil, NewErrPublicKeyDataInvalid(req.Data, nil)
 	}
 
-	req.Fingerprint = ssh.FingerprintLegacyMD5(pubKey)
+	req.Fingerprint = ssh.FingerprintSHA256(pubKey)
 
-	returnedKey, err := s.store.PublicKeyGet(ctx, req.Fingerprint, tenant)
-	if err != nil && err != store.ErrNoDocuments {
-		return nil, NewErrPublicKeyNotFound(req.Fingerprint, err)
-	}
+	// The public keys added before the fingerprints moved to SHA256 are still stored with their legacy MD5 ones.
+	for _, fingerprint := range []string{req.Fingerprint, ssh.FingerprintLegacyMD5(pubKey)} {
+		returnedKey, err := s.store.PublicKeyGet(ctx, fingerprint, tenant)
+		if err != nil && err != store.ErrNoDocuments {
+			return nil, NewErrPublicKeyNotFound(fingerprint, err)
+		}
 
-	if returnedKey != nil {
-		return nil, NewErrPublicKeyDuplicated([]string{req.Fingerprint}, err)
+		if returnedKey != nil {
+			return nil, NewErrPublicKeyDuplicated([]string{fingerprint}, err)
+		}
 	}
 
 	model := models.PublicKey{
@@ -118,15 +129,24 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 			Filter: models.PublicKeyFilter{
 				Hostname: req.Filter.Hostname,
 				Tags:     req.Filter.Tags,
+				Group:    req.Filter.Group,
 			},
 		},
 	}
 
+	// The key acts for the member who added it, so its connections are restricted to the member's scope.
+	if id := gateway.IDFromContext(ctx); id != nil {
+		model.CreatedBy = id.ID
+	}
+
 	err = s.store.PublicKeyCreate(ctx, &model)
 	if err != nil {
 		return nil, err
 	}
 
+	s.audit(ctx, tenant, models.AuditActionPublicKeyCreate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: model.Fingerprint}, nil, publicKeyAuditFields(model.PublicKeyFields))
+	s.dispatch(ctx, tenant, webhook.WebhookPublicKeyCreatedEvent, model)
+
 	return &responses.PublicKeyCreate{
 		Data:        model.Data,
 		Filter:      responses.PublicKeyFilter(model.Filter),
@@ -157,6 +177,10 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 		}
 	}
 
+	if err := s.checkDeviceGroup(ctx, tenant, key.Filter.Group); err != nil {
+		return nil, err
+	}
+
 	model := models.PublicKeyUpdate{
 		PublicKeyFields: models.PublicKeyFields{
 			Name:     key.Name,
@@ -164,11 +188,19 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 			Filter: models.PublicKeyFilter{
 				Hostname: key.Filter.Hostname,
 				Tags:     key.Filter.Tags,
+				Group:    key.Filter.Group,
 			},
 		},
 	}
 
-	return s.store.PublicKeyUpdate(ctx, fingerprint, tenant, &model)
+	updated, err := s.store.PublicKeyUpdate(ctx, fingerprint, tenant, &model)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionPublicKeyUpdate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, nil, publicKeyAuditFields(model.PublicKeyFields))
+
+	return updated, nil
 }
 
 func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant string) error {
@@ -177,14 +209,25 @@ func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant strin
 		return NewErrNamespaceNotFound(tenant, err)
 	}
 
-	_, err = s.store.PublicKeyGet(ctx, fingerprint, tenant)
+	key, err := s.store.PublicKeyGet(ctx, fingerprint, tenant)
 	if err != nil {
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	return s.store.PublicKeyDelete(ctx, fingerprint, tenant)
+	if err := s.store.PublicKeyDelete(ctx, fingerprint, tenant); err != nil {
+		return err
+	}
+
+	if key != nil {
+		s.audit(ctx, tenant, models.AuditActionPublicKeyDelete, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, publicKeyAuditFields(key.PublicKeyFields), nil)
+	}
+
+	return nil
 }
 
+// CreatePrivateKey creates the key the SSH server authenticates on the devices with, when their agents do not accept
+// user certificates. The agents look it up by its SHA256 fingerprint, while the agents that predate it still do by its
+// legacy MD5 one.
 func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
 	key, err := rsa.GenerateKey(rand.Reader, 4096)
 	if err != nil {
@@ -201,8 +244,9 @@ func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, err
 			Type:  "RSA PRIVATE KEY",
 			Bytes: x509.MarshalPKCS1PrivateKey(key),
 		}),
-		Fingerprint: ssh.FingerprintLegacyMD5(pubKey),
-		CreatedAt:   clock.Now(),
+		Fingerprint:       ssh.FingerprintSHA256(pubKey),
+		LegacyFingerprint: ssh.FingerprintLegacyMD5(pubKey),
+		CreatedAt:         clock.Now(),
 	}
 
 	if err := s.store.PrivateKeyCreate(ctx, privateKey); err != nil {
@@ -211,3 +255,14 @@ func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, err
 
 	return privateKey, nil
 }
+
+// publicKeyAuditFields returns the fields of a public key recorded on the audit log.
+func publicKeyAuditFields(fields models.PublicKeyFields) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     fields.Name,
+		"username": fields.Username,
+		"hostname": fields.Filter.Hostname,
+		"tags":     fields.Filter.Tags,
+		"group":    fields.Filter.Group,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type SSHKeysTagsService interface {
@@ -28,7 +29,7 @@ func (s *service) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || key.Filter.Group != "" {
 		return NewErrPublicKeyFilter(nil)
 	}
 
@@ -56,6 +57,8 @@ func (s *service) AddPublicKeyTag(ctx context.Context, tenant, fingerprint, tag
 		}
 	}
 
+	s.audit(ctx, tenant, models.AuditActionPublicKeyTagAdd, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, nil, map[string]interface{}{"tag": tag})
+
 	return nil
 }
 
@@ -73,7 +76,7 @@ func (s *service) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, t
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || key.Filter.Group != "" {
 		return NewErrPublicKeyFilter(nil)
 	}
 
@@ -88,6 +91,8 @@ func (s *service) RemovePublicKeyTag(ctx context.Context, tenant, fingerprint, t
 		return err
 	}
 
+	s.audit(ctx, tenant, models.AuditActionPublicKeyTagRemove, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]interface{}{"tag": tag}, nil)
+
 	return nil
 }
 
@@ -120,7 +125,7 @@ func (s *service) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint s
 		return NewErrPublicKeyNotFound(fingerprint, err)
 	}
 
-	if key.Filter.Hostname != "" {
+	if key.Filter.Hostname != "" || key.Filter.Group != "" {
 		return NewErrPublicKeyNotFound(fingerprint, nil)
 	}
 
@@ -152,5 +157,7 @@ func (s *service) UpdatePublicKeyTags(ctx context.Context, tenant, fingerprint s
 		}
 	}
 
+	s.audit(ctx, tenant, models.AuditActionPublicKeyTagUpdate, models.AuditTarget{Type: models.AuditTargetPublicKey, ID: fingerprint}, map[string]interface{}{"tags": key.Filter.Tags}, map[string]interface{}{"tags": tags})
+
 	return nil
 }
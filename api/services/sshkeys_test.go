@@ -553,7 +553,8 @@ func TestCreatePublicKeys(t *testing.T) {
 
 	pubKey, _ := ssh.NewPublicKey(publicKey)
 	data := ssh.MarshalAuthorizedKey(pubKey)
-	fingerprint := ssh.FingerprintLegacyMD5(pubKey)
+	fingerprint := ssh.FingerprintSHA256(pubKey)
+	legacyFingerprint := ssh.FingerprintLegacyMD5(pubKey)
 
 	keyInvalidData := requests.PublicKeyCreate{
 		Data:        nil,
@@ -692,12 +693,23 @@ func TestCreatePublicKeys(t *testing.T) {
 			},
 			expected: Expected{nil, NewErrPublicKeyDuplicated([]string{keyWithHostname.Fingerprint}, nil)},
 		},
+		{
+			description: "fail when public key is duplicated with its legacy fingerprint",
+			tenantID:    "tenant",
+			req:         keyWithHostname,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, keyWithHostname.Fingerprint, "tenant").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("PublicKeyGet", ctx, legacyFingerprint, "tenant").Return(&keyWithHostnameModel, nil).Once()
+			},
+			expected: Expected{nil, NewErrPublicKeyDuplicated([]string{legacyFingerprint}, nil)},
+		},
 		{
 			description: "fail to create a public key when filter is hostname",
 			tenantID:    "tenant",
 			req:         keyWithHostname,
 			requiredMocks: func() {
 				mock.On("PublicKeyGet", ctx, keyWithHostname.Fingerprint, "tenant").Return(nil, nil).Once()
+				mock.On("PublicKeyGet", ctx, legacyFingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithHostnameModel).Return(err).Once()
 			},
 			expected: Expected{nil, err},
@@ -708,6 +720,7 @@ func TestCreatePublicKeys(t *testing.T) {
 			req:         keyWithHostname,
 			requiredMocks: func() {
 				mock.On("PublicKeyGet", ctx, keyWithHostname.Fingerprint, "tenant").Return(nil, nil).Once()
+				mock.On("PublicKeyGet", ctx, legacyFingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithHostnameModel).Return(nil).Once()
 			},
 			expected: Expected{resWithHostnameModel, nil},
@@ -719,6 +732,7 @@ func TestCreatePublicKeys(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("TagsGet", ctx, keyWithTags.TenantID).Return([]string{"tag1", "tag2"}, 2, nil).Once()
 				mock.On("PublicKeyGet", ctx, keyWithTags.Fingerprint, "tenant").Return(nil, nil).Once()
+				mock.On("PublicKeyGet", ctx, legacyFingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithTagsModel).Return(err).Once()
 			},
 			expected: Expected{nil, err},
@@ -730,6 +744,7 @@ func TestCreatePublicKeys(t *testing.T) {
 			requiredMocks: func() {
 				mock.On("TagsGet", ctx, keyWithTags.TenantID).Return([]string{"tag1", "tag2"}, 2, nil).Once()
 				mock.On("PublicKeyGet", ctx, keyWithTags.Fingerprint, "tenant").Return(nil, nil).Once()
+				mock.On("PublicKeyGet", ctx, legacyFingerprint, "tenant").Return(nil, nil).Once()
 				mock.On("PublicKeyCreate", ctx, &keyWithTagsModel).Return(nil).Once()
 			},
 			expected: Expected{resWithTagsModel, nil},
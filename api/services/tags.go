@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 )
 
@@ -39,7 +40,13 @@ func (s *service) RenameTag(ctx context.Context, tenant string, oldTag string, n
 		return NewErrTagDuplicated(newTag, nil)
 	}
 
-	return s.store.TagRename(ctx, tenant, oldTag, newTag)
+	if err := s.store.TagRename(ctx, tenant, oldTag, newTag); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionTagRename, models.AuditTarget{Type: models.AuditTargetTag, ID: oldTag}, map[string]interface{}{"name": oldTag}, map[string]interface{}{"name": newTag})
+
+	return nil
 }
 
 func (s *service) DeleteTag(ctx context.Context, tenant string, tag string) error {
@@ -61,5 +68,11 @@ func (s *service) DeleteTag(ctx context.Context, tenant string, tag string) erro
 		return NewErrTagNotFound(tag, nil)
 	}
 
-	return s.store.TagDelete(ctx, namespace.TenantID, tag)
+	if err := s.store.TagDelete(ctx, namespace.TenantID, tag); err != nil {
+		return err
+	}
+
+	s.audit(ctx, namespace.TenantID, models.AuditActionTagDelete, models.AuditTarget{Type: models.AuditTargetTag, ID: tag}, map[string]interface{}{"name": tag}, nil)
+
+	return nil
 }
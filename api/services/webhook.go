@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookQueue enqueues the webhook's deliveries to be sent in background.
+type WebhookQueue interface {
+	Enqueue(ctx context.Context, id string) error
+}
+
+// WithWebhookQueue sets the queue where the deliveries of the webhook's events are enqueued.
+func WithWebhookQueue(queue WebhookQueue) Option {
+	return func(s *service) {
+		s.webhooks = queue
+	}
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*responses.WebhookCreate, error)
+	ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error)
+	DeleteWebhook(ctx context.Context, tenant, id string) error
+	ListWebhookDeliveries(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+	RedeliverWebhook(ctx context.Context, tenant, id, deliveryID string) (*models.WebhookDelivery, error)
+	DeliverWebhook(ctx context.Context, deliveryID string) error
+}
+
+// CreateWebhook subscribes a URL to a namespace's events.
+//
+// The secret used to sign the deliveries is returned only here.
+func (s *service) CreateWebhook(ctx context.Context, tenant string, req requests.WebhookCreate) (*responses.WebhookCreate, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	hook := &models.Webhook{
+		TenantID:  tenant,
+		URL:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Events:    req.Events,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.WebhookCreate(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditActionWebhookCreate, models.AuditTarget{Type: models.AuditTargetWebhook, ID: hook.ID}, nil, webhookAuditFields(hook))
+
+	return &responses.WebhookCreate{Webhook: *hook, Secret: hook.Secret}, nil
+}
+
+// ListWebhooks lists the webhooks of a namespace, from the newest to the oldest.
+func (s *service) ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	return s.store.WebhookList(ctx, tenant, pagination)
+}
+
+// DeleteWebhook deletes a webhook from a namespace, with its deliveries.
+func (s *service) DeleteWebhook(ctx context.Context, tenant, id string) error {
+	hook, err := s.store.WebhookGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrWebhookNotFound(id, err)
+	}
+
+	if err := s.store.WebhookDelete(ctx, tenant, id); err != nil {
+		return NewErrWebhookNotFound(id, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditActionWebhookDelete, models.AuditTarget{Type: models.AuditTargetWebhook, ID: id}, webhookAuditFields(hook), nil)
+
+	return nil
+}
+
+// ListWebhookDeliveries lists the deliveries of a namespace's webhook, from the newest to the oldest.
+func (s *service) ListWebhookDeliveries(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	if _, err := s.store.WebhookGet(ctx, tenant, id); err != nil {
+		return nil, 0, NewErrWebhookNotFound(id, err)
+	}
+
+	return s.store.WebhookDeliveryList(ctx, tenant, id, pagination)
+}
+
+// RedeliverWebhook delivers again the payload of a webhook's delivery, as a new delivery.
+func (s *service) RedeliverWebhook(ctx context.Context, tenant, id, deliveryID string) (*models.WebhookDelivery, error) {
+	if s.webhooks == nil {
+		return nil, NewErrWebhookUnavailable(nil)
+	}
+
+	delivery, err := s.store.WebhookDeliveryGet(ctx, deliveryID)
+	if err != nil || delivery.TenantID != tenant || delivery.WebhookID != id {
+		return nil, NewErrWebhookDeliveryNotFound(deliveryID, err)
+	}
+
+	redelivery := &models.WebhookDelivery{
+		TenantID:  delivery.TenantID,
+		WebhookID: delivery.WebhookID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+		Status:    models.WebhookDeliveryStatusPending,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.WebhookDeliveryCreate(ctx, redelivery); err != nil {
+		return nil, err
+	}
+
+	if err := s.webhooks.Enqueue(ctx, redelivery.ID); err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
+
+// DeliverWebhook sends a delivery to its webhook's URL, recording the result of the attempt.
+//
+// It returns an error when the attempt fails, so the delivery can be retried. A delivery whose webhook was deleted is
+// not sent.
+func (s *service) DeliverWebhook(ctx context.Context, deliveryID string) error {
+	delivery, err := s.store.WebhookDeliveryGet(ctx, deliveryID)
+	if err != nil {
+		return NewErrWebhookDeliveryNotFound(deliveryID, err)
+	}
+
+	hook, err := s.store.WebhookGet(ctx, delivery.TenantID, delivery.WebhookID)
+	if err != nil {
+		return NewErrWebhookNotFound(delivery.WebhookID, err)
+	}
+
+	status, sendErr := webhook.Send(ctx, hook.URL, hook.Secret, delivery.ID, delivery.Event, []byte(delivery.Payload))
+
+	delivery.Attempts++
+	delivery.StatusCode = status
+	if sendErr != nil {
+		delivery.Status = models.WebhookDeliveryStatusFailed
+		delivery.Error = sendErr.Error()
+	} else {
+		deliveredAt := clock.Now()
+
+		delivery.Status = models.WebhookDeliveryStatusSucceeded
+		delivery.Error = ""
+		delivery.DeliveredAt = &deliveredAt
+	}
+
+	if err := s.store.WebhookDeliveryUpdate(ctx, delivery); err != nil {
+		logrus.WithError(err).WithField("id", delivery.ID).Error("Failed to record the webhook's delivery attempt")
+	}
+
+	return sendErr
+}
+
+// dispatch records a delivery of an event to each namespace's webhook subscribed to it, enqueuing them to be sent.
+//
+// As the event already happened when it is dispatched, a failure to deliver it is logged instead of returned.
+func (s *service) dispatch(ctx context.Context, tenant, event string, data interface{}) {
+	if s.webhooks == nil {
+		return
+	}
+
+	logger := logrus.WithFields(logrus.Fields{
+		"tenant": tenant,
+		"event":  event,
+	})
+
+	hooks, err := s.store.WebhookListByEvent(ctx, tenant, event)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list the webhooks subscribed to the event")
+
+		return
+	}
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	now := clock.Now()
+
+	payload, err := json.Marshal(webhook.EventWebhookRequest{
+		Event:     event,
+		TenantID:  tenant,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to encode the event's payload")
+
+		return
+	}
+
+	for _, hook := range hooks {
+		delivery := &models.WebhookDelivery{
+			TenantID:  tenant,
+			WebhookID: hook.ID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    models.WebhookDeliveryStatusPending,
+			CreatedAt: now,
+		}
+
+		if err := s.store.WebhookDeliveryCreate(ctx, delivery); err != nil {
+			logger.WithError(err).WithField("webhook", hook.ID).Error("Failed to record the webhook's delivery")
+
+			continue
+		}
+
+		if err := s.webhooks.Enqueue(ctx, delivery.ID); err != nil {
+			logger.WithError(err).WithField("webhook", hook.ID).Error("Failed to enqueue the webhook's delivery")
+		}
+	}
+}
+
+// webhookAuditFields returns the fields of a webhook recorded on the audit log.
+func webhookAuditFields(hook *models.Webhook) map[string]interface{} {
+	return map[string]interface{}{
+		"url":    hook.URL,
+		"events": hook.Events,
+	}
+}
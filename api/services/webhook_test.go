@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// webhookQueueMock records the deliveries enqueued.
+type webhookQueueMock struct {
+	enqueued []string
+}
+
+func (q *webhookQueueMock) Enqueue(_ context.Context, id string) error {
+	q.enqueued = append(q.enqueued, id)
+
+	return nil
+}
+
+func TestCreateWebhook(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	req := requests.WebhookCreate{URL: "https://example.com/hook", Events: []string{webhook.WebhookDeviceOnlineEvent}}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace does not exist",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "succeeds generating the webhook's secret",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("WebhookCreate", ctx, mock.MatchedBy(func(hook *models.Webhook) bool {
+					return hook.TenantID == "tenant" && hook.URL == req.URL && len(hook.Secret) == 64 && hook.CreatedAt.Equal(now)
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			res, err := s.CreateWebhook(ctx, "tenant", req)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.Equal(t, res.Webhook.Secret, res.Secret)
+			}
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the webhook does not exist in the namespace",
+			requiredMocks: func() {
+				storeMock.On("WebhookGet", ctx, "tenant", "id").Return(nil, Err).Once()
+			},
+			expected: NewErrWebhookNotFound("id", Err),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				storeMock.On("WebhookGet", ctx, "tenant", "id").Return(&models.Webhook{ID: "id", TenantID: "tenant"}, nil).Once()
+				storeMock.On("WebhookDelete", ctx, "tenant", "id").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.Equal(t, tc.expected, s.DeleteWebhook(ctx, "tenant", "id"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	storeMock := &mocks.Store{}
+	queue := &webhookQueueMock{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithWebhookQueue(queue))
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	delivery := &models.WebhookDelivery{
+		ID:        "delivery",
+		TenantID:  "tenant",
+		WebhookID: "id",
+		Event:     webhook.WebhookDeviceOnlineEvent,
+		Payload:   "{}",
+		Status:    models.WebhookDeliveryStatusFailed,
+		Attempts:  5,
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the delivery does not exist",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("WebhookDeliveryGet", ctx, "delivery").Return(nil, Err).Once()
+			},
+			expected: NewErrWebhookDeliveryNotFound("delivery", Err),
+		},
+		{
+			description: "fails when the delivery is from another namespace",
+			tenant:      "other",
+			requiredMocks: func() {
+				storeMock.On("WebhookDeliveryGet", ctx, "delivery").Return(delivery, nil).Once()
+			},
+			expected: NewErrWebhookDeliveryNotFound("delivery", nil),
+		},
+		{
+			description: "succeeds enqueuing a new delivery",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("WebhookDeliveryGet", ctx, "delivery").Return(delivery, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("WebhookDeliveryCreate", ctx, mock.MatchedBy(func(redelivery *models.WebhookDelivery) bool {
+					return redelivery.WebhookID == "id" && redelivery.Payload == "{}" && redelivery.Status == models.WebhookDeliveryStatusPending && redelivery.Attempts == 0
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.WebhookDelivery).ID = "redelivery"
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			_, err := s.RedeliverWebhook(ctx, tc.tenant, "id", "delivery")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	assert.Equal(t, []string{"redelivery"}, queue.enqueued)
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, webhook.Sign("secret", []byte("{}")), r.Header.Get(webhook.WebhookSignatureHeader))
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	hook := &models.Webhook{ID: "id", TenantID: "tenant", URL: server.URL, Secret: "secret"}
+
+	cases := []struct {
+		description   string
+		status        int
+		requiredMocks func()
+		expected      bool
+	}{
+		{
+			description: "records the failed attempt",
+			status:      http.StatusInternalServerError,
+			requiredMocks: func() {
+				storeMock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Payload: "{}"}, nil).Once()
+				storeMock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+					return delivery.Status == models.WebhookDeliveryStatusFailed && delivery.StatusCode == http.StatusInternalServerError &&
+						delivery.Attempts == 1 && delivery.DeliveredAt == nil
+				})).Return(nil).Once()
+			},
+			expected: true,
+		},
+		{
+			description: "records the succeeded attempt",
+			status:      http.StatusOK,
+			requiredMocks: func() {
+				storeMock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Payload: "{}", Attempts: 1}, nil).Once()
+				storeMock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("WebhookDeliveryUpdate", ctx, mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+					return delivery.Status == models.WebhookDeliveryStatusSucceeded && delivery.StatusCode == http.StatusOK &&
+						delivery.Attempts == 2 && delivery.DeliveredAt != nil
+				})).Return(nil).Once()
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			status = tc.status
+			tc.requiredMocks()
+
+			err := s.DeliverWebhook(ctx, "delivery")
+			assert.Equal(t, tc.expected, err != nil)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDispatchWebhook(t *testing.T) {
+	storeMock := &mocks.Store{}
+	queue := &webhookQueueMock{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithWebhookQueue(queue)).service
+
+	ctx := context.TODO()
+
+	device := models.Device{UID: "uid", TenantID: "tenant"}
+
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("WebhookListByEvent", ctx, "tenant", webhook.WebhookDeviceOfflineEvent).Return([]models.Webhook{{ID: "first"}, {ID: "second"}}, nil).Once()
+	for _, id := range []string{"first", "second"} {
+		id := id
+		storeMock.On("WebhookDeliveryCreate", ctx, mock.MatchedBy(func(delivery *models.WebhookDelivery) bool {
+			var payload webhook.EventWebhookRequest
+			if err := json.Unmarshal([]byte(delivery.Payload), &payload); err != nil {
+				return false
+			}
+
+			return delivery.WebhookID == id && payload.Event == webhook.WebhookDeviceOfflineEvent && payload.TenantID == "tenant"
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.WebhookDelivery).ID = id + "-delivery"
+		}).Return(nil).Once()
+	}
+
+	s.dispatch(ctx, "tenant", webhook.WebhookDeviceOfflineEvent, device)
+
+	assert.Equal(t, []string{"first-delivery", "second-delivery"}, queue.enqueued)
+
+	storeMock.AssertExpectations(t)
+}
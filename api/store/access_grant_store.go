@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AccessGrantStore interface {
+	AccessGrantCreate(ctx context.Context, grant *models.AccessGrant) error
+	AccessGrantList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error)
+	AccessGrantGet(ctx context.Context, tenant, id string) (*models.AccessGrant, error)
+	// AccessGrantListActive returns the namespace's grants, not expired yet, given to the member or to the public key
+	// with the fingerprint. An empty member or fingerprint is not matched.
+	AccessGrantListActive(ctx context.Context, tenant, member, fingerprint string) ([]models.AccessGrant, error)
+	// AccessGrantListExpired returns the grants of all namespaces whose time is over, but that were not marked as expired.
+	AccessGrantListExpired(ctx context.Context) ([]models.AccessGrant, error)
+	// AccessGrantSetExpired marks a grant as expired.
+	AccessGrantSetExpired(ctx context.Context, id string) error
+	AccessGrantDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AccessRequestStore interface {
+	AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error
+	// AccessRequestList returns the access requests of a namespace with the status, from the newest to the oldest. An
+	// empty status lists the requests of all statuses.
+	AccessRequestList(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error)
+	AccessRequestGet(ctx context.Context, tenant, id string) (*models.AccessRequest, error)
+	// AccessRequestGetPending returns the newest pending request of the member, or of the public key with the
+	// fingerprint, to the device. An empty member or fingerprint is not matched.
+	AccessRequestGetPending(ctx context.Context, tenant, device, member, fingerprint string) (*models.AccessRequest, error)
+	// AccessRequestDecide sets the status, the decision and the grant of a pending access request. It returns
+	// ErrNoDocuments when the request is not pending anymore.
+	AccessRequestDecide(ctx context.Context, request *models.AccessRequest) error
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type APIKeyStore interface {
+	APIKeyCreate(ctx context.Context, key *models.APIKey) error
+	APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error)
+	APIKeyGet(ctx context.Context, tenant, id string) (*models.APIKey, error)
+	APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error)
+	APIKeySetLastUsed(ctx context.Context, id string, lastUsed time.Time) error
+	APIKeyDelete(ctx context.Context, tenant, id string) error
+}
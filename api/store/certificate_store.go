@@ -0,0 +1,14 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type CertificateAuthorityStore interface {
+	// CertificateAuthorityCreate creates the certificate authority of a namespace. It returns ErrDuplicate when the
+	// namespace already has one.
+	CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error
+	CertificateAuthorityGet(ctx context.Context, tenant string) (*models.CertificateAuthority, error)
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupStore interface {
+	// DeviceGroupCreate creates a device group, setting its ID. It returns ErrDuplicate when the namespace already has
+	// a group with the same name.
+	DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error
+	DeviceGroupList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	// DeviceGroupUpdate updates the name and the filter of a device group.
+	DeviceGroupUpdate(ctx context.Context, group *models.DeviceGroup) error
+	DeviceGroupDelete(ctx context.Context, tenant, id string) error
+}
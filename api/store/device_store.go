@@ -34,6 +34,8 @@ type DeviceStore interface {
 	DeviceGetByName(ctx context.Context, name string, tenantID string) (*models.Device, error)
 	DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error)
 	DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error
+	// DeviceSetHostKey pins the SSH host key of the device's agent, replacing the one pinned before.
+	DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error
 	DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error)
 	DeviceChooser(ctx context.Context, tenantID string, chosen []string) error
 	DeviceRemovedCount(ctx context.Context, tenant string) (int64, error)
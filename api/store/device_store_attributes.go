@@ -0,0 +1,13 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceAttributesStore interface {
+	DeviceSetAttribute(ctx context.Context, uid models.UID, key, value string) error
+	DeviceRemoveAttribute(ctx context.Context, uid models.UID, key string) error
+	DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error
+}
@@ -9,6 +9,8 @@ import (
 
 type FirewallStore interface {
 	FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error)
+	// FirewallRuleListActive returns the active firewall rules of a namespace, sorted by their priority.
+	FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error)
 	FirewallRuleCreate(ctx context.Context, rule *models.FirewallRule) error
 	FirewallRuleGet(ctx context.Context, id string) (*models.FirewallRule, error)
 	FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error)
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type InvitationStore interface {
+	InvitationCreate(ctx context.Context, invitation *models.Invitation) error
+	InvitationList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error)
+	InvitationGet(ctx context.Context, tenant, id string) (*models.Invitation, error)
+	InvitationGetByEmail(ctx context.Context, tenant, email string) (*models.Invitation, error)
+	InvitationGetByDigest(ctx context.Context, digest string) (*models.Invitation, error)
+	InvitationDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func cloneAccessGrant(grant *models.AccessGrant) *models.AccessGrant {
+	clone := *grant
+
+	return &clone
+}
+
+// sortAccessGrants sorts the grants from the newest to the oldest.
+func (s *Store) sortAccessGrants(list []*models.AccessGrant) []models.AccessGrant {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("access_grants", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	grants := make([]models.AccessGrant, 0, len(list))
+	for _, grant := range list {
+		grants = append(grants, *cloneAccessGrant(grant))
+	}
+
+	return grants
+}
+
+func (s *Store) AccessGrantCreate(_ context.Context, grant *models.AccessGrant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant.ID = newID()
+
+	s.accessGrants[grant.ID] = cloneAccessGrant(grant)
+	s.inserted("access_grants", grant.ID)
+
+	return nil
+}
+
+// AccessGrantList returns the access grants of a namespace, from the newest to the oldest, based on the given
+// pagination.
+func (s *Store) AccessGrantList(_ context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.AccessGrant, 0)
+	for _, grant := range s.accessGrants {
+		if grant.TenantID == tenant {
+			list = append(list, grant)
+		}
+	}
+
+	grants := s.sortAccessGrants(list)
+	start, end := paginate(len(grants), pagination)
+
+	return grants[start:end], len(grants), nil
+}
+
+func (s *Store) AccessGrantGet(_ context.Context, tenant, id string) (*models.AccessGrant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	grant, ok := s.accessGrants[id]
+	if !ok || grant.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneAccessGrant(grant), nil
+}
+
+func (s *Store) AccessGrantListActive(_ context.Context, tenant, member, fingerprint string) ([]models.AccessGrant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := clock.Now()
+
+	list := make([]*models.AccessGrant, 0)
+	for _, grant := range s.accessGrants {
+		if grant.TenantID != tenant || grant.Expired || !grant.ExpiresAt.After(now) {
+			continue
+		}
+
+		if (member != "" && grant.Member == member) || (fingerprint != "" && grant.Fingerprint == fingerprint) {
+			list = append(list, grant)
+		}
+	}
+
+	return s.sortAccessGrants(list), nil
+}
+
+func (s *Store) AccessGrantListExpired(_ context.Context) ([]models.AccessGrant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := clock.Now()
+
+	list := make([]*models.AccessGrant, 0)
+	for _, grant := range s.accessGrants {
+		if !grant.Expired && !grant.ExpiresAt.After(now) {
+			list = append(list, grant)
+		}
+	}
+
+	return s.sortAccessGrants(list), nil
+}
+
+func (s *Store) AccessGrantSetExpired(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant, ok := s.accessGrants[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	grant.Expired = true
+
+	return nil
+}
+
+func (s *Store) AccessGrantDelete(_ context.Context, tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant, ok := s.accessGrants[id]
+	if !ok || grant.TenantID != tenant {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.accessGrants, id)
+	s.removed("access_grants", id)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessGrant(t *testing.T) {
+	ctx := context.TODO()
+	s := NewStore()
+
+	now := clock.Now()
+
+	grants := []models.AccessGrant{
+		{TenantID: "tenant", Member: "member", Device: "uid", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+		{TenantID: "tenant", Fingerprint: "fingerprint", Tag: "production", CreatedAt: now.Add(-30 * time.Minute), ExpiresAt: now.Add(-time.Minute)},
+		{TenantID: "other", Member: "member", Device: "uid", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	}
+
+	for i := range grants {
+		assert.NoError(t, s.AccessGrantCreate(ctx, &grants[i]))
+		assert.NotEmpty(t, grants[i].ID)
+	}
+
+	list, count, err := s.AccessGrantList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, grants[1].ID, list[0].ID)
+	assert.Equal(t, grants[0].ID, list[1].ID)
+
+	active, err := s.AccessGrantListActive(ctx, "tenant", "member", "")
+	assert.NoError(t, err)
+	assert.Len(t, active, 1)
+	assert.Equal(t, grants[0].ID, active[0].ID)
+
+	// The grant of the public key is over.
+	active, err = s.AccessGrantListActive(ctx, "tenant", "", "fingerprint")
+	assert.NoError(t, err)
+	assert.Len(t, active, 0)
+
+	active, err = s.AccessGrantListActive(ctx, "tenant", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, active, 0)
+
+	expired, err := s.AccessGrantListExpired(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, grants[1].ID, expired[0].ID)
+
+	assert.NoError(t, s.AccessGrantSetExpired(ctx, grants[1].ID))
+
+	expired, err = s.AccessGrantListExpired(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 0)
+
+	grant, err := s.AccessGrantGet(ctx, "tenant", grants[1].ID)
+	assert.NoError(t, err)
+	assert.True(t, grant.Expired)
+	assert.Equal(t, "production", grant.Tag)
+
+	_, err = s.AccessGrantGet(ctx, "other", grants[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	assert.Equal(t, store.ErrNoDocuments, s.AccessGrantDelete(ctx, "other", grants[0].ID))
+	assert.NoError(t, s.AccessGrantDelete(ctx, "tenant", grants[0].ID))
+
+	_, err = s.AccessGrantGet(ctx, "tenant", grants[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func cloneAccessRequest(request *models.AccessRequest) *models.AccessRequest {
+	clone := *request
+
+	if request.DecidedAt != nil {
+		decidedAt := *request.DecidedAt
+		clone.DecidedAt = &decidedAt
+	}
+
+	return &clone
+}
+
+// sortAccessRequests sorts the requests from the newest to the oldest.
+func (s *Store) sortAccessRequests(list []*models.AccessRequest) []models.AccessRequest {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("access_requests", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	requests := make([]models.AccessRequest, 0, len(list))
+	for _, request := range list {
+		requests = append(requests, *cloneAccessRequest(request))
+	}
+
+	return requests
+}
+
+func (s *Store) AccessRequestCreate(_ context.Context, request *models.AccessRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request.ID = newID()
+
+	s.accessRequests[request.ID] = cloneAccessRequest(request)
+	s.inserted("access_requests", request.ID)
+
+	return nil
+}
+
+func (s *Store) AccessRequestList(_ context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.AccessRequest, 0)
+	for _, request := range s.accessRequests {
+		if request.TenantID == tenant && (status == "" || request.Status == status) {
+			list = append(list, request)
+		}
+	}
+
+	requests := s.sortAccessRequests(list)
+	start, end := paginate(len(requests), pagination)
+
+	return requests[start:end], len(requests), nil
+}
+
+func (s *Store) AccessRequestGet(_ context.Context, tenant, id string) (*models.AccessRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	request, ok := s.accessRequests[id]
+	if !ok || request.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneAccessRequest(request), nil
+}
+
+func (s *Store) AccessRequestGetPending(_ context.Context, tenant, device, member, fingerprint string) (*models.AccessRequest, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.AccessRequest, 0)
+	for _, request := range s.accessRequests {
+		if request.TenantID != tenant || request.Device != device || request.Status != models.AccessRequestStatusPending {
+			continue
+		}
+
+		if (member != "" && request.Member == member) || (fingerprint != "" && request.Fingerprint == fingerprint) {
+			list = append(list, request)
+		}
+	}
+
+	requests := s.sortAccessRequests(list)
+	if len(requests) == 0 {
+		return nil, store.ErrNoDocuments
+	}
+
+	return &requests[0], nil
+}
+
+func (s *Store) AccessRequestDecide(_ context.Context, request *models.AccessRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.accessRequests[request.ID]
+	if !ok || stored.TenantID != request.TenantID || stored.Status != models.AccessRequestStatusPending {
+		return store.ErrNoDocuments
+	}
+
+	decided := cloneAccessRequest(request)
+
+	stored.Status = decided.Status
+	stored.DecidedBy = decided.DecidedBy
+	stored.DecidedAt = decided.DecidedAt
+	stored.Grant = decided.Grant
+
+	return nil
+}
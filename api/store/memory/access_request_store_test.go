@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRequest(t *testing.T) {
+	ctx := context.TODO()
+	s := NewStore()
+
+	now := clock.Now()
+
+	requests := []models.AccessRequest{
+		{TenantID: "tenant", Member: "member", Device: "uid", Reason: "incident", Duration: 60, Status: models.AccessRequestStatusPending, CreatedAt: now.Add(-time.Hour)},
+		{TenantID: "tenant", Fingerprint: "fingerprint", Device: "uid", Duration: 60, Status: models.AccessRequestStatusPending, CreatedAt: now.Add(-30 * time.Minute)},
+		{TenantID: "other", Member: "member", Device: "uid", Duration: 60, Status: models.AccessRequestStatusPending, CreatedAt: now},
+	}
+
+	for i := range requests {
+		assert.NoError(t, s.AccessRequestCreate(ctx, &requests[i]))
+		assert.NotEmpty(t, requests[i].ID)
+	}
+
+	list, count, err := s.AccessRequestList(ctx, "tenant", "", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, requests[1].ID, list[0].ID)
+	assert.Equal(t, requests[0].ID, list[1].ID)
+
+	pending, err := s.AccessRequestGetPending(ctx, "tenant", "uid", "member", "")
+	assert.NoError(t, err)
+	assert.Equal(t, requests[0].ID, pending.ID)
+
+	_, err = s.AccessRequestGetPending(ctx, "tenant", "other", "member", "")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.AccessRequestGetPending(ctx, "tenant", "uid", "", "")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	decidedAt := now
+	approved := requests[0]
+	approved.Status = models.AccessRequestStatusApproved
+	approved.DecidedBy = "admin"
+	approved.DecidedAt = &decidedAt
+	approved.Grant = "grant"
+
+	assert.NoError(t, s.AccessRequestDecide(ctx, &approved))
+
+	// A decided request cannot be decided again.
+	assert.Equal(t, store.ErrNoDocuments, s.AccessRequestDecide(ctx, &approved))
+
+	request, err := s.AccessRequestGet(ctx, "tenant", requests[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccessRequestStatusApproved, request.Status)
+	assert.Equal(t, "admin", request.DecidedBy)
+	assert.Equal(t, "grant", request.Grant)
+	assert.NotNil(t, request.DecidedAt)
+
+	_, err = s.AccessRequestGetPending(ctx, "tenant", "uid", "member", "")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	list, count, err = s.AccessRequestList(ctx, "tenant", models.AccessRequestStatusPending, paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, requests[1].ID, list[0].ID)
+
+	_, err = s.AccessRequestGet(ctx, "other", requests[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/order"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) AnnouncementList(_ context.Context, pagination paginator.Query, ordination order.Query) ([]models.AnnouncementShort, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Announcement, 0, len(s.announcements))
+	for _, announcement := range s.announcements {
+		list = append(list, announcement)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Date.Equal(list[j].Date) {
+			return s.before("announcements", list[i].UUID, list[j].UUID)
+		}
+
+		if ordination.OrderBy == order.Desc {
+			return list[i].Date.After(list[j].Date)
+		}
+
+		return list[i].Date.Before(list[j].Date)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	var announcements []models.AnnouncementShort
+	for _, announcement := range list[start:end] {
+		announcements = append(announcements, models.AnnouncementShort{
+			UUID:  announcement.UUID,
+			Title: announcement.Title,
+			Date:  announcement.Date,
+		})
+	}
+
+	return announcements, len(announcements), nil
+}
+
+func (s *Store) AnnouncementGet(_ context.Context, uuid string) (*models.Announcement, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	announcement, ok := s.announcements[uuid]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	clone := *announcement
+
+	return &clone, nil
+}
+
+func (s *Store) AnnouncementCreate(_ context.Context, announcement *models.Announcement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.announcements[announcement.UUID]; ok {
+		return store.ErrDuplicate
+	}
+
+	clone := *announcement
+	s.announcements[announcement.UUID] = &clone
+	s.inserted("announcements", announcement.UUID)
+
+	return nil
+}
+
+func (s *Store) AnnouncementUpdate(_ context.Context, announcement *models.Announcement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.announcements[announcement.UUID]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	current.Title = announcement.Title
+	current.Content = announcement.Content
+
+	return nil
+}
+
+func (s *Store) AnnouncementDelete(_ context.Context, uuid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.announcements[uuid]; !ok {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.announcements, uuid)
+	s.removed("announcements", uuid)
+
+	return nil
+}
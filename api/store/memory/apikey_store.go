@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) APIKeyCreate(_ context.Context, key *models.APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.apiKeys {
+		if k.Digest == key.Digest {
+			return store.ErrDuplicate
+		}
+	}
+
+	key.ID = newID()
+
+	s.apiKeys[key.ID] = cloneAPIKey(key)
+	s.inserted("api_keys", key.ID)
+
+	return nil
+}
+
+// APIKeyList returns the API keys of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) APIKeyList(_ context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.APIKey, 0)
+	for _, key := range s.apiKeys {
+		if key.TenantID == tenant {
+			list = append(list, key)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("api_keys", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	keys := make([]models.APIKey, 0, end-start)
+	for _, key := range list[start:end] {
+		keys = append(keys, *cloneAPIKey(key))
+	}
+
+	return keys, len(list), nil
+}
+
+func (s *Store) APIKeyGet(_ context.Context, tenant, id string) (*models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.apiKeys[id]
+	if !ok || key.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneAPIKey(key), nil
+}
+
+func (s *Store) APIKeyGetByDigest(_ context.Context, digest string) (*models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		if key.Digest == digest {
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) APIKeySetLastUsed(_ context.Context, id string, lastUsed time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	key.LastUsedAt = &lastUsed
+
+	return nil
+}
+
+func (s *Store) APIKeyDelete(_ context.Context, tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[id]
+	if !ok || key.TenantID != tenant {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.apiKeys, id)
+	s.removed("api_keys", id)
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey(t *testing.T) {
+	ctx := context.TODO()
+	s := NewStore()
+
+	now := clock.Now()
+	expiresAt := now.Add(time.Hour)
+
+	keys := []models.APIKey{
+		{TenantID: "tenant", UserID: "id", Name: "first", Role: "operator", Digest: "first", CreatedAt: now, ExpiresAt: &expiresAt},
+		{TenantID: "tenant", UserID: "id", Name: "second", Role: "observer", Digest: "second", CreatedAt: now.Add(time.Second)},
+		{TenantID: "other", UserID: "id", Name: "third", Role: "observer", Digest: "third", CreatedAt: now},
+	}
+
+	for i := range keys {
+		assert.NoError(t, s.APIKeyCreate(ctx, &keys[i]))
+		assert.NotEmpty(t, keys[i].ID)
+	}
+
+	assert.Equal(t, store.ErrDuplicate, s.APIKeyCreate(ctx, &models.APIKey{TenantID: "tenant", Digest: "first", CreatedAt: now}))
+
+	list, count, err := s.APIKeyList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "second", list[0].Name)
+	assert.Equal(t, "first", list[1].Name)
+	assert.Nil(t, list[0].ExpiresAt)
+	assert.NotNil(t, list[1].ExpiresAt)
+
+	key, err := s.APIKeyGet(ctx, "tenant", keys[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "first", key.Name)
+
+	_, err = s.APIKeyGet(ctx, "other", keys[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	key, err = s.APIKeyGetByDigest(ctx, "second")
+	assert.NoError(t, err)
+	assert.Equal(t, keys[1].ID, key.ID)
+	assert.Nil(t, key.LastUsedAt)
+
+	assert.NoError(t, s.APIKeySetLastUsed(ctx, keys[1].ID, now))
+	key, err = s.APIKeyGetByDigest(ctx, "second")
+	assert.NoError(t, err)
+	assert.NotNil(t, key.LastUsedAt)
+
+	assert.Equal(t, store.ErrNoDocuments, s.APIKeyDelete(ctx, "other", keys[0].ID))
+	assert.NoError(t, s.APIKeyDelete(ctx, "tenant", keys[0].ID))
+
+	_, err = s.APIKeyGet(ctx, "tenant", keys[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// auditLogProperties returns the properties of a models.AuditLog accepted by the AuditList's filters.
+func auditLogProperties(log *models.AuditLog) properties {
+	return func(name string) (interface{}, bool) {
+		switch name {
+		case "actor.id":
+			return log.Actor.ID, true
+		case "actor.username":
+			return log.Actor.Username, true
+		case "actor.role":
+			return log.Actor.Role, true
+		case "action":
+			return log.Action, true
+		case "target.type":
+			return log.Target.Type, true
+		case "target.id":
+			return log.Target.ID, true
+		case "ip_address":
+			return log.IPAddress, true
+		case "request_id":
+			return log.RequestID, true
+		}
+
+		return nil, false
+	}
+}
+
+func (s *Store) AuditCreate(_ context.Context, log *models.AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	log.ID = newID()
+
+	s.auditLogs = append(s.auditLogs, *cloneAuditLog(log))
+
+	return nil
+}
+
+// AuditList returns the audit log's entries of a namespace, from the newest to the oldest, based on the given filters
+// and pagination.
+func (s *Store) AuditList(_ context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	match, err := buildFilter(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// The entries are kept in insertion order, so they are walked backwards to list the newest ones first when their
+	// creation times are equal.
+	list := make([]*models.AuditLog, 0)
+	for i := len(s.auditLogs) - 1; i >= 0; i-- {
+		log := &s.auditLogs[i]
+		if log.TenantID != tenant {
+			continue
+		}
+
+		ok, err := match(auditLogProperties(log))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if ok {
+			list = append(list, log)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	logs := make([]models.AuditLog, 0, end-start)
+	for _, log := range list[start:end] {
+		logs = append(logs, *cloneAuditLog(log))
+	}
+
+	return logs, len(list), nil
+}
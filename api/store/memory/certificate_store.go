@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func cloneCertificateAuthority(ca *models.CertificateAuthority) *models.CertificateAuthority {
+	clone := *ca
+	clone.Data = append([]byte{}, ca.Data...)
+
+	return &clone
+}
+
+func (s *Store) CertificateAuthorityCreate(_ context.Context, ca *models.CertificateAuthority) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.authorities[ca.TenantID]; ok {
+		return store.ErrDuplicate
+	}
+
+	s.authorities[ca.TenantID] = cloneCertificateAuthority(ca)
+
+	return nil
+}
+
+func (s *Store) CertificateAuthorityGet(_ context.Context, tenant string) (*models.CertificateAuthority, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ca, ok := s.authorities[tenant]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneCertificateAuthority(ca), nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceGroupNameTaken reports whether the namespace has a device group, other than the one with the ID, with the name.
+func (s *Store) deviceGroupNameTaken(tenant, name, id string) bool {
+	for _, group := range s.deviceGroups {
+		if group.TenantID == tenant && group.Name == name && group.ID != id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) DeviceGroupCreate(_ context.Context, group *models.DeviceGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deviceGroupNameTaken(group.TenantID, group.Name, "") {
+		return store.ErrDuplicate
+	}
+
+	group.ID = newID()
+
+	clone := *group
+	s.deviceGroups[group.ID] = &clone
+	s.inserted("device_groups", group.ID)
+
+	return nil
+}
+
+// DeviceGroupList returns the device groups of a namespace, sorted by name, based on the given pagination.
+func (s *Store) DeviceGroupList(_ context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.DeviceGroup, 0)
+	for _, group := range s.deviceGroups {
+		if group.TenantID == tenant {
+			list = append(list, group)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	groups := make([]models.DeviceGroup, 0, end-start)
+	for _, group := range list[start:end] {
+		groups = append(groups, *group)
+	}
+
+	return groups, len(list), nil
+}
+
+func (s *Store) DeviceGroupGet(_ context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	group, ok := s.deviceGroups[id]
+	if !ok || group.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	clone := *group
+
+	return &clone, nil
+}
+
+func (s *Store) DeviceGroupUpdate(_ context.Context, group *models.DeviceGroup) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.deviceGroups[group.ID]
+	if !ok || current.TenantID != group.TenantID {
+		return store.ErrNoDocuments
+	}
+
+	if s.deviceGroupNameTaken(group.TenantID, group.Name, group.ID) {
+		return store.ErrDuplicate
+	}
+
+	current.Name = group.Name
+	current.Filter = group.Filter
+
+	return nil
+}
+
+func (s *Store) DeviceGroupDelete(_ context.Context, tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.deviceGroups[id]
+	if !ok || group.TenantID != tenant {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.deviceGroups, id)
+	s.removed("device_groups", id)
+
+	return nil
+}
@@ -0,0 +1,561 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// deviceProperties returns the properties of a models.Device accepted by the DeviceList's filters.
+func deviceProperties(device *models.Device) properties {
+	return func(name string) (interface{}, bool) {
+		switch name {
+		case "uid":
+			return device.UID, true
+		case "name":
+			return device.Name, true
+		case "tenant_id":
+			return device.TenantID, true
+		case "status":
+			return string(device.Status), true
+		case "online":
+			return device.Online, true
+		case "namespace":
+			return device.Namespace, true
+		case "remote_addr":
+			return device.RemoteAddr, true
+		case "public_url":
+			return device.PublicURL, true
+		case "identity.mac":
+			if device.Identity == nil {
+				return "", true
+			}
+
+			return device.Identity.MAC, true
+		case "tags":
+			return device.Tags, true
+		}
+
+		if key, ok := strings.CutPrefix(name, "attributes."); ok {
+			// A missing attribute matches no value.
+			if value, ok := device.Attributes[key]; ok {
+				return value, true
+			}
+
+			return nil, true
+		}
+
+		return nil, false
+	}
+}
+
+// deviceLess returns the function that compares two devices by a sort field, reporting whether a is less than b and
+// whether they are equal. When the field is not valid, nil is returned.
+func deviceLess(field string) func(a, b *models.Device) (bool, bool) {
+	switch field {
+	case "uid":
+		return func(a, b *models.Device) (bool, bool) { return a.UID < b.UID, a.UID == b.UID }
+	case "name":
+		return func(a, b *models.Device) (bool, bool) { return a.Name < b.Name, a.Name == b.Name }
+	case "last_seen":
+		return func(a, b *models.Device) (bool, bool) {
+			return a.LastSeen.Before(b.LastSeen), a.LastSeen.Equal(b.LastSeen)
+		}
+	case "created_at":
+		return func(a, b *models.Device) (bool, bool) {
+			return a.CreatedAt.Before(b.CreatedAt), a.CreatedAt.Equal(b.CreatedAt)
+		}
+	case "status":
+		return func(a, b *models.Device) (bool, bool) { return a.Status < b.Status, a.Status == b.Status }
+	case "online":
+		return func(a, b *models.Device) (bool, bool) { return !a.Online && b.Online, a.Online == b.Online }
+	case "namespace":
+		return func(a, b *models.Device) (bool, bool) { return a.Namespace < b.Namespace, a.Namespace == b.Namespace }
+	}
+
+	return nil
+}
+
+// deviceView returns a copy of the device with its namespace's name and the online status based on the connected
+// devices. When the device's namespace does not exist, nil is returned.
+func (s *Store) deviceView(device *models.Device, now time.Time) *models.Device {
+	namespace, ok := s.namespaces[device.TenantID]
+	if !ok {
+		return nil
+	}
+
+	view := cloneDevice(device)
+	view.Namespace = namespace.Name
+	view.Online = s.deviceOnline(device.UID, now)
+
+	return view
+}
+
+func (s *Store) deviceOnline(uid string, now time.Time) bool {
+	connected, ok := s.connectedDevices[models.UID(uid)]
+
+	return ok && connected.lastSeen.After(now.Add(-connectedDeviceTTL))
+}
+
+// deviceGetWhere gets the first device, in insertion order, that matches the condition.
+func (s *Store) deviceGetWhere(match func(device *models.Device) bool) (*models.Device, error) {
+	var found *models.Device
+	for _, device := range s.devices {
+		if match(device) && (found == nil || s.before("devices", device.UID, found.UID)) {
+			found = device
+		}
+	}
+
+	if found == nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneDevice(found), nil
+}
+
+// DeviceList returns a list of devices based on the given filters, pagination and sorting.
+func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status models.DeviceStatus, sort string, order string, mode store.DeviceListMode) ([]models.Device, int, error) {
+	match, err := buildFilter(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := clock.Now()
+	tenant := gateway.TenantFromContext(ctx)
+
+	list := make([]*models.Device, 0)
+	for _, device := range s.devices {
+		if status != "" && device.Status != status {
+			continue
+		}
+
+		// Only match for the respective tenant if requested
+		if tenant != nil && device.TenantID != tenant.ID {
+			continue
+		}
+
+		view := s.deviceView(device, now)
+		if view == nil {
+			continue
+		}
+
+		ok, err := match(deviceProperties(view))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		// The value of "acceptable" is based on the device status and the list mode. See the Mongo's DeviceList for
+		// details.
+		switch status {
+		case models.DeviceStatusPending, models.DeviceStatusRejected:
+			switch mode {
+			case store.DeviceListModeMaxDeviceReached:
+				removed, ok := s.removedDevices[removedDevice{tenantID: device.TenantID, uid: models.UID(device.UID)}]
+				view.Acceptable = ok && removed.After(now.Add(-removedDeviceTTL))
+			default:
+				view.Acceptable = true
+			}
+		}
+
+		list = append(list, view)
+	}
+
+	less, desc := deviceLess(sort), order == "desc"
+	if less == nil {
+		less, desc = deviceLess("last_seen"), true
+	}
+
+	sortDevices(list, func(a, b *models.Device) bool {
+		lt, eq := less(a, b)
+		if eq {
+			return s.before("devices", a.UID, b.UID)
+		}
+
+		return lt != desc
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	devices := make([]models.Device, 0, end-start)
+	for _, device := range list[start:end] {
+		devices = append(devices, *device)
+	}
+
+	return devices, len(list), nil
+}
+
+// sortDevices sorts the list of devices, keeping the order of the equal ones.
+func sortDevices(list []*models.Device, less func(a, b *models.Device) bool) {
+	sort.SliceStable(list, func(i, j int) bool {
+		return less(list[i], list[j])
+	})
+}
+
+func (s *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.deviceGet(ctx, uid)
+}
+
+func (s *Store) deviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
+	device, ok := s.devices[uid]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil && device.TenantID != tenant.ID {
+		return nil, store.ErrNoDocuments
+	}
+
+	view := s.deviceView(device, clock.Now())
+	if view == nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	return view, nil
+}
+
+func (s *Store) DeviceDelete(_ context.Context, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.devices, uid)
+	s.removed("devices", string(uid))
+
+	for id, session := range s.sessions {
+		if session.DeviceUID == uid {
+			delete(s.sessions, id)
+			s.removed("sessions", id)
+		}
+	}
+
+	delete(s.connectedDevices, uid)
+
+	return nil
+}
+
+func (s *Store) DeviceCreate(_ context.Context, d models.Device, hostname string) error {
+	if hostname == "" {
+		hostname = strings.ReplaceAll(d.Identity.MAC, ":", "-")
+	}
+
+	if d.Name != "" {
+		hostname = d.Name
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := cloneDevice(&d)
+
+	if device, ok := s.devices[models.UID(d.UID)]; ok {
+		device.TenantID = data.TenantID
+		device.Identity = data.Identity
+		device.Info = data.Info
+		device.PublicKey = data.PublicKey
+		device.LastSeen = data.LastSeen
+		device.RemoteAddr = data.RemoteAddr
+		device.Position = data.Position
+
+		// The attributes are set one by one to keep the ones that were not sent.
+		for key, value := range data.Attributes {
+			if device.Attributes == nil {
+				device.Attributes = make(map[string]string)
+			}
+
+			device.Attributes[key] = value
+		}
+
+		return nil
+	}
+
+	data.Name = hostname
+	data.CreatedAt = clock.Now()
+	data.Online = false
+	data.Namespace = ""
+	data.Acceptable = false
+
+	if data.Status == "" {
+		data.Status = models.DeviceStatusPending
+	}
+
+	if data.Tags == nil {
+		data.Tags = []string{}
+	}
+
+	s.devices[models.UID(d.UID)] = data
+	s.inserted("devices", d.UID)
+
+	return nil
+}
+
+func (s *Store) DeviceRename(_ context.Context, uid models.UID, hostname string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Name = hostname
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceLookup(_ context.Context, namespace, hostname string) (*models.Device, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tenant *models.Namespace
+	for _, ns := range s.namespaces {
+		if ns.Name == namespace {
+			tenant = ns
+		}
+	}
+
+	if tenant == nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.deviceGetWhere(func(device *models.Device) bool {
+		return device.TenantID == tenant.TenantID && device.Name == hostname && device.Status == models.DeviceStatusAccepted
+	})
+}
+
+func (s *Store) DeviceSetOnline(_ context.Context, uid models.UID, online bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[uid]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	if !online {
+		delete(s.connectedDevices, uid)
+
+		return nil
+	}
+
+	now := clock.Now()
+	device.LastSeen = now
+	s.connectedDevices[uid] = connectedDevice{tenantID: device.TenantID, status: device.Status, lastSeen: now}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateOnline(_ context.Context, uid models.UID, online bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Online = online
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateLastSeen(_ context.Context, uid models.UID, ts time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.LastSeen = ts
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateStatus(_ context.Context, uid models.UID, status models.DeviceStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[uid]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	device.Status = status
+	s.connectedDevices[uid] = connectedDevice{tenantID: device.TenantID, status: status, lastSeen: clock.Now()}
+
+	return nil
+}
+
+func (s *Store) DeviceListByUsage(_ context.Context, tenant string) ([]models.UID, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	usage := make(map[models.UID]int)
+	for _, session := range s.sessions {
+		if session.TenantID == tenant {
+			usage[session.DeviceUID]++
+		}
+	}
+
+	uids := make([]models.UID, 0, len(usage))
+	for uid := range usage {
+		uids = append(uids, uid)
+	}
+
+	sort.Slice(uids, func(i, j int) bool {
+		if usage[uids[i]] == usage[uids[j]] {
+			return uids[i] < uids[j]
+		}
+
+		return usage[uids[i]] > usage[uids[j]]
+	})
+
+	if len(uids) > 3 {
+		uids = uids[:3]
+	}
+
+	return uids, nil
+}
+
+func (s *Store) DeviceGetByMac(_ context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.deviceGetWhere(func(device *models.Device) bool {
+		return device.TenantID == tenantID && device.Identity != nil && device.Identity.MAC == mac && (status == "" || device.Status == status)
+	})
+}
+
+func (s *Store) DeviceGetByName(_ context.Context, name string, tenantID string) (*models.Device, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.deviceGetWhere(func(device *models.Device) bool {
+		return device.TenantID == tenantID && device.Name == name
+	})
+}
+
+func (s *Store) DeviceGetByUID(_ context.Context, uid models.UID, tenantID string) (*models.Device, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	device, ok := s.devices[uid]
+	if !ok || device.TenantID != tenantID {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneDevice(device), nil
+}
+
+func (s *Store) DeviceSetPosition(_ context.Context, uid models.UID, position models.DevicePosition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Position = &position
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetHostKey(_ context.Context, uid models.UID, hostKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[uid]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	device.HostKey = hostKey
+
+	return nil
+}
+
+func (s *Store) DeviceChooser(_ context.Context, tenantID string, chosen []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, device := range s.devices {
+		if device.TenantID == tenantID && device.Status == models.DeviceStatusAccepted && !hasString(chosen, device.UID) {
+			device.Status = models.DeviceStatusPending
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdate(_ context.Context, uid models.UID, name *string, publicURL *bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, ok := s.devices[uid]
+	if !ok {
+		return nil
+	}
+
+	if name != nil {
+		device.Name = *name
+	}
+
+	if publicURL != nil {
+		device.PublicURL = *publicURL
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceRemovedCount(_ context.Context, tenant string) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := clock.Now().Add(-removedDeviceTTL)
+
+	var count int64
+	for key, timestamp := range s.removedDevices {
+		if key.tenantID == tenant && timestamp.After(limit) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *Store) DeviceRemovedGet(_ context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	timestamp, ok := s.removedDevices[removedDevice{tenantID: tenant, uid: uid}]
+	if !ok || !timestamp.After(clock.Now().Add(-removedDeviceTTL)) {
+		return nil, store.ErrNoDocuments
+	}
+
+	return &models.DeviceRemoved{UID: uid, Tenant: tenant, Timestamp: timestamp}, nil
+}
+
+func (s *Store) DeviceRemovedInsert(_ context.Context, tenant string, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removedDevices[removedDevice{tenantID: tenant, uid: uid}] = time.Now()
+
+	return nil
+}
+
+func (s *Store) DeviceRemovedDelete(_ context.Context, tenant string, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.removedDevices, removedDevice{tenantID: tenant, uid: uid})
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DeviceSetAttribute(_ context.Context, uid models.UID, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		if device.Attributes == nil {
+			device.Attributes = make(map[string]string)
+		}
+
+		device.Attributes[key] = value
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceRemoveAttribute(_ context.Context, uid models.UID, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		delete(device.Attributes, key)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateAttributes(_ context.Context, uid models.UID, attributes map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Attributes = cloneAttributes(attributes)
+	}
+
+	return nil
+}
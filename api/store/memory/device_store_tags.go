@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DeviceCreateTag(_ context.Context, uid models.UID, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Tags = append(device.Tags, tag)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceRemoveTag(_ context.Context, uid models.UID, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Tags, _ = removeString(device.Tags, tag)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUpdateTag(_ context.Context, uid models.UID, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if device, ok := s.devices[uid]; ok {
+		device.Tags = cloneStrings(tags)
+	}
+
+	return nil
+}
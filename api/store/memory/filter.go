@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	ErrFilterInvalid         = errors.New("filter is invalid")
+	ErrFilterPropertyInvalid = errors.New("filter property is not valid")
+)
+
+// properties returns the value of a document's property used by a models.Filter, and whether the property exists.
+type properties func(name string) (interface{}, bool)
+
+// condition reports whether a document matches a filter's property.
+type condition func(props properties) (bool, error)
+
+// predicate reports whether a document matches a list of filters.
+type predicate func(props properties) (bool, error)
+
+// buildFilter creates a predicate from models.Filter for filtering the documents.
+//
+// It follows the same rules of the Mongo's queries.BuildFilterQuery: properties are grouped by the operator that
+// follows them, and the groups are joined by AND. Properties that are not followed by an operator are joined by OR.
+// When there are no conditions, every document matches.
+func buildFilter(filters []models.Filter) (predicate, error) {
+	const (
+		TypeProperty = "property"
+		TypeOperator = "operator"
+	)
+
+	operators := map[string]func(name string, value interface{}) (condition, error){
+		"contains": func(name string, value interface{}) (condition, error) {
+			switch v := value.(type) {
+			case string:
+				return func(props properties) (bool, error) {
+					property, ok := props(name)
+					if !ok {
+						return false, ErrFilterPropertyInvalid
+					}
+
+					for _, item := range values(property) {
+						if str, ok := item.(string); ok && strings.Contains(strings.ToLower(str), strings.ToLower(v)) {
+							return true, nil
+						}
+					}
+
+					return false, nil
+				}, nil
+			case []interface{}:
+				return func(props properties) (bool, error) {
+					property, ok := props(name)
+					if !ok {
+						return false, ErrFilterPropertyInvalid
+					}
+
+					items := values(property)
+					for _, expected := range v {
+						if !contains(items, expected) {
+							return false, nil
+						}
+					}
+
+					return true, nil
+				}, nil
+			}
+
+			return nil, ErrFilterPropertyInvalid
+		},
+		"eq": func(name string, value interface{}) (condition, error) { //nolint:unparam
+			return func(props properties) (bool, error) {
+				property, ok := props(name)
+				if !ok {
+					return false, ErrFilterPropertyInvalid
+				}
+
+				return contains(values(property), value), nil
+			}, nil
+		},
+		"bool": func(name string, value interface{}) (condition, error) {
+			switch v := value.(type) {
+			case int:
+				value = v != 0
+			case float64:
+				value = v != 0
+			case string:
+				var err error
+				value, err = strconv.ParseBool(v)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return func(props properties) (bool, error) {
+				property, ok := props(name)
+				if !ok {
+					return false, ErrFilterPropertyInvalid
+				}
+
+				return property == value, nil
+			}, nil
+		},
+		"gt": func(name string, value interface{}) (condition, error) {
+			if v, ok := value.(string); ok {
+				var err error
+				value, err = strconv.Atoi(v)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			limit, ok := number(value)
+			if !ok {
+				return nil, ErrFilterPropertyInvalid
+			}
+
+			return func(props properties) (bool, error) {
+				property, ok := props(name)
+				if !ok {
+					return false, ErrFilterPropertyInvalid
+				}
+
+				n, ok := number(property)
+
+				return ok && n > limit, nil
+			}, nil
+		},
+	}
+
+	var groups [][]condition
+	var conditions []condition
+	var joins []string
+
+	for _, filter := range filters {
+		switch filter.Type {
+		case TypeProperty:
+			params, ok := filter.Params.(*models.PropertyParams)
+			if !ok {
+				return nil, ErrFilterInvalid
+			}
+
+			fn, ok := operators[params.Operator]
+			if !ok {
+				// If the operator is not found, jump to next iteration.
+				continue
+			}
+
+			cond, err := fn(params.Name, params.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, cond)
+		case TypeOperator:
+			params, ok := filter.Params.(*models.OperatorParams)
+			if !ok {
+				return nil, ErrFilterInvalid
+			}
+
+			if params.Name != "and" && params.Name != "or" {
+				// If the operation's name is not found, jump to next iteration.
+				continue
+			}
+
+			if len(conditions) > 0 {
+				groups = append(groups, conditions)
+				joins = append(joins, params.Name)
+			}
+
+			conditions = nil
+		default:
+			return nil, ErrFilterInvalid
+		}
+	}
+
+	if len(conditions) > 0 {
+		groups = append(groups, conditions)
+		joins = append(joins, "or")
+	}
+
+	return func(props properties) (bool, error) {
+		for i, group := range groups {
+			matched := joins[i] == "and"
+			for _, cond := range group {
+				ok, err := cond(props)
+				if err != nil {
+					return false, err
+				}
+
+				if joins[i] == "and" {
+					matched = matched && ok
+				} else {
+					matched = matched || ok
+				}
+			}
+
+			if !matched {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}, nil
+}
+
+// values returns the items of a property, wrapping it into a list when it is not a list of strings.
+func values(property interface{}) []interface{} {
+	if list, ok := property.([]string); ok {
+		items := make([]interface{}, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+
+		return items
+	}
+
+	return []interface{}{property}
+}
+
+// contains reports whether the items have the expected value. Numbers are compared by their values, independent of
+// their types.
+func contains(items []interface{}, expected interface{}) bool {
+	for _, item := range items {
+		if a, ok := number(item); ok {
+			if b, ok := number(expected); ok && a == b {
+				return true
+			}
+
+			continue
+		}
+
+		if fmt.Sprint(item) == fmt.Sprint(expected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+
+	return 0, false
+}
+
+// paginate returns the bounds of the page in a list with length items. When the PerPage is -1, the results are not
+// paginated.
+func paginate(length int, pagination paginator.Query) (int, int) {
+	if pagination.PerPage == -1 {
+		return 0, length
+	}
+
+	start := pagination.PerPage * (pagination.Page - 1)
+	if start < 0 {
+		start = 0
+	}
+
+	if start > length {
+		start = length
+	}
+
+	end := start + pagination.PerPage
+	if end > length || pagination.PerPage < 0 {
+		end = length
+	}
+
+	return start, end
+}
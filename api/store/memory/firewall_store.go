@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := gateway.TenantFromContext(ctx)
+
+	list := make([]*models.FirewallRule, 0)
+	for _, rule := range s.firewallRules {
+		// Only match for the respective tenant if requested
+		if tenant != nil && rule.TenantID != tenant.ID {
+			continue
+		}
+
+		list = append(list, rule)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Priority == list[j].Priority {
+			return s.before("firewall_rules", list[i].ID, list[j].ID)
+		}
+
+		return list[i].Priority < list[j].Priority
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	rules := make([]models.FirewallRule, 0, end-start)
+	for _, rule := range list[start:end] {
+		rules = append(rules, *cloneFirewallRule(rule))
+	}
+
+	return rules, len(list), nil
+}
+
+func (s *Store) FirewallRuleListActive(_ context.Context, tenant string) ([]models.FirewallRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.FirewallRule, 0)
+	for _, rule := range s.firewallRules {
+		if rule.TenantID == tenant && rule.Active {
+			list = append(list, rule)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Priority == list[j].Priority {
+			return s.before("firewall_rules", list[i].ID, list[j].ID)
+		}
+
+		return list[i].Priority < list[j].Priority
+	})
+
+	rules := make([]models.FirewallRule, 0, len(list))
+	for _, rule := range list {
+		rules = append(rules, *cloneFirewallRule(rule))
+	}
+
+	return rules, nil
+}
+
+func (s *Store) FirewallRuleCreate(_ context.Context, rule *models.FirewallRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule.ID = newID()
+
+	s.firewallRules[rule.ID] = cloneFirewallRule(rule)
+	s.inserted("firewall_rules", rule.ID)
+
+	return nil
+}
+
+func (s *Store) FirewallRuleGet(_ context.Context, id string) (*models.FirewallRule, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rule, ok := s.firewallRules[id]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneFirewallRule(rule), nil
+}
+
+func (s *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if current, ok := s.firewallRules[id]; ok {
+		current.FirewallRuleFields = rule.FirewallRuleFields
+		current.Filter.Tags = cloneStrings(rule.Filter.Tags)
+	}
+	s.mu.Unlock()
+
+	return s.FirewallRuleGet(ctx, id)
+}
+
+func (s *Store) FirewallRuleDelete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.firewallRules, id)
+	s.removed("firewall_rules", id)
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirewallRule(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	err := s.FirewallRuleCreate(data.Context, &data.FirewallRule)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, data.FirewallRule.ID)
+
+	err = s.FirewallRuleCreate(data.Context, &models.FirewallRule{TenantID: data.FirewallRule.TenantID, FirewallRuleFields: models.FirewallRuleFields{
+		Priority: 0,
+		Action:   "deny",
+		SourceIP: ".*",
+		Username: ".*",
+		Filter:   models.FirewallFilter{Tags: []string{"tag1"}},
+	}})
+	assert.NoError(t, err)
+
+	rules, count, err := s.FirewallRuleList(data.Context, paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "deny", rules[0].Action)
+	assert.Equal(t, []string{"tag1"}, rules[0].Filter.Tags)
+
+	rule, err := s.FirewallRuleUpdate(data.Context, data.FirewallRule.ID, models.FirewallRuleUpdate{FirewallRuleFields: models.FirewallRuleFields{
+		Priority: 2,
+		Action:   "deny",
+		SourceIP: ".*",
+		Username: "root",
+		Filter:   models.FirewallFilter{Hostname: ".*"},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "root", rule.Username)
+
+	err = s.FirewallRuleAddTag(data.Context, data.FirewallRule.ID, "tag2")
+	assert.NoError(t, err)
+
+	tags, count, err := s.FirewallRuleGetTags(data.Context, data.FirewallRule.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+
+	err = s.FirewallRuleDelete(data.Context, data.FirewallRule.ID)
+	assert.NoError(t, err)
+
+	_, err = s.FirewallRuleGet(data.Context, data.FirewallRule.ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestFirewallRuleListActive(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	fields := func(priority int, active bool) models.FirewallRuleFields {
+		return models.FirewallRuleFields{
+			Priority: priority,
+			Action:   "allow",
+			Active:   active,
+			SourceIP: ".*",
+			Username: ".*",
+			Filter:   models.FirewallFilter{Tags: []string{"tag1"}},
+		}
+	}
+
+	second := &models.FirewallRule{TenantID: data.FirewallRule.TenantID, FirewallRuleFields: fields(2, true)}
+	first := &models.FirewallRule{TenantID: data.FirewallRule.TenantID, FirewallRuleFields: fields(1, true)}
+	inactive := &models.FirewallRule{TenantID: data.FirewallRule.TenantID, FirewallRuleFields: fields(0, false)}
+	other := &models.FirewallRule{TenantID: "other", FirewallRuleFields: fields(0, true)}
+
+	for _, rule := range []*models.FirewallRule{second, first, inactive, other} {
+		assert.NoError(t, s.FirewallRuleCreate(data.Context, rule))
+	}
+
+	rules, err := s.FirewallRuleListActive(data.Context, data.FirewallRule.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.FirewallRule{*first, *second}, rules)
+
+	rules, err = s.FirewallRuleListActive(data.Context, "missing")
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// FirewallRuleAddTag adds a tag to the tag's list in models.FirewallRule.
+//
+// The tag needs to exist on a models.Device. If it is not, the tag addition to
+// models.FirewallRule will fail.
+func (s *Store) FirewallRuleAddTag(_ context.Context, id, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.firewallRules[id]
+	if !ok || hasString(rule.Filter.Tags, tag) {
+		return store.ErrNoDocuments
+	}
+
+	rule.Filter.Tags = append(rule.Filter.Tags, tag)
+
+	return nil
+}
+
+// FirewallRuleRemoveTag removes a tag from the tag's list in models.FirewallRule.
+//
+// The tag needs to exist on a models.Device. If it is not, the tag deletion from
+// models.FirewallRule will fail.
+func (s *Store) FirewallRuleRemoveTag(_ context.Context, id, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.firewallRules[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	var removed bool
+	if rule.Filter.Tags, removed = removeString(rule.Filter.Tags, tag); !removed {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// FirewallRuleUpdateTags update with a new set the tag's list in models.FirewallRule.
+//
+// All tags need to exist on a models.Device. If it is not true, the tags' update
+// to models.FirewallRule will fail.
+func (s *Store) FirewallRuleUpdateTags(_ context.Context, id string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.firewallRules[id]
+	// Like in Mongo, when nothing is modified, the update is considered as not found.
+	if !ok || equal(rule.Filter.Tags, tags) {
+		return store.ErrNoDocuments
+	}
+
+	rule.Filter.Tags = cloneStrings(tags)
+
+	return nil
+}
+
+// FirewallRuleRenameTag renames a tag to a new name in models.FirewallRule.
+func (s *Store) FirewallRuleRenameTag(_ context.Context, tenant, tagCurrent, tagNew string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.firewallRuleRenameTag(tenant, tagCurrent, tagNew) {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) firewallRuleRenameTag(tenant, tagCurrent, tagNew string) bool {
+	renamed := false
+	for _, rule := range s.firewallRules {
+		if rule.TenantID == tenant && renameString(rule.Filter.Tags, tagCurrent, tagNew) {
+			renamed = true
+		}
+	}
+
+	return renamed
+}
+
+// FirewallRuleDeleteTag removes a tag from all models.FirewallRule.
+func (s *Store) FirewallRuleDeleteTag(_ context.Context, tenant, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.firewallRuleDeleteTag(tenant, tag) {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) firewallRuleDeleteTag(tenant, tag string) bool {
+	deleted := false
+	for _, rule := range s.firewallRules {
+		if rule.TenantID != tenant {
+			continue
+		}
+
+		var removed bool
+		if rule.Filter.Tags, removed = removeString(rule.Filter.Tags, tag); removed {
+			deleted = true
+		}
+	}
+
+	return deleted
+}
+
+// FirewallRuleGetTags gets all tags from all models.FirewallRule.
+func (s *Store) FirewallRuleGetTags(_ context.Context, tenant string) ([]string, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := s.firewallRuleTags(tenant)
+
+	return tags, len(tags), nil
+}
+
+func (s *Store) firewallRuleTags(tenant string) []string {
+	tags := make([]string, 0)
+	for _, rule := range s.firewallRules {
+		if rule.TenantID != tenant {
+			continue
+		}
+
+		for _, tag := range rule.Filter.Tags {
+			if !hasString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
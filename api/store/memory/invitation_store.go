@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) InvitationCreate(_ context.Context, invitation *models.Invitation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, i := range s.invitations {
+		if i.Digest == invitation.Digest || (i.TenantID == invitation.TenantID && i.Email == invitation.Email) {
+			return store.ErrDuplicate
+		}
+	}
+
+	invitation.ID = newID()
+
+	clone := *invitation
+	s.invitations[invitation.ID] = &clone
+	s.inserted("invitations", invitation.ID)
+
+	return nil
+}
+
+// InvitationList returns the invitations of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) InvitationList(_ context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Invitation, 0)
+	for _, invitation := range s.invitations {
+		if invitation.TenantID == tenant {
+			list = append(list, invitation)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("invitations", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	invitations := make([]models.Invitation, 0, end-start)
+	for _, invitation := range list[start:end] {
+		invitations = append(invitations, *invitation)
+	}
+
+	return invitations, len(list), nil
+}
+
+func (s *Store) InvitationGet(_ context.Context, tenant, id string) (*models.Invitation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	invitation, ok := s.invitations[id]
+	if !ok || invitation.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	clone := *invitation
+
+	return &clone, nil
+}
+
+func (s *Store) InvitationGetByEmail(_ context.Context, tenant, email string) (*models.Invitation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, invitation := range s.invitations {
+		if invitation.TenantID == tenant && invitation.Email == email {
+			clone := *invitation
+
+			return &clone, nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) InvitationGetByDigest(_ context.Context, digest string) (*models.Invitation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, invitation := range s.invitations {
+		if invitation.Digest == digest {
+			clone := *invitation
+
+			return &clone, nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) InvitationDelete(_ context.Context, tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	invitation, ok := s.invitations[id]
+	if !ok || invitation.TenantID != tenant {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.invitations, id)
+	s.removed("invitations", id)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) LicenseLoad(_ context.Context) (*models.License, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *models.License
+	for i := range s.licenses {
+		if latest == nil || !s.licenses[i].CreatedAt.Before(latest.CreatedAt) {
+			latest = &s.licenses[i]
+		}
+	}
+
+	if latest == nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	return &models.License{RawData: append([]byte{}, latest.RawData...), CreatedAt: latest.CreatedAt}, nil
+}
+
+func (s *Store) LicenseSave(_ context.Context, license *models.License) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.licenses = append(s.licenses, models.License{RawData: append([]byte{}, license.RawData...), CreatedAt: license.CreatedAt})
+
+	return nil
+}
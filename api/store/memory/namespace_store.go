@@ -0,0 +1,500 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// namespaceProperties returns the properties of a models.Namespace accepted by the NamespaceList's filters.
+func (s *Store) namespaceProperties(ns *models.Namespace) properties {
+	return func(name string) (interface{}, bool) {
+		switch name {
+		case "name":
+			return ns.Name, true
+		case "owner":
+			return ns.Owner, true
+		case "tenant_id":
+			return ns.TenantID, true
+		case "max_devices":
+			return ns.MaxDevices, true
+		case "settings.session_record":
+			return ns.Settings != nil && ns.Settings.SessionRecord, true
+		case "devices":
+			return s.namespaceDevices(ns.TenantID, ""), true
+		case "sessions":
+			return s.namespaceSessions(ns.TenantID), true
+		}
+
+		return nil, false
+	}
+}
+
+// namespaceDevices counts the devices of a namespace. When status is not empty, only the devices with that status are
+// counted.
+func (s *Store) namespaceDevices(tenantID string, status models.DeviceStatus) int {
+	count := 0
+	for _, device := range s.devices {
+		if device.TenantID == tenantID && (status == "" || device.Status == status) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (s *Store) namespaceSessions(tenantID string) int {
+	count := 0
+	for _, session := range s.sessions {
+		if session.TenantID == tenantID {
+			count++
+		}
+	}
+
+	return count
+}
+
+func hasMember(ns *models.Namespace, id string) bool {
+	for _, member := range ns.Members {
+		if member.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// namespaceGet returns a copy of the namespace with the number of its accepted devices.
+func (s *Store) namespaceGet(tenantID string) (*models.Namespace, error) {
+	ns, ok := s.namespaces[tenantID]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	clone := cloneNamespace(ns)
+	clone.DevicesCount = s.namespaceDevices(tenantID, models.DeviceStatusAccepted)
+
+	return clone, nil
+}
+
+func (s *Store) NamespaceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, export bool) ([]models.Namespace, int, error) {
+	match, err := buildFilter(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Only match for the respective tenant if requested
+	var member string
+	if id := gateway.IDFromContext(ctx); id != nil {
+		user, ok := s.users[id.ID]
+		if !ok {
+			return nil, 0, store.ErrNoDocuments
+		}
+
+		member = user.ID
+	}
+
+	list := make([]*models.Namespace, 0)
+	for _, ns := range s.namespaces {
+		if member != "" && !hasMember(ns, member) {
+			continue
+		}
+
+		ok, err := match(s.namespaceProperties(ns))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if ok {
+			list = append(list, ns)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("namespaces", list[i].TenantID, list[j].TenantID)
+		}
+
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	start, end := 0, len(list)
+	if pagination.Page != 0 && pagination.PerPage != 0 {
+		start, end = paginate(len(list), pagination)
+	}
+
+	namespaces := make([]models.Namespace, 0, end-start)
+	for _, ns := range list[start:end] {
+		clone, _ := s.namespaceGet(ns.TenantID)
+		if export {
+			clone.Devices = s.namespaceDevices(ns.TenantID, "")
+			clone.Sessions = s.namespaceSessions(ns.TenantID)
+		}
+
+		namespaces = append(namespaces, *clone)
+	}
+
+	return namespaces, len(list), nil
+}
+
+func (s *Store) NamespaceGet(_ context.Context, tenantID string) (*models.Namespace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.namespaceGet(tenantID)
+}
+
+func (s *Store) NamespaceGetByName(_ context.Context, name string) (*models.Namespace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, ns := range s.namespaces {
+		if ns.Name == name {
+			return cloneNamespace(ns), nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) NamespaceCreate(_ context.Context, namespace *models.Namespace) (*models.Namespace, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ns := range s.namespaces {
+		if ns.TenantID == namespace.TenantID || ns.Name == namespace.Name {
+			return nil, store.ErrDuplicate
+		}
+	}
+
+	s.namespaces[namespace.TenantID] = cloneNamespace(namespace)
+	s.inserted("namespaces", namespace.TenantID)
+
+	if owner, ok := s.users[namespace.Owner]; ok {
+		owner.Namespaces++
+	}
+
+	return namespace, nil
+}
+
+func (s *Store) NamespaceDelete(_ context.Context, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ns, ok := s.namespaces[tenantID]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.namespaces, tenantID)
+	s.removed("namespaces", tenantID)
+
+	for uid, device := range s.devices {
+		if device.TenantID == tenantID {
+			delete(s.devices, uid)
+			s.removed("devices", string(uid))
+		}
+	}
+
+	for uid, session := range s.sessions {
+		if session.TenantID == tenantID {
+			delete(s.sessions, uid)
+			s.removed("sessions", uid)
+		}
+	}
+
+	for uid, connected := range s.connectedDevices {
+		if connected.tenantID == tenantID {
+			delete(s.connectedDevices, uid)
+		}
+	}
+
+	for id, rule := range s.firewallRules {
+		if rule.TenantID == tenantID {
+			delete(s.firewallRules, id)
+			s.removed("firewall_rules", id)
+		}
+	}
+
+	for id := range s.publicKeys {
+		if id.tenantID == tenantID {
+			delete(s.publicKeys, id)
+			s.removed("public_keys", id.tenantID+"/"+id.fingerprint)
+		}
+	}
+
+	frames := make([]models.RecordedSession, 0, len(s.recordedSessions))
+	for _, frame := range s.recordedSessions {
+		if frame.TenantID != tenantID {
+			frames = append(frames, frame)
+		}
+	}
+
+	s.recordedSessions = frames
+
+	for uid, lines := range s.recordLines {
+		if len(lines) > 0 && lines[0].TenantID == tenantID {
+			delete(s.recordLines, uid)
+		}
+	}
+
+	for id, key := range s.apiKeys {
+		if key.TenantID == tenantID {
+			delete(s.apiKeys, id)
+			s.removed("api_keys", id)
+		}
+	}
+
+	for id, webhook := range s.webhooks {
+		if webhook.TenantID == tenantID {
+			delete(s.webhooks, id)
+			s.removed("webhooks", id)
+		}
+	}
+
+	for id, delivery := range s.deliveries {
+		if delivery.TenantID == tenantID {
+			delete(s.deliveries, id)
+			s.removed("webhook_deliveries", id)
+		}
+	}
+
+	for id, group := range s.deviceGroups {
+		if group.TenantID == tenantID {
+			delete(s.deviceGroups, id)
+			s.removed("device_groups", id)
+		}
+	}
+
+	for id, invitation := range s.invitations {
+		if invitation.TenantID == tenantID {
+			delete(s.invitations, id)
+			s.removed("invitations", id)
+		}
+	}
+
+	for id := range s.roles {
+		if id.tenantID == tenantID {
+			delete(s.roles, id)
+			s.removed("roles", id.tenantID+"/"+id.name)
+		}
+	}
+
+	for id, grant := range s.accessGrants {
+		if grant.TenantID == tenantID {
+			delete(s.accessGrants, id)
+			s.removed("access_grants", id)
+		}
+	}
+
+	for id, request := range s.accessRequests {
+		if request.TenantID == tenantID {
+			delete(s.accessRequests, id)
+			s.removed("access_requests", id)
+		}
+	}
+
+	delete(s.authorities, tenantID)
+
+	if owner, ok := s.users[ns.Owner]; ok {
+		owner.Namespaces--
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceRename(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
+	s.mu.Lock()
+	if ns, ok := s.namespaces[tenantID]; ok {
+		ns.Name = name
+	}
+	s.mu.Unlock()
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceUpdate(_ context.Context, tenantID string, namespace *models.Namespace) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ns, ok := s.namespaces[tenantID]
+	if !ok {
+		return nil
+	}
+
+	ns.Name = namespace.Name
+	ns.MaxDevices = namespace.MaxDevices
+
+	if ns.Settings == nil {
+		ns.Settings = new(models.NamespaceSettings)
+	}
+
+	ns.Settings.SessionRecord = namespace.Settings != nil && namespace.Settings.SessionRecord
+
+	return nil
+}
+
+func (s *Store) NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error) {
+	s.mu.Lock()
+	if ns, ok := s.namespaces[tenantID]; ok {
+		if hasMember(ns, memberID) {
+			s.mu.Unlock()
+
+			return nil, ErrNamespaceDuplicatedMember
+		}
+
+		ns.Members = append(ns.Members, models.Member{ID: memberID, Role: memberRole})
+	}
+	s.mu.Unlock()
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) (*models.Namespace, error) {
+	s.mu.Lock()
+	ns, ok := s.namespaces[tenantID]
+	if !ok || !hasMember(ns, memberID) {
+		s.mu.Unlock()
+
+		return nil, ErrUserNotFound
+	}
+
+	members := make([]models.Member, 0, len(ns.Members))
+	for _, member := range ns.Members {
+		if member.ID != memberID {
+			members = append(members, member)
+		}
+	}
+
+	ns.Members = members
+	s.mu.Unlock()
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceEditMember(_ context.Context, tenantID string, memberID string, memberNewRole string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		for i := range ns.Members {
+			if ns.Members[i].ID == memberID {
+				ns.Members[i].Role = memberNewRole
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetMemberScope(_ context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		for i := range ns.Members {
+			if ns.Members[i].ID == memberID {
+				ns.Members[i].Scope = cloneMemberScope(scope)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceGetFirst(_ context.Context, id string) (*models.Namespace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var first *models.Namespace
+	for _, ns := range s.namespaces {
+		if hasMember(ns, id) && (first == nil || s.before("namespaces", ns.TenantID, first.TenantID)) {
+			first = ns
+		}
+	}
+
+	if first == nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneNamespace(first), nil
+}
+
+func (s *Store) NamespaceSetSessionRecord(_ context.Context, sessionRecord bool, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		if ns.Settings == nil {
+			ns.Settings = new(models.NamespaceSettings)
+		}
+
+		ns.Settings.SessionRecord = sessionRecord
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetRecordInput(_ context.Context, recordInput bool, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		if ns.Settings == nil {
+			ns.Settings = new(models.NamespaceSettings)
+		}
+
+		ns.Settings.RecordInput = recordInput
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetRequireMFA(_ context.Context, requireMFA bool, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		if ns.Settings == nil {
+			ns.Settings = new(models.NamespaceSettings)
+		}
+
+		ns.Settings.RequireMFA = requireMFA
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetApprovalTags(_ context.Context, tags []string, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ns, ok := s.namespaces[tenantID]; ok {
+		if ns.Settings == nil {
+			ns.Settings = new(models.NamespaceSettings)
+		}
+
+		ns.Settings.ApprovalTags = cloneStrings(tags)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceGetSessionRecord(_ context.Context, tenantID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ns, ok := s.namespaces[tenantID]
+	if !ok {
+		return false, store.ErrNoDocuments
+	}
+
+	return ns.Settings != nil && ns.Settings.SessionRecord, nil
+}
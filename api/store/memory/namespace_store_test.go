@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceCreate(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.Equal(t, store.ErrDuplicate, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, user.Namespaces)
+}
+
+func TestNamespaceGet(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = s.DeviceUpdateStatus(data.Context, models.UID(data.Device.UID), models.DeviceStatusAccepted)
+	assert.NoError(t, err)
+
+	ns, err := s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Namespace.Name, ns.Name)
+	assert.Equal(t, data.Namespace.Members, ns.Members)
+	assert.Equal(t, data.Namespace.Settings, ns.Settings)
+	assert.Equal(t, 1, ns.DevicesCount)
+
+	ns, err = s.NamespaceGetByName(data.Context, data.Namespace.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Namespace.TenantID, ns.TenantID)
+}
+
+func TestNamespaceList(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &models.Namespace{Name: "other", TenantID: "tenant", Owner: "owner"})
+	assert.NoError(t, err)
+
+	list, count, err := s.NamespaceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, list, 2)
+
+	list, count, err = s.NamespaceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "eq", Value: "other"}},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "tenant", list[0].TenantID)
+}
+
+func TestNamespaceMembers(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	ns, err := s.NamespaceAddMember(data.Context, data.Namespace.TenantID, "member", guard.RoleObserver)
+	assert.NoError(t, err)
+	assert.Len(t, ns.Members, 2)
+
+	_, err = s.NamespaceAddMember(data.Context, data.Namespace.TenantID, "member", guard.RoleObserver)
+	assert.Equal(t, ErrNamespaceDuplicatedMember, err)
+
+	err = s.NamespaceEditMember(data.Context, data.Namespace.TenantID, "member", guard.RoleOperator)
+	assert.NoError(t, err)
+
+	ns, err = s.NamespaceGetFirst(data.Context, "member")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Member{ID: "member", Role: guard.RoleOperator}, ns.Members[1])
+
+	scope := &models.MemberScope{Tags: []string{"customer-a"}, Groups: []string{"group"}}
+	assert.NoError(t, s.NamespaceSetMemberScope(data.Context, data.Namespace.TenantID, "member", scope))
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, scope, ns.Members[1].Scope)
+
+	assert.NoError(t, s.NamespaceSetMemberScope(data.Context, data.Namespace.TenantID, "member", nil))
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Members[1].Scope)
+
+	ns, err = s.NamespaceRemoveMember(data.Context, data.Namespace.TenantID, "member")
+	assert.NoError(t, err)
+	assert.Len(t, ns.Members, 1)
+
+	_, err = s.NamespaceRemoveMember(data.Context, data.Namespace.TenantID, "member")
+	assert.Equal(t, ErrUserNotFound, err)
+}
+
+func TestNamespaceDelete(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = s.NamespaceDelete(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.DeviceGetByUID(data.Context, models.UID(data.Device.UID), data.Namespace.TenantID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, user.Namespaces)
+}
+
+func TestNamespaceSessionRecord(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.NamespaceSetSessionRecord(data.Context, false, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	record, err := s.NamespaceGetSessionRecord(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.False(t, record)
+
+	err = s.NamespaceSetRecordInput(data.Context, true, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	ns, err := s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.True(t, ns.Settings.RecordInput)
+
+	err = s.NamespaceSetRequireMFA(data.Context, true, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.True(t, ns.Settings.RequireMFA)
+
+	err = s.NamespaceSetApprovalTags(data.Context, []string{"production"}, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"production"}, ns.Settings.ApprovalTags)
+
+	err = s.NamespaceSetApprovalTags(data.Context, nil, data.Namespace.TenantID)
+	assert.NoError(t, err)
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Empty(t, ns.Settings.ApprovalTags)
+}
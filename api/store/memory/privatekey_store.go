@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) PrivateKeyCreate(_ context.Context, key *models.PrivateKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current, ok := s.privateKeys[key.Fingerprint]; ok && current.CreatedAt.After(clock.Now().Add(-privateKeyTTL)) {
+		return store.ErrDuplicate
+	}
+
+	clone := *key
+	clone.Data = append([]byte{}, key.Data...)
+	s.privateKeys[key.Fingerprint] = &clone
+
+	return nil
+}
+
+func (s *Store) PrivateKeyGet(_ context.Context, fingerprint string) (*models.PrivateKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.privateKeys[fingerprint]
+	if !ok {
+		for _, current := range s.privateKeys {
+			if current.LegacyFingerprint == fingerprint {
+				key, ok = current, true
+
+				break
+			}
+		}
+	}
+
+	if !ok || !key.CreatedAt.After(clock.Now().Add(-privateKeyTTL)) {
+		return nil, store.ErrNoDocuments
+	}
+
+	clone := *key
+	clone.Data = append([]byte{}, key.Data...)
+
+	return &clone, nil
+}
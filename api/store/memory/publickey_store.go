@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) PublicKeyGet(_ context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.publicKeys[publicKeyID{tenantID: tenantID, fingerprint: fingerprint}]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	return clonePublicKey(key), nil
+}
+
+func (s *Store) PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := gateway.TenantFromContext(ctx)
+
+	list := make([]*models.PublicKey, 0)
+	for _, key := range s.publicKeys {
+		// Only match for the respective tenant if requested
+		if tenant != nil && key.TenantID != tenant.ID {
+			continue
+		}
+
+		list = append(list, key)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("public_keys", list[i].TenantID+"/"+list[i].Fingerprint, list[j].TenantID+"/"+list[j].Fingerprint)
+		}
+
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	keys := make([]models.PublicKey, 0, end-start)
+	for _, key := range list[start:end] {
+		keys = append(keys, *clonePublicKey(key))
+	}
+
+	return keys, len(list), nil
+}
+
+func (s *Store) PublicKeyCreate(_ context.Context, key *models.PublicKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := publicKeyID{tenantID: key.TenantID, fingerprint: key.Fingerprint}
+	if _, ok := s.publicKeys[id]; ok {
+		return store.ErrDuplicate
+	}
+
+	s.publicKeys[id] = clonePublicKey(key)
+	s.inserted("public_keys", key.TenantID+"/"+key.Fingerprint)
+
+	return nil
+}
+
+func (s *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
+	s.mu.Lock()
+	if current, ok := s.publicKeys[publicKeyID{tenantID: tenantID, fingerprint: fingerprint}]; ok {
+		current.PublicKeyFields = key.PublicKeyFields
+		current.Filter.Tags = cloneStrings(key.Filter.Tags)
+	}
+	s.mu.Unlock()
+
+	return s.PublicKeyGet(ctx, fingerprint, tenantID)
+}
+
+func (s *Store) PublicKeyDelete(_ context.Context, fingerprint string, tenantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.publicKeys, publicKeyID{tenantID: tenantID, fingerprint: fingerprint})
+	s.removed("public_keys", tenantID+"/"+fingerprint)
+
+	return nil
+}
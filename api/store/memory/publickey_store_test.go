@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicKey(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	err := s.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.NoError(t, err)
+
+	err = s.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.Equal(t, store.ErrDuplicate, err)
+
+	key, err := s.PublicKeyGet(data.Context, data.PublicKey.Fingerprint, data.PublicKey.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, data.PublicKey.Data, key.Data)
+	assert.Equal(t, data.PublicKey.Filter, key.Filter)
+
+	key, err = s.PublicKeyUpdate(data.Context, data.PublicKey.Fingerprint, data.PublicKey.TenantID, &models.PublicKeyUpdate{
+		PublicKeyFields: models.PublicKeyFields{Name: "new", Filter: models.PublicKeyFilter{Tags: []string{"tag1"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "new", key.Name)
+	assert.Equal(t, models.PublicKeyFilter{Tags: []string{"tag1"}}, key.Filter)
+
+	keys, count, err := s.PublicKeyList(data.Context, paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, keys, 1)
+
+	err = s.PublicKeyDelete(data.Context, data.PublicKey.Fingerprint, data.PublicKey.TenantID)
+	assert.NoError(t, err)
+
+	_, err = s.PublicKeyGet(data.Context, data.PublicKey.Fingerprint, data.PublicKey.TenantID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestPublicKeyTags(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	data.PublicKey.Filter = models.PublicKeyFilter{Tags: []string{"tag1"}}
+
+	err := s.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.NoError(t, err)
+
+	err = s.PublicKeyAddTag(data.Context, data.PublicKey.TenantID, data.PublicKey.Fingerprint, "tag2")
+	assert.NoError(t, err)
+
+	err = s.PublicKeyAddTag(data.Context, data.PublicKey.TenantID, data.PublicKey.Fingerprint, "tag2")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	tags, count, err := s.PublicKeyGetTags(data.Context, data.PublicKey.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"tag1", "tag2"}, tags)
+
+	err = s.PublicKeyRenameTag(data.Context, data.PublicKey.TenantID, "tag1", "tag3")
+	assert.NoError(t, err)
+
+	err = s.PublicKeyRemoveTag(data.Context, data.PublicKey.TenantID, data.PublicKey.Fingerprint, "tag2")
+	assert.NoError(t, err)
+
+	err = s.PublicKeyUpdateTags(data.Context, data.PublicKey.TenantID, data.PublicKey.Fingerprint, []string{"tag3"})
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	err = s.PublicKeyDeleteTag(data.Context, data.PublicKey.TenantID, "tag3")
+	assert.NoError(t, err)
+
+	tags, _, err = s.PublicKeyGetTags(data.Context, data.PublicKey.TenantID)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// PublicKeyAddTag adds a tag to the tag's list in models.PublicKey.
+//
+// To add a tag to a models.PublicKey, that tag needs to exist on a models.Device. If it is not, the tag addition to
+// PublicKey will fail.
+func (s *Store) PublicKeyAddTag(_ context.Context, tenant, fingerprint, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.publicKeys[publicKeyID{tenantID: tenant, fingerprint: fingerprint}]
+	if !ok || hasString(key.Filter.Tags, tag) {
+		return store.ErrNoDocuments
+	}
+
+	key.Filter.Tags = append(key.Filter.Tags, tag)
+
+	return nil
+}
+
+// PublicKeyRemoveTag removes a tag to the tag's list in models.PublicKey.
+//
+// To remove a tag from a models.PublicKey, that tag needs to exist on a models.Device. If it is not, the tag deletion from
+// PublicKey will fail.
+func (s *Store) PublicKeyRemoveTag(_ context.Context, tenant, fingerprint, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.publicKeys[publicKeyID{tenantID: tenant, fingerprint: fingerprint}]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	var removed bool
+	if key.Filter.Tags, removed = removeString(key.Filter.Tags, tag); !removed {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// PublicKeyUpdateTags update with a new set the tag's list in models.PublicKey.
+//
+// To update models.PublicKey with a new set, all tags need to exist on a models.Device. If it is not true, the update
+// action will fail.
+func (s *Store) PublicKeyUpdateTags(_ context.Context, tenant, fingerprint string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.publicKeys[publicKeyID{tenantID: tenant, fingerprint: fingerprint}]
+	// Like in Mongo, when nothing is modified, the update is considered as not found.
+	if !ok || equal(key.Filter.Tags, tags) {
+		return store.ErrNoDocuments
+	}
+
+	key.Filter.Tags = cloneStrings(tags)
+
+	return nil
+}
+
+// PublicKeyRenameTag renames a tag to a new name.
+func (s *Store) PublicKeyRenameTag(_ context.Context, tenant, old, neo string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.publicKeyRenameTag(tenant, old, neo) {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) publicKeyRenameTag(tenant, old, neo string) bool {
+	renamed := false
+	for id, key := range s.publicKeys {
+		if id.tenantID == tenant && renameString(key.Filter.Tags, old, neo) {
+			renamed = true
+		}
+	}
+
+	return renamed
+}
+
+// PublicKeyDeleteTag remove a tag from all public keys.
+func (s *Store) PublicKeyDeleteTag(_ context.Context, tenant, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.publicKeyDeleteTag(tenant, name) {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) publicKeyDeleteTag(tenant, name string) bool {
+	deleted := false
+	for id, key := range s.publicKeys {
+		if id.tenantID != tenant {
+			continue
+		}
+
+		var removed bool
+		if key.Filter.Tags, removed = removeString(key.Filter.Tags, name); removed {
+			deleted = true
+		}
+	}
+
+	return deleted
+}
+
+// PublicKeyGetTags gets all tags from public keys.
+func (s *Store) PublicKeyGetTags(_ context.Context, tenant string) ([]string, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := s.publicKeyTags(tenant)
+
+	return tags, len(tags), nil
+}
+
+func (s *Store) publicKeyTags(tenant string) []string {
+	tags := make([]string, 0)
+	for id, key := range s.publicKeys {
+		if id.tenantID != tenant {
+			continue
+		}
+
+		for _, tag := range key.Filter.Tags {
+			if !hasString(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	sort.Strings(tags)
+
+	return tags
+}
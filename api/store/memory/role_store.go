@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// cloneRole returns a copy of the role that does not share its permissions.
+func cloneRole(role *models.Role) *models.Role {
+	clone := *role
+	clone.Permissions = append([]string{}, role.Permissions...)
+
+	return &clone
+}
+
+func (s *Store) RoleCreate(_ context.Context, role *models.Role) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := roleID{tenantID: role.TenantID, name: role.Name}
+	if _, ok := s.roles[id]; ok {
+		return store.ErrDuplicate
+	}
+
+	s.roles[id] = cloneRole(role)
+	s.inserted("roles", role.TenantID+"/"+role.Name)
+
+	return nil
+}
+
+// RoleList returns the custom roles of a namespace, sorted by name, based on the given pagination.
+func (s *Store) RoleList(_ context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Role, 0)
+	for id, role := range s.roles {
+		if id.tenantID == tenant {
+			list = append(list, role)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	roles := make([]models.Role, 0, end-start)
+	for _, role := range list[start:end] {
+		roles = append(roles, *cloneRole(role))
+	}
+
+	return roles, len(list), nil
+}
+
+func (s *Store) RoleGet(_ context.Context, tenant, name string) (*models.Role, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	role, ok := s.roles[roleID{tenantID: tenant, name: name}]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneRole(role), nil
+}
+
+func (s *Store) RoleUpdate(_ context.Context, role *models.Role) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.roles[roleID{tenantID: role.TenantID, name: role.Name}]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	current.Description = role.Description
+	current.Permissions = append([]string{}, role.Permissions...)
+
+	return nil
+}
+
+func (s *Store) RoleDelete(_ context.Context, tenant, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := roleID{tenantID: tenant, name: name}
+	if _, ok := s.roles[id]; !ok {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.roles, id)
+	s.removed("roles", tenant+"/"+name)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole(t *testing.T) {
+	ctx := context.TODO()
+	s := NewStore()
+
+	now := clock.Now()
+
+	roles := []models.Role{
+		{TenantID: "tenant", Name: "support", Permissions: []string{"device:connect"}, CreatedAt: now},
+		{TenantID: "tenant", Name: "auditor", Permissions: []string{"session:play"}, CreatedAt: now},
+		{TenantID: "other", Name: "support", Permissions: []string{}, CreatedAt: now},
+	}
+
+	for i := range roles {
+		assert.NoError(t, s.RoleCreate(ctx, &roles[i]))
+	}
+
+	assert.Equal(t, store.ErrDuplicate, s.RoleCreate(ctx, &models.Role{TenantID: "tenant", Name: "support"}))
+
+	list, count, err := s.RoleList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "auditor", list[0].Name)
+	assert.Equal(t, "support", list[1].Name)
+
+	_, err = s.RoleGet(ctx, "other", "auditor")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	roles[0].Description = "Support team"
+	roles[0].Permissions = []string{"device:connect", "device:details"}
+	assert.NoError(t, s.RoleUpdate(ctx, &roles[0]))
+
+	role, err := s.RoleGet(ctx, "tenant", "support")
+	assert.NoError(t, err)
+	assert.Equal(t, "Support team", role.Description)
+	assert.Equal(t, []string{"device:connect", "device:details"}, role.Permissions)
+
+	assert.Equal(t, store.ErrNoDocuments, s.RoleUpdate(ctx, &models.Role{TenantID: "tenant", Name: "unknown"}))
+
+	assert.Equal(t, store.ErrNoDocuments, s.RoleDelete(ctx, "other", "auditor"))
+	assert.NoError(t, s.RoleDelete(ctx, "tenant", "support"))
+
+	_, err = s.RoleGet(ctx, "tenant", "support")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	role, err = s.RoleGet(ctx, "other", "support")
+	assert.NoError(t, err)
+	assert.Equal(t, "support", role.Name)
+}
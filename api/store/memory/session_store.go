@@ -0,0 +1,328 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// sessionView returns a copy of the session with its active status and its device.
+func (s *Store) sessionView(ctx context.Context, session *models.Session) (*models.Session, error) {
+	view := cloneSession(session)
+
+	lastSeen, ok := s.activeSessions[session.UID]
+	view.Active = ok && lastSeen.After(clock.Now().Add(-activeSessionTTL))
+
+	device, err := s.deviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, err
+	}
+
+	view.Device = device
+
+	return view, nil
+}
+
+func (s *Store) SessionList(ctx context.Context, pagination paginator.Query, devices []models.UID) ([]models.Session, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := gateway.TenantFromContext(ctx)
+
+	var allowed map[models.UID]bool
+	if devices != nil {
+		allowed = make(map[models.UID]bool, len(devices))
+		for _, uid := range devices {
+			allowed[uid] = true
+		}
+	}
+
+	list := make([]*models.Session, 0)
+	for _, session := range s.sessions {
+		// Only match for the respective tenant if requested
+		if tenant != nil && session.TenantID != tenant.ID {
+			continue
+		}
+
+		if allowed != nil && !allowed[session.DeviceUID] {
+			continue
+		}
+
+		list = append(list, session)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].StartedAt.Equal(list[j].StartedAt) {
+			return s.before("sessions", list[i].UID, list[j].UID)
+		}
+
+		return list[i].StartedAt.After(list[j].StartedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	sessions := make([]models.Session, 0, end-start)
+	for _, session := range list[start:end] {
+		view, err := s.sessionView(ctx, session)
+		if err != nil {
+			return sessions, len(list), err
+		}
+
+		sessions = append(sessions, *view)
+	}
+
+	return sessions, len(list), nil
+}
+
+func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[string(uid)]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil && session.TenantID != tenant.ID {
+		return nil, store.ErrNoDocuments
+	}
+
+	return s.sessionView(ctx, session)
+}
+
+func (s *Store) SessionListByGrant(_ context.Context, grant string) ([]models.Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Session, 0)
+	for _, session := range s.sessions {
+		if session.Grant == grant && !session.Closed {
+			list = append(list, session)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return s.before("sessions", list[i].UID, list[j].UID)
+	})
+
+	sessions := make([]models.Session, 0, len(list))
+	for _, session := range list {
+		sessions = append(sessions, *cloneSession(session))
+	}
+
+	return sessions, nil
+}
+
+func (s *Store) SessionSetAuthenticated(_ context.Context, uid models.UID, authenticated bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[string(uid)]; ok {
+		session.Authenticated = authenticated
+	}
+
+	return nil
+}
+
+func (s *Store) SessionSetRecorded(_ context.Context, uid models.UID, recorded bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[string(uid)]; ok {
+		session.Recorded = recorded
+	}
+
+	return nil
+}
+
+func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
+	session.StartedAt = clock.Now()
+	session.LastSeen = session.StartedAt
+	session.Recorded = false
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	device, err := s.deviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.TenantID = device.TenantID
+
+	if _, ok := s.sessions[session.UID]; ok {
+		return nil, store.ErrDuplicate
+	}
+
+	s.sessions[session.UID] = cloneSession(&session)
+	s.inserted("sessions", session.UID)
+	s.activeSessions[session.UID] = session.StartedAt
+
+	return &session, nil
+}
+
+func (s *Store) SessionSetLastSeen(_ context.Context, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[string(uid)]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	if session.Closed {
+		return nil
+	}
+
+	session.LastSeen = clock.Now()
+	s.activeSessions[session.UID] = session.LastSeen
+
+	return nil
+}
+
+func (s *Store) SessionDeleteActives(_ context.Context, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[string(uid)]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	session.LastSeen = clock.Now()
+	session.Closed = true
+	delete(s.activeSessions, session.UID)
+
+	return nil
+}
+
+func (s *Store) SessionCreateRecordFrame(_ context.Context, uid models.UID, recordSession *models.RecordedSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recordedSessions = append(s.recordedSessions, *recordSession)
+
+	if session, ok := s.sessions[string(uid)]; ok {
+		session.Recorded = true
+	}
+
+	return nil
+}
+
+func (s *Store) SessionUpdateDeviceUID(_ context.Context, oldUID models.UID, newUID models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		if session.DeviceUID == oldUID {
+			session.DeviceUID = newUID
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) SessionDeleteRecordFrame(_ context.Context, uid models.UID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frames := make([]models.RecordedSession, 0, len(s.recordedSessions))
+	for _, frame := range s.recordedSessions {
+		if frame.UID != uid {
+			frames = append(frames, frame)
+		}
+	}
+
+	s.recordedSessions = frames
+	delete(s.recordLines, uid)
+
+	return nil
+}
+
+func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tenant := gateway.TenantFromContext(ctx)
+
+	sessionRecord := make([]models.RecordedSession, 0)
+	for _, frame := range s.recordedSessions {
+		if frame.UID != uid {
+			continue
+		}
+
+		// Only match for the respective tenant if requested
+		if tenant != nil && frame.TenantID != tenant.ID {
+			continue
+		}
+
+		sessionRecord = append(sessionRecord, frame)
+	}
+
+	return sessionRecord, len(sessionRecord), nil
+}
+
+func (s *Store) SessionRecordIndex(_ context.Context, uid models.UID, lines []models.SessionRecordLine) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(lines) == 0 {
+		delete(s.recordLines, uid)
+
+		return nil
+	}
+
+	s.recordLines[uid] = append([]models.SessionRecordLine(nil), lines...)
+
+	return nil
+}
+
+func (s *Store) SessionRecordSearch(_ context.Context, tenant, query string, devices []models.UID, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	query = strings.ToLower(query)
+
+	var allowed map[models.UID]bool
+	if devices != nil {
+		allowed = make(map[models.UID]bool, len(devices))
+		for _, uid := range devices {
+			allowed[uid] = true
+		}
+	}
+
+	list := make([]models.SessionRecordLine, 0)
+	for _, lines := range s.recordLines {
+		for _, line := range lines {
+			if allowed != nil && !allowed[line.DeviceUID] {
+				continue
+			}
+
+			if line.TenantID == tenant && strings.Contains(strings.ToLower(line.Text), query) {
+				list = append(list, line)
+			}
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		switch {
+		case !list[i].StartedAt.Equal(list[j].StartedAt):
+			return list[i].StartedAt.After(list[j].StartedAt)
+		case list[i].UID != list[j].UID:
+			return list[i].UID < list[j].UID
+		default:
+			return list[i].Offset < list[j].Offset
+		}
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	return list[start:end], len(list), nil
+}
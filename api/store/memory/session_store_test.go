@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCreate(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	session, err := s.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+	assert.Equal(t, data.Device.TenantID, session.TenantID)
+
+	got, err := s.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.True(t, got.Active)
+	assert.Equal(t, data.Device.UID, got.Device.UID)
+
+	sessions, count, err := s.SessionList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, sessions, 1)
+
+	sessions, count, err = s.SessionList(data.Context, paginator.Query{Page: 1, PerPage: 10}, []models.UID{models.UID(data.Device.UID)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, sessions, 1)
+
+	sessions, count, err = s.SessionList(data.Context, paginator.Query{Page: 1, PerPage: 10}, []models.UID{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Len(t, sessions, 0)
+}
+
+func TestSessionDeleteActives(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = s.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	err = s.SessionDeleteActives(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+
+	// A closed session is not set as active again.
+	err = s.SessionSetLastSeen(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+
+	session, err := s.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.False(t, session.Active)
+}
+
+func TestSessionRecordFrame(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = s.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	err = s.SessionCreateRecordFrame(data.Context, models.UID(data.Session.UID), &data.RecordedSession)
+	assert.NoError(t, err)
+
+	session, err := s.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.True(t, session.Recorded)
+
+	frames, count, err := s.SessionGetRecordFrame(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, data.RecordedSession.Message, frames[0].Message)
+
+	err = s.SessionDeleteRecordFrame(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+
+	_, count, err = s.SessionGetRecordFrame(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSessionRecordSearch(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	startedAt := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	line := func(uid, tenant string, offset float64, text string) models.SessionRecordLine {
+		return models.SessionRecordLine{
+			UID:       models.UID(uid),
+			TenantID:  tenant,
+			DeviceUID: "device",
+			Username:  "root",
+			StartedAt: startedAt,
+			Type:      models.RecordedSessionTypeOutput,
+			Offset:    offset,
+			Text:      text,
+		}
+	}
+
+	err := s.SessionRecordIndex(data.Context, "first", []models.SessionRecordLine{
+		line("first", "tenant", 0, "root@device:~# ls"),
+		line("first", "tenant", 1.5, "root@device:~# RM -RF /tmp/100%_data"),
+	})
+	assert.NoError(t, err)
+
+	err = s.SessionRecordIndex(data.Context, "second", []models.SessionRecordLine{
+		line("second", "other", 0, "root@device:~# rm -rf /tmp/100%_data"),
+	})
+	assert.NoError(t, err)
+
+	query := paginator.Query{Page: 1, PerPage: 10}
+
+	lines, count, err := s.SessionRecordSearch(data.Context, "tenant", "rm -rf /tmp/100%_", nil, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "first", string(lines[0].UID))
+	assert.Equal(t, 1.5, lines[0].Offset)
+	assert.True(t, lines[0].StartedAt.Equal(startedAt))
+
+	// The wildcards of the query are matched literally.
+	_, count, err = s.SessionRecordSearch(data.Context, "tenant", "100%%", nil, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Indexing the session again replaces its lines.
+	err = s.SessionRecordIndex(data.Context, "first", []models.SessionRecordLine{
+		line("first", "tenant", 0, "root@device:~# ls"),
+	})
+	assert.NoError(t, err)
+
+	_, count, err = s.SessionRecordSearch(data.Context, "tenant", "rm -rf", nil, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	lines, count, err = s.SessionRecordSearch(data.Context, "tenant", "LS", nil, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "root@device:~# ls", lines[0].Text)
+
+	// The search is restricted to the sessions of the devices given.
+	_, count, err = s.SessionRecordSearch(data.Context, "tenant", "ls", []models.UID{"device"}, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, count, err = s.SessionRecordSearch(data.Context, "tenant", "ls", []models.UID{"other"}, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	_, count, err = s.SessionRecordSearch(data.Context, "tenant", "ls", []models.UID{}, query)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSessionListByGrant(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	granted := data.Session
+	granted.Grant = "grant"
+	_, err = s.SessionCreate(data.Context, granted)
+	assert.NoError(t, err)
+
+	other := data.Session
+	other.UID = "other"
+	_, err = s.SessionCreate(data.Context, other)
+	assert.NoError(t, err)
+
+	sessions, err := s.SessionListByGrant(data.Context, "grant")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, granted.UID, sessions[0].UID)
+	assert.Equal(t, "grant", sessions[0].Grant)
+
+	// The closed sessions are not listed.
+	err = s.SessionDeleteActives(data.Context, models.UID(granted.UID))
+	assert.NoError(t, err)
+
+	sessions, err = s.SessionListByGrant(data.Context, "grant")
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 0)
+}
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) GetStats(ctx context.Context) (*models.Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Only match for the respective tenant if requested
+	match := func(tenantID string) bool {
+		tenant := gateway.TenantFromContext(ctx)
+
+		return tenant == nil || tenant.ID == tenantID
+	}
+
+	now := clock.Now()
+	stats := new(models.Stats)
+
+	for _, connected := range s.connectedDevices {
+		if match(connected.tenantID) && connected.status == models.DeviceStatusAccepted && connected.lastSeen.After(now.Add(-connectedDeviceTTL)) {
+			stats.OnlineDevices++
+		}
+	}
+
+	for _, device := range s.devices {
+		if !match(device.TenantID) {
+			continue
+		}
+
+		switch device.Status {
+		case models.DeviceStatusAccepted:
+			stats.RegisteredDevices++
+		case models.DeviceStatusPending:
+			stats.PendingDevices++
+		case models.DeviceStatusRejected:
+			stats.RejectedDevices++
+		}
+	}
+
+	for uid, session := range s.sessions {
+		if lastSeen, ok := s.activeSessions[uid]; ok && match(session.TenantID) && lastSeen.After(now.Add(-activeSessionTTL)) {
+			stats.ActiveSessions++
+		}
+	}
+
+	return stats, nil
+}
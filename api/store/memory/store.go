@@ -0,0 +1,135 @@
+// Package memory implements a store.Store that keeps its data in memory.
+//
+// It has the same behavior of the database backed stores, but its data is lost when the process stops, so it is meant
+// for development and for testing the services without a database.
+package memory
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	ErrNamespaceDuplicatedMember = errors.New("this member is already in this namespace")
+	ErrUserNotFound              = errors.New("user not found")
+)
+
+// The expiration of the data that Mongo removes automatically through TTL indexes is evaluated when it is read.
+const (
+	connectedDeviceTTL = 120 * time.Second
+	activeSessionTTL   = 30 * time.Second
+	recoveryTokenTTL   = 24 * time.Hour
+	removedDeviceTTL   = 720 * time.Hour
+	privateKeyTTL      = 60 * time.Second
+)
+
+type connectedDevice struct {
+	tenantID string
+	status   models.DeviceStatus
+	lastSeen time.Time
+}
+
+type removedDevice struct {
+	tenantID string
+	uid      models.UID
+}
+
+type publicKeyID struct {
+	tenantID    string
+	fingerprint string
+}
+
+type roleID struct {
+	tenantID string
+	name     string
+}
+
+// Store is a thread-safe store.Store that keeps its data in memory.
+type Store struct {
+	mu sync.RWMutex
+
+	// sequence and order keep the insertion order of the documents, used to sort them when their sorting fields are
+	// equal.
+	sequence int64
+	order    map[string]int64
+
+	announcements    map[string]*models.Announcement
+	devices          map[models.UID]*models.Device
+	connectedDevices map[models.UID]connectedDevice
+	removedDevices   map[removedDevice]time.Time
+	sessions         map[string]*models.Session
+	activeSessions   map[string]time.Time
+	recordedSessions []models.RecordedSession
+	recordLines      map[models.UID][]models.SessionRecordLine
+	users            map[string]*models.User
+	recoveryTokens   []models.UserTokenRecover
+	namespaces       map[string]*models.Namespace
+	publicKeys       map[publicKeyID]*models.PublicKey
+	privateKeys      map[string]*models.PrivateKey
+	firewallRules    map[string]*models.FirewallRule
+	licenses         []models.License
+	auditLogs        []models.AuditLog
+	apiKeys          map[string]*models.APIKey
+	webhooks         map[string]*models.Webhook
+	deliveries       map[string]*models.WebhookDelivery
+	deviceGroups     map[string]*models.DeviceGroup
+	invitations      map[string]*models.Invitation
+	roles            map[roleID]*models.Role
+	accessGrants     map[string]*models.AccessGrant
+	accessRequests   map[string]*models.AccessRequest
+	authorities      map[string]*models.CertificateAuthority
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore creates an empty in-memory store.
+func NewStore() *Store {
+	return &Store{
+		order:            make(map[string]int64),
+		announcements:    make(map[string]*models.Announcement),
+		devices:          make(map[models.UID]*models.Device),
+		connectedDevices: make(map[models.UID]connectedDevice),
+		removedDevices:   make(map[removedDevice]time.Time),
+		sessions:         make(map[string]*models.Session),
+		activeSessions:   make(map[string]time.Time),
+		recordLines:      make(map[models.UID][]models.SessionRecordLine),
+		users:            make(map[string]*models.User),
+		namespaces:       make(map[string]*models.Namespace),
+		publicKeys:       make(map[publicKeyID]*models.PublicKey),
+		privateKeys:      make(map[string]*models.PrivateKey),
+		firewallRules:    make(map[string]*models.FirewallRule),
+		apiKeys:          make(map[string]*models.APIKey),
+		webhooks:         make(map[string]*models.Webhook),
+		deliveries:       make(map[string]*models.WebhookDelivery),
+		deviceGroups:     make(map[string]*models.DeviceGroup),
+		invitations:      make(map[string]*models.Invitation),
+		roles:            make(map[roleID]*models.Role),
+		accessGrants:     make(map[string]*models.AccessGrant),
+		accessRequests:   make(map[string]*models.AccessRequest),
+		authorities:      make(map[string]*models.CertificateAuthority),
+	}
+}
+
+// inserted records the insertion of a document, identified by its collection and key, when it was not recorded yet.
+func (s *Store) inserted(collection, key string) {
+	if _, ok := s.order[collection+"/"+key]; ok {
+		return
+	}
+
+	s.sequence++
+	s.order[collection+"/"+key] = s.sequence
+}
+
+// removed forgets the insertion of a document.
+func (s *Store) removed(collection, key string) {
+	delete(s.order, collection+"/"+key)
+}
+
+// before reports whether the document a was inserted before b.
+func (s *Store) before(collection, a, b string) bool {
+	return s.order[collection+"/"+a] < s.order[collection+"/"+b]
+}
@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type Data struct {
+	User            models.User
+	Namespace       models.Namespace
+	FirewallRule    models.FirewallRule
+	Device          models.Device
+	PublicKey       models.PublicKey
+	Session         models.Session
+	RecordedSession models.RecordedSession
+	Context         context.Context
+}
+
+func initData() Data {
+	identity := &models.DeviceIdentity{MAC: "mac"}
+	uid := sha256.Sum256(structhash.Dump(identity, 1))
+
+	return Data{
+		models.User{
+			UserData: models.UserData{
+				Name:     "user",
+				Username: "username",
+				Email:    "user@shellhub.io",
+			},
+			UserPassword: models.UserPassword{
+				Password: "password",
+			},
+			ID: "507f1f77bcf86cd799439011",
+		},
+		models.Namespace{
+			Name:     "namespace",
+			Owner:    "507f1f77bcf86cd799439011",
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			Members: []models.Member{
+				{
+					ID:   "507f1f77bcf86cd799439011",
+					Role: guard.RoleOwner,
+				},
+			},
+			MaxDevices: -1,
+			Settings:   &models.NamespaceSettings{SessionRecord: true},
+		},
+		models.FirewallRule{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			FirewallRuleFields: models.FirewallRuleFields{
+				Priority: 1,
+				Action:   "allow",
+				Active:   true,
+				SourceIP: ".*",
+				Username: ".*",
+				Filter: models.FirewallFilter{
+					Hostname: ".*",
+				},
+			},
+		},
+		models.Device{
+			UID:      hex.EncodeToString(uid[:]),
+			Identity: identity,
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			LastSeen: clock.Now(),
+		},
+		models.PublicKey{
+			Data:            []byte("teste"),
+			Fingerprint:     "fingerprint",
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			CreatedAt:       clock.Now(),
+			PublicKeyFields: models.PublicKeyFields{Name: "teste1", Filter: models.PublicKeyFilter{Hostname: ".*"}},
+		},
+		models.Session{
+			Username:      "username",
+			UID:           "uid",
+			TenantID:      "00000000-0000-4000-0000-000000000000",
+			DeviceUID:     models.UID(hex.EncodeToString(uid[:])),
+			IPAddress:     "0.0.0.0",
+			Authenticated: true,
+		},
+		models.RecordedSession{
+			UID:      models.UID("uid"),
+			Message:  "message",
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			Time:     clock.Now(),
+			Width:    0,
+			Height:   0,
+		},
+		context.TODO(),
+	}
+}
+
+func TestStoreGetStats(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "")
+	assert.NoError(t, err)
+
+	err = s.DeviceUpdateStatus(data.Context, models.UID(data.Device.UID), models.DeviceStatusAccepted)
+	assert.NoError(t, err)
+
+	_, err = s.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	stats, err := s.GetStats(data.Context)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Stats{
+		RegisteredDevices: 1,
+		OnlineDevices:     1,
+		ActiveSessions:    1,
+	}, stats)
+}
+
+func TestStoreConcurrency(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			assert.NoError(t, s.DeviceCreateTag(data.Context, models.UID(data.Device.UID), fmt.Sprintf("tag%d", i)))
+			assert.NoError(t, s.DeviceSetOnline(data.Context, models.UID(data.Device.UID), true))
+
+			_, _, err := s.DeviceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil, "", "", "", store.DeviceListModeDefault)
+			assert.NoError(t, err)
+		}(i)
+	}
+
+	wg.Wait()
+
+	device, err := s.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Len(t, device.Tags, 10)
+	assert.True(t, device.Online)
+}
+
+func TestStoreCopies(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	data.Namespace.Members[0].Role = "changed"
+
+	ns, err := s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, "changed", ns.Members[0].Role)
+
+	ns.Name = "changed"
+
+	ns, err = s.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, "namespace", ns.Name)
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"sort"
+)
+
+func (s *Store) TagsGet(_ context.Context, tenant string) ([]string, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	set := make(map[string]struct{})
+	for _, device := range s.devices {
+		if device.TenantID == tenant {
+			for _, tag := range device.Tags {
+				set[tag] = struct{}{}
+			}
+		}
+	}
+
+	for _, tag := range s.publicKeyTags(tenant) {
+		set[tag] = struct{}{}
+	}
+
+	for _, tag := range s.firewallRuleTags(tenant) {
+		set[tag] = struct{}{}
+	}
+
+	tags := make([]string, 0, len(set))
+	for tag := range set {
+		tags = append(tags, tag)
+	}
+
+	sort.Strings(tags)
+
+	return tags, len(tags), nil
+}
+
+func (s *Store) TagRename(_ context.Context, tenantID string, tag string, newTag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, device := range s.devices {
+		if device.TenantID == tenantID {
+			renameString(device.Tags, tag, newTag)
+		}
+	}
+
+	s.publicKeyRenameTag(tenantID, tag, newTag)
+	s.firewallRuleRenameTag(tenantID, tag, newTag)
+
+	return nil
+}
+
+func (s *Store) TagDelete(_ context.Context, tenantID string, tag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, device := range s.devices {
+		if device.TenantID == tenantID {
+			device.Tags, _ = removeString(device.Tags, tag)
+		}
+	}
+
+	s.publicKeyDeleteTag(tenantID, tag)
+	s.firewallRuleDeleteTag(tenantID, tag)
+
+	return nil
+}
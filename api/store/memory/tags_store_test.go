@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTags(t *testing.T) {
+	data := initData()
+	s := NewStore()
+
+	err := s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = s.DeviceCreateTag(data.Context, models.UID(data.Device.UID), "device")
+	assert.NoError(t, err)
+
+	data.PublicKey.Filter = models.PublicKeyFilter{Tags: []string{"device", "key"}}
+	err = s.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.NoError(t, err)
+
+	data.FirewallRule.Filter = models.FirewallFilter{Tags: []string{"rule"}}
+	err = s.FirewallRuleCreate(data.Context, &data.FirewallRule)
+	assert.NoError(t, err)
+
+	tags, count, err := s.TagsGet(data.Context, data.Device.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.ElementsMatch(t, []string{"device", "key", "rule"}, tags)
+
+	err = s.TagRename(data.Context, data.Device.TenantID, "device", "renamed")
+	assert.NoError(t, err)
+
+	d, err := s.DeviceGetByUID(data.Context, models.UID(data.Device.UID), data.Device.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"renamed"}, d.Tags)
+
+	err = s.TagDelete(data.Context, data.Device.TenantID, "renamed")
+	assert.NoError(t, err)
+
+	tags, _, err = s.TagsGet(data.Context, data.Device.TenantID)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"key", "rule"}, tags)
+}
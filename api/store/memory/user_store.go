@@ -0,0 +1,364 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// userProperties returns the properties of a models.User accepted by the UserList's filters.
+func (s *Store) userProperties(user *models.User) properties {
+	return func(name string) (interface{}, bool) {
+		switch name {
+		case "name":
+			return user.Name, true
+		case "username":
+			return user.Username, true
+		case "email":
+			return user.Email, true
+		case "confirmed":
+			return user.Confirmed, true
+		case "namespaces":
+			return s.userNamespaces(user.ID), true
+		case "max_namespaces":
+			return user.MaxNamespaces, true
+		case "email_marketing":
+			return user.EmailMarketing, true
+		}
+
+		return nil, false
+	}
+}
+
+// userNamespaces counts the namespaces owned by a user.
+func (s *Store) userNamespaces(id string) int {
+	count := 0
+	for _, ns := range s.namespaces {
+		if ns.Owner == id {
+			count++
+		}
+	}
+
+	return count
+}
+
+// userGetWhere gets the user that matches the condition.
+func (s *Store) userGetWhere(match func(user *models.User) bool) (*models.User, error) {
+	for _, user := range s.users {
+		if match(user) {
+			clone := *user
+
+			return &clone, nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) UserList(ctx context.Context, pagination paginator.Query, filters []models.Filter) ([]models.User, int, error) {
+	match, err := buildFilter(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ns *models.Namespace
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		if ns = s.namespaces[tenant.ID]; ns == nil {
+			return []models.User{}, 0, nil
+		}
+	}
+
+	list := make([]*models.User, 0)
+	for _, user := range s.users {
+		if ns != nil && !hasMember(ns, user.ID) {
+			continue
+		}
+
+		ok, err := match(s.userProperties(user))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if ok {
+			list = append(list, user)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("users", list[i].ID, list[j].ID)
+		}
+
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	start, end := 0, len(list)
+	if pagination.Page > 0 && pagination.PerPage > 0 {
+		start, end = paginate(len(list), pagination)
+	}
+
+	// The namespaces' counter is replaced by the number of namespaces owned by the user, like the Mongo's UserList.
+	users := make([]models.User, 0, end-start)
+	for _, user := range list[start:end] {
+		clone := *user
+		clone.Namespaces = s.userNamespaces(user.ID)
+
+		users = append(users, clone)
+	}
+
+	return users, len(list), nil
+}
+
+func (s *Store) UserCreate(_ context.Context, user *models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = newID()
+	}
+
+	for _, u := range s.users {
+		if u.ID == user.ID || u.Username == user.Username || u.Email == user.Email {
+			return store.ErrDuplicate
+		}
+	}
+
+	clone := *user
+	s.users[user.ID] = &clone
+	s.inserted("users", user.ID)
+
+	return nil
+}
+
+func (s *Store) UserGetByUsername(_ context.Context, username string) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userGetWhere(func(user *models.User) bool {
+		return user.Username == username
+	})
+}
+
+func (s *Store) UserGetByEmail(_ context.Context, email string) (*models.User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userGetWhere(func(user *models.User) bool {
+		return user.Email == email
+	})
+}
+
+func (s *Store) UserGetByID(_ context.Context, id string, ns bool) (*models.User, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil, 0, store.ErrNoDocuments
+	}
+
+	clone := *user
+	if !ns {
+		return &clone, 0, nil
+	}
+
+	return &clone, s.userNamespaces(id), nil
+}
+
+func (s *Store) UserUpdateData(_ context.Context, id string, data models.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[id]; ok {
+		user.Name = data.Name
+		user.Username = data.Username
+		user.Email = data.Email
+		user.LastLogin = data.LastLogin
+	}
+
+	return nil
+}
+
+func (s *Store) UserUpdateMFA(_ context.Context, id string, mfa models.UserMFA) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	mfa.RecoveryCodes = append([]string(nil), mfa.RecoveryCodes...)
+	mfa.LastCounter = user.MFA.LastCounter
+	user.MFA = mfa
+
+	return nil
+}
+
+func (s *Store) UserSetMFACounter(_ context.Context, id string, counter uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok || counter <= user.MFA.LastCounter {
+		return store.ErrNoDocuments
+	}
+
+	user.MFA.LastCounter = counter
+
+	return nil
+}
+
+func (s *Store) UserRemoveMFARecoveryCode(_ context.Context, id string, digest string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	for i, code := range user.MFA.RecoveryCodes {
+		if code == digest {
+			user.MFA.RecoveryCodes = append(append([]string{}, user.MFA.RecoveryCodes[:i]...), user.MFA.RecoveryCodes[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return store.ErrNoDocuments
+}
+
+func (s *Store) UserUpdatePassword(_ context.Context, newPassword string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	user.Password = newPassword
+
+	return nil
+}
+
+func (s *Store) UserUpdateFromAdmin(_ context.Context, name string, username string, email string, password string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	user.Name = name
+
+	if username != "" {
+		user.Username = username
+	}
+
+	if email != "" {
+		user.Email = email
+	}
+
+	if password != "" {
+		user.Password = password
+	}
+
+	return nil
+}
+
+func (s *Store) UserCreateToken(_ context.Context, token *models.UserTokenRecover) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.recoveryTokens = append(s.recoveryTokens, *token)
+
+	return nil
+}
+
+func (s *Store) UserGetToken(_ context.Context, id string) (*models.UserTokenRecover, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	limit := clock.Now().Add(-recoveryTokenTTL)
+	for _, token := range s.recoveryTokens {
+		if token.User == id && token.CreatedAt.After(limit) {
+			return &token, nil
+		}
+	}
+
+	return nil, store.ErrNoDocuments
+}
+
+func (s *Store) UserDeleteTokens(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := make([]models.UserTokenRecover, 0, len(s.recoveryTokens))
+	for _, token := range s.recoveryTokens {
+		if token.User != id {
+			tokens = append(tokens, token)
+		}
+	}
+
+	s.recoveryTokens = tokens
+
+	return nil
+}
+
+func (s *Store) UserUpdateAccountStatus(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[id]; ok {
+		user.Confirmed = true
+	}
+
+	return nil
+}
+
+func (s *Store) UserDelete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.users, id)
+	s.removed("users", id)
+
+	return nil
+}
+
+func (s *Store) UserDetachInfo(_ context.Context, id string) (map[string][]*models.Namespace, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ownerNamespaceList := make([]*models.Namespace, 0)
+	membersNamespaceList := make([]*models.Namespace, 0)
+
+	for _, ns := range s.namespaces {
+		if !hasMember(ns, id) {
+			continue
+		}
+
+		if ns.Owner != id {
+			membersNamespaceList = append(membersNamespaceList, cloneNamespace(ns))
+		} else {
+			ownerNamespaceList = append(ownerNamespaceList, cloneNamespace(ns))
+		}
+	}
+
+	namespacesMap := make(map[string][]*models.Namespace, 2)
+	namespacesMap["member"] = membersNamespaceList
+	namespacesMap["owner"] = ownerNamespaceList
+
+	return namespacesMap, nil
+}
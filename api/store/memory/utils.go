@@ -0,0 +1,228 @@
+package memory
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// The documents are copied when they are stored and when they are returned, so the callers can not change the store's
+// data without calling its methods.
+
+func cloneStrings(list []string) []string {
+	if list == nil {
+		return nil
+	}
+
+	return append([]string{}, list...)
+}
+
+func cloneAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+
+	clone := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		clone[key] = value
+	}
+
+	return clone
+}
+
+func cloneDevice(device *models.Device) *models.Device {
+	clone := *device
+
+	if device.Identity != nil {
+		identity := *device.Identity
+		clone.Identity = &identity
+	}
+
+	if device.Info != nil {
+		info := *device.Info
+		clone.Info = &info
+	}
+
+	if device.Position != nil {
+		position := *device.Position
+		clone.Position = &position
+	}
+
+	clone.Tags = cloneStrings(device.Tags)
+	clone.Attributes = cloneAttributes(device.Attributes)
+
+	return &clone
+}
+
+func cloneNamespace(namespace *models.Namespace) *models.Namespace {
+	clone := *namespace
+
+	if namespace.Members != nil {
+		clone.Members = append([]models.Member{}, namespace.Members...)
+		for i := range clone.Members {
+			clone.Members[i].Scope = cloneMemberScope(clone.Members[i].Scope)
+		}
+	}
+
+	if namespace.Settings != nil {
+		settings := *namespace.Settings
+		settings.ApprovalTags = cloneStrings(settings.ApprovalTags)
+		clone.Settings = &settings
+	}
+
+	if namespace.Billing != nil {
+		billing := *namespace.Billing
+		if billing.PaymentFailed != nil {
+			failed := *billing.PaymentFailed
+			billing.PaymentFailed = &failed
+		}
+
+		clone.Billing = &billing
+	}
+
+	return &clone
+}
+
+// cloneMemberScope clones a member's scope, returning nil when the scope is empty.
+func cloneMemberScope(scope *models.MemberScope) *models.MemberScope {
+	if scope.IsEmpty() {
+		return nil
+	}
+
+	return &models.MemberScope{Tags: cloneStrings(scope.Tags), Groups: cloneStrings(scope.Groups)}
+}
+
+func cloneSession(session *models.Session) *models.Session {
+	clone := *session
+	clone.Device = nil
+
+	return &clone
+}
+
+func clonePublicKey(key *models.PublicKey) *models.PublicKey {
+	clone := *key
+	clone.Data = append([]byte{}, key.Data...)
+	clone.Filter.Tags = cloneStrings(key.Filter.Tags)
+
+	return &clone
+}
+
+func cloneFirewallRule(rule *models.FirewallRule) *models.FirewallRule {
+	clone := *rule
+	clone.Filter.Tags = cloneStrings(rule.Filter.Tags)
+
+	return &clone
+}
+
+func cloneAuditLog(log *models.AuditLog) *models.AuditLog {
+	clone := *log
+	clone.Diff.Before = cloneMap(log.Diff.Before)
+	clone.Diff.After = cloneMap(log.Diff.After)
+
+	return &clone
+}
+
+func cloneAPIKey(key *models.APIKey) *models.APIKey {
+	clone := *key
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+
+	if key.LastUsedAt != nil {
+		lastUsedAt := *key.LastUsedAt
+		clone.LastUsedAt = &lastUsedAt
+	}
+
+	return &clone
+}
+
+func cloneWebhook(webhook *models.Webhook) *models.Webhook {
+	clone := *webhook
+	clone.Events = cloneStrings(webhook.Events)
+
+	return &clone
+}
+
+func cloneWebhookDelivery(delivery *models.WebhookDelivery) *models.WebhookDelivery {
+	clone := *delivery
+	if delivery.DeliveredAt != nil {
+		deliveredAt := *delivery.DeliveredAt
+		clone.DeliveredAt = &deliveredAt
+	}
+
+	return &clone
+}
+
+// cloneMap returns a shallow copy of a map, keeping it nil when it is nil.
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	clone := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		clone[key] = value
+	}
+
+	return clone
+}
+
+// newID generates a random identifier with the same format of a Mongo's ObjectID.
+func newID() string {
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// removeString removes all occurrences of value from list, reporting whether something was removed.
+func removeString(list []string, value string) ([]string, bool) {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if item != value {
+			result = append(result, item)
+		}
+	}
+
+	return result, len(result) != len(list)
+}
+
+// renameString replaces the occurrences of old by neo in list, reporting whether something was replaced.
+func renameString(list []string, old, neo string) bool {
+	renamed := false
+	for i, item := range list {
+		if item == old {
+			list[i] = neo
+			renamed = true
+		}
+	}
+
+	return renamed
+}
+
+func hasString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equal reports whether the lists have the same items in the same order.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
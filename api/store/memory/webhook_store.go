@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) WebhookCreate(_ context.Context, webhook *models.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook.ID = newID()
+
+	s.webhooks[webhook.ID] = cloneWebhook(webhook)
+	s.inserted("webhooks", webhook.ID)
+
+	return nil
+}
+
+// WebhookList returns the webhooks of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) WebhookList(_ context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Webhook, 0)
+	for _, webhook := range s.webhooks {
+		if webhook.TenantID == tenant {
+			list = append(list, webhook)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("webhooks", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	webhooks := make([]models.Webhook, 0, end-start)
+	for _, webhook := range list[start:end] {
+		webhooks = append(webhooks, *cloneWebhook(webhook))
+	}
+
+	return webhooks, len(list), nil
+}
+
+func (s *Store) WebhookListByEvent(_ context.Context, tenant, event string) ([]models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.Webhook, 0)
+	for _, webhook := range s.webhooks {
+		if webhook.TenantID == tenant && webhook.HasEvent(event) {
+			list = append(list, webhook)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return s.before("webhooks", list[i].ID, list[j].ID)
+	})
+
+	webhooks := make([]models.Webhook, 0, len(list))
+	for _, webhook := range list {
+		webhooks = append(webhooks, *cloneWebhook(webhook))
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookGet(_ context.Context, tenant, id string) (*models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok || webhook.TenantID != tenant {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneWebhook(webhook), nil
+}
+
+func (s *Store) WebhookDelete(_ context.Context, tenant, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhook, ok := s.webhooks[id]
+	if !ok || webhook.TenantID != tenant {
+		return store.ErrNoDocuments
+	}
+
+	delete(s.webhooks, id)
+	s.removed("webhooks", id)
+
+	for deliveryID, delivery := range s.deliveries {
+		if delivery.WebhookID == id {
+			delete(s.deliveries, deliveryID)
+			s.removed("webhook_deliveries", deliveryID)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryCreate(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery.ID = newID()
+
+	s.deliveries[delivery.ID] = cloneWebhookDelivery(delivery)
+	s.inserted("webhook_deliveries", delivery.ID)
+
+	return nil
+}
+
+// WebhookDeliveryList returns the deliveries of a namespace's webhook, from the newest to the oldest, based on the
+// given pagination.
+func (s *Store) WebhookDeliveryList(_ context.Context, tenant, webhookID string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := make([]*models.WebhookDelivery, 0)
+	for _, delivery := range s.deliveries {
+		if delivery.TenantID == tenant && delivery.WebhookID == webhookID {
+			list = append(list, delivery)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return s.before("webhook_deliveries", list[j].ID, list[i].ID)
+		}
+
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+
+	start, end := paginate(len(list), pagination)
+
+	deliveries := make([]models.WebhookDelivery, 0, end-start)
+	for _, delivery := range list[start:end] {
+		deliveries = append(deliveries, *cloneWebhookDelivery(delivery))
+	}
+
+	return deliveries, len(list), nil
+}
+
+func (s *Store) WebhookDeliveryGet(_ context.Context, id string) (*models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return nil, store.ErrNoDocuments
+	}
+
+	return cloneWebhookDelivery(delivery), nil
+}
+
+func (s *Store) WebhookDeliveryUpdate(_ context.Context, delivery *models.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.deliveries[delivery.ID]
+	if !ok {
+		return store.ErrNoDocuments
+	}
+
+	update := cloneWebhookDelivery(delivery)
+
+	current.Status = update.Status
+	current.StatusCode = update.StatusCode
+	current.Error = update.Error
+	current.Attempts = update.Attempts
+	current.DeliveredAt = update.DeliveredAt
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	ctx := context.TODO()
+	s := NewStore()
+
+	now := clock.Now()
+
+	webhooks := []models.Webhook{
+		{TenantID: "tenant", URL: "http://first", Secret: "secret", Events: []string{"device.online", "device.offline"}, CreatedAt: now},
+		{TenantID: "tenant", URL: "http://second", Secret: "secret", Events: []string{"device.offline"}, CreatedAt: now.Add(time.Second)},
+		{TenantID: "other", URL: "http://third", Secret: "secret", Events: []string{"device.online"}, CreatedAt: now},
+	}
+
+	for i := range webhooks {
+		assert.NoError(t, s.WebhookCreate(ctx, &webhooks[i]))
+		assert.NotEmpty(t, webhooks[i].ID)
+	}
+
+	list, count, err := s.WebhookList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "http://second", list[0].URL)
+	assert.Equal(t, []string{"device.online", "device.offline"}, list[1].Events)
+
+	subscribed, err := s.WebhookListByEvent(ctx, "tenant", "device.online")
+	assert.NoError(t, err)
+	assert.Len(t, subscribed, 1)
+	assert.Equal(t, webhooks[0].ID, subscribed[0].ID)
+
+	webhook, err := s.WebhookGet(ctx, "tenant", webhooks[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", webhook.Secret)
+	assert.Equal(t, webhooks[0].Events, webhook.Events)
+
+	_, err = s.WebhookGet(ctx, "other", webhooks[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	delivery := &models.WebhookDelivery{TenantID: "tenant", WebhookID: webhooks[0].ID, Event: "device.online", Payload: "{}", Status: models.WebhookDeliveryStatusPending, CreatedAt: now}
+	assert.NoError(t, s.WebhookDeliveryCreate(ctx, delivery))
+	assert.NotEmpty(t, delivery.ID)
+
+	delivery.Status = models.WebhookDeliveryStatusSucceeded
+	delivery.StatusCode = 200
+	delivery.Attempts = 1
+	delivery.DeliveredAt = &now
+	assert.NoError(t, s.WebhookDeliveryUpdate(ctx, delivery))
+
+	got, err := s.WebhookDeliveryGet(ctx, delivery.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryStatusSucceeded, got.Status)
+	assert.Equal(t, 200, got.StatusCode)
+	assert.Equal(t, 1, got.Attempts)
+	assert.NotNil(t, got.DeliveredAt)
+
+	deliveries, count, err := s.WebhookDeliveryList(ctx, "tenant", webhooks[0].ID, paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, delivery.ID, deliveries[0].ID)
+
+	assert.Equal(t, store.ErrNoDocuments, s.WebhookDelete(ctx, "other", webhooks[0].ID))
+	assert.NoError(t, s.WebhookDelete(ctx, "tenant", webhooks[0].ID))
+
+	_, err = s.WebhookDeliveryGet(ctx, delivery.ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
@@ -42,6 +42,27 @@ func (_m *Cache) Get(ctx context.Context, key string, value interface{}) error {
 	return r0
 }
 
+// Incr provides a mock function with given fields: ctx, key, ttl
+func (_m *Cache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Set provides a mock function with given fields: ctx, key, value, ttl
 func (_m *Cache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	ret := _m.Called(ctx, key, value, ttl)
@@ -22,6 +22,399 @@ type Store struct {
 	mock.Mock
 }
 
+// APIKeyCreate provides a mock function with given fields: ctx, key
+func (_m *Store) APIKeyCreate(ctx context.Context, key *models.APIKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) APIKeyDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) APIKeyGet(ctx context.Context, tenant string, id string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.APIKey, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.APIKey); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyGetByDigest provides a mock function with given fields: ctx, digest
+func (_m *Store) APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, digest)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.APIKey, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.APIKey); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.APIKey, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.APIKey); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// APIKeySetLastUsed provides a mock function with given fields: ctx, id, lastUsed
+func (_m *Store) APIKeySetLastUsed(ctx context.Context, id string, lastUsed time.Time) error {
+	ret := _m.Called(ctx, id, lastUsed)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastUsed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessGrantCreate provides a mock function with given fields: ctx, grant
+func (_m *Store) AccessGrantCreate(ctx context.Context, grant *models.AccessGrant) error {
+	ret := _m.Called(ctx, grant)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessGrant) error); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessGrantDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) AccessGrantDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessGrantGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) AccessGrantGet(ctx context.Context, tenant string, id string) (*models.AccessGrant, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.AccessGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AccessGrant, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessGrantList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) AccessGrantList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.AccessGrant
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.AccessGrant, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AccessGrantListActive provides a mock function with given fields: ctx, tenant, member, fingerprint
+func (_m *Store) AccessGrantListActive(ctx context.Context, tenant string, member string, fingerprint string) ([]models.AccessGrant, error) {
+	ret := _m.Called(ctx, tenant, member, fingerprint)
+
+	var r0 []models.AccessGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]models.AccessGrant, error)); ok {
+		return rf(ctx, tenant, member, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []models.AccessGrant); ok {
+		r0 = rf(ctx, tenant, member, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, member, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessGrantListExpired provides a mock function with given fields: ctx
+func (_m *Store) AccessGrantListExpired(ctx context.Context) ([]models.AccessGrant, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.AccessGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.AccessGrant, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.AccessGrant); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessGrantSetExpired provides a mock function with given fields: ctx, id
+func (_m *Store) AccessGrantSetExpired(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestCreate provides a mock function with given fields: ctx, _a1
+func (_m *Store) AccessRequestCreate(ctx context.Context, _a1 *models.AccessRequest) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestDecide provides a mock function with given fields: ctx, _a1
+func (_m *Store) AccessRequestDecide(ctx context.Context, _a1 *models.AccessRequest) error {
+	ret := _m.Called(ctx, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) AccessRequestGet(ctx context.Context, tenant string, id string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRequestGetPending provides a mock function with given fields: ctx, tenant, device, member, fingerprint
+func (_m *Store) AccessRequestGetPending(ctx context.Context, tenant string, device string, member string, fingerprint string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, tenant, device, member, fingerprint)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, tenant, device, member, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, device, member, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, device, member, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRequestList provides a mock function with given fields: ctx, tenant, status, pagination
+func (_m *Store) AccessRequestList(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	ret := _m.Called(ctx, tenant, status, pagination)
+
+	var r0 []models.AccessRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) ([]models.AccessRequest, int, error)); ok {
+		return rf(ctx, tenant, status, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) []models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, status, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.AccessRequestStatus, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, status, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AnnouncementCreate provides a mock function with given fields: ctx, announcement
 func (_m *Store) AnnouncementCreate(ctx context.Context, announcement *models.Announcement) error {
 	ret := _m.Called(ctx, announcement)
@@ -123,6 +516,93 @@ func (_m *Store) AnnouncementUpdate(ctx context.Context, announcement *models.An
 	return r0
 }
 
+// AuditCreate provides a mock function with given fields: ctx, log
+func (_m *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	ret := _m.Called(ctx, log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog) error); ok {
+		r0 = rf(ctx, log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditList provides a mock function with given fields: ctx, tenant, pagination, filters
+func (_m *Store) AuditList(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filters)
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, tenant, pagination, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter) []models.AuditLog); ok {
+		r0 = rf(ctx, tenant, pagination, filters)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, []models.Filter) int); ok {
+		r1 = rf(ctx, tenant, pagination, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, []models.Filter) error); ok {
+		r2 = rf(ctx, tenant, pagination, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CertificateAuthorityCreate provides a mock function with given fields: ctx, ca
+func (_m *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	ret := _m.Called(ctx, ca)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CertificateAuthority) error); ok {
+		r0 = rf(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateAuthorityGet provides a mock function with given fields: ctx, tenant
+func (_m *Store) CertificateAuthorityGet(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CertificateAuthority, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceChooser provides a mock function with given fields: ctx, tenantID, chosen
 func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	ret := _m.Called(ctx, tenantID, chosen)
@@ -274,13 +754,114 @@ func (_m *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID st
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string) error); ok {
-		r1 = rf(ctx, uid, tenantID)
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string) error); ok {
+		r1 = rf(ctx, uid, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupCreate provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) error); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) DeviceGroupList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceGroupUpdate provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupUpdate(ctx context.Context, group *models.DeviceGroup) error {
+	ret := _m.Called(ctx, group)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) error); ok {
+		r0 = rf(ctx, group)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // DeviceList provides a mock function with given fields: ctx, pagination, filters, status, sort, _a5, mode
@@ -368,6 +949,20 @@ func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname st
 	return r0, r1
 }
 
+// DeviceRemoveAttribute provides a mock function with given fields: ctx, uid, key
+func (_m *Store) DeviceRemoveAttribute(ctx context.Context, uid models.UID, key string) error {
+	ret := _m.Called(ctx, uid, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceRemoveTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Store) DeviceRemoveTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -474,6 +1069,34 @@ func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname stri
 	return r0
 }
 
+// DeviceSetAttribute provides a mock function with given fields: ctx, uid, key, value
+func (_m *Store) DeviceSetAttribute(ctx context.Context, uid models.UID, key string, value string) error {
+	ret := _m.Called(ctx, uid, key, value)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
+		r0 = rf(ctx, uid, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetHostKey provides a mock function with given fields: ctx, uid, hostKey
+func (_m *Store) DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error {
+	ret := _m.Called(ctx, uid, hostKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, hostKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, online
 func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -516,6 +1139,20 @@ func (_m *Store) DeviceUpdate(ctx context.Context, uid models.UID, name *string,
 	return r0
 }
 
+// DeviceUpdateAttributes provides a mock function with given fields: ctx, uid, attributes
+func (_m *Store) DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, uid, attributes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceUpdateLastSeen provides a mock function with given fields: ctx, uid, ts
 func (_m *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
 	ret := _m.Called(ctx, uid, ts)
@@ -720,6 +1357,32 @@ func (_m *Store) FirewallRuleList(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
+// FirewallRuleListActive provides a mock function with given fields: ctx, tenant
+func (_m *Store) FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.FirewallRule, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.FirewallRule); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FirewallRuleRemoveTag provides a mock function with given fields: ctx, id, tag
 func (_m *Store) FirewallRuleRemoveTag(ctx context.Context, id string, tag string) error {
 	ret := _m.Called(ctx, id, tag)
@@ -814,6 +1477,145 @@ func (_m *Store) GetStats(ctx context.Context) (*models.Stats, error) {
 	return r0, r1
 }
 
+// InvitationCreate provides a mock function with given fields: ctx, invitation
+func (_m *Store) InvitationCreate(ctx context.Context, invitation *models.Invitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Invitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) InvitationDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InvitationGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) InvitationGet(ctx context.Context, tenant string, id string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Invitation, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationGetByDigest provides a mock function with given fields: ctx, digest
+func (_m *Store) InvitationGetByDigest(ctx context.Context, digest string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, digest)
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Invitation, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Invitation); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationGetByEmail provides a mock function with given fields: ctx, tenant, email
+func (_m *Store) InvitationGetByEmail(ctx context.Context, tenant string, email string) (*models.Invitation, error) {
+	ret := _m.Called(ctx, tenant, email)
+
+	var r0 *models.Invitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Invitation, error)); ok {
+		return rf(ctx, tenant, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Invitation); ok {
+		r0 = rf(ctx, tenant, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvitationList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) InvitationList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Invitation
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Invitation, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Invitation); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Invitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // LicenseLoad provides a mock function with given fields: ctx
 func (_m *Store) LicenseLoad(ctx context.Context) (*models.License, error) {
 	ret := _m.Called(ctx)
@@ -1121,6 +1923,62 @@ func (_m *Store) NamespaceRename(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// NamespaceSetApprovalTags provides a mock function with given fields: ctx, tags, tenantID
+func (_m *Store) NamespaceSetApprovalTags(ctx context.Context, tags []string, tenantID string) error {
+	ret := _m.Called(ctx, tags, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string) error); ok {
+		r0 = rf(ctx, tags, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetMemberScope provides a mock function with given fields: ctx, tenantID, memberID, scope
+func (_m *Store) NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	ret := _m.Called(ctx, tenantID, memberID, scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.MemberScope) error); ok {
+		r0 = rf(ctx, tenantID, memberID, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetRecordInput provides a mock function with given fields: ctx, recordInput, tenantID
+func (_m *Store) NamespaceSetRecordInput(ctx context.Context, recordInput bool, tenantID string) error {
+	ret := _m.Called(ctx, recordInput, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, recordInput, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetRequireMFA provides a mock function with given fields: ctx, requireMFA, tenantID
+func (_m *Store) NamespaceSetRequireMFA(ctx context.Context, requireMFA bool, tenantID string) error {
+	ret := _m.Called(ctx, requireMFA, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, requireMFA, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -1356,8 +2214,76 @@ func (_m *Store) PublicKeyRenameTag(ctx context.Context, tenant string, old stri
 	ret := _m.Called(ctx, tenant, old, neo)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, old, neo)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, old, neo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublicKeyUpdate provides a mock function with given fields: ctx, fingerprint, tenantID, key
+func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint, tenantID, key)
+
+	var r0 *models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) (*models.PublicKey, error)); ok {
+		return rf(ctx, fingerprint, tenantID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) *models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint, tenantID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.PublicKeyUpdate) error); ok {
+		r1 = rf(ctx, fingerprint, tenantID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublicKeyUpdateTags provides a mock function with given fields: ctx, tenant, fingerprint, tags
+func (_m *Store) PublicKeyUpdateTags(ctx context.Context, tenant string, fingerprint string, tags []string) error {
+	ret := _m.Called(ctx, tenant, fingerprint, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleCreate provides a mock function with given fields: ctx, role
+func (_m *Store) RoleCreate(ctx context.Context, role *models.Role) error {
+	ret := _m.Called(ctx, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Role) error); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RoleDelete provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) RoleDelete(ctx context.Context, tenant string, name string) error {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1365,25 +2291,25 @@ func (_m *Store) PublicKeyRenameTag(ctx context.Context, tenant string, old stri
 	return r0
 }
 
-// PublicKeyUpdate provides a mock function with given fields: ctx, fingerprint, tenantID, key
-func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
-	ret := _m.Called(ctx, fingerprint, tenantID, key)
+// RoleGet provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) RoleGet(ctx context.Context, tenant string, name string) (*models.Role, error) {
+	ret := _m.Called(ctx, tenant, name)
 
-	var r0 *models.PublicKey
+	var r0 *models.Role
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) (*models.PublicKey, error)); ok {
-		return rf(ctx, fingerprint, tenantID, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Role, error)); ok {
+		return rf(ctx, tenant, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.PublicKeyUpdate) *models.PublicKey); ok {
-		r0 = rf(ctx, fingerprint, tenantID, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Role); ok {
+		r0 = rf(ctx, tenant, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.PublicKey)
+			r0 = ret.Get(0).(*models.Role)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.PublicKeyUpdate) error); ok {
-		r1 = rf(ctx, fingerprint, tenantID, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1391,13 +2317,46 @@ func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
-// PublicKeyUpdateTags provides a mock function with given fields: ctx, tenant, fingerprint, tags
-func (_m *Store) PublicKeyUpdateTags(ctx context.Context, tenant string, fingerprint string, tags []string) error {
-	ret := _m.Called(ctx, tenant, fingerprint, tags)
+// RoleList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) RoleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Role
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Role, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Role); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RoleUpdate provides a mock function with given fields: ctx, role
+func (_m *Store) RoleUpdate(ctx context.Context, role *models.Role) error {
+	ret := _m.Called(ctx, role)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
-		r0 = rf(ctx, tenant, fingerprint, tags)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Role) error); ok {
+		r0 = rf(ctx, role)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1532,32 +2491,105 @@ func (_m *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]m
 	return r0, r1, r2
 }
 
-// SessionList provides a mock function with given fields: ctx, pagination
-func (_m *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, pagination)
+// SessionList provides a mock function with given fields: ctx, pagination, devices
+func (_m *Store) SessionList(ctx context.Context, pagination paginator.Query, devices []models.UID) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, pagination, devices)
 
 	var r0 []models.Session
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.Session, int, error)); ok {
-		return rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.UID) ([]models.Session, int, error)); ok {
+		return rf(ctx, pagination, devices)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
-		r0 = rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.UID) []models.Session); ok {
+		r0 = rf(ctx, pagination, devices)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.UID) int); ok {
+		r1 = rf(ctx, pagination, devices)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.UID) error); ok {
+		r2 = rf(ctx, pagination, devices)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SessionListByGrant provides a mock function with given fields: ctx, grant
+func (_m *Store) SessionListByGrant(ctx context.Context, grant string) ([]models.Session, error) {
+	ret := _m.Called(ctx, grant)
+
+	var r0 []models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Session, error)); ok {
+		return rf(ctx, grant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Session); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, grant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SessionRecordIndex provides a mock function with given fields: ctx, uid, lines
+func (_m *Store) SessionRecordIndex(ctx context.Context, uid models.UID, lines []models.SessionRecordLine) error {
+	ret := _m.Called(ctx, uid, lines)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []models.SessionRecordLine) error); ok {
+		r0 = rf(ctx, uid, lines)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionRecordSearch provides a mock function with given fields: ctx, tenant, query, devices, pagination
+func (_m *Store) SessionRecordSearch(ctx context.Context, tenant string, query string, devices []models.UID, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	ret := _m.Called(ctx, tenant, query, devices, pagination)
+
+	var r0 []models.SessionRecordLine
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []models.UID, paginator.Query) ([]models.SessionRecordLine, int, error)); ok {
+		return rf(ctx, tenant, query, devices, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []models.UID, paginator.Query) []models.SessionRecordLine); ok {
+		r0 = rf(ctx, tenant, query, devices, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SessionRecordLine)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []models.UID, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, query, devices, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, []models.UID, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, query, devices, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1908,6 +2940,34 @@ func (_m *Store) UserList(ctx context.Context, pagination paginator.Query, filte
 	return r0, r1, r2
 }
 
+// UserRemoveMFARecoveryCode provides a mock function with given fields: ctx, id, digest
+func (_m *Store) UserRemoveMFARecoveryCode(ctx context.Context, id string, digest string) error {
+	ret := _m.Called(ctx, id, digest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSetMFACounter provides a mock function with given fields: ctx, id, counter
+func (_m *Store) UserSetMFACounter(ctx context.Context, id string, counter uint64) error {
+	ret := _m.Called(ctx, id, counter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = rf(ctx, id, counter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserUpdateAccountStatus provides a mock function with given fields: ctx, id
 func (_m *Store) UserUpdateAccountStatus(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -1950,6 +3010,20 @@ func (_m *Store) UserUpdateFromAdmin(ctx context.Context, name string, username
 	return r0
 }
 
+// UserUpdateMFA provides a mock function with given fields: ctx, id, mfa
+func (_m *Store) UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error {
+	ret := _m.Called(ctx, id, mfa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserMFA) error); ok {
+		r0 = rf(ctx, id, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserUpdatePassword provides a mock function with given fields: ctx, newPassword, id
 func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id string) error {
 	ret := _m.Called(ctx, newPassword, id)
@@ -1964,6 +3038,206 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 	return r0
 }
 
+// WebhookCreate provides a mock function with given fields: ctx, webhook
+func (_m *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryCreate provides a mock function with given fields: ctx, delivery
+func (_m *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryGet provides a mock function with given fields: ctx, id
+func (_m *Store) WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryList provides a mock function with given fields: ctx, tenant, webhookID, pagination
+func (_m *Store) WebhookDeliveryList(ctx context.Context, tenant string, webhookID string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, webhookID, pagination)
+
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, webhookID, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, webhookID, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, webhookID, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, webhookID, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookDeliveryUpdate provides a mock function with given fields: ctx, delivery
+func (_m *Store) WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Webhook
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookListByEvent provides a mock function with given fields: ctx, tenant, event
+func (_m *Store) WebhookListByEvent(ctx context.Context, tenant string, event string) ([]models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, event)
+
+	var r0 []models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.Webhook, error)); ok {
+		return rf(ctx, tenant, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewStore interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,170 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) AccessGrantCreate(ctx context.Context, grant *models.AccessGrant) error {
+	result, err := s.db.Collection("access_grants").InsertOne(ctx, grant)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		grant.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// AccessGrantList returns the access grants of a namespace, from the newest to the oldest, based on the given
+// pagination.
+func (s *Store) AccessGrantList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("access_grants"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	grants := make([]models.AccessGrant, 0)
+	cursor, err := s.db.Collection("access_grants").Aggregate(ctx, query)
+	if err != nil {
+		return grants, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		grant := new(models.AccessGrant)
+		if err := cursor.Decode(grant); err != nil {
+			return grants, count, FromMongoError(err)
+		}
+
+		grants = append(grants, *grant)
+	}
+
+	return grants, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) AccessGrantGet(ctx context.Context, tenant, id string) (*models.AccessGrant, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	grant := new(models.AccessGrant)
+	if err := s.db.Collection("access_grants").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(grant); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return grant, nil
+}
+
+// accessGrantFind returns the access grants matched by the filter, from the newest to the oldest.
+func (s *Store) accessGrantFind(ctx context.Context, filter bson.M) ([]models.AccessGrant, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := s.db.Collection("access_grants").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	grants := make([]models.AccessGrant, 0)
+	if err := cursor.All(ctx, &grants); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return grants, nil
+}
+
+func (s *Store) AccessGrantListActive(ctx context.Context, tenant, member, fingerprint string) ([]models.AccessGrant, error) {
+	subjects := make([]bson.M, 0, 2)
+	if member != "" {
+		subjects = append(subjects, bson.M{"member": member})
+	}
+
+	if fingerprint != "" {
+		subjects = append(subjects, bson.M{"fingerprint": fingerprint})
+	}
+
+	if len(subjects) == 0 {
+		return []models.AccessGrant{}, nil
+	}
+
+	return s.accessGrantFind(ctx, bson.M{
+		"tenant_id":  tenant,
+		"expired":    false,
+		"expires_at": bson.M{"$gt": clock.Now()},
+		"$or":        subjects,
+	})
+}
+
+func (s *Store) AccessGrantListExpired(ctx context.Context) ([]models.AccessGrant, error) {
+	return s.accessGrantFind(ctx, bson.M{
+		"expired":    false,
+		"expires_at": bson.M{"$lte": clock.Now()},
+	})
+}
+
+func (s *Store) AccessGrantSetExpired(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("access_grants").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"expired": true}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) AccessGrantDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("access_grants").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
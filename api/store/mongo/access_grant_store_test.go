@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessGrant(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := clock.Now().Truncate(time.Millisecond)
+
+	grants := []models.AccessGrant{
+		{TenantID: "tenant", Member: "member", Device: "uid", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+		{TenantID: "tenant", Fingerprint: "fingerprint", Tag: "production", CreatedAt: now.Add(-30 * time.Minute), ExpiresAt: now.Add(-time.Minute)},
+		{TenantID: "other", Member: "member", Device: "uid", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	}
+
+	for i := range grants {
+		assert.NoError(t, mongostore.AccessGrantCreate(ctx, &grants[i]))
+		assert.NotEmpty(t, grants[i].ID)
+	}
+
+	list, count, err := mongostore.AccessGrantList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, grants[1].ID, list[0].ID)
+	assert.Equal(t, grants[0].ID, list[1].ID)
+
+	active, err := mongostore.AccessGrantListActive(ctx, "tenant", "member", "")
+	assert.NoError(t, err)
+	assert.Len(t, active, 1)
+	assert.Equal(t, grants[0].ID, active[0].ID)
+
+	active, err = mongostore.AccessGrantListActive(ctx, "tenant", "", "fingerprint")
+	assert.NoError(t, err)
+	assert.Len(t, active, 0)
+
+	expired, err := mongostore.AccessGrantListExpired(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, grants[1].ID, expired[0].ID)
+
+	assert.NoError(t, mongostore.AccessGrantSetExpired(ctx, grants[1].ID))
+
+	expired, err = mongostore.AccessGrantListExpired(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 0)
+
+	_, err = mongostore.AccessGrantGet(ctx, "other", grants[0].ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	assert.EqualError(t, mongostore.AccessGrantDelete(ctx, "other", grants[0].ID), store.ErrNoDocuments.Error())
+	assert.NoError(t, mongostore.AccessGrantDelete(ctx, "tenant", grants[0].ID))
+
+	_, err = mongostore.AccessGrantGet(ctx, "tenant", grants[0].ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -0,0 +1,146 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error {
+	result, err := s.db.Collection("access_requests").InsertOne(ctx, request)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		request.ID = id.Hex()
+	}
+
+	return nil
+}
+
+func (s *Store) AccessRequestList(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	match := bson.M{"tenant_id": tenant}
+	if status != "" {
+		match["status"] = status
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("access_requests"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	requests := make([]models.AccessRequest, 0)
+	cursor, err := s.db.Collection("access_requests").Aggregate(ctx, query)
+	if err != nil {
+		return requests, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		request := new(models.AccessRequest)
+		if err := cursor.Decode(request); err != nil {
+			return requests, count, FromMongoError(err)
+		}
+
+		requests = append(requests, *request)
+	}
+
+	return requests, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) AccessRequestGet(ctx context.Context, tenant, id string) (*models.AccessRequest, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	request := new(models.AccessRequest)
+	if err := s.db.Collection("access_requests").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(request); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestGetPending(ctx context.Context, tenant, device, member, fingerprint string) (*models.AccessRequest, error) {
+	subjects := make([]bson.M, 0, 2)
+	if member != "" {
+		subjects = append(subjects, bson.M{"member": member})
+	}
+
+	if fingerprint != "" {
+		subjects = append(subjects, bson.M{"fingerprint": fingerprint})
+	}
+
+	if len(subjects) == 0 {
+		return nil, store.ErrNoDocuments
+	}
+
+	filter := bson.M{
+		"tenant_id": tenant,
+		"device":    device,
+		"status":    models.AccessRequestStatusPending,
+		"$or":       subjects,
+	}
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	request := new(models.AccessRequest)
+	if err := s.db.Collection("access_requests").FindOne(ctx, filter, opts).Decode(request); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestDecide(ctx context.Context, request *models.AccessRequest) error {
+	objID, err := primitive.ObjectIDFromHex(request.ID)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	filter := bson.M{"_id": objID, "tenant_id": request.TenantID, "status": models.AccessRequestStatusPending}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     request.Status,
+			"decided_by": request.DecidedBy,
+			"decided_at": request.DecidedAt,
+			"grant":      request.Grant,
+		},
+	}
+
+	result, err := s.db.Collection("access_requests").UpdateOne(ctx, filter, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) APIKeyCreate(ctx context.Context, key *models.APIKey) error {
+	result, err := s.db.Collection("api_keys").InsertOne(ctx, key)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		key.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// APIKeyList returns the API keys of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("api_keys"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	keys := make([]models.APIKey, 0)
+	cursor, err := s.db.Collection("api_keys").Aggregate(ctx, query)
+	if err != nil {
+		return keys, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		key := new(models.APIKey)
+		if err := cursor.Decode(key); err != nil {
+			return keys, count, FromMongoError(err)
+		}
+
+		keys = append(keys, *key)
+	}
+
+	return keys, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) APIKeyGet(ctx context.Context, tenant, id string) (*models.APIKey, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	key := new(models.APIKey)
+	if err := s.db.Collection("api_keys").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(key); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error) {
+	key := new(models.APIKey)
+	if err := s.db.Collection("api_keys").FindOne(ctx, bson.M{"digest": digest}).Decode(key); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeySetLastUsed(ctx context.Context, id string, lastUsed time.Time) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("api_keys").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"last_used_at": lastUsed}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeyDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("api_keys").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	key := &models.APIKey{TenantID: "tenant", UserID: "id", Name: "ci", Role: "operator", Digest: "digest", CreatedAt: clock.Now()}
+
+	err := mongostore.APIKeyCreate(ctx, key)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key.ID)
+
+	got, err := mongostore.APIKeyGet(ctx, "tenant", key.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci", got.Name)
+
+	_, err = mongostore.APIKeyGet(ctx, "other", key.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	got, err = mongostore.APIKeyGetByDigest(ctx, "digest")
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID, got.ID)
+}
+
+func TestAPIKeyList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	keys := []models.APIKey{
+		{TenantID: "tenant", Name: "first", Digest: "first", CreatedAt: clock.Now()},
+		{TenantID: "tenant", Name: "second", Digest: "second", CreatedAt: clock.Now().Add(1)},
+		{TenantID: "other", Name: "third", Digest: "third", CreatedAt: clock.Now()},
+	}
+
+	for i := range keys {
+		assert.NoError(t, mongostore.APIKeyCreate(ctx, &keys[i]))
+	}
+
+	list, count, err := mongostore.APIKeyList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "second", list[0].Name)
+	assert.Equal(t, "first", list[1].Name)
+}
+
+func TestAPIKeySetLastUsed(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	key := &models.APIKey{TenantID: "tenant", Name: "ci", Digest: "digest", CreatedAt: clock.Now()}
+	assert.NoError(t, mongostore.APIKeyCreate(ctx, key))
+
+	err := mongostore.APIKeySetLastUsed(ctx, key.ID, clock.Now())
+	assert.NoError(t, err)
+
+	got, err := mongostore.APIKeyGet(ctx, "tenant", key.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.LastUsedAt)
+}
+
+func TestAPIKeyDelete(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	key := &models.APIKey{TenantID: "tenant", Name: "ci", Digest: "digest", CreatedAt: clock.Now()}
+	assert.NoError(t, mongostore.APIKeyCreate(ctx, key))
+
+	err := mongostore.APIKeyDelete(ctx, "other", key.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.APIKeyDelete(ctx, "tenant", key.ID)
+	assert.NoError(t, err)
+
+	_, err = mongostore.APIKeyGet(ctx, "tenant", key.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
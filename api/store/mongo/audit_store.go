@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	result, err := s.db.Collection("audit_logs").InsertOne(ctx, log)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		log.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// AuditList returns the audit log's entries of a namespace, from the newest to the oldest, based on the given filters
+// and pagination.
+func (s *Store) AuditList(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	queryMatch, err := queries.BuildFilterQuery(filters)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	if len(queryMatch) > 0 {
+		query = append(query, queryMatch...)
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("audit_logs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	logs := make([]models.AuditLog, 0)
+	cursor, err := s.db.Collection("audit_logs").Aggregate(ctx, query)
+	if err != nil {
+		return logs, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		log := new(models.AuditLog)
+		if err := cursor.Decode(log); err != nil {
+			return logs, count, FromMongoError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	return logs, count, FromMongoError(cursor.Err())
+}
@@ -0,0 +1,69 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	log := &models.AuditLog{
+		TenantID:  "tenant",
+		Actor:     models.AuditActor{ID: "id", Username: "username", Role: "owner"},
+		Action:    models.AuditActionDeviceRename,
+		Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+		Diff:      models.AuditDiff{Before: map[string]interface{}{"name": "old"}, After: map[string]interface{}{"name": "new"}},
+		CreatedAt: clock.Now(),
+	}
+
+	err := mongostore.AuditCreate(context.TODO(), log)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, log.ID)
+}
+
+func TestAuditList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	logs := []models.AuditLog{
+		{TenantID: "tenant", Action: models.AuditActionDeviceRename, Target: models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"}, CreatedAt: clock.Now()},
+		{TenantID: "tenant", Action: models.AuditActionDeviceDelete, Target: models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"}, CreatedAt: clock.Now().Add(1)},
+		{TenantID: "other", Action: models.AuditActionDeviceDelete, Target: models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"}, CreatedAt: clock.Now()},
+	}
+
+	for i := range logs {
+		assert.NoError(t, mongostore.AuditCreate(ctx, &logs[i]))
+	}
+
+	list, count, err := mongostore.AuditList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, models.AuditActionDeviceDelete, list[0].Action)
+	assert.Equal(t, models.AuditActionDeviceRename, list[1].Action)
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "action", Operator: "eq", Value: models.AuditActionDeviceRename},
+		},
+	}
+
+	list, count, err = mongostore.AuditList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1}, filters)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, models.AuditActionDeviceRename, list[0].Action)
+}
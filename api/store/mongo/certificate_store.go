@@ -0,0 +1,23 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	_, err := s.db.Collection("certificate_authorities").InsertOne(ctx, ca)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) CertificateAuthorityGet(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	ca := new(models.CertificateAuthority)
+	if err := s.db.Collection("certificate_authorities").FindOne(ctx, bson.M{"tenant_id": tenant}).Decode(&ca); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return ca, nil
+}
@@ -0,0 +1,35 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.CertificateAuthorityGet(ctx, "tenant")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	ca := &models.CertificateAuthority{TenantID: "tenant", Data: []byte("private"), PublicKey: "public", CreatedAt: clock.Now()}
+	assert.NoError(t, mongostore.CertificateAuthorityCreate(ctx, ca))
+
+	got, err := mongostore.CertificateAuthorityGet(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("private"), got.Data)
+	assert.Equal(t, "public", got.PublicKey)
+
+	_, err = mongostore.CertificateAuthorityGet(ctx, "other")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
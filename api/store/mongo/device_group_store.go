@@ -0,0 +1,122 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	result, err := s.db.Collection("device_groups").InsertOne(ctx, group)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		group.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// DeviceGroupList returns the device groups of a namespace, sorted by name, based on the given pagination.
+func (s *Store) DeviceGroupList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_groups"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "name", Value: 1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	groups := make([]models.DeviceGroup, 0)
+	cursor, err := s.db.Collection("device_groups").Aggregate(ctx, query)
+	if err != nil {
+		return groups, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		group := new(models.DeviceGroup)
+		if err := cursor.Decode(group); err != nil {
+			return groups, count, FromMongoError(err)
+		}
+
+		groups = append(groups, *group)
+	}
+
+	return groups, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	group := new(models.DeviceGroup)
+	if err := s.db.Collection("device_groups").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(group); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupUpdate(ctx context.Context, group *models.DeviceGroup) error {
+	objID, err := primitive.ObjectIDFromHex(group.ID)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("device_groups").UpdateOne(ctx,
+		bson.M{"_id": objID, "tenant_id": group.TenantID},
+		bson.M{"$set": bson.M{"name": group.Name, "filter": group.Filter}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("device_groups").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -263,6 +263,24 @@ func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname stri
 		logrus.Error(err)
 	}
 
+	attributes := d.Attributes
+	d.Attributes = nil
+
+	data, err := bson.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	var set bson.M
+	if err := bson.Unmarshal(data, &set); err != nil {
+		return err
+	}
+
+	// The attributes are set one by one to keep the ones that were not sent.
+	for key, value := range attributes {
+		set["attributes."+key] = value
+	}
+
 	q := bson.M{
 		"$setOnInsert": bson.M{
 			"name":       hostname,
@@ -270,10 +288,10 @@ func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname stri
 			"created_at": clock.Now(),
 			"tags":       []string{},
 		},
-		"$set": d,
+		"$set": set,
 	}
 	opts := options.Update().SetUpsert(true)
-	_, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": d.UID}, q, opts)
+	_, err = s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": d.UID}, q, opts)
 
 	return FromMongoError(err)
 }
@@ -468,6 +486,23 @@ func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return err
 }
 
+func (s *Store) DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error {
+	result, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"host_key": hostKey}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	filter := bson.M{
 		"status":    "accepted",
@@ -0,0 +1,26 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceSetAttribute(ctx context.Context, uid models.UID, key, value string) error {
+	_, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"attributes." + key: value}})
+
+	return FromMongoError(err)
+}
+
+func (s *Store) DeviceRemoveAttribute(ctx context.Context, uid models.UID, key string) error {
+	_, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$unset": bson.M{"attributes." + key: ""}})
+
+	return FromMongoError(err)
+}
+
+func (s *Store) DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	_, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"attributes": attributes}})
+
+	return FromMongoError(err)
+}
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceAttributes(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	device := data.Device
+	device.Attributes = map[string]string{"site": "berlin", "rack": "12"}
+
+	err = mongostore.DeviceCreate(data.Context, device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceSetAttribute(data.Context, models.UID(device.UID), "owner", "ops")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceRemoveAttribute(data.Context, models.UID(device.UID), "rack")
+	assert.NoError(t, err)
+
+	// Registering the device again replaces only the attributes that were sent.
+	device.Attributes = map[string]string{"site": "paris"}
+
+	err = mongostore.DeviceCreate(data.Context, device, "hostname")
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGetByUID(data.Context, models.UID(device.UID), "00000000-0000-4000-0000-000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "paris", "owner": "ops"}, d.Attributes)
+
+	err = mongostore.DeviceUpdateAttributes(data.Context, models.UID(device.UID), map[string]string{"rack": "13"})
+	assert.NoError(t, err)
+
+	d, err = mongostore.DeviceGetByUID(data.Context, models.UID(device.UID), "00000000-0000-4000-0000-000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"rack": "13"}, d.Attributes)
+}
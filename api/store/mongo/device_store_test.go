@@ -62,6 +62,35 @@ func TestDeviceRename(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeviceSetHostKey(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceSetHostKey(data.Context, models.UID(data.Device.UID), "ssh-ed25519 key")
+	assert.NoError(t, err)
+
+	// Registering the device again keeps its pinned host key.
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh-ed25519 key", d.HostKey)
+
+	err = mongostore.DeviceSetHostKey(data.Context, models.UID("unknown"), "ssh-ed25519 key")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
 func TestDeviceLookup(t *testing.T) {
 	data := initData()
 
@@ -9,6 +9,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
@@ -58,15 +59,37 @@ func (s *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query
 	return rules, count, FromMongoError(err)
 }
 
+func (s *Store) FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}})
+
+	cursor, err := s.db.Collection("firewall_rules").Find(ctx, bson.M{"tenant_id": tenant, "active": true}, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	rules := make([]models.FirewallRule, 0)
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rules, nil
+}
+
 func (s *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRule) error {
 	if err := rule.Validate(); err != nil {
 		return FromMongoError(err)
 	}
 
-	if _, err := s.db.Collection("firewall_rules").InsertOne(ctx, &rule); err != nil {
+	result, err := s.db.Collection("firewall_rules").InsertOne(ctx, &rule)
+	if err != nil {
 		return FromMongoError(err)
 	}
 
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		rule.ID = id.Hex()
+	}
+
 	return nil
 }
 
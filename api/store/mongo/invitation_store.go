@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) InvitationCreate(ctx context.Context, invitation *models.Invitation) error {
+	result, err := s.db.Collection("invitations").InsertOne(ctx, invitation)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		invitation.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// InvitationList returns the invitations of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) InvitationList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("invitations"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	invitations := make([]models.Invitation, 0)
+	cursor, err := s.db.Collection("invitations").Aggregate(ctx, query)
+	if err != nil {
+		return invitations, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		invitation := new(models.Invitation)
+		if err := cursor.Decode(invitation); err != nil {
+			return invitations, count, FromMongoError(err)
+		}
+
+		invitations = append(invitations, *invitation)
+	}
+
+	return invitations, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) InvitationGet(ctx context.Context, tenant, id string) (*models.Invitation, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(invitation); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetByEmail(ctx context.Context, tenant, email string) (*models.Invitation, error) {
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"tenant_id": tenant, "email": email}).Decode(invitation); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetByDigest(ctx context.Context, digest string) (*models.Invitation, error) {
+	invitation := new(models.Invitation)
+	if err := s.db.Collection("invitations").FindOne(ctx, bson.M{"digest": digest}).Decode(invitation); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("invitations").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
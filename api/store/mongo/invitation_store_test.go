@@ -0,0 +1,88 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	invitation := &models.Invitation{TenantID: "tenant", Email: "john@example.com", Role: "operator", Digest: "digest", CreatedAt: clock.Now(), ExpiresAt: clock.Now().Add(time.Hour)}
+
+	err := mongostore.InvitationCreate(ctx, invitation)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, invitation.ID)
+
+	got, err := mongostore.InvitationGet(ctx, "tenant", invitation.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "john@example.com", got.Email)
+
+	_, err = mongostore.InvitationGet(ctx, "other", invitation.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	got, err = mongostore.InvitationGetByEmail(ctx, "tenant", "john@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, invitation.ID, got.ID)
+
+	got, err = mongostore.InvitationGetByDigest(ctx, "digest")
+	assert.NoError(t, err)
+	assert.Equal(t, invitation.ID, got.ID)
+}
+
+func TestInvitationList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	invitations := []models.Invitation{
+		{TenantID: "tenant", Email: "first@example.com", Digest: "first", CreatedAt: clock.Now()},
+		{TenantID: "tenant", Email: "second@example.com", Digest: "second", CreatedAt: clock.Now().Add(1)},
+		{TenantID: "other", Email: "first@example.com", Digest: "third", CreatedAt: clock.Now()},
+	}
+
+	for i := range invitations {
+		assert.NoError(t, mongostore.InvitationCreate(ctx, &invitations[i]))
+	}
+
+	list, count, err := mongostore.InvitationList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "second@example.com", list[0].Email)
+	assert.Equal(t, "first@example.com", list[1].Email)
+}
+
+func TestInvitationDelete(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	invitation := &models.Invitation{TenantID: "tenant", Email: "john@example.com", Digest: "digest", CreatedAt: clock.Now()}
+	assert.NoError(t, mongostore.InvitationCreate(ctx, invitation))
+
+	err := mongostore.InvitationDelete(ctx, "other", invitation.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.InvitationDelete(ctx, "tenant", invitation.ID)
+	assert.NoError(t, err)
+
+	_, err = mongostore.InvitationGet(ctx, "tenant", invitation.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration56 = migrate.Migration{
+	Version:     56,
+	Description: "create indexes on audit_logs for tenant_id and created_at, and created_at",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   56,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+		fieldCreatedAt := "created_at"
+
+		fieldNameTenantIDCreatedAt := "tenant_id_1_created_at_-1"
+		fieldNameCreatedAt := "created_at_1"
+		if _, err := db.Collection("audit_logs").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldTenantID, Value: 1},
+					bson.E{Key: fieldCreatedAt, Value: -1},
+				},
+				Options: &options.IndexOptions{
+					Name: &fieldNameTenantIDCreatedAt,
+				},
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldCreatedAt, Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Name: &fieldNameCreatedAt,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   56,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantIDCreatedAt := "tenant_id_1_created_at_-1"
+		fieldNameCreatedAt := "created_at_1"
+
+		if _, err := db.Collection("audit_logs").Indexes().DropOne(context.Background(), fieldNameTenantIDCreatedAt); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("audit_logs").Indexes().DropOne(context.Background(), fieldNameCreatedAt); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration56(t *testing.T) {
+	logrus.Info("Testing Migration 56")
+
+	fieldNameTenantIDCreatedAt := "tenant_id_1_created_at_-1"
+	fieldNameCreatedAt := "created_at_1"
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	cases := []struct {
+		description string
+		test        func() error
+	}{
+		{
+			"Success to apply up on migration 56",
+			func() error {
+				migrations := GenerateMigrations()[55:56]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				err := migrates.Up(migrate.AllAvailable)
+				if err != nil {
+					return err
+				}
+
+				cursor, err := db.Client().Database("test").Collection("audit_logs").Indexes().List(context.Background())
+				if err != nil {
+					return err
+				}
+
+				var foundNameTenantIDCreatedAt bool
+				var foundNameCreatedAt bool
+				for cursor.Next(context.Background()) {
+					var index bson.M
+					if err := cursor.Decode(&index); err != nil {
+						return err
+					}
+
+					switch index["name"] {
+					case fieldNameTenantIDCreatedAt:
+						foundNameTenantIDCreatedAt = true
+					case fieldNameCreatedAt:
+						foundNameCreatedAt = true
+					}
+				}
+
+				if !foundNameTenantIDCreatedAt || !foundNameCreatedAt {
+					return errors.New("one of the indexes was not created")
+				}
+
+				return nil
+			},
+		},
+		{
+			"Success to apply down on migration 56",
+			func() error {
+				migrations := GenerateMigrations()[55:56]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				err := migrates.Down(migrate.AllAvailable)
+				if err != nil {
+					return err
+				}
+
+				cursor, err := db.Client().Database("test").Collection("audit_logs").Indexes().List(context.Background())
+				if err != nil {
+					return errors.New("index not dropped")
+				}
+
+				var foundNameTenantIDCreatedAt bool
+				var foundNameCreatedAt bool
+				for cursor.Next(context.Background()) {
+					var index bson.M
+					if err := cursor.Decode(&index); err != nil {
+						return err
+					}
+
+					switch index["name"] {
+					case fieldNameTenantIDCreatedAt:
+						foundNameTenantIDCreatedAt = true
+					case fieldNameCreatedAt:
+						foundNameCreatedAt = true
+					}
+				}
+
+				if foundNameTenantIDCreatedAt || foundNameCreatedAt {
+					return errors.New("one of the indexes was deleted")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, test := range cases {
+		tc := test
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.test()
+			assert.NoError(t, err)
+		})
+	}
+}
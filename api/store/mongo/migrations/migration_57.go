@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration57 = migrate.Migration{
+	Version:     57,
+	Description: "create indexes on api_keys for digest, unique, and tenant_id",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   57,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldDigest := "digest"
+		fieldTenantID := "tenant_id"
+
+		fieldNameDigest := "digest_1"
+		fieldNameTenantID := "tenant_id_1"
+		unique := true
+		if _, err := db.Collection("api_keys").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldDigest, Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Name:   &fieldNameDigest,
+					Unique: &unique,
+				},
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldTenantID, Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Name: &fieldNameTenantID,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   57,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameDigest := "digest_1"
+		fieldNameTenantID := "tenant_id_1"
+
+		if _, err := db.Collection("api_keys").Indexes().DropOne(context.Background(), fieldNameDigest); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("api_keys").Indexes().DropOne(context.Background(), fieldNameTenantID); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration58 = migrate.Migration{
+	Version:     58,
+	Description: "create indexes on webhooks for tenant_id and on webhook_deliveries for webhook_id and created_at",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   58,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+		fieldWebhookID := "webhook_id"
+		fieldCreatedAt := "created_at"
+
+		fieldNameTenantID := "tenant_id_1"
+		if _, err := db.Collection("webhooks").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameTenantID,
+			},
+		}); err != nil {
+			return err
+		}
+
+		fieldNameWebhookIDCreatedAt := "webhook_id_1_created_at_-1"
+		if _, err := db.Collection("webhook_deliveries").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldWebhookID, Value: 1},
+				bson.E{Key: fieldCreatedAt, Value: -1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameWebhookIDCreatedAt,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   58,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantID := "tenant_id_1"
+		fieldNameWebhookIDCreatedAt := "webhook_id_1_created_at_-1"
+
+		if _, err := db.Collection("webhooks").Indexes().DropOne(context.Background(), fieldNameTenantID); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("webhook_deliveries").Indexes().DropOne(context.Background(), fieldNameWebhookIDCreatedAt); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
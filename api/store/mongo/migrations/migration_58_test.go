@@ -0,0 +1,108 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration58(t *testing.T) {
+	logrus.Info("Testing Migration 58")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	// hasIndex checks if an index exists in a collection.
+	hasIndex := func(collection, name string) (bool, error) {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(context.Background())
+		if err != nil {
+			return false, err
+		}
+
+		for cursor.Next(context.Background()) {
+			var index bson.M
+			if err := cursor.Decode(&index); err != nil {
+				return false, err
+			}
+
+			if index["name"] == name {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	cases := []struct {
+		description string
+		test        func() error
+	}{
+		{
+			"Success to apply up on migration 58",
+			func() error {
+				migrations := GenerateMigrations()[57:58]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Up(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				foundTenantID, err := hasIndex("webhooks", "tenant_id_1")
+				if err != nil {
+					return err
+				}
+
+				foundWebhookIDCreatedAt, err := hasIndex("webhook_deliveries", "webhook_id_1_created_at_-1")
+				if err != nil {
+					return err
+				}
+
+				if !foundTenantID || !foundWebhookIDCreatedAt {
+					return errors.New("one of the indexes was not created")
+				}
+
+				return nil
+			},
+		},
+		{
+			"Success to apply down on migration 58",
+			func() error {
+				migrations := GenerateMigrations()[57:58]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Down(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				foundTenantID, err := hasIndex("webhooks", "tenant_id_1")
+				if err != nil {
+					return err
+				}
+
+				foundWebhookIDCreatedAt, err := hasIndex("webhook_deliveries", "webhook_id_1_created_at_-1")
+				if err != nil {
+					return err
+				}
+
+				if foundTenantID || foundWebhookIDCreatedAt {
+					return errors.New("one of the indexes was not dropped")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, test := range cases {
+		tc := test
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.test()
+			assert.NoError(t, err)
+		})
+	}
+}
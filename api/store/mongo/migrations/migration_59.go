@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration59 = migrate.Migration{
+	Version:     59,
+	Description: "create indexes on session_record_lines for uid and for tenant_id and started_at",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   59,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldUID := "uid"
+		fieldTenantID := "tenant_id"
+		fieldStartedAt := "started_at"
+
+		fieldNameUID := "uid_1"
+		if _, err := db.Collection("session_record_lines").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldUID, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameUID,
+			},
+		}); err != nil {
+			return err
+		}
+
+		fieldNameTenantIDStartedAt := "tenant_id_1_started_at_-1"
+		if _, err := db.Collection("session_record_lines").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+				bson.E{Key: fieldStartedAt, Value: -1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameTenantIDStartedAt,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   59,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameUID := "uid_1"
+		fieldNameTenantIDStartedAt := "tenant_id_1_started_at_-1"
+
+		if _, err := db.Collection("session_record_lines").Indexes().DropOne(context.Background(), fieldNameUID); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("session_record_lines").Indexes().DropOne(context.Background(), fieldNameTenantIDStartedAt); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
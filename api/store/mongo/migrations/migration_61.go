@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration61 = migrate.Migration{
+	Version:     61,
+	Description: "create unique indexes on invitations for digest and for tenant_id and email",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   61,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldDigest := "digest"
+		fieldTenantID := "tenant_id"
+		fieldEmail := "email"
+
+		fieldNameDigest := "digest_1"
+		fieldNameTenantIDEmail := "tenant_id_1_email_1"
+		unique := true
+		if _, err := db.Collection("invitations").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldDigest, Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Name:   &fieldNameDigest,
+					Unique: &unique,
+				},
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: fieldTenantID, Value: 1},
+					bson.E{Key: fieldEmail, Value: 1},
+				},
+				Options: &options.IndexOptions{
+					Name:   &fieldNameTenantIDEmail,
+					Unique: &unique,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   61,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameDigest := "digest_1"
+		fieldNameTenantIDEmail := "tenant_id_1_email_1"
+
+		if _, err := db.Collection("invitations").Indexes().DropOne(context.Background(), fieldNameDigest); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("invitations").Indexes().DropOne(context.Background(), fieldNameTenantIDEmail); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
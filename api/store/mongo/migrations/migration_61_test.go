@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration61(t *testing.T) {
+	logrus.Info("Testing Migration 61")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	// hasIndex checks if an index exists in a collection.
+	hasIndex := func(collection, name string) (bool, error) {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(context.Background())
+		if err != nil {
+			return false, err
+		}
+
+		for cursor.Next(context.Background()) {
+			var index bson.M
+			if err := cursor.Decode(&index); err != nil {
+				return false, err
+			}
+
+			if index["name"] == name {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	cases := []struct {
+		description string
+		test        func() error
+	}{
+		{
+			"Success to apply up on migration 61",
+			func() error {
+				migrations := GenerateMigrations()[60:61]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Up(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				found, err := hasIndex("invitations", "tenant_id_1_email_1")
+				if err != nil {
+					return err
+				}
+
+				if !found {
+					return errors.New("the index was not created")
+				}
+
+				return nil
+			},
+		},
+		{
+			"Success to apply down on migration 61",
+			func() error {
+				migrations := GenerateMigrations()[60:61]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Down(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				found, err := hasIndex("invitations", "tenant_id_1_email_1")
+				if err != nil {
+					return err
+				}
+
+				if found {
+					return errors.New("the index was not dropped")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, test := range cases {
+		tc := test
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.test()
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration62 = migrate.Migration{
+	Version:     62,
+	Description: "create an unique index on roles for tenant_id and name",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   62,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+		fieldName := "name"
+
+		fieldNameTenantIDName := "tenant_id_1_name_1"
+		unique := true
+		if _, err := db.Collection("roles").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+				bson.E{Key: fieldName, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name:   &fieldNameTenantIDName,
+				Unique: &unique,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   62,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantIDName := "tenant_id_1_name_1"
+
+		if _, err := db.Collection("roles").Indexes().DropOne(context.Background(), fieldNameTenantIDName); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,90 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration63 = migrate.Migration{
+	Version:     63,
+	Description: "create indexes on access_grants for tenant_id and for expires_at, and on sessions for grant",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   63,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+		fieldExpiresAt := "expires_at"
+		fieldGrant := "grant"
+
+		fieldNameTenantID := "tenant_id_1"
+		if _, err := db.Collection("access_grants").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameTenantID,
+			},
+		}); err != nil {
+			return err
+		}
+
+		fieldNameExpiresAt := "expires_at_1"
+		if _, err := db.Collection("access_grants").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldExpiresAt, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameExpiresAt,
+			},
+		}); err != nil {
+			return err
+		}
+
+		fieldNameGrant := "grant_1"
+		sparse := true
+		if _, err := db.Collection("sessions").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldGrant, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name:   &fieldNameGrant,
+				Sparse: &sparse,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   63,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantID := "tenant_id_1"
+		fieldNameExpiresAt := "expires_at_1"
+		fieldNameGrant := "grant_1"
+
+		if _, err := db.Collection("access_grants").Indexes().DropOne(context.Background(), fieldNameTenantID); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("access_grants").Indexes().DropOne(context.Background(), fieldNameExpiresAt); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("sessions").Indexes().DropOne(context.Background(), fieldNameGrant); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration63(t *testing.T) {
+	logrus.Info("Testing Migration 63")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	// hasIndex checks if an index exists in a collection.
+	hasIndex := func(collection, name string) (bool, error) {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(context.Background())
+		if err != nil {
+			return false, err
+		}
+
+		for cursor.Next(context.Background()) {
+			var index bson.M
+			if err := cursor.Decode(&index); err != nil {
+				return false, err
+			}
+
+			if index["name"] == name {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	cases := []struct {
+		description string
+		test        func() error
+	}{
+		{
+			"Success to apply up on migration 63",
+			func() error {
+				migrations := GenerateMigrations()[62:63]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Up(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				foundTenantID, err := hasIndex("access_grants", "tenant_id_1")
+				if err != nil {
+					return err
+				}
+
+				foundExpiresAt, err := hasIndex("access_grants", "expires_at_1")
+				if err != nil {
+					return err
+				}
+
+				foundGrant, err := hasIndex("sessions", "grant_1")
+				if err != nil {
+					return err
+				}
+
+				if !foundTenantID || !foundExpiresAt || !foundGrant {
+					return errors.New("one of the indexes was not created")
+				}
+
+				return nil
+			},
+		},
+		{
+			"Success to apply down on migration 63",
+			func() error {
+				migrations := GenerateMigrations()[62:63]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				if err := migrates.Down(migrate.AllAvailable); err != nil {
+					return err
+				}
+
+				foundTenantID, err := hasIndex("access_grants", "tenant_id_1")
+				if err != nil {
+					return err
+				}
+
+				foundExpiresAt, err := hasIndex("access_grants", "expires_at_1")
+				if err != nil {
+					return err
+				}
+
+				foundGrant, err := hasIndex("sessions", "grant_1")
+				if err != nil {
+					return err
+				}
+
+				if foundTenantID || foundExpiresAt || foundGrant {
+					return errors.New("one of the indexes was not dropped")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, test := range cases {
+		tc := test
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.test()
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration64 = migrate.Migration{
+	Version:     64,
+	Description: "create indexes on access_requests for tenant_id and for device",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+		fieldDevice := "device"
+
+		fieldNameTenantID := "tenant_id_1"
+		if _, err := db.Collection("access_requests").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameTenantID,
+			},
+		}); err != nil {
+			return err
+		}
+
+		fieldNameDevice := "device_1"
+		if _, err := db.Collection("access_requests").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldDevice, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name: &fieldNameDevice,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantID := "tenant_id_1"
+		fieldNameDevice := "device_1"
+
+		if _, err := db.Collection("access_requests").Indexes().DropOne(context.Background(), fieldNameTenantID); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("access_requests").Indexes().DropOne(context.Background(), fieldNameDevice); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
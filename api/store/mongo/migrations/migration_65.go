@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration65 = migrate.Migration{
+	Version:     65,
+	Description: "create an unique index on certificate_authorities for tenant_id",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Applying migration")
+		fieldTenantID := "tenant_id"
+
+		fieldNameTenantID := "tenant_id_1"
+		unique := true
+		if _, err := db.Collection("certificate_authorities").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: fieldTenantID, Value: 1},
+			},
+			Options: &options.IndexOptions{
+				Name:   &fieldNameTenantID,
+				Unique: &unique,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Down",
+		}).Info("Applying migration")
+		fieldNameTenantID := "tenant_id_1"
+
+		if _, err := db.Collection("certificate_authorities").Indexes().DropOne(context.Background(), fieldNameTenantID); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
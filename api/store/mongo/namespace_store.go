@@ -216,7 +216,7 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 			logrus.Error(err)
 		}
 
-		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions"}
+		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "session_record_lines", "api_keys", "webhooks", "webhook_deliveries", "device_groups", "invitations", "roles", "access_grants", "access_requests", "certificate_authorities"}
 		for _, collection := range collections {
 			if _, err := s.db.Collection(collection).DeleteMany(sessCtx, bson.M{"tenant_id": tenantID}); err != nil {
 				return nil, FromMongoError(err)
@@ -311,6 +311,23 @@ func (s *Store) NamespaceEditMember(ctx context.Context, tenantID string, member
 	return nil
 }
 
+func (s *Store) NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	update := bson.M{"$unset": bson.M{"members.$.scope": ""}}
+	if !scope.IsEmpty() {
+		update = bson.M{"$set": bson.M{"members.$.scope": scope}}
+	}
+
+	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID, "members.id": memberID}, update); err != nil {
+		return FromMongoError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error) {
 	ns := new(models.Namespace)
 	if err := s.db.Collection("namespaces").FindOne(ctx, bson.M{"members": bson.M{"$elemMatch": bson.M{"id": id}}}).Decode(&ns); err != nil {
@@ -328,6 +345,43 @@ func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord boo
 	return nil
 }
 
+func (s *Store) NamespaceSetRecordInput(ctx context.Context, recordInput bool, tenantID string) error {
+	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"settings.record_input": recordInput}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetRequireMFA(ctx context.Context, requireMFA bool, tenantID string) error {
+	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"settings.require_mfa": requireMFA}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetApprovalTags(ctx context.Context, tags []string, tenantID string) error {
+	update := bson.M{"$set": bson.M{"settings.approval_tags": tags}}
+	if len(tags) == 0 {
+		update = bson.M{"$unset": bson.M{"settings.approval_tags": ""}}
+	}
+
+	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, update); err != nil {
+		return FromMongoError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
 	var settings struct {
 		Settings *models.NamespaceSettings `json:"settings" bson:"settings"`
@@ -207,6 +207,39 @@ func TestNamespaceRemoveMember(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNamespaceSetMemberScope(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	_, err = mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	_, err = mongostore.NamespaceAddMember(data.Context, "00000000-0000-4000-0000-000000000000", "507f1f77bcf86cd799439012", guard.RoleObserver)
+	assert.NoError(t, err)
+
+	scope := &models.MemberScope{Tags: []string{"customer-a"}}
+	err = mongostore.NamespaceSetMemberScope(data.Context, "00000000-0000-4000-0000-000000000000", "507f1f77bcf86cd799439012", scope)
+	assert.NoError(t, err)
+
+	ns, err := mongostore.NamespaceGet(data.Context, "00000000-0000-4000-0000-000000000000")
+	assert.NoError(t, err)
+	assert.Equal(t, scope, ns.Members[len(ns.Members)-1].Scope)
+
+	err = mongostore.NamespaceSetMemberScope(data.Context, "00000000-0000-4000-0000-000000000000", "507f1f77bcf86cd799439012", nil)
+	assert.NoError(t, err)
+
+	ns, err = mongostore.NamespaceGet(data.Context, "00000000-0000-4000-0000-000000000000")
+	assert.NoError(t, err)
+	assert.Nil(t, ns.Members[len(ns.Members)-1].Scope)
+}
+
 func TestNamespaceGetByName(t *testing.T) {
 	data := initData()
 
@@ -0,0 +1,101 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) RoleCreate(ctx context.Context, role *models.Role) error {
+	if _, err := s.db.Collection("roles").InsertOne(ctx, role); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+// RoleList returns the custom roles of a namespace, sorted by name, based on the given pagination.
+func (s *Store) RoleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("roles"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "name", Value: 1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	roles := make([]models.Role, 0)
+	cursor, err := s.db.Collection("roles").Aggregate(ctx, query)
+	if err != nil {
+		return roles, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		role := new(models.Role)
+		if err := cursor.Decode(role); err != nil {
+			return roles, count, FromMongoError(err)
+		}
+
+		roles = append(roles, *role)
+	}
+
+	return roles, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) RoleGet(ctx context.Context, tenant, name string) (*models.Role, error) {
+	role := new(models.Role)
+	if err := s.db.Collection("roles").FindOne(ctx, bson.M{"tenant_id": tenant, "name": name}).Decode(role); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return role, nil
+}
+
+func (s *Store) RoleUpdate(ctx context.Context, role *models.Role) error {
+	result, err := s.db.Collection("roles").UpdateOne(ctx,
+		bson.M{"tenant_id": role.TenantID, "name": role.Name},
+		bson.M{"$set": bson.M{"description": role.Description, "permissions": role.Permissions}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) RoleDelete(ctx context.Context, tenant, name string) error {
+	result, err := s.db.Collection("roles").DeleteOne(ctx, bson.M{"tenant_id": tenant, "name": name})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
@@ -12,7 +13,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
+func (s *Store) SessionList(ctx context.Context, pagination paginator.Query, devices []models.UID) ([]models.Session, int, error) {
 	query := []bson.M{
 		{
 			"$match": bson.M{
@@ -51,6 +52,14 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 		})
 	}
 
+	if devices != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"device_uid": bson.M{"$in": devices},
+			},
+		})
+	}
+
 	queryCount := query
 	queryCount = append(queryCount, bson.M{"$count": "count"})
 	count, err := AggregateCount(ctx, s.db.Collection("sessions"), queryCount)
@@ -139,6 +148,21 @@ func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session
 	return session, nil
 }
 
+func (s *Store) SessionListByGrant(ctx context.Context, grant string) ([]models.Session, error) {
+	cursor, err := s.db.Collection("sessions").Find(ctx, bson.M{"grant": grant, "closed": false}, options.Find().SetSort(bson.D{{Key: "started_at", Value: 1}}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	sessions := make([]models.Session, 0)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return sessions, nil
+}
+
 func (s *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
 	_, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"authenticated": authenticated}})
 
@@ -250,7 +274,11 @@ func (s *Store) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, n
 }
 
 func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error {
-	_, err := s.db.Collection("recorded_sessions").DeleteMany(ctx, bson.M{"uid": uid})
+	if _, err := s.db.Collection("recorded_sessions").DeleteMany(ctx, bson.M{"uid": uid}); err != nil {
+		return FromMongoError(err)
+	}
+
+	_, err := s.db.Collection("session_record_lines").DeleteMany(ctx, bson.M{"uid": uid})
 
 	return FromMongoError(err)
 }
@@ -308,3 +336,70 @@ func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]mo
 
 	return sessionRecord, count, nil
 }
+
+func (s *Store) SessionRecordIndex(ctx context.Context, uid models.UID, lines []models.SessionRecordLine) error {
+	if _, err := s.db.Collection("session_record_lines").DeleteMany(ctx, bson.M{"uid": uid}); err != nil {
+		return FromMongoError(err)
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, 0, len(lines))
+	for _, line := range lines {
+		documents = append(documents, line)
+	}
+
+	_, err := s.db.Collection("session_record_lines").InsertMany(ctx, documents)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) SessionRecordSearch(ctx context.Context, tenant, query string, devices []models.UID, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	match := bson.M{
+		"tenant_id": tenant,
+		"text":      bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"},
+	}
+
+	if devices != nil {
+		match["device_uid"] = bson.M{"$in": devices}
+	}
+
+	pipeline := []bson.M{{"$match": match}}
+
+	queryCount := pipeline
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("session_record_lines"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.D{
+			{Key: "started_at", Value: -1},
+			{Key: "uid", Value: 1},
+			{Key: "offset", Value: 1},
+		},
+	})
+
+	pipeline = append(pipeline, queries.BuildPaginationQuery(pagination)...)
+
+	lines := make([]models.SessionRecordLine, 0)
+	cursor, err := s.db.Collection("session_record_lines").Aggregate(ctx, pipeline)
+	if err != nil {
+		return lines, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		line := new(models.SessionRecordLine)
+		if err := cursor.Decode(line); err != nil {
+			return lines, count, FromMongoError(err)
+		}
+
+		lines = append(lines, *line)
+	}
+
+	return lines, count, FromMongoError(cursor.Err())
+}
@@ -70,7 +70,7 @@ func TestSessionList(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, s)
 
-	sessions, count, err := mongostore.SessionList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	sessions, count, err := mongostore.SessionList(data.Context, paginator.Query{Page: -1, PerPage: -1}, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, count)
 	assert.NotEmpty(t, sessions)
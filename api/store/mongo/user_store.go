@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -196,6 +197,75 @@ func (s *Store) UserUpdateData(ctx context.Context, id string, data models.User)
 	return nil
 }
 
+func (s *Store) UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	// The fields are set one by one to keep the counter of the last accepted TOTP code.
+	result, err := s.db.Collection("users").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{
+		"mfa.enabled":        mfa.Enabled,
+		"mfa.secret":         mfa.Secret,
+		"mfa.recovery_codes": mfa.RecoveryCodes,
+	}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSetMFACounter(ctx context.Context, id string, counter uint64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	filter := bson.M{
+		"_id": objID,
+		"$or": []bson.M{
+			{"mfa.last_counter": bson.M{"$exists": false}},
+			{"mfa.last_counter": bson.M{"$lt": int64(counter)}},
+		},
+	}
+
+	result, err := s.db.Collection("users").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"mfa.last_counter": int64(counter)}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserRemoveMFARecoveryCode(ctx context.Context, id string, digest string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	filter := bson.M{"_id": objID, "mfa.recovery_codes": digest}
+
+	result, err := s.db.Collection("users").UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"mfa.recovery_codes": digest}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) UserUpdatePassword(ctx context.Context, newPassword string, id string) error {
 	if _, _, err := s.UserGetByID(ctx, id, false); err != nil {
 		return FromMongoError(err)
@@ -0,0 +1,219 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	result, err := s.db.Collection("webhooks").InsertOne(ctx, webhook)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		webhook.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// WebhookList returns the webhooks of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhooks"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	webhooks := make([]models.Webhook, 0)
+	cursor, err := s.db.Collection("webhooks").Aggregate(ctx, query)
+	if err != nil {
+		return webhooks, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		webhook := new(models.Webhook)
+		if err := cursor.Decode(webhook); err != nil {
+			return webhooks, count, FromMongoError(err)
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	return webhooks, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) WebhookListByEvent(ctx context.Context, tenant, event string) ([]models.Webhook, error) {
+	cursor, err := s.db.Collection("webhooks").Find(ctx, bson.M{"tenant_id": tenant, "events": event})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	webhook := new(models.Webhook)
+	if err := s.db.Collection("webhooks").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(webhook); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("webhooks").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	if _, err := s.db.Collection("webhook_deliveries").DeleteMany(ctx, bson.M{"webhook_id": id}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result, err := s.db.Collection("webhook_deliveries").InsertOne(ctx, delivery)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		delivery.ID = id.Hex()
+	}
+
+	return nil
+}
+
+// WebhookDeliveryList returns the deliveries of a namespace's webhook, from the newest to the oldest, based on the
+// given pagination.
+func (s *Store) WebhookDeliveryList(ctx context.Context, tenant, webhookID string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id":  tenant,
+				"webhook_id": webhookID,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhook_deliveries"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.D{
+			{Key: "created_at", Value: -1},
+			{Key: "_id", Value: -1},
+		},
+	})
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	cursor, err := s.db.Collection("webhook_deliveries").Aggregate(ctx, query)
+	if err != nil {
+		return deliveries, count, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		delivery := new(models.WebhookDelivery)
+		if err := cursor.Decode(delivery); err != nil {
+			return deliveries, count, FromMongoError(err)
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	delivery := new(models.WebhookDelivery)
+	if err := s.db.Collection("webhook_deliveries").FindOne(ctx, bson.M{"_id": objID}).Decode(delivery); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return delivery, nil
+}
+
+func (s *Store) WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	objID, err := primitive.ObjectIDFromHex(delivery.ID)
+	if err != nil {
+		return store.ErrNoDocuments
+	}
+
+	result, err := s.db.Collection("webhook_deliveries").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{
+		"status":       delivery.Status,
+		"status_code":  delivery.StatusCode,
+		"error":        delivery.Error,
+		"attempts":     delivery.Attempts,
+		"delivered_at": delivery.DeliveredAt,
+	}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
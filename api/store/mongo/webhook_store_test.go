@@ -0,0 +1,76 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	ctx := context.TODO()
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	webhooks := []models.Webhook{
+		{TenantID: "tenant", URL: "http://first", Secret: "secret", Events: []string{"device.online"}, CreatedAt: clock.Now()},
+		{TenantID: "tenant", URL: "http://second", Secret: "secret", Events: []string{"device.offline"}, CreatedAt: clock.Now().Add(1)},
+		{TenantID: "other", URL: "http://third", Secret: "secret", Events: []string{"device.online"}, CreatedAt: clock.Now()},
+	}
+
+	for i := range webhooks {
+		assert.NoError(t, mongostore.WebhookCreate(ctx, &webhooks[i]))
+		assert.NotEmpty(t, webhooks[i].ID)
+	}
+
+	list, count, err := mongostore.WebhookList(ctx, "tenant", paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "http://second", list[0].URL)
+	assert.Equal(t, "http://first", list[1].URL)
+
+	subscribed, err := mongostore.WebhookListByEvent(ctx, "tenant", "device.online")
+	assert.NoError(t, err)
+	assert.Len(t, subscribed, 1)
+	assert.Equal(t, webhooks[0].ID, subscribed[0].ID)
+
+	webhook, err := mongostore.WebhookGet(ctx, "tenant", webhooks[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", webhook.Secret)
+
+	_, err = mongostore.WebhookGet(ctx, "other", webhooks[0].ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	delivery := &models.WebhookDelivery{TenantID: "tenant", WebhookID: webhooks[0].ID, Event: "device.online", Payload: "{}", Status: models.WebhookDeliveryStatusPending, CreatedAt: clock.Now()}
+	assert.NoError(t, mongostore.WebhookDeliveryCreate(ctx, delivery))
+	assert.NotEmpty(t, delivery.ID)
+
+	delivery.Status = models.WebhookDeliveryStatusSucceeded
+	delivery.StatusCode = 200
+	delivery.Attempts = 1
+	assert.NoError(t, mongostore.WebhookDeliveryUpdate(ctx, delivery))
+
+	got, err := mongostore.WebhookDeliveryGet(ctx, delivery.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryStatusSucceeded, got.Status)
+	assert.Equal(t, 1, got.Attempts)
+
+	deliveries, count, err := mongostore.WebhookDeliveryList(ctx, "tenant", webhooks[0].ID, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, delivery.ID, deliveries[0].ID)
+
+	assert.EqualError(t, mongostore.WebhookDelete(ctx, "other", webhooks[0].ID), store.ErrNoDocuments.Error())
+	assert.NoError(t, mongostore.WebhookDelete(ctx, "tenant", webhooks[0].ID))
+
+	_, err = mongostore.WebhookDeliveryGet(ctx, delivery.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
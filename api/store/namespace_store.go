@@ -18,7 +18,15 @@ type NamespaceStore interface {
 	NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error)
 	NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) (*models.Namespace, error)
 	NamespaceEditMember(ctx context.Context, tenantID string, memberID string, memberNewRole string) error
+	// NamespaceSetMemberScope sets the device scope of a namespace's member. A nil scope removes the restriction.
+	NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error
 	NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error)
 	NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error
+	// NamespaceSetRecordInput defines if the input of the namespace's recorded sessions is also recorded.
+	NamespaceSetRecordInput(ctx context.Context, recordInput bool, tenantID string) error
+	// NamespaceSetRequireMFA defines if the namespace's members must have MFA enabled.
+	NamespaceSetRequireMFA(ctx context.Context, requireMFA bool, tenantID string) error
+	// NamespaceSetApprovalTags sets the tags of the namespace's devices that require an approved access request.
+	NamespaceSetApprovalTags(ctx context.Context, tags []string, tenantID string) error
 	NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error)
 }
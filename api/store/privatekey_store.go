@@ -8,5 +8,7 @@ import (
 
 type PrivateKeyStore interface {
 	PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error
+	// PrivateKeyGet retrieves a private key by its fingerprint or, for the agents that predate the SHA256 fingerprints,
+	// by its legacy MD5 one.
 	PrivateKeyGet(ctx context.Context, fingerprint string) (*models.PrivateKey, error)
 }
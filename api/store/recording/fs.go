@@ -0,0 +1,94 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// FileSystem stores each session's recording as a file in a directory.
+type FileSystem struct {
+	dir   string
+	locks locks
+}
+
+// NewFileSystem creates a recording storage on a directory, creating it when it does not exist.
+func NewFileSystem(dir string) (*FileSystem, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &FileSystem{dir: dir}, nil
+}
+
+func (f *FileSystem) path(uid models.UID) (string, error) {
+	name, err := objectName(uid)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(f.dir, name), nil
+}
+
+func (f *FileSystem) RecordingAppend(_ context.Context, uid models.UID, frame *models.RecordedSession) error {
+	path, err := f.path(uid)
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	defer f.locks.lock(path)()
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+func (f *FileSystem) RecordingRead(_ context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	path, err := f.path(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []models.RecordedSession{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return decodeFrames(file)
+}
+
+func (f *FileSystem) RecordingDelete(_ context.Context, uid models.UID) error {
+	path, err := f.path(uid)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package recording
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSystem(t *testing.T) {
+	ctx := context.TODO()
+
+	dir := t.TempDir()
+
+	storage, err := NewFileSystem(dir)
+	assert.NoError(t, err)
+
+	frames, err := storage.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, frames)
+
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	first := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "first", Time: now, Width: 80, Height: 24}
+	second := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "second", Time: now.Add(time.Second), Width: 80, Height: 24}
+
+	assert.NoError(t, storage.RecordingAppend(ctx, "uid", first))
+	assert.NoError(t, storage.RecordingAppend(ctx, "uid", second))
+
+	frames, err = storage.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RecordedSession{*first, *second}, frames)
+
+	assert.FileExists(t, filepath.Join(dir, "uid.jsonl.gz"))
+
+	assert.NoError(t, storage.RecordingDelete(ctx, "uid"))
+	assert.NoError(t, storage.RecordingDelete(ctx, "uid"))
+
+	_, err = os.Stat(filepath.Join(dir, "uid.jsonl.gz"))
+	assert.True(t, os.IsNotExist(err))
+
+	assert.Equal(t, ErrInvalidUID, storage.RecordingAppend(ctx, "../uid", first))
+}
@@ -0,0 +1,187 @@
+// Package recording stores the sessions' recorded frames on a filesystem or an S3-compatible object storage instead
+// of the database.
+//
+// Each session's frames are kept in a single object, or in parts of it on S3, as newline-delimited JSON compressed by
+// gzip. Every appended frame is a gzip member of its own, which is a valid gzip stream when concatenated to the previous
+// ones.
+package recording
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	StorageDatabase   = "database"
+	StorageFileSystem = "fs"
+	StorageS3         = "s3"
+)
+
+// Config defines where the sessions' recorded frames are stored.
+type Config struct {
+	// RecordStorage is where the frames are stored. It can be "database", "fs" or "s3".
+	RecordStorage string `envconfig:"record_storage" default:"database"`
+	// RecordStoragePath is the directory where the frames are stored, when the storage is "fs".
+	RecordStoragePath string `envconfig:"record_storage_path" default:"/var/lib/shellhub/records"`
+	// RecordS3Endpoint is the URL of the S3-compatible service, as "http://minio:9000", when the storage is "s3".
+	RecordS3Endpoint string `envconfig:"record_s3_endpoint"`
+	// RecordS3Bucket is the bucket where the frames are stored, when the storage is "s3".
+	RecordS3Bucket string `envconfig:"record_s3_bucket" default:"shellhub"`
+	// RecordS3Region is the region of the bucket, when the storage is "s3".
+	RecordS3Region string `envconfig:"record_s3_region" default:"us-east-1"`
+	// RecordS3AccessKey is the access key used to sign the requests, when the storage is "s3".
+	RecordS3AccessKey string `envconfig:"record_s3_access_key"`
+	// RecordS3SecretKey is the secret key used to sign the requests, when the storage is "s3".
+	RecordS3SecretKey string `envconfig:"record_s3_secret_key"`
+}
+
+// New returns the recording storage defined by the config, or nil when the frames are stored in the database.
+func New(cfg Config) (store.RecordingStorage, error) {
+	switch cfg.RecordStorage {
+	case "", StorageDatabase:
+		return nil, nil
+	case StorageFileSystem:
+		return NewFileSystem(cfg.RecordStoragePath)
+	case StorageS3:
+		return NewS3(cfg.RecordS3Endpoint, cfg.RecordS3Bucket, cfg.RecordS3Region, cfg.RecordS3AccessKey, cfg.RecordS3SecretKey)
+	default:
+		return nil, fmt.Errorf("recording storage not supported: %s", cfg.RecordStorage)
+	}
+}
+
+// ErrInvalidUID is returned when a session's UID cannot be used to name its recording.
+var ErrInvalidUID = errors.New("invalid session uid")
+
+// objectName returns the name of the object holding the session's recording.
+func objectName(uid models.UID) (string, error) {
+	if uid == "" || strings.ContainsAny(string(uid), `/\`) || strings.Contains(string(uid), "..") {
+		return "", ErrInvalidUID
+	}
+
+	return string(uid) + ".jsonl.gz", nil
+}
+
+// locks serializes the appends to the same recording, sharding the recordings by their names.
+type locks [64]sync.Mutex
+
+// lock locks the recording's shard, returning the function that unlocks it.
+func (l *locks) lock(name string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(name)) //nolint:errcheck
+
+	mu := &l[hash.Sum32()%uint32(len(l))]
+	mu.Lock()
+
+	return mu.Unlock
+}
+
+// encodeFrame encodes a frame as a gzip member to be appended to a recording.
+func encodeFrame(frame *models.RecordedSession) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(frame); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decodeFrames decodes the frames of a recording.
+func decodeFrames(reader io.Reader) ([]models.RecordedSession, error) {
+	frames := make([]models.RecordedSession, 0)
+
+	uncompressed, err := gzip.NewReader(reader)
+	if err == io.EOF {
+		return frames, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer uncompressed.Close()
+
+	decoder := json.NewDecoder(uncompressed)
+	for {
+		var frame models.RecordedSession
+		if err := decoder.Decode(&frame); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, nil
+}
+
+// Store is a store whose sessions' recorded frames are kept on a RecordingStorage instead of the database.
+type Store struct {
+	store.Store
+	storage store.RecordingStorage
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore wraps a store to keep the sessions' recorded frames on a recording storage.
+func NewStore(st store.Store, storage store.RecordingStorage) *Store {
+	return &Store{Store: st, storage: storage}
+}
+
+// SessionCreateRecordFrame appends the frame to the session's recording, flagging the session as recorded.
+func (s *Store) SessionCreateRecordFrame(ctx context.Context, uid models.UID, recordSession *models.RecordedSession) error {
+	if err := s.storage.RecordingAppend(ctx, uid, recordSession); err != nil {
+		return err
+	}
+
+	return s.Store.SessionSetRecorded(ctx, uid, true)
+}
+
+// SessionGetRecordFrame returns the frames of the session's recording.
+func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	frames, err := s.storage.RecordingRead(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		filtered := make([]models.RecordedSession, 0, len(frames))
+		for _, frame := range frames {
+			if frame.TenantID == tenant.ID {
+				filtered = append(filtered, frame)
+			}
+		}
+
+		frames = filtered
+	}
+
+	return frames, len(frames), nil
+}
+
+// SessionDeleteRecordFrame deletes the session's recording and its indexed lines, kept in the database.
+func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error {
+	if err := s.storage.RecordingDelete(ctx, uid); err != nil {
+		return err
+	}
+
+	return s.Store.SessionRecordIndex(ctx, uid, nil)
+}
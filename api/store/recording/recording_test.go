@@ -0,0 +1,42 @@
+package recording
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.TODO()
+
+	storeMock := &mocks.Store{}
+
+	storage, err := NewFileSystem(t.TempDir())
+	assert.NoError(t, err)
+
+	s := NewStore(storeMock, storage)
+
+	frame := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "frame", Time: time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)}
+
+	storeMock.On("SessionSetRecorded", ctx, models.UID("uid"), true).Return(nil).Once()
+	assert.NoError(t, s.SessionCreateRecordFrame(ctx, "uid", frame))
+
+	frames, count, err := s.SessionGetRecordFrame(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.RecordedSession{*frame}, frames)
+
+	storeMock.On("SessionRecordIndex", ctx, models.UID("uid"), []models.SessionRecordLine(nil)).Return(nil).Once()
+	assert.NoError(t, s.SessionDeleteRecordFrame(ctx, "uid"))
+
+	frames, count, err = s.SessionGetRecordFrame(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Empty(t, frames)
+
+	storeMock.AssertExpectations(t)
+}
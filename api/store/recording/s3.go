@@ -0,0 +1,410 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// S3 stores each session's recording as objects in a bucket of an S3-compatible service, as AWS S3 or MinIO.
+//
+// As objects cannot be appended to, the frames are buffered in memory and flushed as a new part of the recording when
+// the buffer is full or has waited for s3FlushInterval. The parts are named by the time their first frame was buffered
+// and by the instance that flushed them, so the API instances never overwrite each other's parts, and are read back in
+// the order of their names. The frames still buffered when the instance stops are lost.
+type S3 struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	// instance identifies the parts flushed by this instance.
+	instance string
+
+	partSize      int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	parts   uint64
+	buffers map[models.UID]*s3Buffer
+}
+
+// s3Buffer holds the frames of a recording not flushed yet.
+type s3Buffer struct {
+	data    []byte
+	started time.Time
+	timer   *time.Timer
+}
+
+const (
+	// s3PartSize is the size of the buffered frames that flushes them as a part.
+	s3PartSize = 1 << 20
+	// s3FlushInterval is the time that the frames are buffered before being flushed as a part.
+	s3FlushInterval = 5 * time.Second
+)
+
+// NewS3 creates a recording storage on a bucket of an S3-compatible service, addressed by path style requests.
+func NewS3(endpoint, bucket, region, accessKey, secretKey string) (*S3, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %s", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, errors.New("s3 bucket not provided")
+	}
+
+	instance := make([]byte, 8)
+	if _, err := rand.Read(instance); err != nil {
+		return nil, err
+	}
+
+	return &S3{
+		endpoint:      parsed,
+		bucket:        bucket,
+		region:        region,
+		accessKey:     accessKey,
+		secretKey:     secretKey,
+		client:        &http.Client{Timeout: 30 * time.Second},
+		instance:      hex.EncodeToString(instance),
+		partSize:      s3PartSize,
+		flushInterval: s3FlushInterval,
+		buffers:       make(map[models.UID]*s3Buffer),
+	}, nil
+}
+
+func (s *S3) RecordingAppend(ctx context.Context, uid models.UID, frame *models.RecordedSession) error {
+	if _, err := objectName(uid); err != nil {
+		return err
+	}
+
+	data, err := encodeFrame(frame)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+
+	buffer, ok := s.buffers[uid]
+	if !ok {
+		buffer = &s3Buffer{started: clock.Now()}
+		buffer.timer = time.AfterFunc(s.flushInterval, func() {
+			if err := s.flush(context.Background(), uid); err != nil {
+				logrus.WithError(err).WithField("uid", uid).Error("Failed to flush the recorded frames to the s3 storage")
+			}
+		})
+
+		s.buffers[uid] = buffer
+	}
+
+	buffer.data = append(buffer.data, data...)
+
+	if len(buffer.data) < s.partSize {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	name, part := s.take(uid)
+
+	s.mu.Unlock()
+
+	return s.put(ctx, name, part)
+}
+
+func (s *S3) RecordingRead(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	name, err := objectName(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.flush(ctx, uid); err != nil {
+		return nil, err
+	}
+
+	parts, err := s.list(ctx, partsPrefix(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	// The recordings written before they were split into parts are kept in a single object.
+	data, err := s.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range parts {
+		content, err := s.get(ctx, part)
+		if err != nil {
+			return nil, err
+		}
+
+		data = append(data, content...)
+	}
+
+	return decodeFrames(bytes.NewReader(data))
+}
+
+func (s *S3) RecordingDelete(ctx context.Context, uid models.UID) error {
+	name, err := objectName(uid)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if buffer, ok := s.buffers[uid]; ok {
+		buffer.timer.Stop()
+		delete(s.buffers, uid)
+	}
+	s.mu.Unlock()
+
+	parts, err := s.list(ctx, partsPrefix(uid))
+	if err != nil {
+		return err
+	}
+
+	for _, object := range append([]string{name}, parts...) {
+		if err := s.delete(ctx, object); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// partsPrefix returns the prefix of the names of the recording's parts.
+func partsPrefix(uid models.UID) string {
+	return string(uid) + "/"
+}
+
+// take removes the recording's buffer, returning the name and the content of the part holding its frames. It must be
+// called with the mutex locked.
+func (s *S3) take(uid models.UID) (string, []byte) {
+	buffer := s.buffers[uid]
+	buffer.timer.Stop()
+	delete(s.buffers, uid)
+
+	s.parts++
+
+	// The time and the counter are zero padded, so the names are sorted as the parts were buffered.
+	return fmt.Sprintf("%s%020d-%s-%020d.jsonl.gz", partsPrefix(uid), buffer.started.UnixNano(), s.instance, s.parts), buffer.data
+}
+
+// flush uploads the recording's buffered frames as a part, when there is any.
+func (s *S3) flush(ctx context.Context, uid models.UID) error {
+	s.mu.Lock()
+
+	if _, ok := s.buffers[uid]; !ok {
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	name, part := s.take(uid)
+
+	s.mu.Unlock()
+
+	return s.put(ctx, name, part)
+}
+
+// get returns the object's content, or no content when the object does not exist.
+func (s *S3) get(ctx context.Context, name string) ([]byte, error) {
+	res, err := s.do(ctx, http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, responseError(res)
+	}
+}
+
+func (s *S3) put(ctx context.Context, name string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, name, nil, data)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+func (s *S3) delete(ctx context.Context, name string) error {
+	res, err := s.do(ctx, http.MethodDelete, name, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+// listResult is the response of the ListObjectsV2 request.
+type listResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// list returns the names of the bucket's objects with the prefix, sorted by the service.
+func (s *S3) list(ctx context.Context, prefix string) ([]string, error) {
+	names := make([]string, 0)
+
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	for {
+		res, err := s.do(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			err := responseError(res)
+			res.Body.Close()
+
+			return nil, err
+		}
+
+		var result listResult
+		err = xml.NewDecoder(res.Body).Decode(&result)
+		res.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			names = append(names, content.Key)
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+// do sends a request to the bucket's object, or to the bucket when the name is empty, signed by the AWS Signature
+// Version 4.
+func (s *S3) do(ctx context.Context, method, name string, query url.Values, body []byte) (*http.Response, error) {
+	target := *s.endpoint
+	target.Path = strings.TrimSuffix(target.Path, "/") + "/" + s.bucket
+	if name != "" {
+		target.Path += "/" + name
+	}
+
+	// The query is encoded sorted by its keys, as the canonical request of the signature requires.
+	target.RawQuery = strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	s.sign(req, body, clock.Now())
+
+	return s.client.Do(req)
+}
+
+// sign signs the request by the AWS Signature Version 4, on the headers host, x-amz-content-sha256 and x-amz-date.
+//
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func (s *S3) sign(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	timestamp := now.Format("20060102T150405Z")
+
+	payload := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payload[:])
+
+	req.Header.Set("x-amz-date", timestamp)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + timestamp,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	toSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		timestamp,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, toSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// responseError returns an error with the status and the body of an unexpected response.
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return fmt.Errorf("unexpected s3 response: %s: %s", res.Status, strings.TrimSpace(string(body)))
+}
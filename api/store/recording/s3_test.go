@@ -0,0 +1,198 @@
+package recording
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMinIO starts a stand-in of an S3-compatible service, storing the objects of a bucket in memory.
+func newMinIO(t *testing.T, bucket string) (*httptest.Server, map[string][]byte) {
+	t.Helper()
+
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		hash := sha256.Sum256(body)
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") ||
+			r.Header.Get("x-amz-content-sha256") != hex.EncodeToString(hash[:]) {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/"+bucket && r.URL.Query().Get("list-type") == "2" {
+			keys := make([]string, 0)
+			for name := range objects {
+				if strings.HasPrefix(name, r.URL.Query().Get("prefix")) {
+					keys = append(keys, name)
+				}
+			}
+
+			sort.Strings(keys)
+
+			w.Write([]byte("<ListBucketResult>")) //nolint:errcheck
+			for _, key := range keys {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", key)
+			}
+			w.Write([]byte("<IsTruncated>false</IsTruncated></ListBucketResult>")) //nolint:errcheck
+
+			return
+		}
+
+		name, ok := strings.CutPrefix(r.URL.Path, "/"+bucket+"/")
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			object, ok := objects[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			w.Write(object) //nolint:errcheck
+		case http.MethodPut:
+			objects[name] = body
+		case http.MethodDelete:
+			delete(objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, objects
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.TODO()
+
+	server, objects := newMinIO(t, "records")
+
+	storage, err := NewS3(server.URL, "records", "us-east-1", "access", "secret")
+	assert.NoError(t, err)
+
+	frames, err := storage.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, frames)
+
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	first := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "first", Time: now, Width: 80, Height: 24}
+	second := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "second", Time: now.Add(time.Second), Width: 100, Height: 30}
+
+	assert.NoError(t, storage.RecordingAppend(ctx, "uid", first))
+	assert.NoError(t, storage.RecordingAppend(ctx, "uid", second))
+
+	// The frames are buffered until they are flushed as a part.
+	assert.Empty(t, objects)
+
+	frames, err = storage.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RecordedSession{*first, *second}, frames)
+	assert.Len(t, objects, 1)
+
+	assert.NoError(t, storage.RecordingDelete(ctx, "uid"))
+	assert.Empty(t, objects)
+
+	frames, err = storage.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, frames)
+}
+
+func TestS3Parts(t *testing.T) {
+	ctx := context.TODO()
+
+	server, objects := newMinIO(t, "records")
+
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+	frame := func(message string, at int) *models.RecordedSession {
+		return &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: message, Time: now.Add(time.Duration(at) * time.Second)}
+	}
+
+	// A recording written before the frames were split into parts.
+	legacy, err := encodeFrame(frame("legacy", 0))
+	assert.NoError(t, err)
+
+	objects["uid.jsonl.gz"] = legacy
+
+	// Two instances record the session, each flushing its own parts.
+	instances := make([]*S3, 2)
+	for i := range instances {
+		instances[i], err = NewS3(server.URL, "records", "us-east-1", "access", "secret")
+		assert.NoError(t, err)
+
+		instances[i].partSize = 1
+	}
+
+	assert.NoError(t, instances[0].RecordingAppend(ctx, "uid", frame("first", 1)))
+	assert.NoError(t, instances[1].RecordingAppend(ctx, "uid", frame("second", 2)))
+	assert.NoError(t, instances[0].RecordingAppend(ctx, "uid", frame("third", 3)))
+
+	assert.Len(t, objects, 4)
+
+	reader, err := NewS3(server.URL, "records", "us-east-1", "access", "secret")
+	assert.NoError(t, err)
+
+	frames, err := reader.RecordingRead(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RecordedSession{*frame("legacy", 0), *frame("first", 1), *frame("second", 2), *frame("third", 3)}, frames)
+
+	assert.NoError(t, reader.RecordingDelete(ctx, "uid"))
+	assert.Empty(t, objects)
+}
+
+func TestS3FlushInterval(t *testing.T) {
+	ctx := context.TODO()
+
+	server, _ := newMinIO(t, "records")
+
+	storage, err := NewS3(server.URL, "records", "us-east-1", "access", "secret")
+	assert.NoError(t, err)
+
+	storage.flushInterval = 10 * time.Millisecond
+
+	now := time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, storage.RecordingAppend(ctx, "uid", &models.RecordedSession{UID: "uid", Message: "frame", Time: now}))
+
+	// The part is flushed without another frame being appended or the recording being read.
+	assert.Eventually(t, func() bool {
+		parts, err := storage.list(ctx, "uid/")
+
+		return err == nil && len(parts) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestS3Forbidden(t *testing.T) {
+	server, _ := newMinIO(t, "records")
+
+	storage, err := NewS3(server.URL, "records", "us-east-1", "other", "secret")
+	assert.NoError(t, err)
+
+	_, err = storage.RecordingRead(context.TODO(), "uid")
+	assert.ErrorContains(t, err, "403")
+}
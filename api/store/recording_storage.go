@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// RecordingStorage stores the sessions' recorded frames outside the database, as one object per session.
+type RecordingStorage interface {
+	// RecordingAppend appends a frame to the session's recording, creating it when it does not exist.
+	RecordingAppend(ctx context.Context, uid models.UID, frame *models.RecordedSession) error
+	// RecordingRead returns the frames of the session's recording, in the order they were appended. It returns no
+	// frames when the recording does not exist.
+	RecordingRead(ctx context.Context, uid models.UID) ([]models.RecordedSession, error)
+	// RecordingDelete deletes the session's recording. Deleting a recording that does not exist is not an error.
+	RecordingDelete(ctx context.Context, uid models.UID) error
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type RoleStore interface {
+	// RoleCreate creates a custom role. It returns ErrDuplicate when the namespace already has a role with the same name.
+	RoleCreate(ctx context.Context, role *models.Role) error
+	RoleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error)
+	RoleGet(ctx context.Context, tenant, name string) (*models.Role, error)
+	// RoleUpdate updates the description and the permissions of a custom role.
+	RoleUpdate(ctx context.Context, role *models.Role) error
+	RoleDelete(ctx context.Context, tenant, name string) error
+}
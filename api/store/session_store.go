@@ -8,7 +8,8 @@ import (
 )
 
 type SessionStore interface {
-	SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error)
+	// SessionList lists the sessions. When devices is not nil, only the sessions of those devices are listed.
+	SessionList(ctx context.Context, pagination paginator.Query, devices []models.UID) ([]models.Session, int, error)
 	SessionGet(ctx context.Context, uid models.UID) (*models.Session, error)
 	SessionCreate(ctx context.Context, session models.Session) (*models.Session, error)
 	SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
@@ -19,4 +20,11 @@ type SessionStore interface {
 	SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error)
 	SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error
 	SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error
+	// SessionListByGrant lists the sessions opened under an access grant that are not closed yet, without their devices.
+	SessionListByGrant(ctx context.Context, grant string) ([]models.Session, error)
+	// SessionRecordIndex replaces the indexed lines of a session's record.
+	SessionRecordIndex(ctx context.Context, uid models.UID, lines []models.SessionRecordLine) error
+	// SessionRecordSearch lists the indexed lines of the namespace's records containing the query, case insensitively,
+	// from the most recent session. When devices is not nil, only the lines of those devices' sessions are listed.
+	SessionRecordSearch(ctx context.Context, tenant, query string, devices []models.UID, pagination paginator.Query) ([]models.SessionRecordLine, int, error)
 }
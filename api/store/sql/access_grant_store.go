@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const accessGrantColumns = "id, tenant_id, member, fingerprint, device, tag, reason, created_by, created_at, expires_at, expired"
+
+func scanAccessGrant(row scanner) (*models.AccessGrant, error) {
+	grant := new(models.AccessGrant)
+
+	if err := row.Scan(&grant.ID, &grant.TenantID, &grant.Member, &grant.Fingerprint, &grant.Device, &grant.Tag, &grant.Reason,
+		&grant.CreatedBy, &grant.CreatedAt, &grant.ExpiresAt, &grant.Expired); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// accessGrantListWhere returns the access grants matched by a condition.
+func (s *Store) accessGrantListWhere(ctx context.Context, suffix string, args ...interface{}) ([]models.AccessGrant, error) {
+	rows, err := s.query(ctx, "SELECT "+accessGrantColumns+" FROM access_grants "+suffix, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := make([]models.AccessGrant, 0)
+	for rows.Next() {
+		grant, err := scanAccessGrant(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		grants = append(grants, *grant)
+	}
+
+	return grants, rows.Err()
+}
+
+func (s *Store) AccessGrantCreate(ctx context.Context, grant *models.AccessGrant) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO access_grants ("+accessGrantColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, grant.TenantID, grant.Member, grant.Fingerprint, grant.Device, grant.Tag, grant.Reason, grant.CreatedBy, grant.CreatedAt,
+		grant.ExpiresAt, grant.Expired); err != nil {
+		return FromSQLError(err)
+	}
+
+	grant.ID = id
+
+	return nil
+}
+
+// AccessGrantList returns the access grants of a namespace, from the newest to the oldest, based on the given
+// pagination.
+func (s *Store) AccessGrantList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.AccessGrant, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM access_grants WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	grants, err := s.accessGrantListWhere(ctx, "WHERE tenant_id = ? ORDER BY created_at DESC"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return grants, count, nil
+}
+
+func (s *Store) AccessGrantGet(ctx context.Context, tenant, id string) (*models.AccessGrant, error) {
+	grant, err := scanAccessGrant(s.queryRow(ctx, "SELECT "+accessGrantColumns+" FROM access_grants WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return grant, nil
+}
+
+func (s *Store) AccessGrantListActive(ctx context.Context, tenant, member, fingerprint string) ([]models.AccessGrant, error) {
+	if member == "" && fingerprint == "" {
+		return []models.AccessGrant{}, nil
+	}
+
+	// A grant has either a member or a fingerprint, so the empty one must not match the grants of the other.
+	grants, err := s.accessGrantListWhere(ctx, "WHERE tenant_id = ? AND expired = ? AND expires_at > ? AND ((member <> '' AND member = ?) OR (fingerprint <> '' AND fingerprint = ?)) ORDER BY created_at DESC",
+		tenant, false, clock.Now(), member, fingerprint)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return grants, nil
+}
+
+func (s *Store) AccessGrantListExpired(ctx context.Context) ([]models.AccessGrant, error) {
+	grants, err := s.accessGrantListWhere(ctx, "WHERE expired = ? AND expires_at <= ? ORDER BY created_at DESC", false, clock.Now())
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return grants, nil
+}
+
+func (s *Store) AccessGrantSetExpired(ctx context.Context, id string) error {
+	result, err := s.exec(ctx, "UPDATE access_grants SET expired = ? WHERE id = ?", true, id)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) AccessGrantDelete(ctx context.Context, tenant, id string) error {
+	result, err := s.exec(ctx, "DELETE FROM access_grants WHERE id = ? AND tenant_id = ?", id, tenant)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const accessRequestColumns = "id, tenant_id, member, fingerprint, device, reason, duration, status, created_at, decided_by, decided_at, grant_id"
+
+func scanAccessRequest(row scanner) (*models.AccessRequest, error) {
+	request := new(models.AccessRequest)
+
+	var decidedAt dbsql.NullTime
+
+	if err := row.Scan(&request.ID, &request.TenantID, &request.Member, &request.Fingerprint, &request.Device, &request.Reason,
+		&request.Duration, &request.Status, &request.CreatedAt, &request.DecidedBy, &decidedAt, &request.Grant); err != nil {
+		return nil, err
+	}
+
+	if decidedAt.Valid {
+		request.DecidedAt = &decidedAt.Time
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO access_requests ("+accessRequestColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, request.TenantID, request.Member, request.Fingerprint, request.Device, request.Reason, request.Duration, request.Status,
+		request.CreatedAt, request.DecidedBy, request.DecidedAt, request.Grant); err != nil {
+		return FromSQLError(err)
+	}
+
+	request.ID = id
+
+	return nil
+}
+
+func (s *Store) AccessRequestList(ctx context.Context, tenant string, status models.AccessRequestStatus, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	where := "WHERE tenant_id = ?"
+	args := []interface{}{tenant}
+	if status != "" {
+		where += " AND status = ?"
+		args = append(args, status)
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM access_requests "+where, args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+accessRequestColumns+" FROM access_requests "+where+" ORDER BY created_at DESC"+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	requests := make([]models.AccessRequest, 0)
+	for rows.Next() {
+		request, err := scanAccessRequest(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		requests = append(requests, *request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return requests, count, nil
+}
+
+func (s *Store) AccessRequestGet(ctx context.Context, tenant, id string) (*models.AccessRequest, error) {
+	request, err := scanAccessRequest(s.queryRow(ctx, "SELECT "+accessRequestColumns+" FROM access_requests WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestGetPending(ctx context.Context, tenant, device, member, fingerprint string) (*models.AccessRequest, error) {
+	if member == "" && fingerprint == "" {
+		return nil, store.ErrNoDocuments
+	}
+
+	// A request has either a member or a fingerprint, so the empty one must not match the requests of the other.
+	request, err := scanAccessRequest(s.queryRow(ctx, "SELECT "+accessRequestColumns+" FROM access_requests WHERE tenant_id = ? AND device = ? AND status = ? AND ((member <> '' AND member = ?) OR (fingerprint <> '' AND fingerprint = ?)) ORDER BY created_at DESC LIMIT 1",
+		tenant, device, models.AccessRequestStatusPending, member, fingerprint))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestDecide(ctx context.Context, request *models.AccessRequest) error {
+	result, err := s.exec(ctx, "UPDATE access_requests SET status = ?, decided_by = ?, decided_at = ?, grant_id = ? WHERE id = ? AND tenant_id = ? AND status = ?",
+		request.Status, request.DecidedBy, request.DecidedAt, request.Grant, request.ID, request.TenantID, models.AccessRequestStatusPending)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/order"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) AnnouncementList(ctx context.Context, pagination paginator.Query, order order.Query) ([]models.AnnouncementShort, int, error) {
+	rows, err := s.query(ctx, "SELECT uuid, title, date FROM announcements"+buildOrderQuery(order, "date")+buildPaginationQuery(pagination))
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	var announcements []models.AnnouncementShort
+	for rows.Next() {
+		var announcement models.AnnouncementShort
+		if err := rows.Scan(&announcement.UUID, &announcement.Title, &announcement.Date); err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		announcements = append(announcements, announcement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return announcements, len(announcements), nil
+}
+
+func (s *Store) AnnouncementGet(ctx context.Context, uuid string) (*models.Announcement, error) {
+	announcement := new(models.Announcement)
+
+	err := s.queryRow(ctx, "SELECT uuid, title, content, date FROM announcements WHERE uuid = ?", uuid).
+		Scan(&announcement.UUID, &announcement.Title, &announcement.Content, &announcement.Date)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return announcement, nil
+}
+
+func (s *Store) AnnouncementCreate(ctx context.Context, announcement *models.Announcement) error {
+	if _, err := s.exec(ctx, "INSERT INTO announcements (uuid, title, content, date) VALUES (?, ?, ?, ?)", announcement.UUID, announcement.Title, announcement.Content, announcement.Date); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AnnouncementUpdate(ctx context.Context, announcement *models.Announcement) error {
+	result, err := s.exec(ctx, "UPDATE announcements SET title = ?, content = ? WHERE uuid = ?", announcement.Title, announcement.Content, announcement.UUID)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) AnnouncementDelete(ctx context.Context, uuid string) error {
+	result, err := s.exec(ctx, "DELETE FROM announcements WHERE uuid = ?", uuid)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
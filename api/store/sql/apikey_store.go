@@ -0,0 +1,120 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const apiKeyColumns = "id, tenant_id, user_id, name, role, digest, created_at, expires_at, last_used_at"
+
+func scanAPIKey(row scanner) (*models.APIKey, error) {
+	key := new(models.APIKey)
+
+	var expiresAt, lastUsedAt dbsql.NullTime
+
+	if err := row.Scan(&key.ID, &key.TenantID, &key.UserID, &key.Name, &key.Role, &key.Digest, &key.CreatedAt, &expiresAt, &lastUsedAt); err != nil {
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyCreate(ctx context.Context, key *models.APIKey) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO api_keys ("+apiKeyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, key.TenantID, key.UserID, key.Name, key.Role, key.Digest, key.CreatedAt, key.ExpiresAt, key.LastUsedAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	key.ID = id
+
+	return nil
+}
+
+// APIKeyList returns the API keys of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM api_keys WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE tenant_id = ? ORDER BY created_at DESC"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	keys := make([]models.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return keys, count, nil
+}
+
+func (s *Store) APIKeyGet(ctx context.Context, tenant, id string) (*models.APIKey, error) {
+	key, err := scanAPIKey(s.queryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error) {
+	key, err := scanAPIKey(s.queryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE digest = ?", digest))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeySetLastUsed(ctx context.Context, id string, lastUsed time.Time) error {
+	result, err := s.exec(ctx, "UPDATE api_keys SET last_used_at = ? WHERE id = ?", lastUsed, id)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeyDelete(ctx context.Context, tenant, id string) error {
+	result, err := s.exec(ctx, "DELETE FROM api_keys WHERE id = ? AND tenant_id = ?", id, tenant)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
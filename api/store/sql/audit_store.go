@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const auditLogColumns = "id, tenant_id, actor_id, actor_username, actor_role, action, target_type, target_id, diff, ip_address, request_id, created_at"
+
+// auditLogFilterFields are the properties of a models.AuditLog accepted by the AuditList's filters.
+var auditLogFilterFields = map[string]filterField{
+	"actor.id":       {Column: "actor_id"},
+	"actor.username": {Column: "actor_username"},
+	"actor.role":     {Column: "actor_role"},
+	"action":         {Column: "action"},
+	"target.type":    {Column: "target_type"},
+	"target.id":      {Column: "target_id"},
+	"ip_address":     {Column: "ip_address"},
+	"request_id":     {Column: "request_id"},
+}
+
+func scanAuditLog(row scanner) (*models.AuditLog, error) {
+	log := new(models.AuditLog)
+
+	var diff dbsql.NullString
+
+	if err := row.Scan(&log.ID, &log.TenantID, &log.Actor.ID, &log.Actor.Username, &log.Actor.Role, &log.Action, &log.Target.Type, &log.Target.ID, &diff, &log.IPAddress, &log.RequestID, &log.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if diff.Valid {
+		if err := json.Unmarshal([]byte(diff.String), &log.Diff); err != nil {
+			return nil, err
+		}
+	}
+
+	return log, nil
+}
+
+func (s *Store) AuditCreate(ctx context.Context, log *models.AuditLog) error {
+	diff, err := json.Marshal(log.Diff)
+	if err != nil {
+		return err
+	}
+
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO audit_logs ("+auditLogColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, log.TenantID, log.Actor.ID, log.Actor.Username, log.Actor.Role, log.Action, log.Target.Type, log.Target.ID, string(diff), log.IPAddress, log.RequestID, log.CreatedAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	log.ID = id
+
+	return nil
+}
+
+// AuditList returns the audit log's entries of a namespace, from the newest to the oldest, based on the given filters
+// and pagination.
+func (s *Store) AuditList(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter) ([]models.AuditLog, int, error) {
+	filter, filterArgs, err := buildFilterQuery(filters, auditLogFilterFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{"tenant_id = ?", filter}
+	args := append([]interface{}{tenant}, filterArgs...)
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM audit_logs"+where(conditions...), args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+auditLogColumns+" FROM audit_logs"+where(conditions...)+" ORDER BY created_at DESC"+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	logs := make([]models.AuditLog, 0)
+	for rows.Next() {
+		log, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		logs = append(logs, *log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return logs, count, nil
+}
@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestStore(t)
+
+	now := clock.Now()
+
+	logs := []models.AuditLog{
+		{
+			TenantID:  "tenant",
+			Actor:     models.AuditActor{ID: "id", Username: "username", Role: "owner"},
+			Action:    models.AuditActionDeviceRename,
+			Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+			Diff:      models.AuditDiff{Before: map[string]interface{}{"name": "old"}, After: map[string]interface{}{"name": "new"}},
+			IPAddress: "127.0.0.1",
+			RequestID: "request",
+			CreatedAt: now,
+		},
+		{
+			TenantID:  "tenant",
+			Actor:     models.AuditActor{ID: "id", Username: "username", Role: "owner"},
+			Action:    models.AuditActionDeviceDelete,
+			Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+			CreatedAt: now.Add(time.Second),
+		},
+		{
+			TenantID:  "other",
+			Action:    models.AuditActionDeviceDelete,
+			Target:    models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+			CreatedAt: now,
+		},
+	}
+
+	for i := range logs {
+		assert.NoError(t, s.AuditCreate(ctx, &logs[i]))
+		assert.NotEmpty(t, logs[i].ID)
+	}
+
+	list, count, err := s.AuditList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, models.AuditActionDeviceDelete, list[0].Action)
+	assert.Equal(t, models.AuditActionDeviceRename, list[1].Action)
+	assert.Equal(t, logs[0].Actor, list[1].Actor)
+	assert.Equal(t, logs[0].Diff, list[1].Diff)
+	assert.Equal(t, "request", list[1].RequestID)
+
+	list, count, err = s.AuditList(ctx, "tenant", paginator.Query{Page: 2, PerPage: 1}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, list, 1)
+	assert.Equal(t, models.AuditActionDeviceRename, list[0].Action)
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "action", Operator: "eq", Value: models.AuditActionDeviceRename},
+		},
+	}
+
+	list, count, err = s.AuditList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10}, filters)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, logs[0].ID, list[0].ID)
+
+	filters = []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "invalid", Operator: "eq", Value: "value"},
+		},
+	}
+
+	_, _, err = s.AuditList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10}, filters)
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	_, err := s.exec(ctx, "INSERT INTO certificate_authorities (tenant_id, data, public_key, created_at) VALUES (?, ?, ?, ?)",
+		ca.TenantID, ca.Data, ca.PublicKey, ca.CreatedAt)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) CertificateAuthorityGet(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	ca := new(models.CertificateAuthority)
+	if err := s.queryRow(ctx, "SELECT tenant_id, data, public_key, created_at FROM certificate_authorities WHERE tenant_id = ?", tenant).
+		Scan(&ca.TenantID, &ca.Data, &ca.PublicKey, &ca.CreatedAt); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return ca, nil
+}
@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestStore(t)
+
+	_, err := s.CertificateAuthorityGet(ctx, "tenant")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	ca := &models.CertificateAuthority{TenantID: "tenant", Data: []byte("private"), PublicKey: "public", CreatedAt: clock.Now()}
+	assert.NoError(t, s.CertificateAuthorityCreate(ctx, ca))
+
+	assert.Equal(t, store.ErrDuplicate, s.CertificateAuthorityCreate(ctx, &models.CertificateAuthority{TenantID: "tenant", Data: []byte("other"), PublicKey: "other", CreatedAt: clock.Now()}))
+
+	got, err := s.CertificateAuthorityGet(ctx, "tenant")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("private"), got.Data)
+	assert.Equal(t, "public", got.PublicKey)
+
+	_, err = s.CertificateAuthorityGet(ctx, "other")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
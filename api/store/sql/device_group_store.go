@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const deviceGroupColumns = "id, tenant_id, name, filter, created_at"
+
+func scanDeviceGroup(row scanner) (*models.DeviceGroup, error) {
+	group := new(models.DeviceGroup)
+
+	if err := row.Scan(&group.ID, &group.TenantID, &group.Name, &group.Filter, &group.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO device_groups ("+deviceGroupColumns+") VALUES (?, ?, ?, ?, ?)",
+		id, group.TenantID, group.Name, group.Filter, group.CreatedAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	group.ID = id
+
+	return nil
+}
+
+// DeviceGroupList returns the device groups of a namespace, sorted by name, based on the given pagination.
+func (s *Store) DeviceGroupList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM device_groups WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+deviceGroupColumns+" FROM device_groups WHERE tenant_id = ? ORDER BY name"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	groups := make([]models.DeviceGroup, 0)
+	for rows.Next() {
+		group, err := scanDeviceGroup(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		groups = append(groups, *group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return groups, count, nil
+}
+
+func (s *Store) DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	group, err := scanDeviceGroup(s.queryRow(ctx, "SELECT "+deviceGroupColumns+" FROM device_groups WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupUpdate(ctx context.Context, group *models.DeviceGroup) error {
+	result, err := s.exec(ctx, "UPDATE device_groups SET name = ?, filter = ? WHERE id = ? AND tenant_id = ?", group.Name, group.Filter, group.ID, group.TenantID)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupDelete(ctx context.Context, tenant, id string) error {
+	result, err := s.exec(ctx, "DELETE FROM device_groups WHERE id = ? AND tenant_id = ?", id, tenant)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceGroup(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestStore(t)
+
+	now := clock.Now()
+
+	groups := []models.DeviceGroup{
+		{TenantID: "tenant", Name: "web", Filter: "W10=", CreatedAt: now},
+		{TenantID: "tenant", Name: "db", Filter: "W10=", CreatedAt: now.Add(time.Second)},
+		{TenantID: "other", Name: "web", Filter: "W10=", CreatedAt: now},
+	}
+
+	for i := range groups {
+		assert.NoError(t, s.DeviceGroupCreate(ctx, &groups[i]))
+		assert.NotEmpty(t, groups[i].ID)
+	}
+
+	assert.Equal(t, store.ErrDuplicate, s.DeviceGroupCreate(ctx, &models.DeviceGroup{TenantID: "tenant", Name: "web", Filter: "W10="}))
+
+	list, count, err := s.DeviceGroupList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "db", list[0].Name)
+	assert.Equal(t, "web", list[1].Name)
+
+	_, err = s.DeviceGroupGet(ctx, "other", groups[1].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	groups[0].Name = "db"
+	assert.Equal(t, store.ErrDuplicate, s.DeviceGroupUpdate(ctx, &groups[0]))
+
+	groups[0].Name = "www"
+	groups[0].Filter = "W3t9XQ=="
+	assert.NoError(t, s.DeviceGroupUpdate(ctx, &groups[0]))
+
+	group, err := s.DeviceGroupGet(ctx, "tenant", groups[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "www", group.Name)
+	assert.Equal(t, "W3t9XQ==", group.Filter)
+
+	assert.Equal(t, store.ErrNoDocuments, s.DeviceGroupDelete(ctx, "other", groups[0].ID))
+	assert.NoError(t, s.DeviceGroupDelete(ctx, "tenant", groups[0].ID))
+
+	_, err = s.DeviceGroupGet(ctx, "tenant", groups[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
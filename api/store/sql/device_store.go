@@ -0,0 +1,615 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// deviceColumns are the columns of the devices table loaded into a models.Device.
+const deviceColumns = "d.uid, d.name, d.tenant_id, d.identity_mac, d.info, d.public_key, d.last_seen, d.online, d.status, d.created_at, d.remote_addr, d.latitude, d.longitude, d.public_url, d.host_key"
+
+// deviceFilterFields are the properties of a models.Device accepted by the DeviceList's filters.
+var deviceFilterFields = map[string]filterField{
+	"uid":          {Column: "d.uid"},
+	"name":         {Column: "d.name"},
+	"tenant_id":    {Column: "d.tenant_id"},
+	"status":       {Column: "d.status"},
+	"online":       {Column: "d.online"},
+	"namespace":    {Column: "d.namespace"},
+	"remote_addr":  {Column: "d.remote_addr"},
+	"public_url":   {Column: "d.public_url"},
+	"identity.mac": {Column: "d.identity_mac"},
+	"tags":         {Tags: "EXISTS (SELECT 1 FROM device_tags WHERE device_tags.device_uid = d.uid AND %s)"},
+	"attributes":   {Attributes: "EXISTS (SELECT 1 FROM device_attributes WHERE device_attributes.device_uid = d.uid AND device_attributes.name = ? AND %s)"},
+}
+
+// deviceSortFields are the properties of a models.Device accepted to sort the DeviceList.
+var deviceSortFields = map[string]string{
+	"uid":        "d.uid",
+	"name":       "d.name",
+	"last_seen":  "d.last_seen",
+	"created_at": "d.created_at",
+	"status":     "d.status",
+	"online":     "d.online",
+	"namespace":  "d.namespace",
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDevice scans a row with the deviceColumns, followed by the extra destinations, into a models.Device.
+func scanDevice(row scanner, extra ...interface{}) (*models.Device, error) {
+	device := new(models.Device)
+
+	var mac, info dbsql.NullString
+	var latitude, longitude dbsql.NullFloat64
+	var status string
+
+	dest := []interface{}{
+		&device.UID,
+		&device.Name,
+		&device.TenantID,
+		&mac,
+		&info,
+		&device.PublicKey,
+		&device.LastSeen,
+		&device.Online,
+		&status,
+		&device.CreatedAt,
+		&device.RemoteAddr,
+		&latitude,
+		&longitude,
+		&device.PublicURL,
+		&device.HostKey,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	device.Status = models.DeviceStatus(status)
+
+	if mac.Valid {
+		device.Identity = &models.DeviceIdentity{MAC: mac.String}
+	}
+
+	if info.Valid {
+		if err := json.Unmarshal([]byte(info.String), &device.Info); err != nil {
+			return nil, err
+		}
+	}
+
+	if latitude.Valid && longitude.Valid {
+		device.Position = &models.DevicePosition{Latitude: latitude.Float64, Longitude: longitude.Float64}
+	}
+
+	return device, nil
+}
+
+// deviceView is a query that selects the devices with their namespace's name, the online status based on the
+// connected devices and the acceptable expression.
+func deviceView(acceptable string) string {
+	return fmt.Sprintf(`SELECT d.uid, d.name, d.tenant_id, d.identity_mac, d.info, d.public_key, d.last_seen,
+	EXISTS (SELECT 1 FROM connected_devices c WHERE c.uid = d.uid AND c.last_seen > ?) AS online,
+	d.status, d.created_at, d.remote_addr, d.latitude, d.longitude, d.public_url, d.host_key,
+	n.name AS namespace, %s AS acceptable
+	FROM devices d JOIN namespaces n ON n.tenant_id = d.tenant_id`, acceptable)
+}
+
+// loadDeviceTags sets the tags of each device in the list.
+func (e executor) loadDeviceTags(ctx context.Context, devices []*models.Device) error {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	uids := make([]interface{}, len(devices))
+	index := make(map[string][]*models.Device, len(devices))
+	for i, device := range devices {
+		uids[i] = device.UID
+		index[device.UID] = append(index[device.UID], device)
+		device.Tags = []string{}
+	}
+
+	rows, err := e.query(ctx, "SELECT device_uid, tag FROM device_tags WHERE device_uid IN ("+placeholders(len(uids))+") ORDER BY id", uids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid, tag string
+		if err := rows.Scan(&uid, &tag); err != nil {
+			return err
+		}
+
+		for _, device := range index[uid] {
+			device.Tags = append(device.Tags, tag)
+		}
+	}
+
+	return rows.Err()
+}
+
+// loadDeviceAttributes sets the attributes of each device in the list.
+func (e executor) loadDeviceAttributes(ctx context.Context, devices []*models.Device) error {
+	if len(devices) == 0 {
+		return nil
+	}
+
+	uids := make([]interface{}, len(devices))
+	index := make(map[string][]*models.Device, len(devices))
+	for i, device := range devices {
+		uids[i] = device.UID
+		index[device.UID] = append(index[device.UID], device)
+	}
+
+	rows, err := e.query(ctx, "SELECT device_uid, name, value FROM device_attributes WHERE device_uid IN ("+placeholders(len(uids))+")", uids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid, name, value string
+		if err := rows.Scan(&uid, &name, &value); err != nil {
+			return err
+		}
+
+		for _, device := range index[uid] {
+			if device.Attributes == nil {
+				device.Attributes = make(map[string]string)
+			}
+
+			device.Attributes[name] = value
+		}
+	}
+
+	return rows.Err()
+}
+
+// deviceGetWhere gets the first device that matches the condition from the devices table.
+func (e executor) deviceGetWhere(ctx context.Context, condition string, args ...interface{}) (*models.Device, error) {
+	device, err := scanDevice(e.queryRow(ctx, "SELECT "+deviceColumns+" FROM devices d WHERE "+condition+" LIMIT 1", args...))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := e.loadDeviceTags(ctx, []*models.Device{device}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := e.loadDeviceAttributes(ctx, []*models.Device{device}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return device, nil
+}
+
+// DeviceList returns a list of devices based on the given filters, pagination and sorting.
+func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status models.DeviceStatus, sort string, order string, mode store.DeviceListMode) ([]models.Device, int, error) {
+	filter, filterArgs, err := buildFilterQuery(filters, deviceFilterFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := clock.Now()
+	args := []interface{}{now.Add(-connectedDeviceTTL)}
+
+	// The value of "acceptable" is based on the device status and the list mode. See the Mongo's DeviceList for
+	// details.
+	acceptable := "(1 = 0)"
+	switch status {
+	case models.DeviceStatusPending, models.DeviceStatusRejected:
+		switch mode {
+		case store.DeviceListModeMaxDeviceReached:
+			acceptable = "EXISTS (SELECT 1 FROM removed_devices r WHERE r.uid = d.uid AND r.timestamp > ?)"
+			args = append(args, now.Add(-removedDeviceTTL))
+		default:
+			acceptable = "(1 = 1)"
+		}
+	}
+
+	var conditions []string
+	if status != "" {
+		conditions = append(conditions, "d.status = ?")
+		args = append(args, string(status))
+	}
+
+	if filter != "" {
+		conditions = append(conditions, filter)
+		args = append(args, filterArgs...)
+	}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "d.tenant_id = ?")
+		args = append(args, tenant.ID)
+	}
+
+	query := "FROM (" + deviceView(acceptable) + ") d" + where(conditions...)
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) "+query, args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	orderBy := " ORDER BY d.last_seen DESC"
+	if column, ok := deviceSortFields[sort]; ok {
+		if order == "desc" {
+			orderBy = fmt.Sprintf(" ORDER BY %s DESC", column)
+		} else {
+			orderBy = fmt.Sprintf(" ORDER BY %s ASC", column)
+		}
+	}
+
+	rows, err := s.query(ctx, "SELECT "+deviceColumns+", d.namespace, d.acceptable "+query+orderBy+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.Device, 0)
+	for rows.Next() {
+		var namespace string
+		var acceptable bool
+
+		device, err := scanDevice(rows, &namespace, &acceptable)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		device.Namespace = namespace
+		device.Acceptable = acceptable
+
+		list = append(list, device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	if err := s.loadDeviceTags(ctx, list); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	if err := s.loadDeviceAttributes(ctx, list); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	devices := make([]models.Device, len(list))
+	for i, device := range list {
+		devices[i] = *device
+	}
+
+	return devices, count, nil
+}
+
+func (s *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error) {
+	conditions := []string{"d.uid = ?"}
+	args := []interface{}{clock.Now().Add(-connectedDeviceTTL), string(uid)}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "d.tenant_id = ?")
+		args = append(args, tenant.ID)
+	}
+
+	var namespace string
+	var acceptable bool
+
+	device, err := scanDevice(s.queryRow(ctx, "SELECT "+deviceColumns+", d.namespace, d.acceptable FROM ("+deviceView("(1 = 0)")+") d"+where(conditions...), args...), &namespace, &acceptable)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	device.Namespace = namespace
+
+	if err := s.loadDeviceTags(ctx, []*models.Device{device}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.loadDeviceAttributes(ctx, []*models.Device{device}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return device, nil
+}
+
+func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
+	if _, err := s.exec(ctx, "DELETE FROM devices WHERE uid = ?", string(uid)); err != nil {
+		return FromSQLError(err)
+	}
+
+	if _, err := s.exec(ctx, "DELETE FROM device_tags WHERE device_uid = ?", string(uid)); err != nil {
+		return FromSQLError(err)
+	}
+
+	if _, err := s.exec(ctx, "DELETE FROM device_attributes WHERE device_uid = ?", string(uid)); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	if _, err := s.exec(ctx, "DELETE FROM sessions WHERE device_uid = ?", string(uid)); err != nil {
+		return FromSQLError(err)
+	}
+
+	_, err := s.exec(ctx, "DELETE FROM connected_devices WHERE uid = ?", string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
+	if hostname == "" {
+		hostname = strings.ReplaceAll(d.Identity.MAC, ":", "-")
+	}
+
+	if d.Name != "" {
+		hostname = d.Name
+	}
+
+	status := models.DeviceStatusPending
+	if d.Status != "" {
+		status = d.Status
+	}
+
+	var mac, info, latitude, longitude interface{}
+	if d.Identity != nil {
+		mac = d.Identity.MAC
+	}
+
+	if d.Info != nil {
+		data, err := json.Marshal(d.Info)
+		if err != nil {
+			return err
+		}
+
+		info = string(data)
+	}
+
+	if d.Position != nil {
+		latitude, longitude = d.Position.Latitude, d.Position.Longitude
+	}
+
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, `INSERT INTO devices (uid, name, tenant_id, identity_mac, info, public_key, last_seen, status, created_at, remote_addr, latitude, longitude, public_url)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (uid) DO UPDATE SET tenant_id = excluded.tenant_id, identity_mac = excluded.identity_mac, info = excluded.info,
+	public_key = excluded.public_key, last_seen = excluded.last_seen, remote_addr = excluded.remote_addr,
+	latitude = excluded.latitude, longitude = excluded.longitude`,
+			d.UID, hostname, d.TenantID, mac, info, d.PublicKey, d.LastSeen, string(status), clock.Now(), d.RemoteAddr, latitude, longitude, d.PublicURL); err != nil {
+			return err
+		}
+
+		// The attributes are set one by one to keep the ones that were not sent.
+		for key, value := range d.Attributes {
+			if err := tx.deviceSetAttribute(ctx, d.UID, key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *Store) DeviceRename(ctx context.Context, uid models.UID, hostname string) error {
+	if _, err := s.exec(ctx, "UPDATE devices SET name = ? WHERE uid = ?", hostname, string(uid)); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*models.Device, error) {
+	return s.deviceGetWhere(ctx, "d.tenant_id = (SELECT tenant_id FROM namespaces WHERE name = ?) AND d.name = ? AND d.status = ?", namespace, hostname, string(models.DeviceStatusAccepted))
+}
+
+func (s *Store) DeviceSetOnline(ctx context.Context, uid models.UID, online bool) error {
+	device, err := s.deviceGetWhere(ctx, "d.uid = ?", string(uid))
+	if err != nil {
+		return err
+	}
+
+	if !online {
+		_, err := s.exec(ctx, "DELETE FROM connected_devices WHERE uid = ?", string(uid))
+
+		return FromSQLError(err)
+	}
+
+	now := clock.Now()
+	if _, err := s.exec(ctx, "UPDATE devices SET last_seen = ? WHERE uid = ?", now, device.UID); err != nil {
+		return FromSQLError(err)
+	}
+
+	return s.deviceConnect(ctx, device.UID, device.TenantID, string(device.Status), now)
+}
+
+// deviceConnect inserts, or refreshes, a device in the connected devices.
+func (e executor) deviceConnect(ctx context.Context, uid, tenant, status string, lastSeen time.Time) error {
+	_, err := e.exec(ctx, `INSERT INTO connected_devices (uid, tenant_id, status, last_seen) VALUES (?, ?, ?, ?)
+	ON CONFLICT (uid) DO UPDATE SET tenant_id = excluded.tenant_id, status = excluded.status, last_seen = excluded.last_seen`,
+		uid, tenant, status, lastSeen)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceUpdateOnline(ctx context.Context, uid models.UID, online bool) error {
+	_, err := s.exec(ctx, "UPDATE devices SET online = ? WHERE uid = ?", online, string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
+	_, err := s.exec(ctx, "UPDATE devices SET last_seen = ? WHERE uid = ?", ts, string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceUpdateStatus(ctx context.Context, uid models.UID, status models.DeviceStatus) error {
+	device, err := s.deviceGetWhere(ctx, "d.uid = ?", string(uid))
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.exec(ctx, "UPDATE devices SET status = ? WHERE uid = ?", string(status), device.UID); err != nil {
+		return FromSQLError(err)
+	}
+
+	return s.deviceConnect(ctx, device.UID, device.TenantID, string(status), clock.Now())
+}
+
+func (s *Store) DeviceListByUsage(ctx context.Context, tenant string) ([]models.UID, error) {
+	uids := make([]models.UID, 0)
+
+	rows, err := s.query(ctx, "SELECT device_uid FROM sessions WHERE tenant_id = ? GROUP BY device_uid ORDER BY COUNT(*) DESC LIMIT 3", tenant)
+	if err != nil {
+		return uids, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var uid string
+		if err := rows.Scan(&uid); err != nil {
+			return uids, err
+		}
+
+		uids = append(uids, models.UID(uid))
+	}
+
+	return uids, rows.Err()
+}
+
+func (s *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
+	if status != "" {
+		return s.deviceGetWhere(ctx, "d.tenant_id = ? AND d.identity_mac = ? AND d.status = ?", tenantID, mac, string(status))
+	}
+
+	return s.deviceGetWhere(ctx, "d.tenant_id = ? AND d.identity_mac = ?", tenantID, mac)
+}
+
+func (s *Store) DeviceGetByName(ctx context.Context, name string, tenantID string) (*models.Device, error) {
+	return s.deviceGetWhere(ctx, "d.tenant_id = ? AND d.name = ?", tenantID, name)
+}
+
+func (s *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
+	var device *models.Device
+	if err := s.cache.Get(ctx, strings.Join([]string{"device", string(uid)}, "/"), &device); err != nil {
+		logrus.Error(err)
+	}
+
+	if device != nil {
+		return device, nil
+	}
+
+	device, err := s.deviceGetWhere(ctx, "d.tenant_id = ? AND d.uid = ?", tenantID, string(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"device", string(uid)}, "/"), device, time.Minute); err != nil {
+		logrus.Error(err)
+	}
+
+	return device, nil
+}
+
+func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error {
+	_, err := s.exec(ctx, "UPDATE devices SET latitude = ?, longitude = ? WHERE uid = ?", position.Latitude, position.Longitude, string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error {
+	result, err := s.exec(ctx, "UPDATE devices SET host_key = ? WHERE uid = ?", hostKey, string(uid))
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
+	args := []interface{}{string(models.DeviceStatusPending), string(models.DeviceStatusAccepted), tenantID}
+
+	query := "UPDATE devices SET status = ? WHERE status = ? AND tenant_id = ?"
+	if len(chosen) > 0 {
+		query += " AND uid NOT IN (" + placeholders(len(chosen)) + ")"
+		for _, uid := range chosen {
+			args = append(args, uid)
+		}
+	}
+
+	_, err := s.exec(ctx, query, args...)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceUpdate(ctx context.Context, uid models.UID, name *string, publicURL *bool) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if name != nil {
+			if _, err := tx.exec(ctx, "UPDATE devices SET name = ? WHERE uid = ?", *name, string(uid)); err != nil {
+				return err
+			}
+		}
+
+		if publicURL != nil {
+			if _, err := tx.exec(ctx, "UPDATE devices SET public_url = ? WHERE uid = ?", *publicURL, string(uid)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *Store) DeviceRemovedCount(ctx context.Context, tenant string) (int64, error) {
+	var count int64
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM removed_devices WHERE tenant_id = ? AND timestamp > ?", tenant, clock.Now().Add(-removedDeviceTTL)).Scan(&count); err != nil {
+		return 0, FromSQLError(err)
+	}
+
+	return count, nil
+}
+
+func (s *Store) DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error) {
+	var slot models.DeviceRemoved
+	var id string
+
+	if err := s.queryRow(ctx, "SELECT uid, tenant_id, timestamp FROM removed_devices WHERE tenant_id = ? AND uid = ? AND timestamp > ?", tenant, string(uid), clock.Now().Add(-removedDeviceTTL)).Scan(&id, &slot.Tenant, &slot.Timestamp); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	slot.UID = models.UID(id)
+
+	return &slot, nil
+}
+
+func (s *Store) DeviceRemovedInsert(ctx context.Context, tenant string, uid models.UID) error {
+	_, err := s.exec(ctx, "INSERT INTO removed_devices (tenant_id, uid, timestamp) VALUES (?, ?, ?) ON CONFLICT (tenant_id, uid) DO UPDATE SET timestamp = excluded.timestamp", tenant, string(uid), time.Now())
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid models.UID) error {
+	_, err := s.exec(ctx, "DELETE FROM removed_devices WHERE tenant_id = ? AND uid = ?", tenant, string(uid))
+
+	return FromSQLError(err)
+}
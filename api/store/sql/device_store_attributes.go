@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DeviceSetAttribute(ctx context.Context, uid models.UID, key, value string) error {
+	return FromSQLError(s.deviceSetAttribute(ctx, string(uid), key, value))
+}
+
+func (s *Store) DeviceRemoveAttribute(ctx context.Context, uid models.UID, key string) error {
+	_, err := s.exec(ctx, "DELETE FROM device_attributes WHERE device_uid = ? AND name = ?", string(uid), key)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM device_attributes WHERE device_uid = ?", string(uid)); err != nil {
+			return err
+		}
+
+		for key, value := range attributes {
+			if err := tx.deviceSetAttribute(ctx, string(uid), key, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+// deviceSetAttribute inserts, or replaces, the value of a device's attribute.
+func (e executor) deviceSetAttribute(ctx context.Context, uid, key, value string) error {
+	_, err := e.exec(ctx, `INSERT INTO device_attributes (device_uid, name, value) SELECT uid, ?, ? FROM devices WHERE uid = ?
+	ON CONFLICT (device_uid, name) DO UPDATE SET value = excluded.value`, key, value, uid)
+
+	return err
+}
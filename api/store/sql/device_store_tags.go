@@ -0,0 +1,40 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) DeviceCreateTag(ctx context.Context, uid models.UID, tag string) error {
+	_, err := s.exec(ctx, "INSERT INTO device_tags (device_uid, tag) SELECT uid, ? FROM devices WHERE uid = ?", tag, string(uid))
+
+	return err
+}
+
+func (s *Store) DeviceRemoveTag(ctx context.Context, uid models.UID, tag string) error {
+	_, err := s.exec(ctx, "DELETE FROM device_tags WHERE device_uid = ? AND tag = ?", string(uid), tag)
+
+	return err
+}
+
+func (s *Store) DeviceUpdateTag(ctx context.Context, uid models.UID, tags []string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		return tx.deviceSetTags(ctx, string(uid), tags)
+	})
+}
+
+// deviceSetTags replaces the tags of a device.
+func (e executor) deviceSetTags(ctx context.Context, uid string, tags []string) error {
+	if _, err := e.exec(ctx, "DELETE FROM device_tags WHERE device_uid = ?", uid); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := e.exec(ctx, "INSERT INTO device_tags (device_uid, tag) SELECT uid, ? FROM devices WHERE uid = ?", tag, uid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceCreate(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	// Creating a device again updates it, keeping its name.
+	err = s.DeviceCreate(data.Context, data.Device, "other")
+	assert.NoError(t, err)
+
+	d, err := s.DeviceGetByUID(data.Context, models.UID(data.Device.UID), data.Device.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, "hostname", d.Name)
+	assert.Equal(t, models.DeviceStatusPending, d.Status)
+	assert.Equal(t, data.Device.Identity, d.Identity)
+}
+
+func TestDeviceGet(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = s.DeviceSetOnline(data.Context, models.UID(data.Device.UID), true)
+	assert.NoError(t, err)
+
+	d, err := s.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, data.Namespace.Name, d.Namespace)
+	assert.True(t, d.Online)
+	assert.Equal(t, []string{}, d.Tags)
+}
+
+func TestDeviceLookup(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = s.DeviceLookup(data.Context, data.Namespace.Name, "hostname")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	err = s.DeviceUpdateStatus(data.Context, models.UID(data.Device.UID), models.DeviceStatusAccepted)
+	assert.NoError(t, err)
+
+	d, err := s.DeviceLookup(data.Context, data.Namespace.Name, "hostname")
+	assert.NoError(t, err)
+	assert.Equal(t, data.Device.UID, d.UID)
+}
+
+func TestDeviceList(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	devices := []models.Device{
+		{UID: "uid1", Name: "device-1", TenantID: data.Namespace.TenantID, Identity: &models.DeviceIdentity{MAC: "mac1"}, LastSeen: data.Device.LastSeen},
+		{UID: "uid2", Name: "device-2", TenantID: data.Namespace.TenantID, Identity: &models.DeviceIdentity{MAC: "mac2"}, LastSeen: data.Device.LastSeen},
+		{UID: "uid3", Name: "other", TenantID: data.Namespace.TenantID, Identity: &models.DeviceIdentity{MAC: "mac3"}, LastSeen: data.Device.LastSeen},
+	}
+
+	for _, device := range devices {
+		assert.NoError(t, s.DeviceCreate(data.Context, device, ""))
+		assert.NoError(t, s.DeviceUpdateStatus(data.Context, models.UID(device.UID), models.DeviceStatusAccepted))
+	}
+
+	assert.NoError(t, s.DeviceUpdateTag(data.Context, "uid1", []string{"production"}))
+	assert.NoError(t, s.DeviceSetAttribute(data.Context, "uid2", "site", "berlin"))
+	assert.NoError(t, s.DeviceSetAttribute(data.Context, "uid3", "site", "paris"))
+
+	cases := []struct {
+		description string
+		filters     []models.Filter
+		pagination  paginator.Query
+		expected    []string
+		count       int
+	}{
+		{
+			description: "lists all devices",
+			pagination:  paginator.Query{Page: 1, PerPage: 10},
+			expected:    []string{"device-1", "device-2", "other"},
+			count:       3,
+		},
+		{
+			description: "paginates the devices",
+			pagination:  paginator.Query{Page: 2, PerPage: 2},
+			expected:    []string{"other"},
+			count:       3,
+		},
+		{
+			description: "filters the devices by name",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "name", Operator: "contains", Value: "device"}},
+			},
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			expected:   []string{"device-1", "device-2"},
+			count:      2,
+		},
+		{
+			description: "filters the devices by tag",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"production"}}},
+			},
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			expected:   []string{"device-1"},
+			count:      1,
+		},
+		{
+			description: "filters the devices by attribute",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "attributes.site", Operator: "eq", Value: "berlin"}},
+			},
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			expected:   []string{"device-2"},
+			count:      1,
+		},
+		{
+			description: "filters the devices by a part of an attribute",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "attributes.site", Operator: "contains", Value: "R"}},
+			},
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			expected:   []string{"device-2", "other"},
+			count:      2,
+		},
+		{
+			description: "filters the devices by an attribute that they do not have",
+			filters: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "attributes.rack", Operator: "eq", Value: "12"}},
+			},
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			expected:   []string{},
+			count:      0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			list, count, err := s.DeviceList(data.Context, tc.pagination, tc.filters, models.DeviceStatusAccepted, "name", "asc", store.DeviceListModeDefault)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.count, count)
+
+			names := make([]string, len(list))
+			for i, device := range list {
+				names[i] = device.Name
+			}
+
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestDeviceListAcceptable(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	list, _, err := s.DeviceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil, models.DeviceStatusPending, "", "", store.DeviceListModeDefault)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.True(t, list[0].Acceptable)
+
+	list, _, err = s.DeviceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil, models.DeviceStatusPending, "", "", store.DeviceListModeMaxDeviceReached)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.False(t, list[0].Acceptable)
+
+	err = s.DeviceRemovedInsert(data.Context, data.Device.TenantID, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	list, _, err = s.DeviceList(data.Context, paginator.Query{Page: 1, PerPage: 10}, nil, models.DeviceStatusPending, "", "", store.DeviceListModeMaxDeviceReached)
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.True(t, list[0].Acceptable)
+}
+
+func TestDeviceDelete(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = s.DeviceDelete(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	_, err = s.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestDeviceRemoved(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.DeviceRemovedInsert(data.Context, data.Device.TenantID, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	count, err := s.DeviceRemovedCount(data.Context, data.Device.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	removed, err := s.DeviceRemovedGet(data.Context, data.Device.TenantID, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, models.UID(data.Device.UID), removed.UID)
+
+	err = s.DeviceRemovedDelete(data.Context, data.Device.TenantID, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+
+	_, err = s.DeviceRemovedGet(data.Context, data.Device.TenantID, models.UID(data.Device.UID))
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestDeviceChooser(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	for _, uid := range []string{"uid1", "uid2"} {
+		assert.NoError(t, s.DeviceCreate(data.Context, models.Device{UID: uid, TenantID: data.Namespace.TenantID, Identity: &models.DeviceIdentity{MAC: uid}, LastSeen: data.Device.LastSeen}, ""))
+		assert.NoError(t, s.DeviceUpdateStatus(data.Context, models.UID(uid), models.DeviceStatusAccepted))
+	}
+
+	err := s.DeviceChooser(data.Context, data.Namespace.TenantID, []string{"uid1"})
+	assert.NoError(t, err)
+
+	d, err := s.DeviceGetByUID(data.Context, "uid2", data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeviceStatusPending, d.Status)
+}
+
+func TestDeviceAttributes(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	device := data.Device
+	device.Attributes = map[string]string{"site": "berlin", "rack": "12"}
+	assert.NoError(t, s.DeviceCreate(data.Context, device, "hostname"))
+
+	assert.NoError(t, s.DeviceSetAttribute(data.Context, models.UID(device.UID), "owner", "ops"))
+	assert.NoError(t, s.DeviceRemoveAttribute(data.Context, models.UID(device.UID), "rack"))
+
+	d, err := s.DeviceGet(data.Context, models.UID(device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "berlin", "owner": "ops"}, d.Attributes)
+
+	// Registering the device again replaces only the attributes that were sent.
+	device.Attributes = map[string]string{"site": "paris"}
+	assert.NoError(t, s.DeviceCreate(data.Context, device, "hostname"))
+
+	d, err = s.DeviceGet(data.Context, models.UID(device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "paris", "owner": "ops"}, d.Attributes)
+
+	assert.NoError(t, s.DeviceUpdateAttributes(data.Context, models.UID(device.UID), map[string]string{"rack": "13"}))
+
+	d, err = s.DeviceGet(data.Context, models.UID(device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"rack": "13"}, d.Attributes)
+}
+
+func TestDeviceHostKey(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.DeviceCreate(data.Context, data.Device, "hostname"))
+	assert.NoError(t, s.DeviceSetHostKey(data.Context, models.UID(data.Device.UID), "ssh-ed25519 key"))
+
+	// Registering the device again keeps its pinned host key.
+	assert.NoError(t, s.DeviceCreate(data.Context, data.Device, "hostname"))
+
+	d, err := s.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh-ed25519 key", d.HostKey)
+
+	assert.Equal(t, store.ErrNoDocuments, s.DeviceSetHostKey(data.Context, models.UID("unknown"), "ssh-ed25519 key"))
+}
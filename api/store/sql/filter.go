@@ -0,0 +1,242 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/order"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	ErrFilterInvalid         = errors.New("filter is invalid")
+	ErrFilterPropertyInvalid = errors.New("filter property is not valid")
+)
+
+// filterField is a property that can be used by a models.Filter.
+type filterField struct {
+	// Column is the SQL expression of the property.
+	Column string
+	// Tags, when set, is a subquery, with a "%s" verb to the condition, that checks the tags of the row through a
+	// column called "tag".
+	Tags string
+	// Attributes, when set, is a subquery, with a "%s" verb to the condition, that checks the value, through a column
+	// called "value", of the attribute whose key is its first argument. The field is used by the properties prefixed
+	// by its name and a dot, as "attributes.site".
+	Attributes string
+}
+
+// lookupFilterField returns the field of a property, resolving the properties of the attributes' fields.
+func lookupFilterField(fields map[string]filterField, name string) (filterField, string, bool) {
+	if field, ok := fields[name]; ok && field.Attributes == "" {
+		return field, "", true
+	}
+
+	prefix, key, ok := strings.Cut(name, ".")
+	if !ok || key == "" {
+		return filterField{}, "", false
+	}
+
+	field, ok := fields[prefix]
+	if !ok || field.Attributes == "" {
+		return filterField{}, "", false
+	}
+
+	return field, key, true
+}
+
+// buildFilterQuery creates a SQL condition from models.Filter for filtering the fields of a table.
+//
+// It follows the same rules of the Mongo's queries.BuildFilterQuery: properties are grouped by the operator that
+// follows them, and the groups are joined by AND. Properties that are not followed by an operator are joined by OR.
+// When there are no conditions, an empty string is returned.
+func buildFilterQuery(filters []models.Filter, fields map[string]filterField) (string, []interface{}, error) {
+	const (
+		TypeProperty = "property"
+		TypeOperator = "operator"
+	)
+
+	properties := map[string]func(field filterField, value interface{}) (string, []interface{}, error){
+		"contains": func(field filterField, value interface{}) (string, []interface{}, error) {
+			switch v := value.(type) {
+			case string:
+				if field.Tags != "" {
+					return fmt.Sprintf(field.Tags, "LOWER(tag) LIKE ? ESCAPE '\\'"), []interface{}{like(v)}, nil
+				}
+
+				return fmt.Sprintf("LOWER(%s) LIKE ? ESCAPE '\\'", field.Column), []interface{}{like(v)}, nil
+			case []interface{}:
+				if field.Tags == "" || len(v) == 0 {
+					return "", nil, ErrFilterPropertyInvalid
+				}
+
+				conditions := make([]string, len(v))
+				for i := range v {
+					conditions[i] = fmt.Sprintf(field.Tags, "tag = ?")
+				}
+
+				return "(" + strings.Join(conditions, " AND ") + ")", v, nil
+			}
+
+			return "", nil, ErrFilterPropertyInvalid
+		},
+		"eq": func(field filterField, value interface{}) (string, []interface{}, error) { //nolint:unparam
+			if field.Tags != "" {
+				return fmt.Sprintf(field.Tags, "tag = ?"), []interface{}{value}, nil
+			}
+
+			return fmt.Sprintf("%s = ?", field.Column), []interface{}{value}, nil
+		},
+		"bool": func(field filterField, value interface{}) (string, []interface{}, error) {
+			switch v := value.(type) {
+			case int:
+				value = v != 0
+			case float64:
+				value = v != 0
+			case string:
+				var err error
+				value, err = strconv.ParseBool(v)
+				if err != nil {
+					return "", nil, err
+				}
+			}
+
+			return fmt.Sprintf("%s = ?", field.Column), []interface{}{value}, nil
+		},
+		"gt": func(field filterField, value interface{}) (string, []interface{}, error) {
+			switch v := value.(type) {
+			case int:
+				value = v
+			case float64:
+				value = v
+			case string:
+				var err error
+				value, err = strconv.Atoi(v)
+				if err != nil {
+					return "", nil, err
+				}
+			}
+
+			return fmt.Sprintf("%s > ?", field.Column), []interface{}{value}, nil
+		},
+	}
+
+	operations := map[string]string{
+		"and": " AND ",
+		"or":  " OR ",
+	}
+
+	var conditions, groups []string
+	var args, groupArgs []interface{}
+
+	for _, filter := range filters {
+		switch filter.Type {
+		case TypeProperty:
+			params, ok := filter.Params.(*models.PropertyParams)
+			if !ok {
+				return "", nil, ErrFilterInvalid
+			}
+
+			fn, ok := properties[params.Operator]
+			if !ok {
+				// If the operator is not found, jump to next iteration.
+				continue
+			}
+
+			field, key, ok := lookupFilterField(fields, params.Name)
+			if !ok {
+				return "", nil, ErrFilterPropertyInvalid
+			}
+
+			var condition string
+			var values []interface{}
+			var err error
+			if field.Attributes != "" {
+				condition, values, err = fn(filterField{Column: "value"}, params.Value)
+				condition = fmt.Sprintf(field.Attributes, condition)
+				values = append([]interface{}{key}, values...)
+			} else {
+				condition, values, err = fn(field, params.Value)
+			}
+
+			if err != nil {
+				return "", nil, err
+			}
+
+			conditions = append(conditions, condition)
+			groupArgs = append(groupArgs, values...)
+		case TypeOperator:
+			params, ok := filter.Params.(*models.OperatorParams)
+			if !ok {
+				return "", nil, ErrFilterInvalid
+			}
+
+			operation, ok := operations[params.Name]
+			if !ok {
+				// If the operation's name is not found, jump to next iteration.
+				continue
+			}
+
+			if len(conditions) > 0 {
+				groups = append(groups, "("+strings.Join(conditions, operation)+")")
+				args = append(args, groupArgs...)
+			}
+
+			conditions, groupArgs = nil, nil
+		default:
+			return "", nil, ErrFilterInvalid
+		}
+	}
+
+	if len(conditions) > 0 {
+		groups = append(groups, "("+strings.Join(conditions, " OR ")+")")
+		args = append(args, groupArgs...)
+	}
+
+	return strings.Join(groups, " AND "), args, nil
+}
+
+// like escapes the value to be used as a case-insensitive "contains" pattern in a LIKE condition.
+func like(value string) string {
+	value = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(strings.ToLower(value))
+
+	return "%" + value + "%"
+}
+
+// buildPaginationQuery creates a LIMIT and OFFSET clause from a paginator.Query. When the PerPage is -1, the results
+// are not paginated.
+func buildPaginationQuery(pagination paginator.Query) string {
+	if pagination.PerPage == -1 {
+		return ""
+	}
+
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", pagination.PerPage, pagination.PerPage*(pagination.Page-1))
+}
+
+// buildOrderQuery creates an ORDER BY clause for column from an order.Query. The default order is ascending.
+func buildOrderQuery(ordination order.Query, column string) string {
+	if ordination.OrderBy == order.Desc {
+		return fmt.Sprintf(" ORDER BY %s DESC", column)
+	}
+
+	return fmt.Sprintf(" ORDER BY %s ASC", column)
+}
+
+// where joins the conditions that are not empty into a WHERE clause.
+func where(conditions ...string) string {
+	list := make([]string, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition != "" {
+			list = append(list, condition)
+		}
+	}
+
+	if len(list) == 0 {
+		return ""
+	}
+
+	return " WHERE " + strings.Join(list, " AND ")
+}
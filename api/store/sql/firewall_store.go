@@ -0,0 +1,207 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const firewallRuleColumns = "id, tenant_id, priority, action, active, source_ip, username, filter_hostname, filter_group"
+
+func scanFirewallRule(row scanner) (*models.FirewallRule, error) {
+	rule := new(models.FirewallRule)
+
+	if err := row.Scan(&rule.ID, &rule.TenantID, &rule.Priority, &rule.Action, &rule.Active, &rule.SourceIP, &rule.Username, &rule.Filter.Hostname, &rule.Filter.Group); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// loadFirewallRuleTags sets the filter's tags of each firewall rule in the list.
+func (e executor) loadFirewallRuleTags(ctx context.Context, rules []*models.FirewallRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(rules))
+	index := make(map[string]*models.FirewallRule, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.ID
+		index[rule.ID] = rule
+	}
+
+	rows, err := e.query(ctx, "SELECT rule_id, tag FROM firewall_rule_tags WHERE rule_id IN ("+placeholders(len(ids))+") ORDER BY id", ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return err
+		}
+
+		index[id].Filter.Tags = append(index[id].Filter.Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+// firewallRuleSetFields sets the fields of a firewall rule, including its filter's tags.
+func (e executor) firewallRuleSetFields(ctx context.Context, id string, fields models.FirewallRuleFields) error {
+	if _, err := e.exec(ctx, "UPDATE firewall_rules SET priority = ?, action = ?, active = ?, source_ip = ?, username = ?, filter_hostname = ?, filter_group = ? WHERE id = ?",
+		fields.Priority, fields.Action, fields.Active, fields.SourceIP, fields.Username, fields.Filter.Hostname, fields.Filter.Group, id); err != nil {
+		return err
+	}
+
+	return e.firewallRuleSetTags(ctx, id, fields.Filter.Tags)
+}
+
+// firewallRuleSetTags replaces the filter's tags of a firewall rule.
+func (e executor) firewallRuleSetTags(ctx context.Context, id string, tags []string) error {
+	if _, err := e.exec(ctx, "DELETE FROM firewall_rule_tags WHERE rule_id = ?", id); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := e.exec(ctx, "INSERT INTO firewall_rule_tags (rule_id, tag) VALUES (?, ?)", id, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	var condition string
+	var args []interface{}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		condition = "tenant_id = ?"
+		args = append(args, tenant.ID)
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM firewall_rules"+where(condition), args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rules, err := s.firewallRuleListWhere(ctx, where(condition)+" ORDER BY priority ASC"+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return rules, count, nil
+}
+
+func (s *Store) FirewallRuleListActive(ctx context.Context, tenant string) ([]models.FirewallRule, error) {
+	rules, err := s.firewallRuleListWhere(ctx, " WHERE tenant_id = ? AND active = ? ORDER BY priority ASC", tenant, true)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return rules, nil
+}
+
+// firewallRuleListWhere returns the firewall rules, with their filter's tags, matched by a condition.
+func (s *Store) firewallRuleListWhere(ctx context.Context, suffix string, args ...interface{}) ([]models.FirewallRule, error) {
+	rows, err := s.query(ctx, "SELECT "+firewallRuleColumns+" FROM firewall_rules"+suffix, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*models.FirewallRule, 0)
+	for rows.Next() {
+		rule, err := scanFirewallRule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadFirewallRuleTags(ctx, list); err != nil {
+		return nil, err
+	}
+
+	rules := make([]models.FirewallRule, len(list))
+	for i, rule := range list {
+		rules[i] = *rule
+	}
+
+	return rules, nil
+}
+
+func (s *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRule) error {
+	if err := rule.Validate(); err != nil {
+		return FromSQLError(err)
+	}
+
+	id := newID()
+
+	err := s.transaction(ctx, func(tx executor) error {
+		fields := rule.FirewallRuleFields
+		if _, err := tx.exec(ctx, "INSERT INTO firewall_rules ("+firewallRuleColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			id, rule.TenantID, fields.Priority, fields.Action, fields.Active, fields.SourceIP, fields.Username, fields.Filter.Hostname, fields.Filter.Group); err != nil {
+			return err
+		}
+
+		return tx.firewallRuleSetTags(ctx, id, fields.Filter.Tags)
+	})
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	rule.ID = id
+
+	return nil
+}
+
+func (s *Store) FirewallRuleGet(ctx context.Context, id string) (*models.FirewallRule, error) {
+	rule, err := scanFirewallRule(s.queryRow(ctx, "SELECT "+firewallRuleColumns+" FROM firewall_rules WHERE id = ?", id))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.loadFirewallRuleTags(ctx, []*models.FirewallRule{rule}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.transaction(ctx, func(tx executor) error {
+		return tx.firewallRuleSetFields(ctx, id, rule.FirewallRuleFields)
+	}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return s.FirewallRuleGet(ctx, id)
+}
+
+func (s *Store) FirewallRuleDelete(ctx context.Context, id string) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM firewall_rule_tags WHERE rule_id = ?", id); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "DELETE FROM firewall_rules WHERE id = ?", id)
+
+		return err
+	}))
+}
@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// FirewallRuleAddTag adds a tag to the tag's list in models.FirewallRule.
+//
+// The tag needs to exist on a models.Device. If it is not, the tag addition to
+// models.FirewallRule will fail.
+func (s *Store) FirewallRuleAddTag(ctx context.Context, id, tag string) error {
+	result, err := s.exec(ctx, `INSERT INTO firewall_rule_tags (rule_id, tag) SELECT id, ? FROM firewall_rules WHERE id = ?
+	AND NOT EXISTS (SELECT 1 FROM firewall_rule_tags WHERE rule_id = ? AND tag = ?)`, tag, id, id, tag)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// FirewallRuleRemoveTag removes a tag from the tag's list in models.FirewallRule.
+//
+// The tag needs to exist on a models.Device. If it is not, the tag deletion from
+// models.FirewallRule will fail.
+func (s *Store) FirewallRuleRemoveTag(ctx context.Context, id, tag string) error {
+	result, err := s.exec(ctx, "DELETE FROM firewall_rule_tags WHERE rule_id = ? AND tag = ?", id, tag)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// FirewallRuleUpdateTags update with a new set the tag's list in models.FirewallRule.
+//
+// All tags need to exist on a models.Device. If it is not true, the tags' update
+// to models.FirewallRule will fail.
+func (s *Store) FirewallRuleUpdateTags(ctx context.Context, id string, tags []string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		current, err := tx.strings(ctx, "SELECT tag FROM firewall_rule_tags WHERE rule_id = ? ORDER BY id", id)
+		if err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.queryRow(ctx, "SELECT COUNT(*) FROM firewall_rules WHERE id = ?", id).Scan(&count); err != nil {
+			return err
+		}
+
+		// Like in Mongo, when nothing is modified, the update is considered as not found.
+		if count == 0 || equal(current, tags) {
+			return store.ErrNoDocuments
+		}
+
+		return tx.firewallRuleSetTags(ctx, id, tags)
+	})
+}
+
+// FirewallRuleRenameTag renames a tag to a new name in models.FirewallRule.
+func (s *Store) FirewallRuleRenameTag(ctx context.Context, tenant, tagCurrent, tagNew string) error {
+	result, err := s.exec(ctx, "UPDATE firewall_rule_tags SET tag = ? WHERE tag = ? AND rule_id IN (SELECT id FROM firewall_rules WHERE tenant_id = ?)", tagNew, tagCurrent, tenant)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// FirewallRuleDeleteTag removes a tag from all models.FirewallRule.
+func (s *Store) FirewallRuleDeleteTag(ctx context.Context, tenant, tag string) error {
+	result, err := s.exec(ctx, "DELETE FROM firewall_rule_tags WHERE tag = ? AND rule_id IN (SELECT id FROM firewall_rules WHERE tenant_id = ?)", tag, tenant)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// FirewallRuleGetTags gets all tags from all models.FirewallRule.
+func (s *Store) FirewallRuleGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	tags, err := s.strings(ctx, "SELECT DISTINCT t.tag FROM firewall_rule_tags t JOIN firewall_rules r ON r.id = t.rule_id WHERE r.tenant_id = ? ORDER BY t.tag", tenant)
+
+	return tags, len(tags), err
+}
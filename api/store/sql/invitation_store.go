@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const invitationColumns = "id, tenant_id, email, role, invited_by, digest, created_at, expires_at"
+
+func scanInvitation(row scanner) (*models.Invitation, error) {
+	invitation := new(models.Invitation)
+
+	if err := row.Scan(&invitation.ID, &invitation.TenantID, &invitation.Email, &invitation.Role, &invitation.InvitedBy, &invitation.Digest, &invitation.CreatedAt, &invitation.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationCreate(ctx context.Context, invitation *models.Invitation) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO invitations ("+invitationColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		id, invitation.TenantID, invitation.Email, invitation.Role, invitation.InvitedBy, invitation.Digest, invitation.CreatedAt, invitation.ExpiresAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	invitation.ID = id
+
+	return nil
+}
+
+// InvitationList returns the invitations of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) InvitationList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Invitation, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM invitations WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+invitationColumns+" FROM invitations WHERE tenant_id = ? ORDER BY created_at DESC"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	invitations := make([]models.Invitation, 0)
+	for rows.Next() {
+		invitation, err := scanInvitation(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		invitations = append(invitations, *invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return invitations, count, nil
+}
+
+func (s *Store) InvitationGet(ctx context.Context, tenant, id string) (*models.Invitation, error) {
+	invitation, err := scanInvitation(s.queryRow(ctx, "SELECT "+invitationColumns+" FROM invitations WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetByEmail(ctx context.Context, tenant, email string) (*models.Invitation, error) {
+	invitation, err := scanInvitation(s.queryRow(ctx, "SELECT "+invitationColumns+" FROM invitations WHERE tenant_id = ? AND email = ?", tenant, email))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationGetByDigest(ctx context.Context, digest string) (*models.Invitation, error) {
+	invitation, err := scanInvitation(s.queryRow(ctx, "SELECT "+invitationColumns+" FROM invitations WHERE digest = ?", digest))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *Store) InvitationDelete(ctx context.Context, tenant, id string) error {
+	result, err := s.exec(ctx, "DELETE FROM invitations WHERE id = ? AND tenant_id = ?", id, tenant)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
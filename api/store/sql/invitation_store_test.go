@@ -0,0 +1,60 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitation(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestStore(t)
+
+	now := clock.Now()
+
+	invitations := []models.Invitation{
+		{TenantID: "tenant", Email: "first@example.com", Role: "operator", InvitedBy: "id", Digest: "first", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{TenantID: "tenant", Email: "second@example.com", Role: "observer", InvitedBy: "id", Digest: "second", CreatedAt: now.Add(time.Second), ExpiresAt: now.Add(time.Hour)},
+		{TenantID: "other", Email: "first@example.com", Role: "observer", InvitedBy: "id", Digest: "third", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+	}
+
+	for i := range invitations {
+		assert.NoError(t, s.InvitationCreate(ctx, &invitations[i]))
+		assert.NotEmpty(t, invitations[i].ID)
+	}
+
+	assert.Equal(t, store.ErrDuplicate, s.InvitationCreate(ctx, &models.Invitation{TenantID: "tenant", Email: "first@example.com", Digest: "fourth", CreatedAt: now, ExpiresAt: now}))
+
+	list, count, err := s.InvitationList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "second@example.com", list[0].Email)
+	assert.Equal(t, "first@example.com", list[1].Email)
+
+	invitation, err := s.InvitationGet(ctx, "tenant", invitations[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "operator", invitation.Role)
+
+	_, err = s.InvitationGet(ctx, "other", invitations[0].ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	invitation, err = s.InvitationGetByEmail(ctx, "other", "first@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, invitations[2].ID, invitation.ID)
+
+	invitation, err = s.InvitationGetByDigest(ctx, "second")
+	assert.NoError(t, err)
+	assert.Equal(t, invitations[1].ID, invitation.ID)
+
+	assert.Equal(t, store.ErrNoDocuments, s.InvitationDelete(ctx, "other", invitations[0].ID))
+	assert.NoError(t, s.InvitationDelete(ctx, "tenant", invitations[0].ID))
+
+	_, err = s.InvitationGetByDigest(ctx, "first")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
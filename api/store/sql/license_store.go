@@ -0,0 +1,22 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) LicenseLoad(ctx context.Context) (*models.License, error) {
+	license := new(models.License)
+	if err := s.queryRow(ctx, "SELECT raw_data, created_at FROM licenses ORDER BY created_at DESC LIMIT 1").Scan(&license.RawData, &license.CreatedAt); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return license, nil
+}
+
+func (s *Store) LicenseSave(ctx context.Context, license *models.License) error {
+	_, err := s.exec(ctx, "INSERT INTO licenses (raw_data, created_at) VALUES (?, ?)", license.RawData, license.CreatedAt)
+
+	return FromSQLError(err)
+}
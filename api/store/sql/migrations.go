@@ -0,0 +1,129 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/sirupsen/logrus"
+)
+
+// migrations contains the schema migrations of each dialect. Each migration is a file named as "<version>_<name>.sql",
+// applied in ascending version order, inside a transaction.
+//
+//go:embed migrations
+var migrations embed.FS
+
+type migration struct {
+	version uint64
+	name    string
+	query   string
+}
+
+func loadMigrations(dialect Dialect) ([]migration, error) {
+	dir := path.Join("migrations", string(dialect))
+
+	entries, err := migrations.ReadDir(dir)
+	if err != nil {
+		return nil, ErrDialectNotSupported
+	}
+
+	list := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".sql")
+
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s: %w", entry.Name(), err)
+		}
+
+		data, err := migrations.ReadFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, migration{version: version, name: name, query: string(data)})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version < list[j].version
+	})
+
+	return list, nil
+}
+
+// ApplyMigrations applies the schema migrations not yet applied to the database.
+func ApplyMigrations(db *dbsql.DB, dialect Dialect) error {
+	ctx := context.TODO()
+	e := executor{q: db, dialect: dialect}
+
+	list, err := loadMigrations(dialect)
+	if err != nil {
+		return err
+	}
+
+	if _, err := e.exec(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, name TEXT NOT NULL, applied_at TIMESTAMP NOT NULL)"); err != nil {
+		return errors.Wrap(err, "Failed to create the migrations table")
+	}
+
+	var current uint64
+	if err := e.queryRow(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return errors.Wrap(err, "Failed to get current migration version")
+	}
+
+	if len(list) == 0 || current == list[len(list)-1].version {
+		logrus.Info("No migrations to apply")
+
+		return nil
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"from": current,
+		"to":   list[len(list)-1].version,
+	}).Info("Migrating database")
+
+	for _, m := range list {
+		if m.version <= current {
+			continue
+		}
+
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   m.version,
+			"name":      m.name,
+		}).Info("Applying migration")
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		e := executor{q: tx, dialect: dialect}
+
+		if _, err := tx.ExecContext(ctx, m.query); err != nil {
+			_ = tx.Rollback()
+
+			return errors.Wrapf(err, "Failed to apply migration %s", m.name)
+		}
+
+		if _, err := e.exec(ctx, "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)", m.version, m.name, clock.Now()); err != nil {
+			_ = tx.Rollback()
+
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
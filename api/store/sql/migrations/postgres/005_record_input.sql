@@ -0,0 +1,3 @@
+ALTER TABLE namespaces ADD COLUMN record_input BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE recorded_sessions ADD COLUMN type TEXT NOT NULL DEFAULT '';
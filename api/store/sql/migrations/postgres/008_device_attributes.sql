@@ -0,0 +1,8 @@
+CREATE TABLE device_attributes (
+    device_uid TEXT NOT NULL,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (device_uid, name)
+);
+
+CREATE INDEX device_attributes_name_value ON device_attributes (name, value);
@@ -0,0 +1 @@
+ALTER TABLE namespace_members ADD COLUMN scope TEXT;
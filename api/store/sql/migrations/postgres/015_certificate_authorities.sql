@@ -0,0 +1,6 @@
+CREATE TABLE certificate_authorities (
+    tenant_id TEXT PRIMARY KEY,
+    data BYTEA NOT NULL,
+    public_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE private_keys ADD COLUMN legacy_fingerprint TEXT NOT NULL DEFAULT '';
+CREATE INDEX private_keys_legacy_fingerprint ON private_keys (legacy_fingerprint);
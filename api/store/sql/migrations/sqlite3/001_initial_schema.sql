@@ -0,0 +1,184 @@
+CREATE TABLE users (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL UNIQUE,
+    email TEXT NOT NULL UNIQUE,
+    password TEXT NOT NULL DEFAULT '',
+    confirmed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    last_login TIMESTAMP NOT NULL,
+    email_marketing BOOLEAN NOT NULL DEFAULT FALSE,
+    max_namespaces INTEGER NOT NULL DEFAULT 0,
+    namespaces INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE recovery_tokens (
+    token TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX recovery_tokens_user_id ON recovery_tokens (user_id);
+
+CREATE TABLE namespaces (
+    tenant_id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    owner TEXT NOT NULL DEFAULT '',
+    max_devices INTEGER NOT NULL DEFAULT 0,
+    session_record BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    billing TEXT
+);
+
+CREATE TABLE namespace_members (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL,
+    UNIQUE (tenant_id, user_id)
+);
+
+CREATE INDEX namespace_members_user_id ON namespace_members (user_id);
+
+CREATE TABLE devices (
+    uid TEXT PRIMARY KEY,
+    name TEXT NOT NULL DEFAULT '',
+    tenant_id TEXT NOT NULL DEFAULT '',
+    identity_mac TEXT,
+    info TEXT,
+    public_key TEXT NOT NULL DEFAULT '',
+    last_seen TIMESTAMP NOT NULL,
+    online BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL,
+    remote_addr TEXT NOT NULL DEFAULT '',
+    latitude REAL,
+    longitude REAL,
+    public_url BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX devices_tenant_id_name ON devices (tenant_id, name);
+CREATE INDEX devices_tenant_id_status ON devices (tenant_id, status);
+CREATE INDEX devices_last_seen ON devices (last_seen);
+
+CREATE TABLE device_tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    device_uid TEXT NOT NULL,
+    tag TEXT NOT NULL
+);
+
+CREATE INDEX device_tags_device_uid ON device_tags (device_uid);
+CREATE INDEX device_tags_tag ON device_tags (tag);
+
+CREATE TABLE connected_devices (
+    uid TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT '',
+    last_seen TIMESTAMP NOT NULL
+);
+
+CREATE TABLE removed_devices (
+    tenant_id TEXT NOT NULL,
+    uid TEXT NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    PRIMARY KEY (tenant_id, uid)
+);
+
+CREATE TABLE sessions (
+    uid TEXT PRIMARY KEY,
+    device_uid TEXT NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL DEFAULT '',
+    ip_address TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP NOT NULL,
+    last_seen TIMESTAMP NOT NULL,
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    authenticated BOOLEAN NOT NULL DEFAULT FALSE,
+    recorded BOOLEAN NOT NULL DEFAULT FALSE,
+    type TEXT NOT NULL DEFAULT '',
+    term TEXT NOT NULL DEFAULT '',
+    latitude REAL NOT NULL DEFAULT 0,
+    longitude REAL NOT NULL DEFAULT 0
+);
+
+CREATE INDEX sessions_tenant_id ON sessions (tenant_id);
+CREATE INDEX sessions_device_uid ON sessions (device_uid);
+CREATE INDEX sessions_started_at ON sessions (started_at);
+
+CREATE TABLE active_sessions (
+    uid TEXT PRIMARY KEY,
+    last_seen TIMESTAMP NOT NULL
+);
+
+CREATE TABLE recorded_sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uid TEXT NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    time TIMESTAMP NOT NULL,
+    width INTEGER NOT NULL DEFAULT 0,
+    height INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX recorded_sessions_uid ON recorded_sessions (uid);
+CREATE INDEX recorded_sessions_time ON recorded_sessions (time);
+
+CREATE TABLE public_keys (
+    fingerprint TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    data BLOB,
+    created_at TIMESTAMP NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    username TEXT NOT NULL DEFAULT '',
+    filter_hostname TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (fingerprint, tenant_id)
+);
+
+CREATE TABLE public_key_tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    tag TEXT NOT NULL
+);
+
+CREATE INDEX public_key_tags_key ON public_key_tags (tenant_id, fingerprint);
+
+CREATE TABLE private_keys (
+    fingerprint TEXT PRIMARY KEY,
+    data BLOB,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE firewall_rules (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    action TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    source_ip TEXT NOT NULL,
+    username TEXT NOT NULL,
+    filter_hostname TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX firewall_rules_tenant_id ON firewall_rules (tenant_id);
+
+CREATE TABLE firewall_rule_tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_id TEXT NOT NULL,
+    tag TEXT NOT NULL
+);
+
+CREATE INDEX firewall_rule_tags_rule_id ON firewall_rule_tags (rule_id);
+
+CREATE TABLE announcements (
+    uuid TEXT PRIMARY KEY,
+    title TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    date TIMESTAMP NOT NULL
+);
+
+CREATE TABLE licenses (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    raw_data BLOB,
+    created_at TIMESTAMP NOT NULL
+);
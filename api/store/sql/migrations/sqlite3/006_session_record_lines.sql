@@ -0,0 +1,15 @@
+CREATE TABLE session_record_lines (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uid TEXT NOT NULL,
+    tenant_id TEXT NOT NULL,
+    device_uid TEXT NOT NULL,
+    username TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    type TEXT NOT NULL,
+    elapsed DOUBLE PRECISION NOT NULL,
+    text TEXT NOT NULL
+);
+
+CREATE INDEX session_record_lines_uid ON session_record_lines (uid);
+
+CREATE INDEX session_record_lines_tenant_id_started_at ON session_record_lines (tenant_id, started_at);
@@ -0,0 +1,12 @@
+CREATE TABLE device_groups (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    filter TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (tenant_id, name)
+);
+
+ALTER TABLE public_keys ADD COLUMN filter_group TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE firewall_rules ADD COLUMN filter_group TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN mfa_secret TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN mfa_recovery_codes TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE namespaces ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,20 @@
+CREATE TABLE access_grants (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    member TEXT NOT NULL DEFAULT '',
+    fingerprint TEXT NOT NULL DEFAULT '',
+    device TEXT NOT NULL DEFAULT '',
+    tag TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    expired BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX access_grants_tenant_id ON access_grants (tenant_id);
+CREATE INDEX access_grants_expires_at ON access_grants (expires_at);
+
+ALTER TABLE sessions ADD COLUMN grant_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX sessions_grant_id ON sessions (grant_id);
@@ -0,0 +1,19 @@
+CREATE TABLE access_requests (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    member TEXT NOT NULL DEFAULT '',
+    fingerprint TEXT NOT NULL DEFAULT '',
+    device TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    duration INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    decided_by TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP,
+    grant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX access_requests_tenant_id ON access_requests (tenant_id);
+CREATE INDEX access_requests_device ON access_requests (device);
+
+ALTER TABLE namespaces ADD COLUMN approval_tags TEXT;
@@ -0,0 +1,6 @@
+CREATE TABLE certificate_authorities (
+    tenant_id TEXT PRIMARY KEY,
+    data BLOB NOT NULL,
+    public_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
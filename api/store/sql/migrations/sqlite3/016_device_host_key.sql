@@ -0,0 +1 @@
+ALTER TABLE devices ADD COLUMN host_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE public_keys ADD COLUMN created_by TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,526 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+const namespaceColumns = "n.name, n.owner, n.tenant_id, n.max_devices, n.session_record, n.record_input, n.require_mfa, n.approval_tags, n.created_at, n.billing"
+
+// namespaceFilterFields are the properties of a models.Namespace accepted by the NamespaceList's filters.
+var namespaceFilterFields = map[string]filterField{
+	"name":                    {Column: "n.name"},
+	"owner":                   {Column: "n.owner"},
+	"tenant_id":               {Column: "n.tenant_id"},
+	"max_devices":             {Column: "n.max_devices"},
+	"settings.session_record": {Column: "n.session_record"},
+	"settings.record_input":   {Column: "n.record_input"},
+	"settings.require_mfa":    {Column: "n.require_mfa"},
+	"devices":                 {Column: "(SELECT COUNT(*) FROM devices WHERE devices.tenant_id = n.tenant_id)"},
+	"sessions":                {Column: "(SELECT COUNT(*) FROM sessions WHERE sessions.tenant_id = n.tenant_id)"},
+}
+
+// scanNamespace scans a row with the namespaceColumns, followed by the extra destinations, into a models.Namespace.
+func scanNamespace(row scanner, extra ...interface{}) (*models.Namespace, error) {
+	ns := new(models.Namespace)
+	ns.Settings = new(models.NamespaceSettings)
+
+	var approvalTags, billing dbsql.NullString
+
+	dest := []interface{}{&ns.Name, &ns.Owner, &ns.TenantID, &ns.MaxDevices, &ns.Settings.SessionRecord, &ns.Settings.RecordInput, &ns.Settings.RequireMFA, &approvalTags, &ns.CreatedAt, &billing}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if approvalTags.Valid {
+		if err := json.Unmarshal([]byte(approvalTags.String), &ns.Settings.ApprovalTags); err != nil {
+			return nil, err
+		}
+	}
+
+	if billing.Valid {
+		if err := json.Unmarshal([]byte(billing.String), &ns.Billing); err != nil {
+			return nil, err
+		}
+	}
+
+	return ns, nil
+}
+
+// loadNamespaceMembers sets the members of each namespace in the list.
+func (e executor) loadNamespaceMembers(ctx context.Context, namespaces []*models.Namespace) error {
+	for _, ns := range namespaces {
+		rows, err := e.query(ctx, "SELECT user_id, role, scope FROM namespace_members WHERE tenant_id = ? ORDER BY id", ns.TenantID)
+		if err != nil {
+			return err
+		}
+
+		ns.Members = []models.Member{}
+		for rows.Next() {
+			var member models.Member
+			var scope dbsql.NullString
+			if err := rows.Scan(&member.ID, &member.Role, &scope); err != nil {
+				rows.Close()
+
+				return err
+			}
+
+			if scope.Valid {
+				if err := json.Unmarshal([]byte(scope.String), &member.Scope); err != nil {
+					rows.Close()
+
+					return err
+				}
+			}
+
+			ns.Members = append(ns.Members, member)
+		}
+
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// namespaceGetWhere gets the first namespace, with its members, that matches the condition.
+func (e executor) namespaceGetWhere(ctx context.Context, condition string, args ...interface{}) (*models.Namespace, error) {
+	ns, err := scanNamespace(e.queryRow(ctx, "SELECT "+namespaceColumns+" FROM namespaces n WHERE "+condition+" LIMIT 1", args...))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := e.loadNamespaceMembers(ctx, []*models.Namespace{ns}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return ns, nil
+}
+
+// namespaceDevicesCount counts the accepted devices of a namespace.
+func (e executor) namespaceDevicesCount(ctx context.Context, tenantID string) (int, error) {
+	var count int
+	err := e.queryRow(ctx, "SELECT COUNT(*) FROM devices WHERE tenant_id = ? AND status = ?", tenantID, string(models.DeviceStatusAccepted)).Scan(&count)
+
+	return count, FromSQLError(err)
+}
+
+func (s *Store) NamespaceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, export bool) ([]models.Namespace, int, error) {
+	filter, args, err := buildFilterQuery(filters, namespaceFilterFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{filter}
+
+	// Only match for the respective tenant if requested
+	if id := gateway.IDFromContext(ctx); id != nil {
+		user, _, err := s.UserGetByID(ctx, id.ID, false)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM namespace_members m WHERE m.tenant_id = n.tenant_id AND m.user_id = ?)")
+		args = append(args, user.ID)
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM namespaces n"+where(conditions...), args...).Scan(&count); err != nil {
+		return nil, 0, err
+	}
+
+	var paginate string
+	if pagination.Page != 0 && pagination.PerPage != 0 {
+		paginate = buildPaginationQuery(pagination)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+namespaceColumns+", "+namespaceFilterFields["devices"].Column+", "+namespaceFilterFields["sessions"].Column+
+		" FROM namespaces n"+where(conditions...)+" ORDER BY n.created_at ASC"+paginate, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]*models.Namespace, 0)
+	for rows.Next() {
+		var devices, sessions int
+
+		ns, err := scanNamespace(rows, &devices, &sessions)
+		if err != nil {
+			rows.Close()
+
+			return nil, count, err
+		}
+
+		if export {
+			ns.Devices, ns.Sessions = devices, sessions
+		}
+
+		list = append(list, ns)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.loadNamespaceMembers(ctx, list); err != nil {
+		return nil, 0, err
+	}
+
+	namespaces := make([]models.Namespace, 0, len(list))
+	for _, ns := range list {
+		ns.DevicesCount, err = s.namespaceDevicesCount(ctx, ns.TenantID)
+		if err != nil {
+			return namespaces, 0, err
+		}
+
+		namespaces = append(namespaces, *ns)
+	}
+
+	return namespaces, count, nil
+}
+
+func (s *Store) NamespaceGet(ctx context.Context, tenantID string) (*models.Namespace, error) {
+	var ns *models.Namespace
+
+	if err := s.cache.Get(ctx, strings.Join([]string{"namespace", tenantID}, "/"), &ns); err != nil {
+		logrus.Error(err)
+	}
+
+	if ns == nil {
+		var err error
+		if ns, err = s.namespaceGetWhere(ctx, "n.tenant_id = ?", tenantID); err != nil {
+			return nil, err
+		}
+
+		if err := s.cache.Set(ctx, strings.Join([]string{"namespace", tenantID}, "/"), ns, time.Minute); err != nil {
+			logrus.Error(err)
+		}
+	}
+
+	count, err := s.namespaceDevicesCount(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	ns.DevicesCount = count
+
+	return ns, nil
+}
+
+func (s *Store) NamespaceGetByName(ctx context.Context, name string) (*models.Namespace, error) {
+	var ns *models.Namespace
+
+	if err := s.cache.Get(ctx, strings.Join([]string{"namespace", name}, "/"), &ns); err != nil {
+		logrus.Error(err)
+	}
+
+	if ns != nil {
+		return ns, nil
+	}
+
+	return s.namespaceGetWhere(ctx, "n.name = ?", name)
+}
+
+func (s *Store) NamespaceCreate(ctx context.Context, namespace *models.Namespace) (*models.Namespace, error) {
+	var billing interface{}
+	if namespace.Billing != nil {
+		data, err := json.Marshal(namespace.Billing)
+		if err != nil {
+			return nil, err
+		}
+
+		billing = string(data)
+	}
+
+	var sessionRecord, recordInput, requireMFA bool
+	var approvalTags interface{}
+	if namespace.Settings != nil {
+		sessionRecord = namespace.Settings.SessionRecord
+		recordInput = namespace.Settings.RecordInput
+		requireMFA = namespace.Settings.RequireMFA
+
+		value, err := approvalTagsValue(namespace.Settings.ApprovalTags)
+		if err != nil {
+			return nil, err
+		}
+
+		approvalTags = value
+	}
+
+	if err := s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "INSERT INTO namespaces (name, owner, tenant_id, max_devices, session_record, record_input, require_mfa, approval_tags, created_at, billing) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			namespace.Name, namespace.Owner, namespace.TenantID, namespace.MaxDevices, sessionRecord, recordInput, requireMFA, approvalTags, namespace.CreatedAt, billing); err != nil {
+			return err
+		}
+
+		for _, member := range namespace.Members {
+			scope, err := memberScopeValue(member.Scope)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, "INSERT INTO namespace_members (tenant_id, user_id, role, scope) VALUES (?, ?, ?, ?)", namespace.TenantID, member.ID, member.Role, scope); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.exec(ctx, "UPDATE users SET namespaces = namespaces + 1 WHERE id = ?", namespace.Owner)
+
+		return err
+	}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return namespace, nil
+}
+
+func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
+	ns, err := s.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.transaction(ctx, func(tx executor) error {
+		queries := []string{
+			"DELETE FROM namespaces WHERE tenant_id = ?",
+			"DELETE FROM namespace_members WHERE tenant_id = ?",
+			"DELETE FROM device_tags WHERE device_uid IN (SELECT uid FROM devices WHERE tenant_id = ?)",
+			"DELETE FROM device_attributes WHERE device_uid IN (SELECT uid FROM devices WHERE tenant_id = ?)",
+			"DELETE FROM devices WHERE tenant_id = ?",
+			"DELETE FROM sessions WHERE tenant_id = ?",
+			"DELETE FROM connected_devices WHERE tenant_id = ?",
+			"DELETE FROM firewall_rule_tags WHERE rule_id IN (SELECT id FROM firewall_rules WHERE tenant_id = ?)",
+			"DELETE FROM firewall_rules WHERE tenant_id = ?",
+			"DELETE FROM public_key_tags WHERE tenant_id = ?",
+			"DELETE FROM public_keys WHERE tenant_id = ?",
+			"DELETE FROM recorded_sessions WHERE tenant_id = ?",
+			"DELETE FROM session_record_lines WHERE tenant_id = ?",
+			"DELETE FROM api_keys WHERE tenant_id = ?",
+			"DELETE FROM webhook_events WHERE webhook_id IN (SELECT id FROM webhooks WHERE tenant_id = ?)",
+			"DELETE FROM webhooks WHERE tenant_id = ?",
+			"DELETE FROM webhook_deliveries WHERE tenant_id = ?",
+			"DELETE FROM device_groups WHERE tenant_id = ?",
+			"DELETE FROM invitations WHERE tenant_id = ?",
+			"DELETE FROM roles WHERE tenant_id = ?",
+			"DELETE FROM access_grants WHERE tenant_id = ?",
+			"DELETE FROM access_requests WHERE tenant_id = ?",
+			"DELETE FROM certificate_authorities WHERE tenant_id = ?",
+		}
+
+		for _, query := range queries {
+			if _, err := tx.exec(ctx, query, tenantID); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.exec(ctx, "UPDATE users SET namespaces = namespaces - 1 WHERE id = ?", ns.Owner)
+
+		return err
+	}); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceRename(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
+	if _, err := s.exec(ctx, "UPDATE namespaces SET name = ? WHERE tenant_id = ?", name, tenantID); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace *models.Namespace) error {
+	var sessionRecord, recordInput, requireMFA bool
+	if namespace.Settings != nil {
+		sessionRecord = namespace.Settings.SessionRecord
+		recordInput = namespace.Settings.RecordInput
+		requireMFA = namespace.Settings.RequireMFA
+	}
+
+	if _, err := s.exec(ctx, "UPDATE namespaces SET name = ?, max_devices = ?, session_record = ?, record_input = ?, require_mfa = ? WHERE tenant_id = ?", namespace.Name, namespace.MaxDevices, sessionRecord, recordInput, requireMFA, tenantID); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM namespace_members WHERE tenant_id = ? AND user_id = ?", tenantID, memberID).Scan(&count); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if count > 0 {
+		return nil, ErrNamespaceDuplicatedMember
+	}
+
+	if _, err := s.exec(ctx, "INSERT INTO namespace_members (tenant_id, user_id, role) SELECT tenant_id, ?, ? FROM namespaces WHERE tenant_id = ?", memberID, memberRole, tenantID); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, memberID string) (*models.Namespace, error) {
+	result, err := s.exec(ctx, "DELETE FROM namespace_members WHERE tenant_id = ? AND user_id = ?", tenantID, memberID)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return s.NamespaceGet(ctx, tenantID)
+}
+
+func (s *Store) NamespaceEditMember(ctx context.Context, tenantID string, memberID string, memberNewRole string) error {
+	if _, err := s.exec(ctx, "UPDATE namespace_members SET role = ? WHERE tenant_id = ? AND user_id = ?", memberNewRole, tenantID, memberID); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetMemberScope(ctx context.Context, tenantID string, memberID string, scope *models.MemberScope) error {
+	value, err := memberScopeValue(scope)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.exec(ctx, "UPDATE namespace_members SET scope = ? WHERE tenant_id = ? AND user_id = ?", value, tenantID, memberID); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+// memberScopeValue encodes a member's scope as JSON, or as NULL when the scope is empty.
+func memberScopeValue(scope *models.MemberScope) (interface{}, error) {
+	if scope.IsEmpty() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (s *Store) NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error) {
+	return s.namespaceGetWhere(ctx, "n.tenant_id = (SELECT tenant_id FROM namespace_members WHERE user_id = ? ORDER BY id LIMIT 1)", id)
+}
+
+func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
+	if _, err := s.exec(ctx, "UPDATE namespaces SET session_record = ? WHERE tenant_id = ?", sessionRecord, tenantID); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetRecordInput(ctx context.Context, recordInput bool, tenantID string) error {
+	if _, err := s.exec(ctx, "UPDATE namespaces SET record_input = ? WHERE tenant_id = ?", recordInput, tenantID); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetRequireMFA(ctx context.Context, requireMFA bool, tenantID string) error {
+	if _, err := s.exec(ctx, "UPDATE namespaces SET require_mfa = ? WHERE tenant_id = ?", requireMFA, tenantID); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceSetApprovalTags(ctx context.Context, tags []string, tenantID string) error {
+	value, err := approvalTagsValue(tags)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.exec(ctx, "UPDATE namespaces SET approval_tags = ? WHERE tenant_id = ?", value, tenantID); err != nil {
+		return FromSQLError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+// approvalTagsValue converts the namespace's approval tags to the value of their column, which is NULL when there is
+// none.
+func approvalTagsValue(tags []string) (interface{}, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
+	var sessionRecord bool
+	if err := s.queryRow(ctx, "SELECT session_record FROM namespaces WHERE tenant_id = ?", tenantID).Scan(&sessionRecord); err != nil {
+		return false, FromSQLError(err)
+	}
+
+	return sessionRecord, nil
+}
@@ -0,0 +1,24 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error {
+	_, err := s.exec(ctx, "INSERT INTO private_keys (fingerprint, legacy_fingerprint, data, created_at) VALUES (?, ?, ?, ?)", key.Fingerprint, key.LegacyFingerprint, key.Data, key.CreatedAt)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) PrivateKeyGet(ctx context.Context, fingerprint string) (*models.PrivateKey, error) {
+	privKey := new(models.PrivateKey)
+	if err := s.queryRow(ctx, "SELECT fingerprint, legacy_fingerprint, data, created_at FROM private_keys WHERE (fingerprint = ? OR legacy_fingerprint = ?) AND created_at > ?", fingerprint, fingerprint, clock.Now().Add(-privateKeyTTL)).
+		Scan(&privKey.Fingerprint, &privKey.LegacyFingerprint, &privKey.Data, &privKey.CreatedAt); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return privKey, nil
+}
@@ -0,0 +1,30 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivateKey(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.PrivateKeyCreate(data.Context, &models.PrivateKey{Data: []byte("data"), Fingerprint: "SHA256:fingerprint", LegacyFingerprint: "legacy", CreatedAt: clock.Now()})
+	assert.NoError(t, err)
+
+	key, err := s.PrivateKeyGet(data.Context, "SHA256:fingerprint")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), key.Data)
+
+	// The agents that predate the SHA256 fingerprints look the key up by its legacy one.
+	key, err = s.PrivateKeyGet(data.Context, "legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA256:fingerprint", key.Fingerprint)
+
+	_, err = s.PrivateKeyGet(data.Context, "missing")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
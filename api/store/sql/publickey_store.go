@@ -0,0 +1,155 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const publicKeyColumns = "data, fingerprint, created_at, tenant_id, created_by, name, username, filter_hostname, filter_group"
+
+func scanPublicKey(row scanner) (*models.PublicKey, error) {
+	key := new(models.PublicKey)
+
+	if err := row.Scan(&key.Data, &key.Fingerprint, &key.CreatedAt, &key.TenantID, &key.CreatedBy, &key.Name, &key.Username, &key.Filter.Hostname, &key.Filter.Group); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// loadPublicKeyTags sets the filter's tags of each public key in the list.
+func (e executor) loadPublicKeyTags(ctx context.Context, keys []*models.PublicKey) error {
+	for _, key := range keys {
+		tags, err := e.strings(ctx, "SELECT tag FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ? ORDER BY id", key.TenantID, key.Fingerprint)
+		if err != nil {
+			return err
+		}
+
+		if len(tags) > 0 {
+			key.Filter.Tags = tags
+		}
+	}
+
+	return nil
+}
+
+// publicKeySetTags replaces the filter's tags of a public key.
+func (e executor) publicKeySetTags(ctx context.Context, tenant, fingerprint string, tags []string) error {
+	if _, err := e.exec(ctx, "DELETE FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ?", tenant, fingerprint); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if _, err := e.exec(ctx, "INSERT INTO public_key_tags (tenant_id, fingerprint, tag) VALUES (?, ?, ?)", tenant, fingerprint, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
+	key, err := scanPublicKey(s.queryRow(ctx, "SELECT "+publicKeyColumns+" FROM public_keys WHERE fingerprint = ? AND tenant_id = ?", fingerprint, tenantID))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.loadPublicKeyTags(ctx, []*models.PublicKey{key}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
+	var condition string
+	var args []interface{}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		condition = "tenant_id = ?"
+		args = append(args, tenant.ID)
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM public_keys"+where(condition), args...).Scan(&count); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := s.query(ctx, "SELECT "+publicKeyColumns+" FROM public_keys"+where(condition)+" ORDER BY created_at ASC"+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	keys := make([]*models.PublicKey, 0)
+	for rows.Next() {
+		key, err := scanPublicKey(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := s.loadPublicKeyTags(ctx, keys); err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]models.PublicKey, len(keys))
+	for i, key := range keys {
+		list[i] = *key
+	}
+
+	return list, count, nil
+}
+
+func (s *Store) PublicKeyCreate(ctx context.Context, key *models.PublicKey) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "INSERT INTO public_keys ("+publicKeyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			key.Data, key.Fingerprint, key.CreatedAt, key.TenantID, key.CreatedBy, key.Name, key.Username, key.Filter.Hostname, key.Filter.Group); err != nil {
+			return err
+		}
+
+		return tx.publicKeySetTags(ctx, key.TenantID, key.Fingerprint, key.Filter.Tags)
+	}))
+}
+
+func (s *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error) {
+	if err := s.transaction(ctx, func(tx executor) error {
+		result, err := tx.exec(ctx, "UPDATE public_keys SET name = ?, username = ?, filter_hostname = ?, filter_group = ? WHERE fingerprint = ? AND tenant_id = ?",
+			key.Name, key.Username, key.Filter.Hostname, key.Filter.Group, fingerprint, tenantID)
+		if err != nil {
+			return err
+		}
+
+		if affected(result) == 0 {
+			return nil
+		}
+
+		return tx.publicKeySetTags(ctx, tenantID, fingerprint, key.Filter.Tags)
+	}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return s.PublicKeyGet(ctx, fingerprint, tenantID)
+}
+
+func (s *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ?", tenantID, fingerprint); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "DELETE FROM public_keys WHERE fingerprint = ? AND tenant_id = ?", fingerprint, tenantID)
+
+		return err
+	})
+}
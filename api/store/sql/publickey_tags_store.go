@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// PublicKeyAddTag adds a tag to the tag's list in models.PublicKey.
+//
+// To add a tag to a models.PublicKey, that tag needs to exist on a models.Device. If it is not, the tag addition to
+// PublicKey will fail.
+func (s *Store) PublicKeyAddTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	result, err := s.exec(ctx, `INSERT INTO public_key_tags (tenant_id, fingerprint, tag) SELECT tenant_id, fingerprint, ? FROM public_keys
+	WHERE tenant_id = ? AND fingerprint = ? AND NOT EXISTS (SELECT 1 FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ? AND tag = ?)`,
+		tag, tenant, fingerprint, tenant, fingerprint, tag)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// PublicKeyRemoveTag removes a tag to the tag's list in models.PublicKey.
+//
+// To remove a tag from a models.PublicKey, that tag needs to exist on a models.Device. If it is not, the tag deletion from
+// PublicKey will fail.
+func (s *Store) PublicKeyRemoveTag(ctx context.Context, tenant, fingerprint, tag string) error {
+	result, err := s.exec(ctx, "DELETE FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ? AND tag = ?", tenant, fingerprint, tag)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// PublicKeyUpdateTags update with a new set the tag's list in models.PublicKey.
+//
+// To update models.PublicKey with a new set, all tags need to exist on a models.Device. If it is not true, the update
+// action will fail.
+func (s *Store) PublicKeyUpdateTags(ctx context.Context, tenant, fingerprint string, tags []string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		current, err := tx.strings(ctx, "SELECT tag FROM public_key_tags WHERE tenant_id = ? AND fingerprint = ? ORDER BY id", tenant, fingerprint)
+		if err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.queryRow(ctx, "SELECT COUNT(*) FROM public_keys WHERE tenant_id = ? AND fingerprint = ?", tenant, fingerprint).Scan(&count); err != nil {
+			return err
+		}
+
+		// Like in Mongo, when nothing is modified, the update is considered as not found.
+		if count == 0 || equal(current, tags) {
+			return store.ErrNoDocuments
+		}
+
+		return tx.publicKeySetTags(ctx, tenant, fingerprint, tags)
+	})
+}
+
+// PublicKeyRenameTag renames a tag to a new name.
+func (s *Store) PublicKeyRenameTag(ctx context.Context, tenant, old, neo string) error {
+	result, err := s.exec(ctx, "UPDATE public_key_tags SET tag = ? WHERE tenant_id = ? AND tag = ?", neo, tenant, old)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// PublicKeyDeleteTag remove a tag from all public keys.
+func (s *Store) PublicKeyDeleteTag(ctx context.Context, tenant, name string) error {
+	result, err := s.exec(ctx, "DELETE FROM public_key_tags WHERE tenant_id = ? AND tag = ?", tenant, name)
+	if err != nil {
+		return err
+	}
+
+	if affected(result) <= 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// PublicKeyGetTags gets all tags from public keys.
+func (s *Store) PublicKeyGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	tags, err := s.strings(ctx, "SELECT DISTINCT tag FROM public_key_tags WHERE tenant_id = ? ORDER BY tag", tenant)
+
+	return tags, len(tags), err
+}
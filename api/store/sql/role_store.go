@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const roleColumns = "tenant_id, name, description, permissions, created_at"
+
+func scanRole(row scanner) (*models.Role, error) {
+	role := new(models.Role)
+
+	var permissions string
+	if err := row.Scan(&role.TenantID, &role.Name, &role.Description, &permissions, &role.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(permissions), &role.Permissions); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// encodePermissions encodes the role's permissions as a JSON list, stored as text.
+func encodePermissions(role *models.Role) (string, error) {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (s *Store) RoleCreate(ctx context.Context, role *models.Role) error {
+	permissions, err := encodePermissions(role)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.exec(ctx, "INSERT INTO roles ("+roleColumns+") VALUES (?, ?, ?, ?, ?)",
+		role.TenantID, role.Name, role.Description, permissions, role.CreatedAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+// RoleList returns the custom roles of a namespace, sorted by name, based on the given pagination.
+func (s *Store) RoleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Role, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM roles WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+roleColumns+" FROM roles WHERE tenant_id = ? ORDER BY name"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	roles := make([]models.Role, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		roles = append(roles, *role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return roles, count, nil
+}
+
+func (s *Store) RoleGet(ctx context.Context, tenant, name string) (*models.Role, error) {
+	role, err := scanRole(s.queryRow(ctx, "SELECT "+roleColumns+" FROM roles WHERE tenant_id = ? AND name = ?", tenant, name))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return role, nil
+}
+
+func (s *Store) RoleUpdate(ctx context.Context, role *models.Role) error {
+	permissions, err := encodePermissions(role)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.exec(ctx, "UPDATE roles SET description = ?, permissions = ? WHERE tenant_id = ? AND name = ?", role.Description, permissions, role.TenantID, role.Name)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) RoleDelete(ctx context.Context, tenant, name string) error {
+	result, err := s.exec(ctx, "DELETE FROM roles WHERE tenant_id = ? AND name = ?", tenant, name)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
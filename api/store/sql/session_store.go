@@ -0,0 +1,380 @@
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// sessionColumns are the columns of the sessions table loaded into a models.Session, followed by its active status.
+const sessionColumns = `s.uid, s.device_uid, s.tenant_id, s.username, s.ip_address, s.started_at, s.last_seen, s.closed, s.authenticated,
+	s.recorded, s.type, s.term, s.latitude, s.longitude, s.grant_id,
+	EXISTS (SELECT 1 FROM active_sessions a WHERE a.uid = s.uid AND a.last_seen > ?) AS active`
+
+func scanSession(row scanner) (*models.Session, error) {
+	session := new(models.Session)
+
+	var device string
+	if err := row.Scan(
+		&session.UID,
+		&device,
+		&session.TenantID,
+		&session.Username,
+		&session.IPAddress,
+		&session.StartedAt,
+		&session.LastSeen,
+		&session.Closed,
+		&session.Authenticated,
+		&session.Recorded,
+		&session.Type,
+		&session.Term,
+		&session.Position.Latitude,
+		&session.Position.Longitude,
+		&session.Grant,
+		&session.Active,
+	); err != nil {
+		return nil, err
+	}
+
+	session.DeviceUID = models.UID(device)
+
+	return session, nil
+}
+
+func (s *Store) SessionList(ctx context.Context, pagination paginator.Query, devices []models.UID) ([]models.Session, int, error) {
+	var conditions []string
+	var args []interface{}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "s.tenant_id = ?")
+		args = append(args, tenant.ID)
+	}
+
+	if devices != nil {
+		if len(devices) == 0 {
+			return []models.Session{}, 0, nil
+		}
+
+		conditions = append(conditions, "s.device_uid IN (?"+strings.Repeat(", ?", len(devices)-1)+")")
+		for _, uid := range devices {
+			args = append(args, string(uid))
+		}
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM sessions s"+where(conditions...), args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+sessionColumns+" FROM sessions s"+where(conditions...)+" ORDER BY s.started_at DESC"+buildPaginationQuery(pagination),
+		append([]interface{}{clock.Now().Add(-activeSessionTTL)}, args...)...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	list := make([]*models.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			rows.Close()
+
+			return nil, 0, err
+		}
+
+		list = append(list, session)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	sessions := make([]models.Session, 0, len(list))
+	for _, session := range list {
+		device, err := s.DeviceGet(ctx, session.DeviceUID)
+		if err != nil {
+			return sessions, count, err
+		}
+
+		session.Device = device
+		sessions = append(sessions, *session)
+	}
+
+	return sessions, count, nil
+}
+
+func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
+	conditions := []string{"s.uid = ?"}
+	args := []interface{}{clock.Now().Add(-activeSessionTTL), string(uid)}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "s.tenant_id = ?")
+		args = append(args, tenant.ID)
+	}
+
+	session, err := scanSession(s.queryRow(ctx, "SELECT "+sessionColumns+" FROM sessions s"+where(conditions...), args...))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	device, err := s.DeviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	session.Device = device
+
+	return session, nil
+}
+
+func (s *Store) SessionListByGrant(ctx context.Context, grant string) ([]models.Session, error) {
+	rows, err := s.query(ctx, "SELECT "+sessionColumns+" FROM sessions s WHERE s.grant_id = ? AND s.closed = ? ORDER BY s.started_at",
+		clock.Now().Add(-activeSessionTTL), grant, false)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	sessions := make([]models.Session, 0)
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, FromSQLError(err)
+		}
+
+		sessions = append(sessions, *session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return sessions, nil
+}
+
+func (s *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
+	_, err := s.exec(ctx, "UPDATE sessions SET authenticated = ? WHERE uid = ?", authenticated, string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error {
+	_, err := s.exec(ctx, "UPDATE sessions SET recorded = ? WHERE uid = ?", recorded, string(uid))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
+	session.StartedAt = clock.Now()
+	session.LastSeen = session.StartedAt
+	session.Recorded = false
+
+	device, err := s.DeviceGet(ctx, session.DeviceUID)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	session.TenantID = device.TenantID
+
+	if err := s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, `INSERT INTO sessions (uid, device_uid, tenant_id, username, ip_address, started_at, last_seen, closed, authenticated, recorded, type, term, latitude, longitude, grant_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			session.UID, string(session.DeviceUID), session.TenantID, session.Username, session.IPAddress, session.StartedAt, session.LastSeen,
+			session.Closed, session.Authenticated, session.Recorded, session.Type, session.Term, session.Position.Latitude, session.Position.Longitude, session.Grant); err != nil {
+			return err
+		}
+
+		return tx.sessionSetActive(ctx, session.UID, session.StartedAt)
+	}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return &session, nil
+}
+
+// sessionSetActive inserts, or refreshes, a session in the active sessions.
+func (e executor) sessionSetActive(ctx context.Context, uid string, lastSeen interface{}) error {
+	_, err := e.exec(ctx, "INSERT INTO active_sessions (uid, last_seen) VALUES (?, ?) ON CONFLICT (uid) DO UPDATE SET last_seen = excluded.last_seen", uid, lastSeen)
+
+	return err
+}
+
+func (s *Store) SessionSetLastSeen(ctx context.Context, uid models.UID) error {
+	var closed bool
+	if err := s.queryRow(ctx, "SELECT closed FROM sessions WHERE uid = ?", string(uid)).Scan(&closed); err != nil {
+		return FromSQLError(err)
+	}
+
+	if closed {
+		return nil
+	}
+
+	now := clock.Now()
+
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "UPDATE sessions SET last_seen = ? WHERE uid = ?", now, string(uid)); err != nil {
+			return err
+		}
+
+		return tx.sessionSetActive(ctx, string(uid), now)
+	}))
+}
+
+func (s *Store) SessionDeleteActives(ctx context.Context, uid models.UID) error {
+	var id string
+	if err := s.queryRow(ctx, "SELECT uid FROM sessions WHERE uid = ?", string(uid)).Scan(&id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "UPDATE sessions SET last_seen = ?, closed = ? WHERE uid = ?", clock.Now(), true, id); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "DELETE FROM active_sessions WHERE uid = ?", id)
+
+		return err
+	}))
+}
+
+func (s *Store) SessionCreateRecordFrame(ctx context.Context, uid models.UID, recordSession *models.RecordedSession) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "INSERT INTO recorded_sessions (uid, tenant_id, message, time, width, height, type) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			string(recordSession.UID), recordSession.TenantID, recordSession.Message, recordSession.Time, recordSession.Width, recordSession.Height, recordSession.Type); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "UPDATE sessions SET recorded = ? WHERE uid = ?", true, string(uid))
+
+		return err
+	}))
+}
+
+func (s *Store) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, newUID models.UID) error {
+	_, err := s.exec(ctx, "UPDATE sessions SET device_uid = ? WHERE device_uid = ?", string(newUID), string(oldUID))
+
+	return FromSQLError(err)
+}
+
+func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM recorded_sessions WHERE uid = ?", string(uid)); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "DELETE FROM session_record_lines WHERE uid = ?", string(uid))
+
+		return err
+	}))
+}
+
+func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	sessionRecord := make([]models.RecordedSession, 0)
+
+	conditions := []string{"uid = ?"}
+	args := []interface{}{string(uid)}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "tenant_id = ?")
+		args = append(args, tenant.ID)
+	}
+
+	rows, err := s.query(ctx, "SELECT uid, message, tenant_id, time, width, height, type FROM recorded_sessions"+where(conditions...)+" ORDER BY id", args...)
+	if err != nil {
+		return sessionRecord, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record models.RecordedSession
+		var id string
+
+		if err := rows.Scan(&id, &record.Message, &record.TenantID, &record.Time, &record.Width, &record.Height, &record.Type); err != nil {
+			return sessionRecord, 0, err
+		}
+
+		record.UID = models.UID(id)
+		sessionRecord = append(sessionRecord, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return sessionRecord, len(sessionRecord), nil
+}
+
+func (s *Store) SessionRecordIndex(ctx context.Context, uid models.UID, lines []models.SessionRecordLine) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM session_record_lines WHERE uid = ?", string(uid)); err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			if _, err := tx.exec(ctx, "INSERT INTO session_record_lines (uid, tenant_id, device_uid, username, started_at, type, elapsed, text) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+				string(line.UID), line.TenantID, string(line.DeviceUID), line.Username, line.StartedAt, line.Type, line.Offset, line.Text); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *Store) SessionRecordSearch(ctx context.Context, tenant, query string, devices []models.UID, pagination paginator.Query) ([]models.SessionRecordLine, int, error) {
+	conditions := []string{"tenant_id = ?", "LOWER(text) LIKE ? ESCAPE '\\'"}
+	args := []interface{}{tenant, like(query)}
+
+	if devices != nil {
+		if len(devices) == 0 {
+			return []models.SessionRecordLine{}, 0, nil
+		}
+
+		conditions = append(conditions, "device_uid IN (?"+strings.Repeat(", ?", len(devices)-1)+")")
+		for _, uid := range devices {
+			args = append(args, string(uid))
+		}
+	}
+
+	condition := where(conditions...)
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM session_record_lines"+condition, args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT uid, tenant_id, device_uid, username, started_at, type, elapsed, text FROM session_record_lines"+condition+
+		" ORDER BY started_at DESC, uid, elapsed"+buildPaginationQuery(pagination), args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	lines := make([]models.SessionRecordLine, 0)
+	for rows.Next() {
+		var line models.SessionRecordLine
+		var uid, device string
+
+		if err := rows.Scan(&uid, &line.TenantID, &device, &line.Username, &line.StartedAt, &line.Type, &line.Offset, &line.Text); err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		line.UID = models.UID(uid)
+		line.DeviceUID = models.UID(device)
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return lines, count, nil
+}
@@ -0,0 +1,62 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+func (s *Store) GetStats(ctx context.Context) (*models.Stats, error) {
+	var condition string
+	var args []interface{}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		condition = "tenant_id = ?"
+		args = append(args, tenant.ID)
+	}
+
+	count := func(query string, values ...interface{}) (int, error) {
+		var count int
+		err := s.queryRow(ctx, query, append(values, args...)...).Scan(&count)
+
+		return count, err
+	}
+
+	now := clock.Now()
+
+	onlineDevices, err := count("SELECT COUNT(*) FROM connected_devices"+where("status = 'accepted'", "last_seen > ?", condition), now.Add(-connectedDeviceTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	registeredDevices, err := count("SELECT COUNT(*) FROM devices" + where("status = 'accepted'", condition))
+	if err != nil {
+		return nil, err
+	}
+
+	pendingDevices, err := count("SELECT COUNT(*) FROM devices" + where("status = 'pending'", condition))
+	if err != nil {
+		return nil, err
+	}
+
+	rejectedDevices, err := count("SELECT COUNT(*) FROM devices" + where("status = 'rejected'", condition))
+	if err != nil {
+		return nil, err
+	}
+
+	activeSessions, err := count("SELECT COUNT(*) FROM sessions"+where("EXISTS (SELECT 1 FROM active_sessions a WHERE a.uid = sessions.uid AND a.last_seen > ?)", condition), now.Add(-activeSessionTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Stats{
+		RegisteredDevices: registeredDevices,
+		OnlineDevices:     onlineDevices,
+		PendingDevices:    pendingDevices,
+		RejectedDevices:   rejectedDevices,
+		ActiveSessions:    activeSessions,
+	}, nil
+}
@@ -0,0 +1,166 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // Registers the "sqlite3" driver.
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+)
+
+// Dialect is the SQL database engine used by the store.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite3"
+)
+
+var (
+	ErrDialectNotSupported       = errors.New("sql dialect not supported")
+	ErrNamespaceDuplicatedMember = errors.New("this member is already in this namespace")
+	ErrUserNotFound              = errors.New("user not found")
+)
+
+// The SQL store has no TTL indexes, so the expiration of the documents that Mongo removes automatically is evaluated
+// when they are queried.
+const (
+	connectedDeviceTTL = 120 * time.Second
+	activeSessionTTL   = 30 * time.Second
+	recoveryTokenTTL   = 24 * time.Hour
+	removedDeviceTTL   = 720 * time.Hour
+	privateKeyTTL      = 60 * time.Second
+)
+
+type Store struct {
+	executor
+	db    *dbsql.DB
+	cache cache.Cache
+}
+
+var _ store.Store = (*Store)(nil)
+
+// Open opens a connection to a SQL database using the dialect's driver.
+//
+// As SQLite does not handle concurrent writes, its connection pool is limited to a single connection.
+func Open(dialect Dialect, dsn string) (*dbsql.DB, error) {
+	switch dialect {
+	case DialectPostgres, DialectSQLite:
+	default:
+		return nil, ErrDialectNotSupported
+	}
+
+	db, err := dbsql.Open(string(dialect), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if dialect == DialectSQLite {
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// SQLiteDSN returns the connection string to a SQLite database file. As the API and its workers share the file, the
+// connection waits for the locks to be released and uses the write-ahead log.
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_busy_timeout=5000&_journal_mode=WAL"
+}
+
+func NewStore(db *dbsql.DB, dialect Dialect, cache cache.Cache) *Store {
+	return &Store{executor: executor{q: db, dialect: dialect}, db: db, cache: cache}
+}
+
+func (s *Store) Database() *dbsql.DB {
+	return s.db
+}
+
+func (s *Store) Cache() cache.Cache {
+	return s.cache
+}
+
+// transaction runs fn inside a database transaction, committing it when fn succeeds and rolling it back otherwise.
+func (s *Store) transaction(ctx context.Context, fn func(tx executor) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(executor{q: tx, dialect: s.dialect}); err != nil {
+		_ = tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (dbsql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*dbsql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *dbsql.Row
+}
+
+// executor runs queries written with "?" placeholders on a database connection or transaction, translating them to the
+// dialect in use.
+type executor struct {
+	q       querier
+	dialect Dialect
+}
+
+func (e executor) exec(ctx context.Context, query string, args ...interface{}) (dbsql.Result, error) {
+	return e.q.ExecContext(ctx, e.rebind(query), normalize(args)...)
+}
+
+func (e executor) query(ctx context.Context, query string, args ...interface{}) (*dbsql.Rows, error) {
+	return e.q.QueryContext(ctx, e.rebind(query), normalize(args)...)
+}
+
+func (e executor) queryRow(ctx context.Context, query string, args ...interface{}) *dbsql.Row {
+	return e.q.QueryRowContext(ctx, e.rebind(query), normalize(args)...)
+}
+
+// rebind replaces the "?" placeholders by "$N" when the dialect is PostgreSQL.
+func (e executor) rebind(query string) string {
+	if e.dialect != DialectPostgres {
+		return query
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(query) + 10)
+
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
+
+// normalize converts the time arguments to UTC, so they are comparable as text on SQLite.
+func normalize(args []interface{}) []interface{} {
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			args[i] = t.UTC()
+		}
+	}
+
+	return args
+}
@@ -0,0 +1,219 @@
+package sql
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	dbsql "database/sql"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type Data struct {
+	User            models.User
+	Namespace       models.Namespace
+	FirewallRule    models.FirewallRule
+	Device          models.Device
+	PublicKey       models.PublicKey
+	Session         models.Session
+	RecordedSession models.RecordedSession
+	Context         context.Context
+}
+
+// newTestDB opens the database a test runs against, closed when the test ends. It is an in-memory SQLite database or,
+// when SHELLHUB_TEST_POSTGRES_URI is set, a schema of its own on that PostgreSQL database, so the tests cover both
+// dialects.
+func newTestDB(t *testing.T) (*dbsql.DB, Dialect) {
+	t.Helper()
+
+	dialect, dsn := DialectSQLite, ":memory:"
+	if uri := os.Getenv("SHELLHUB_TEST_POSTGRES_URI"); uri != "" {
+		dialect, dsn = DialectPostgres, newTestSchema(t, uri)
+	}
+
+	db, err := Open(dialect, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	return db, dialect
+}
+
+// newTestSchema creates an empty schema on the PostgreSQL database, dropped when the test ends, and returns the
+// connection string that uses it.
+func newTestSchema(t *testing.T, uri string) string {
+	t.Helper()
+
+	dsn, err := url.Parse(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := Open(DialectPostgres, uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := "test_" + hex.EncodeToString(suffix)
+	if _, err := db.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DROP SCHEMA " + schema + " CASCADE") //nolint:errcheck
+		db.Close()
+	})
+
+	query := dsn.Query()
+	query.Set("search_path", schema)
+	dsn.RawQuery = query.Encode()
+
+	return dsn.String()
+}
+
+// newTestStore creates a Store backed by the test's database with the schema migrations applied.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	db, dialect := newTestDB(t)
+	if err := ApplyMigrations(db, dialect); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewStore(db, dialect, cache.NewNullCache())
+}
+
+func initData() Data {
+	identity := &models.DeviceIdentity{MAC: "mac"}
+	uid := sha256.Sum256(structhash.Dump(identity, 1))
+
+	return Data{
+		models.User{
+			UserData: models.UserData{
+				Name:     "user",
+				Username: "username",
+				Email:    "user@shellhub.io",
+			},
+			UserPassword: models.UserPassword{
+				Password: "password",
+			},
+			ID: "507f1f77bcf86cd799439011",
+		},
+		models.Namespace{
+			Name:     "namespace",
+			Owner:    "507f1f77bcf86cd799439011",
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			Members: []models.Member{
+				{
+					ID:   "507f1f77bcf86cd799439011",
+					Role: guard.RoleOwner,
+				},
+			},
+			MaxDevices: -1,
+			Settings:   &models.NamespaceSettings{SessionRecord: true},
+		},
+		models.FirewallRule{
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			FirewallRuleFields: models.FirewallRuleFields{
+				Priority: 1,
+				Action:   "allow",
+				Active:   true,
+				SourceIP: ".*",
+				Username: ".*",
+				Filter: models.FirewallFilter{
+					Hostname: ".*",
+				},
+			},
+		},
+		models.Device{
+			UID:      hex.EncodeToString(uid[:]),
+			Identity: identity,
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			LastSeen: clock.Now(),
+		},
+		models.PublicKey{
+			Data:            []byte("teste"),
+			Fingerprint:     "fingerprint",
+			TenantID:        "00000000-0000-4000-0000-000000000000",
+			CreatedAt:       clock.Now(),
+			PublicKeyFields: models.PublicKeyFields{Name: "teste1", Filter: models.PublicKeyFilter{Hostname: ".*"}},
+		},
+		models.Session{
+			Username:      "username",
+			UID:           "uid",
+			TenantID:      "00000000-0000-4000-0000-000000000000",
+			DeviceUID:     models.UID(hex.EncodeToString(uid[:])),
+			IPAddress:     "0.0.0.0",
+			Authenticated: true,
+		},
+		models.RecordedSession{
+			UID:      models.UID("uid"),
+			Message:  "message",
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			Time:     clock.Now(),
+			Width:    0,
+			Height:   0,
+		},
+		context.TODO(),
+	}
+}
+
+func TestApplyMigrations(t *testing.T) {
+	db, dialect := newTestDB(t)
+
+	assert.NoError(t, ApplyMigrations(db, dialect))
+	// Applying the migrations again must not fail, as they are already applied.
+	assert.NoError(t, ApplyMigrations(db, dialect))
+
+	var version int
+	assert.NoError(t, db.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version))
+	assert.Equal(t, 19, version)
+}
+
+func TestRebind(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM devices WHERE uid = $1 AND name = $2", executor{dialect: DialectPostgres}.rebind("SELECT * FROM devices WHERE uid = ? AND name = ?"))
+	assert.Equal(t, "SELECT * FROM devices WHERE uid = ?", executor{dialect: DialectSQLite}.rebind("SELECT * FROM devices WHERE uid = ?"))
+}
+
+func TestStoreGetStats(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = s.DeviceCreate(data.Context, data.Device, "")
+	assert.NoError(t, err)
+
+	err = s.DeviceUpdateStatus(data.Context, models.UID(data.Device.UID), models.DeviceStatusAccepted)
+	assert.NoError(t, err)
+
+	_, err = s.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	stats, err := s.GetStats(data.Context)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Stats{
+		RegisteredDevices: 1,
+		OnlineDevices:     1,
+		ActiveSessions:    1,
+	}, stats)
+}
@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"context"
+)
+
+func (s *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, error) {
+	tags, err := s.strings(ctx, `SELECT t.tag FROM device_tags t JOIN devices d ON d.uid = t.device_uid WHERE d.tenant_id = ?
+	UNION SELECT tag FROM public_key_tags WHERE tenant_id = ?
+	UNION SELECT t.tag FROM firewall_rule_tags t JOIN firewall_rules r ON r.id = t.rule_id WHERE r.tenant_id = ?`, tenant, tenant, tenant)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tags, len(tags), nil
+}
+
+func (s *Store) TagRename(ctx context.Context, tenantID string, tag string, newTag string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "UPDATE device_tags SET tag = ? WHERE tag = ? AND device_uid IN (SELECT uid FROM devices WHERE tenant_id = ?)", newTag, tag, tenantID); err != nil {
+			return FromSQLError(err)
+		}
+
+		if _, err := tx.exec(ctx, "UPDATE public_key_tags SET tag = ? WHERE tenant_id = ? AND tag = ?", newTag, tenantID, tag); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "UPDATE firewall_rule_tags SET tag = ? WHERE tag = ? AND rule_id IN (SELECT id FROM firewall_rules WHERE tenant_id = ?)", newTag, tag, tenantID)
+
+		return err
+	})
+}
+
+func (s *Store) TagDelete(ctx context.Context, tenantID string, tag string) error {
+	return s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "DELETE FROM device_tags WHERE tag = ? AND device_uid IN (SELECT uid FROM devices WHERE tenant_id = ?)", tag, tenantID); err != nil {
+			return err
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM public_key_tags WHERE tenant_id = ? AND tag = ?", tenantID, tag); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, "DELETE FROM firewall_rule_tags WHERE tag = ? AND rule_id IN (SELECT id FROM firewall_rules WHERE tenant_id = ?)", tag, tenantID)
+
+		return err
+	})
+}
@@ -0,0 +1,375 @@
+package sql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const userColumns = "u.id, u.namespaces, u.max_namespaces, u.confirmed, u.created_at, u.last_login, u.email_marketing, u.name, u.email, u.username, u.password, u.mfa_enabled, u.mfa_secret, u.mfa_recovery_codes, u.mfa_last_counter"
+
+// userNamespaces is the SQL expression that counts the namespaces owned by a user.
+const userNamespaces = "(SELECT COUNT(*) FROM namespaces WHERE namespaces.owner = u.id)"
+
+// userFilterFields are the properties of a models.User accepted by the UserList's filters.
+var userFilterFields = map[string]filterField{
+	"name":            {Column: "u.name"},
+	"username":        {Column: "u.username"},
+	"email":           {Column: "u.email"},
+	"confirmed":       {Column: "u.confirmed"},
+	"namespaces":      {Column: userNamespaces},
+	"max_namespaces":  {Column: "u.max_namespaces"},
+	"email_marketing": {Column: "u.email_marketing"},
+}
+
+func scanUser(row scanner) (*models.User, error) {
+	user := new(models.User)
+
+	var recoveryCodes string
+	var lastCounter int64
+	if err := row.Scan(
+		&user.ID,
+		&user.Namespaces,
+		&user.MaxNamespaces,
+		&user.Confirmed,
+		&user.CreatedAt,
+		&user.LastLogin,
+		&user.EmailMarketing,
+		&user.Name,
+		&user.Email,
+		&user.Username,
+		&user.Password,
+		&user.MFA.Enabled,
+		&user.MFA.Secret,
+		&recoveryCodes,
+		&lastCounter,
+	); err != nil {
+		return nil, err
+	}
+
+	user.MFA.LastCounter = uint64(lastCounter)
+
+	if recoveryCodes != "" {
+		if err := json.Unmarshal([]byte(recoveryCodes), &user.MFA.RecoveryCodes); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (s *Store) userGetWhere(ctx context.Context, condition string, args ...interface{}) (*models.User, error) {
+	user, err := scanUser(s.queryRow(ctx, "SELECT "+userColumns+" FROM users u WHERE "+condition, args...))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return user, nil
+}
+
+func (s *Store) UserList(ctx context.Context, pagination paginator.Query, filters []models.Filter) ([]models.User, int, error) {
+	filter, args, err := buildFilterQuery(filters, userFilterFields)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conditions := []string{filter}
+
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM namespace_members m WHERE m.user_id = u.id AND m.tenant_id = ?)")
+		args = append(args, tenant.ID)
+	}
+
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM users u"+where(conditions...), args...).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	var paginate string
+	if pagination.Page > 0 && pagination.PerPage > 0 {
+		paginate = buildPaginationQuery(pagination)
+	}
+
+	// The namespaces' counter is replaced by the number of namespaces owned by the user, like the Mongo's UserList.
+	columns := "u.id, " + userNamespaces + ", u.max_namespaces, u.confirmed, u.created_at, u.last_login, u.email_marketing, u.name, u.email, u.username, u.password, u.mfa_enabled, u.mfa_secret, u.mfa_recovery_codes, u.mfa_last_counter"
+
+	rows, err := s.query(ctx, "SELECT "+columns+" FROM users u"+where(conditions...)+" ORDER BY u.created_at ASC"+paginate, args...)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		users = append(users, *user)
+	}
+
+	return users, count, FromSQLError(rows.Err())
+}
+
+func (s *Store) UserCreate(ctx context.Context, user *models.User) error {
+	if user.ID == "" {
+		user.ID = newID()
+	}
+
+	_, err := s.exec(ctx, `INSERT INTO users (id, namespaces, max_namespaces, confirmed, created_at, last_login, email_marketing, name, email, username, password)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		user.ID, user.Namespaces, user.MaxNamespaces, user.Confirmed, user.CreatedAt, user.LastLogin, user.EmailMarketing, user.Name, user.Email, user.Username, user.Password)
+
+	return FromSQLError(err)
+}
+
+func (s *Store) UserGetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return s.userGetWhere(ctx, "u.username = ?", username)
+}
+
+func (s *Store) UserGetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return s.userGetWhere(ctx, "u.email = ?", email)
+}
+
+func (s *Store) UserGetByID(ctx context.Context, id string, ns bool) (*models.User, int, error) {
+	user, err := s.userGetWhere(ctx, "u.id = ?", id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !ns {
+		return user, 0, nil
+	}
+
+	var owned int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM namespaces WHERE owner = ?", id).Scan(&owned); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return user, owned, nil
+}
+
+func (s *Store) UserUpdateData(ctx context.Context, id string, data models.User) error {
+	if _, err := s.exec(ctx, "UPDATE users SET name = ?, username = ?, email = ?, last_login = ? WHERE id = ?", data.Name, data.Username, data.Email, data.LastLogin, id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error {
+	var recoveryCodes string
+	if len(mfa.RecoveryCodes) > 0 {
+		data, err := json.Marshal(mfa.RecoveryCodes)
+		if err != nil {
+			return err
+		}
+
+		recoveryCodes = string(data)
+	}
+
+	result, err := s.exec(ctx, "UPDATE users SET mfa_enabled = ?, mfa_secret = ?, mfa_recovery_codes = ? WHERE id = ?", mfa.Enabled, mfa.Secret, recoveryCodes, id)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSetMFACounter(ctx context.Context, id string, counter uint64) error {
+	result, err := s.exec(ctx, "UPDATE users SET mfa_last_counter = ? WHERE id = ? AND mfa_last_counter < ?", int64(counter), id, int64(counter))
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// UserRemoveMFARecoveryCode removes the code with a compare-and-swap on the JSON list of recovery codes, reading the
+// list again when another code was removed concurrently.
+func (s *Store) UserRemoveMFARecoveryCode(ctx context.Context, id string, digest string) error {
+	for {
+		var current string
+		if err := s.queryRow(ctx, "SELECT mfa_recovery_codes FROM users WHERE id = ?", id).Scan(&current); err != nil {
+			return FromSQLError(err)
+		}
+
+		var codes []string
+		if current != "" {
+			if err := json.Unmarshal([]byte(current), &codes); err != nil {
+				return err
+			}
+		}
+
+		index := -1
+		for i, code := range codes {
+			if code == digest {
+				index = i
+
+				break
+			}
+		}
+
+		if index < 0 {
+			return store.ErrNoDocuments
+		}
+
+		var remaining string
+		if codes = append(codes[:index], codes[index+1:]...); len(codes) > 0 {
+			data, err := json.Marshal(codes)
+			if err != nil {
+				return err
+			}
+
+			remaining = string(data)
+		}
+
+		result, err := s.exec(ctx, "UPDATE users SET mfa_recovery_codes = ? WHERE id = ? AND mfa_recovery_codes = ?", remaining, id, current)
+		if err != nil {
+			return FromSQLError(err)
+		}
+
+		if affected(result) > 0 {
+			return nil
+		}
+	}
+}
+
+func (s *Store) UserUpdatePassword(ctx context.Context, newPassword string, id string) error {
+	if _, _, err := s.UserGetByID(ctx, id, false); err != nil {
+		return err
+	}
+
+	if _, err := s.exec(ctx, "UPDATE users SET password = ? WHERE id = ?", newPassword, id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserUpdateFromAdmin(ctx context.Context, name string, username string, email string, password string, id string) error {
+	user, _, err := s.UserGetByID(ctx, id, false)
+	if err != nil {
+		return err
+	}
+
+	if username == "" {
+		username = user.Username
+	}
+
+	if email == "" {
+		email = user.Email
+	}
+
+	if password == "" {
+		password = user.Password
+	}
+
+	if _, err := s.exec(ctx, "UPDATE users SET name = ?, username = ?, email = ?, password = ? WHERE id = ?", name, username, email, password, id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserCreateToken(ctx context.Context, token *models.UserTokenRecover) error {
+	if _, err := s.exec(ctx, "INSERT INTO recovery_tokens (token, user_id, created_at) VALUES (?, ?, ?)", token.Token, token.User, token.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) UserGetToken(ctx context.Context, id string) (*models.UserTokenRecover, error) {
+	token := new(models.UserTokenRecover)
+	if err := s.queryRow(ctx, "SELECT token, user_id, created_at FROM recovery_tokens WHERE user_id = ? AND created_at > ? LIMIT 1", id, clock.Now().Add(-recoveryTokenTTL)).
+		Scan(&token.Token, &token.User, &token.CreatedAt); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return token, nil
+}
+
+func (s *Store) UserDeleteTokens(ctx context.Context, id string) error {
+	if _, err := s.exec(ctx, "DELETE FROM recovery_tokens WHERE user_id = ?", id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserUpdateAccountStatus(ctx context.Context, id string) error {
+	if _, err := s.exec(ctx, "UPDATE users SET confirmed = ? WHERE id = ?", true, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) UserDelete(ctx context.Context, id string) error {
+	if _, err := s.exec(ctx, "DELETE FROM users WHERE id = ?", id); err != nil {
+		return FromSQLError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserDetachInfo(ctx context.Context, id string) (map[string][]*models.Namespace, error) {
+	rows, err := s.query(ctx, "SELECT "+namespaceColumns+" FROM namespaces n WHERE EXISTS (SELECT 1 FROM namespace_members m WHERE m.tenant_id = n.tenant_id AND m.user_id = ?)", id)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	namespaces := make([]*models.Namespace, 0)
+	for rows.Next() {
+		namespace, err := scanNamespace(rows)
+		if err != nil {
+			rows.Close()
+
+			return nil, FromSQLError(err)
+		}
+
+		namespaces = append(namespaces, namespace)
+	}
+
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.loadNamespaceMembers(ctx, namespaces); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	namespacesMap := make(map[string][]*models.Namespace, 2)
+	ownerNamespaceList := make([]*models.Namespace, 0)
+	membersNamespaceList := make([]*models.Namespace, 0)
+
+	for _, namespace := range namespaces {
+		if namespace.Owner != id {
+			membersNamespaceList = append(membersNamespaceList, namespace)
+		} else {
+			ownerNamespaceList = append(ownerNamespaceList, namespace)
+		}
+	}
+
+	namespacesMap["member"] = membersNamespaceList
+	namespacesMap["owner"] = ownerNamespaceList
+
+	return namespacesMap, nil
+}
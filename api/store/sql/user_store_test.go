@@ -0,0 +1,215 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCreate(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	err = s.UserCreate(data.Context, &models.User{UserData: data.User.UserData})
+	assert.Equal(t, store.ErrDuplicate, err)
+
+	user := &models.User{UserData: models.UserData{Name: "other", Username: "other", Email: "other@shellhub.io"}}
+	err = s.UserCreate(data.Context, user)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, user.ID)
+}
+
+func TestUserGet(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	user, err := s.UserGetByUsername(data.Context, data.User.Username)
+	assert.NoError(t, err)
+	assert.Equal(t, data.User.ID, user.ID)
+
+	user, err = s.UserGetByEmail(data.Context, data.User.Email)
+	assert.NoError(t, err)
+	assert.Equal(t, data.User.ID, user.ID)
+
+	_, owned, err := s.UserGetByID(data.Context, data.User.ID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, owned)
+
+	_, _, err = s.UserGetByID(data.Context, "unknown", false)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestUserList(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	users, count, err := s.UserList(data.Context, paginator.Query{Page: 1, PerPage: 10}, []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "namespaces", Operator: "gt", Value: "0"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 1, users[0].Namespaces)
+}
+
+func TestUserUpdate(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	err = s.UserUpdatePassword(data.Context, "newpassword", data.User.ID)
+	assert.NoError(t, err)
+
+	err = s.UserUpdateFromAdmin(data.Context, "name", "", "new@shellhub.io", "", data.User.ID)
+	assert.NoError(t, err)
+
+	err = s.UserUpdateAccountStatus(data.Context, data.User.ID)
+	assert.NoError(t, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "name", user.Name)
+	assert.Equal(t, data.User.Username, user.Username)
+	assert.Equal(t, "new@shellhub.io", user.Email)
+	assert.Equal(t, "newpassword", user.Password)
+	assert.True(t, user.Confirmed)
+}
+
+func TestUserUpdateMFA(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	mfa := models.UserMFA{Enabled: true, Secret: "secret", RecoveryCodes: []string{"code1", "code2"}}
+	err = s.UserUpdateMFA(data.Context, data.User.ID, mfa)
+	assert.NoError(t, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, mfa, user.MFA)
+
+	err = s.UserUpdateMFA(data.Context, data.User.ID, models.UserMFA{})
+	assert.NoError(t, err)
+
+	user, _, err = s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserMFA{}, user.MFA)
+
+	err = s.UserUpdateMFA(data.Context, "missing", mfa)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestUserSetMFACounter(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	err = s.UserSetMFACounter(data.Context, data.User.ID, 100)
+	assert.NoError(t, err)
+
+	err = s.UserSetMFACounter(data.Context, data.User.ID, 100)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	err = s.UserSetMFACounter(data.Context, data.User.ID, 99)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	// The counter is kept when the MFA is replaced.
+	err = s.UserUpdateMFA(data.Context, data.User.ID, models.UserMFA{Enabled: true, Secret: "secret"})
+	assert.NoError(t, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), user.MFA.LastCounter)
+
+	err = s.UserSetMFACounter(data.Context, "missing", 101)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestUserRemoveMFARecoveryCode(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreate(data.Context, &data.User)
+	assert.NoError(t, err)
+
+	err = s.UserUpdateMFA(data.Context, data.User.ID, models.UserMFA{Enabled: true, Secret: "secret", RecoveryCodes: []string{"a", "b"}})
+	assert.NoError(t, err)
+
+	err = s.UserRemoveMFARecoveryCode(data.Context, data.User.ID, "a")
+	assert.NoError(t, err)
+
+	err = s.UserRemoveMFARecoveryCode(data.Context, data.User.ID, "a")
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	user, _, err := s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, user.MFA.RecoveryCodes)
+
+	err = s.UserRemoveMFARecoveryCode(data.Context, data.User.ID, "b")
+	assert.NoError(t, err)
+
+	user, _, err = s.UserGetByID(data.Context, data.User.ID, false)
+	assert.NoError(t, err)
+	assert.Empty(t, user.MFA.RecoveryCodes)
+
+	err = s.UserRemoveMFARecoveryCode(data.Context, "missing", "b")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestUserTokens(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	err := s.UserCreateToken(data.Context, &models.UserTokenRecover{Token: "token", User: data.User.ID, CreatedAt: clock.Now()})
+	assert.NoError(t, err)
+
+	token, err := s.UserGetToken(data.Context, data.User.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+
+	err = s.UserDeleteTokens(data.Context, data.User.ID)
+	assert.NoError(t, err)
+
+	_, err = s.UserGetToken(data.Context, data.User.ID)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestUserDetachInfo(t *testing.T) {
+	data := initData()
+	s := newTestStore(t)
+
+	_, err := s.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	_, err = s.NamespaceCreate(data.Context, &models.Namespace{Name: "other", TenantID: "tenant", Owner: "owner", Members: []models.Member{{ID: data.User.ID, Role: "observer"}}})
+	assert.NoError(t, err)
+
+	info, err := s.UserDetachInfo(data.Context, data.User.ID)
+	assert.NoError(t, err)
+	assert.Len(t, info["owner"], 1)
+	assert.Len(t, info["member"], 1)
+}
@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"context"
+	"crypto/rand"
+	dbsql "database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/shellhub-io/shellhub/api/store"
+)
+
+// affected returns the number of rows affected by a statement.
+func affected(result dbsql.Result) int64 {
+	n, _ := result.RowsAffected()
+
+	return n
+}
+
+// placeholders returns n comma separated "?" placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// newID generates a random identifier with the same format of a Mongo's ObjectID.
+func newID() string {
+	id := make([]byte, 12)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+func FromSQLError(err error) error {
+	var pqErr *pq.Error
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, dbsql.ErrNoRows):
+		return store.ErrNoDocuments
+	case errors.As(err, &pqErr) && pqErr.Code == "23505":
+		return store.ErrDuplicate
+	case strings.Contains(err.Error(), "UNIQUE constraint failed"):
+		return store.ErrDuplicate
+	default:
+		return err
+	}
+}
+
+// strings runs a query that selects a single text column, returning its values.
+func (e executor) strings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := e.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]string, 0)
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+
+		list = append(list, value)
+	}
+
+	return list, rows.Err()
+}
+
+// equal reports whether two lists have the same values in the same order.
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
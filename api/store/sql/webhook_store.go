@@ -0,0 +1,258 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	webhookColumns         = "id, tenant_id, url, secret, created_at"
+	webhookDeliveryColumns = "id, tenant_id, webhook_id, event, payload, status, status_code, error, attempts, created_at, delivered_at"
+)
+
+func scanWebhook(row scanner) (*models.Webhook, error) {
+	webhook := new(models.Webhook)
+
+	if err := row.Scan(&webhook.ID, &webhook.TenantID, &webhook.URL, &webhook.Secret, &webhook.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	webhook.Events = []string{}
+
+	return webhook, nil
+}
+
+func scanWebhookDelivery(row scanner) (*models.WebhookDelivery, error) {
+	delivery := new(models.WebhookDelivery)
+
+	var deliveredAt dbsql.NullTime
+
+	if err := row.Scan(&delivery.ID, &delivery.TenantID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.Status, &delivery.StatusCode, &delivery.Error, &delivery.Attempts, &delivery.CreatedAt, &deliveredAt); err != nil {
+		return nil, err
+	}
+
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+
+	return delivery, nil
+}
+
+// loadWebhookEvents sets the events of each webhook in the list.
+func (e executor) loadWebhookEvents(ctx context.Context, webhooks []*models.Webhook) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	ids := make([]interface{}, len(webhooks))
+	index := make(map[string]*models.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		ids[i] = webhook.ID
+		index[webhook.ID] = webhook
+	}
+
+	rows, err := e.query(ctx, "SELECT webhook_id, event FROM webhook_events WHERE webhook_id IN ("+placeholders(len(ids))+") ORDER BY id", ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, event string
+		if err := rows.Scan(&id, &event); err != nil {
+			return err
+		}
+
+		index[id].Events = append(index[id].Events, event)
+	}
+
+	return rows.Err()
+}
+
+// webhookListWhere returns the webhooks matched by a condition, with their events.
+func (e executor) webhookListWhere(ctx context.Context, suffix string, args ...interface{}) ([]models.Webhook, error) {
+	rows, err := e.query(ctx, "SELECT "+webhookColumns+" FROM webhooks "+suffix, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*models.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := e.loadWebhookEvents(ctx, list); err != nil {
+		return nil, err
+	}
+
+	webhooks := make([]models.Webhook, len(list))
+	for i, webhook := range list {
+		webhooks[i] = *webhook
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	id := newID()
+
+	if err := s.transaction(ctx, func(tx executor) error {
+		if _, err := tx.exec(ctx, "INSERT INTO webhooks ("+webhookColumns+") VALUES (?, ?, ?, ?, ?)",
+			id, webhook.TenantID, webhook.URL, webhook.Secret, webhook.CreatedAt); err != nil {
+			return err
+		}
+
+		for _, event := range webhook.Events {
+			if _, err := tx.exec(ctx, "INSERT INTO webhook_events (webhook_id, event) VALUES (?, ?)", id, event); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return FromSQLError(err)
+	}
+
+	webhook.ID = id
+
+	return nil
+}
+
+// WebhookList returns the webhooks of a namespace, from the newest to the oldest, based on the given pagination.
+func (s *Store) WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM webhooks WHERE tenant_id = ?", tenant).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	webhooks, err := s.webhookListWhere(ctx, "WHERE tenant_id = ? ORDER BY created_at DESC"+buildPaginationQuery(pagination), tenant)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *Store) WebhookListByEvent(ctx context.Context, tenant, event string) ([]models.Webhook, error) {
+	webhooks, err := s.webhookListWhere(ctx, "WHERE tenant_id = ? AND id IN (SELECT webhook_id FROM webhook_events WHERE event = ?) ORDER BY created_at", tenant, event)
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error) {
+	webhook, err := scanWebhook(s.queryRow(ctx, "SELECT "+webhookColumns+" FROM webhooks WHERE id = ? AND tenant_id = ?", id, tenant))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	if err := s.loadWebhookEvents(ctx, []*models.Webhook{webhook}); err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookDelete(ctx context.Context, tenant, id string) error {
+	return FromSQLError(s.transaction(ctx, func(tx executor) error {
+		result, err := tx.exec(ctx, "DELETE FROM webhooks WHERE id = ? AND tenant_id = ?", id, tenant)
+		if err != nil {
+			return err
+		}
+
+		if affected(result) == 0 {
+			return store.ErrNoDocuments
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM webhook_events WHERE webhook_id = ?", id); err != nil {
+			return err
+		}
+
+		_, err = tx.exec(ctx, "DELETE FROM webhook_deliveries WHERE webhook_id = ?", id)
+
+		return err
+	}))
+}
+
+func (s *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	id := newID()
+
+	if _, err := s.exec(ctx, "INSERT INTO webhook_deliveries ("+webhookDeliveryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, delivery.TenantID, delivery.WebhookID, delivery.Event, delivery.Payload, delivery.Status, delivery.StatusCode, delivery.Error, delivery.Attempts, delivery.CreatedAt, delivery.DeliveredAt); err != nil {
+		return FromSQLError(err)
+	}
+
+	delivery.ID = id
+
+	return nil
+}
+
+// WebhookDeliveryList returns the deliveries of a namespace's webhook, from the newest to the oldest, based on the
+// given pagination.
+func (s *Store) WebhookDeliveryList(ctx context.Context, tenant, webhookID string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	var count int
+	if err := s.queryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries WHERE tenant_id = ? AND webhook_id = ?", tenant, webhookID).Scan(&count); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	rows, err := s.query(ctx, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE tenant_id = ? AND webhook_id = ? ORDER BY created_at DESC"+buildPaginationQuery(pagination), tenant, webhookID)
+	if err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, 0, FromSQLError(err)
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, FromSQLError(err)
+	}
+
+	return deliveries, count, nil
+}
+
+func (s *Store) WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	delivery, err := scanWebhookDelivery(s.queryRow(ctx, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE id = ?", id))
+	if err != nil {
+		return nil, FromSQLError(err)
+	}
+
+	return delivery, nil
+}
+
+func (s *Store) WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result, err := s.exec(ctx, "UPDATE webhook_deliveries SET status = ?, status_code = ?, error = ?, attempts = ?, delivered_at = ? WHERE id = ?",
+		delivery.Status, delivery.StatusCode, delivery.Error, delivery.Attempts, delivery.DeliveredAt, delivery.ID)
+	if err != nil {
+		return FromSQLError(err)
+	}
+
+	if affected(result) == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ type Store interface {
 	TagsStore
 	DeviceStore
 	DeviceTagsStore
+	DeviceAttributesStore
 	SessionStore
 	UserStore
 	FirewallStore
@@ -15,4 +16,13 @@ type Store interface {
 	PrivateKeyStore
 	LicenseStore
 	StatsStore
+	AuditStore
+	APIKeyStore
+	WebhookStore
+	DeviceGroupStore
+	InvitationStore
+	RoleStore
+	AccessGrantStore
+	AccessRequestStore
+	CertificateAuthorityStore
 }
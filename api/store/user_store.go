@@ -15,6 +15,16 @@ type UserStore interface {
 	UserGetByID(ctx context.Context, id string, ns bool) (*models.User, int, error)
 	UserUpdateData(ctx context.Context, id string, user models.User) error
 	UserUpdatePassword(ctx context.Context, newPassword string, id string) error
+	// UserUpdateMFA replaces the MFA of a user, but the counter of its last accepted TOTP code.
+	UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error
+	// UserSetMFACounter sets the counter of the last TOTP code accepted for a user, only when it is greater than the
+	// current one, so a code is accepted once even by concurrent requests. It returns ErrNoDocuments when the user does
+	// not exist or the counter is not greater.
+	UserSetMFACounter(ctx context.Context, id string, counter uint64) error
+	// UserRemoveMFARecoveryCode removes the digest of a recovery code from a user, only when the user still has it, so
+	// a code is used once even by concurrent requests. It returns ErrNoDocuments when the user does not exist or does
+	// not have the code.
+	UserRemoveMFARecoveryCode(ctx context.Context, id string, digest string) error
 	UserUpdateFromAdmin(ctx context.Context, name string, username string, email string, password string, id string) error
 	UserCreateToken(ctx context.Context, token *models.UserTokenRecover) error
 	UserGetToken(ctx context.Context, id string) (*models.UserTokenRecover, error)
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebhookStore interface {
+	WebhookCreate(ctx context.Context, webhook *models.Webhook) error
+	WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error)
+	// WebhookListByEvent returns all webhooks of a namespace subscribed to an event.
+	WebhookListByEvent(ctx context.Context, tenant, event string) ([]models.Webhook, error)
+	WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error)
+	// WebhookDelete deletes a webhook and its deliveries.
+	WebhookDelete(ctx context.Context, tenant, id string) error
+
+	WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error
+	WebhookDeliveryList(ctx context.Context, tenant, webhookID string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+	WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	// WebhookDeliveryUpdate updates the result of a delivery's last attempt.
+	WebhookDeliveryUpdate(ctx context.Context, delivery *models.WebhookDelivery) error
+}
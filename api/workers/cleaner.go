@@ -7,24 +7,36 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	apistore "github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/recording"
+	"github.com/shellhub-io/shellhub/api/store/sql"
 	"github.com/shellhub-io/shellhub/api/workers/stores"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // StartCleaner starts a worker to delete session's records registers older than days defined by
-// SHELLHUB_RECORD_RETENTION.
+// SHELLHUB_RECORD_RETENTION, and audit log's entries older than days defined by SHELLHUB_AUDIT_RETENTION.
 //
 // If something inside the function does not work properly, it will panic.
-// When SHELLHUB_RECORD_RETENTION is equals to zero, records will never be deleted.
-// When SHELLHUB_RECORD_RETENTION is less than zero, nothing happen.
+// When a retention is equals to zero, its data will never be deleted.
+// When a retention is less than zero, nothing happen.
+// When SHELLHUB_DATABASE is memory, the data is not reachable by the worker, so nothing happen.
 func StartCleaner(ctx context.Context) error {
 	envs, err := getEnvs()
 	if err != nil {
 		return fmt.Errorf("failed to get the envs: %w", err)
 	}
 
-	if envs.SessionRecordCleanupRetention == 0 {
+	if envs.SessionRecordCleanupRetention == 0 && envs.AuditRetention == 0 {
+		return nil
+	}
+
+	if envs.Database == "memory" {
+		logrus.Warn("Session's records and audit log cleaner is not supported by the memory database")
+
 		return nil
 	}
 
@@ -32,9 +44,11 @@ func StartCleaner(ctx context.Context) error {
 		return fmt.Errorf("invalid time interval: %w", fmt.Errorf("%d is not a valid time interval", envs.SessionRecordCleanupRetention))
 	}
 
-	limit := time.Now().UTC().AddDate(0, 0, envs.SessionRecordCleanupRetention*-1)
+	if envs.AuditRetention < 0 {
+		return fmt.Errorf("invalid time interval: %w", fmt.Errorf("%d is not a valid time interval", envs.AuditRetention))
+	}
 
-	store, err := stores.NewMongoStore(ctx, envs.MongoURI)
+	cleanup, err := newCleanup(ctx, envs)
 	if err != nil {
 		return fmt.Errorf("failed to connect to the database: %w", err)
 	}
@@ -52,23 +66,33 @@ func StartCleaner(ctx context.Context) error {
 	)
 
 	mux := asynq.NewServeMux()
+	scheduler := asynq.NewScheduler(addr, nil)
 
-	// Handle session_record:cleanup task
-	mux.HandleFunc("session_record:cleanup", func(ctx context.Context, task *asynq.Task) error {
-		if _, err := store.Database.Collection("recorded_sessions").DeleteMany(ctx,
-			bson.M{"time": bson.D{{"$lte", limit}}},
-		); err != nil {
-			return err
-		}
+	if envs.SessionRecordCleanupRetention > 0 {
+		// Handle session_record:cleanup task
+		mux.HandleFunc("session_record:cleanup", func(ctx context.Context, task *asynq.Task) error {
+			return cleanup.records(ctx, retentionLimit(envs.SessionRecordCleanupRetention))
+		})
 
-		if _, err := store.Database.Collection("sessions").UpdateMany(ctx,
-			bson.M{"started_at": bson.D{{"$lte", limit}}, "recorded": bson.M{"$eq": true}},
-			bson.M{"$set": bson.M{"recorded": false}}); err != nil {
-			return err
+		// Schedule session_record:cleanup to run once a day
+		if _, err := scheduler.Register(envs.SessionRecordCleanupSchedule,
+			asynq.NewTask("session_record:cleanup", nil, asynq.TaskID("session_record:cleanup"))); err != nil {
+			logrus.Error(err)
 		}
+	}
 
-		return nil
-	})
+	if envs.AuditRetention > 0 {
+		// Handle audit_log:cleanup task
+		mux.HandleFunc("audit_log:cleanup", func(ctx context.Context, task *asynq.Task) error {
+			return cleanup.auditLogs(ctx, retentionLimit(envs.AuditRetention))
+		})
+
+		// Schedule audit_log:cleanup to run once a day
+		if _, err := scheduler.Register(envs.AuditCleanupSchedule,
+			asynq.NewTask("audit_log:cleanup", nil, asynq.TaskID("audit_log:cleanup"))); err != nil {
+			logrus.Error(err)
+		}
+	}
 
 	go func() {
 		if err := srv.Run(mux); err != nil {
@@ -76,13 +100,165 @@ func StartCleaner(ctx context.Context) error {
 		}
 	}()
 
-	scheduler := asynq.NewScheduler(addr, nil)
+	return scheduler.Run() //nolint:contextcheck
+}
+
+// retentionLimit returns the time before which the data registered is older than the retention, in days.
+func retentionLimit(retention int) time.Time {
+	return time.Now().UTC().AddDate(0, 0, retention*-1)
+}
+
+// cleanup deletes the data registered before a limit.
+type cleanup struct {
+	// records deletes the session's records, unsetting the recorded flag of their sessions.
+	records func(ctx context.Context, limit time.Time) error
+	// auditLogs deletes the audit log's entries.
+	auditLogs func(ctx context.Context, limit time.Time) error
+}
 
-	// Schedule session_record:cleanup to run once a day
-	if _, err := scheduler.Register(envs.SessionRecordCleanupSchedule,
-		asynq.NewTask("session_record:cleanup", nil, asynq.TaskID("session_record:cleanup"))); err != nil {
-		logrus.Error(err)
+// newCleanup connects to the database defined by SHELLHUB_DATABASE and returns the functions that delete its data.
+//
+// When the session's records are kept on the storage defined by SHELLHUB_RECORD_STORAGE, the records of the sessions
+// cleaned up are also deleted from it.
+func newCleanup(ctx context.Context, envs *Envs) (*cleanup, error) {
+	storage, err := recording.New(envs.Config)
+	if err != nil {
+		return nil, err
 	}
 
-	return scheduler.Run() //nolint:contextcheck
+	switch envs.Database {
+	case "postgres", "sqlite":
+		dialect, dsn := sql.DialectPostgres, envs.PostgresURI
+		if envs.Database == "sqlite" {
+			dialect, dsn = sql.DialectSQLite, sql.SQLiteDSN(envs.SQLitePath)
+		}
+
+		store, err := stores.NewSQLStore(dialect, dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		// The "$N" placeholders are understood by both PostgreSQL and SQLite.
+		return &cleanup{
+			records: func(ctx context.Context, limit time.Time) error {
+				if storage != nil {
+					rows, err := store.Database.QueryContext(ctx, "SELECT uid FROM sessions WHERE started_at <= $1 AND recorded = $2", limit, true)
+					if err != nil {
+						return err
+					}
+
+					uids := make([]string, 0)
+					for rows.Next() {
+						var uid string
+						if err := rows.Scan(&uid); err != nil {
+							rows.Close()
+
+							return err
+						}
+
+						uids = append(uids, uid)
+					}
+
+					rows.Close()
+
+					if err := deleteRecordings(ctx, storage, uids); err != nil {
+						return err
+					}
+				}
+
+				if _, err := store.Database.ExecContext(ctx, "DELETE FROM recorded_sessions WHERE time <= $1", limit); err != nil {
+					return err
+				}
+
+				if _, err := store.Database.ExecContext(ctx, "DELETE FROM session_record_lines WHERE started_at <= $1", limit); err != nil {
+					return err
+				}
+
+				if _, err := store.Database.ExecContext(ctx, "UPDATE sessions SET recorded = $1 WHERE started_at <= $2 AND recorded = $3", false, limit, true); err != nil {
+					return err
+				}
+
+				return nil
+			},
+			auditLogs: func(ctx context.Context, limit time.Time) error {
+				_, err := store.Database.ExecContext(ctx, "DELETE FROM audit_logs WHERE created_at <= $1", limit)
+
+				return err
+			},
+		}, nil
+	default:
+		store, err := stores.NewMongoStore(ctx, envs.MongoURI)
+		if err != nil {
+			return nil, err
+		}
+
+		return &cleanup{
+			records: func(ctx context.Context, limit time.Time) error {
+				if storage != nil {
+					var sessions []struct {
+						UID string `bson:"uid"`
+					}
+
+					cursor, err := store.Database.Collection("sessions").Find(ctx,
+						bson.M{"started_at": bson.D{{"$lte", limit}}, "recorded": bson.M{"$eq": true}},
+						options.Find().SetProjection(bson.M{"uid": 1}),
+					)
+					if err != nil {
+						return err
+					}
+
+					if err := cursor.All(ctx, &sessions); err != nil {
+						return err
+					}
+
+					uids := make([]string, 0, len(sessions))
+					for _, session := range sessions {
+						uids = append(uids, session.UID)
+					}
+
+					if err := deleteRecordings(ctx, storage, uids); err != nil {
+						return err
+					}
+				}
+
+				if _, err := store.Database.Collection("recorded_sessions").DeleteMany(ctx,
+					bson.M{"time": bson.D{{"$lte", limit}}},
+				); err != nil {
+					return err
+				}
+
+				if _, err := store.Database.Collection("session_record_lines").DeleteMany(ctx,
+					bson.M{"started_at": bson.D{{"$lte", limit}}},
+				); err != nil {
+					return err
+				}
+
+				if _, err := store.Database.Collection("sessions").UpdateMany(ctx,
+					bson.M{"started_at": bson.D{{"$lte", limit}}, "recorded": bson.M{"$eq": true}},
+					bson.M{"$set": bson.M{"recorded": false}}); err != nil {
+					return err
+				}
+
+				return nil
+			},
+			auditLogs: func(ctx context.Context, limit time.Time) error {
+				_, err := store.Database.Collection("audit_logs").DeleteMany(ctx,
+					bson.M{"created_at": bson.D{{"$lte", limit}}},
+				)
+
+				return err
+			},
+		}, nil
+	}
+}
+
+// deleteRecordings deletes the records of the sessions from the recording storage.
+func deleteRecordings(ctx context.Context, storage apistore.RecordingStorage, uids []string) error {
+	for _, uid := range uids {
+		if err := storage.RecordingDelete(ctx, models.UID(uid)); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
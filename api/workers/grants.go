@@ -0,0 +1,56 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/hibiken/asynq"
+	"github.com/sirupsen/logrus"
+)
+
+// TaskAccessGrantExpire is the task that enforces the expiration of the access grants whose time is over.
+const TaskAccessGrantExpire = "access_grant:expire"
+
+// StartAccessGrants starts a worker to enforce the expiration of the access grants, scheduled by
+// SHELLHUB_ACCESS_GRANT_EXPIRE_SCHEDULE.
+//
+// As the grants stop giving access when their time is over, the schedule only defines how long the sessions opened
+// under an expired grant can last.
+func StartAccessGrants(ctx context.Context, expire func(ctx context.Context) error) error {
+	envs, err := getEnvs()
+	if err != nil {
+		return fmt.Errorf("failed to get the envs: %w", err)
+	}
+
+	addr, err := asynq.ParseRedisURI(envs.RedisURI)
+	if err != nil {
+		return fmt.Errorf("failed to parse redis uri: %w", err)
+	}
+
+	srv := asynq.NewServer(
+		addr,
+		asynq.Config{ //nolint:exhaustruct
+			Concurrency: runtime.NumCPU(),
+		},
+	)
+
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(TaskAccessGrantExpire, func(ctx context.Context, task *asynq.Task) error {
+		return expire(ctx)
+	})
+
+	scheduler := asynq.NewScheduler(addr, nil)
+	if _, err := scheduler.Register(envs.AccessGrantExpireSchedule,
+		asynq.NewTask(TaskAccessGrantExpire, nil, asynq.TaskID(TaskAccessGrantExpire))); err != nil {
+		return fmt.Errorf("failed to schedule the access grant's expiration: %w", err)
+	}
+
+	go func() {
+		if err := srv.Run(mux); err != nil {
+			logrus.Fatal(err)
+		}
+	}()
+
+	return scheduler.Run() //nolint:contextcheck
+}
@@ -0,0 +1,24 @@
+package stores
+
+import (
+	dbsql "database/sql"
+
+	"github.com/shellhub-io/shellhub/api/store/sql"
+)
+
+type SQLStore struct {
+	Database *dbsql.DB
+	Dialect  sql.Dialect
+}
+
+func NewSQLStore(dialect sql.Dialect, dsn string) (*SQLStore, error) {
+	db, err := sql.Open(dialect, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLStore{
+		Database: db,
+		Dialect:  dialect,
+	}, nil
+}
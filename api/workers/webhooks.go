@@ -0,0 +1,78 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// TaskWebhookDeliver is the task that sends a webhook's delivery, with the delivery's ID as payload.
+const TaskWebhookDeliver = "webhook:deliver"
+
+// WebhookQueue enqueues the webhook's deliveries to be sent by the worker started by StartWebhooks.
+type WebhookQueue struct {
+	client  *asynq.Client
+	retries int
+}
+
+// NewWebhookQueue creates a queue of webhook's deliveries, retried up to the times defined by
+// SHELLHUB_WEBHOOK_RETRIES.
+func NewWebhookQueue() (*WebhookQueue, error) {
+	envs, err := getEnvs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the envs: %w", err)
+	}
+
+	if envs.WebhookRetries < 0 {
+		return nil, fmt.Errorf("invalid webhook retries: %d", envs.WebhookRetries)
+	}
+
+	addr, err := asynq.ParseRedisURI(envs.RedisURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis uri: %w", err)
+	}
+
+	return &WebhookQueue{client: asynq.NewClient(addr), retries: envs.WebhookRetries}, nil
+}
+
+// Enqueue enqueues a webhook's delivery to be sent.
+func (q *WebhookQueue) Enqueue(ctx context.Context, id string) error {
+	_, err := q.client.EnqueueContext(ctx, asynq.NewTask(TaskWebhookDeliver, []byte(id)),
+		asynq.MaxRetry(q.retries),
+		asynq.Timeout(30*time.Second),
+	)
+
+	return err
+}
+
+// StartWebhooks starts a worker to send the webhook's deliveries enqueued by a WebhookQueue.
+//
+// A delivery whose attempt fails is retried with an exponential backoff, handled by asynq.
+func StartWebhooks(ctx context.Context, deliver func(ctx context.Context, id string) error) error {
+	envs, err := getEnvs()
+	if err != nil {
+		return fmt.Errorf("failed to get the envs: %w", err)
+	}
+
+	addr, err := asynq.ParseRedisURI(envs.RedisURI)
+	if err != nil {
+		return fmt.Errorf("failed to parse redis uri: %w", err)
+	}
+
+	srv := asynq.NewServer(
+		addr,
+		asynq.Config{ //nolint:exhaustruct
+			Concurrency: runtime.NumCPU(),
+		},
+	)
+
+	mux := asynq.NewServeMux()
+	mux.HandleFunc(TaskWebhookDeliver, func(ctx context.Context, task *asynq.Task) error {
+		return deliver(ctx, string(task.Payload()))
+	})
+
+	return srv.Run(mux)
+}
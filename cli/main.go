@@ -8,6 +8,7 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
 	"github.com/shellhub-io/shellhub/cli/services"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	log "github.com/sirupsen/logrus"
@@ -154,6 +155,42 @@ func main() {
 		},
 	})
 
+	userCmd.AddCommand(&cobra.Command{
+		Use:     "unlock <username> [ip]",
+		Short:   "Unlock the login of an user",
+		Long:    `Unlock the login of an user, and from an IP, locked out after too many failed attempts`,
+		Example: `cli user unlock shellhub 203.0.113.10`,
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Avoid panic when IP isn't provided.
+			if len(args) == 1 {
+				args = append(args, "")
+			}
+
+			var input struct {
+				Username string
+				IP       string
+			}
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			// The lockouts are kept by the API, so they are unlocked through it.
+			if err := internalclient.NewClient().AuthUnlock(input.Username, input.IP); err != nil {
+				return err
+			}
+
+			cmd.Println("User unlocked successfully")
+			cmd.Println("Username:", input.Username)
+			if input.IP != "" {
+				cmd.Println("IP:", input.IP)
+			}
+
+			return nil
+		},
+	})
+
 	namespaceCmd := &cobra.Command{
 		Use:   "namespace",
 		Short: "Manage namespaces",
@@ -0,0 +1,66 @@
+// Package ansi handles the ANSI escape sequences written to terminals.
+package ansi
+
+import (
+	"strings"
+)
+
+const (
+	esc = 0x1b
+	bel = 0x07
+)
+
+// Strip removes the ANSI escape sequences from a terminal's data, as the ones that color the text or move the cursor,
+// keeping the other characters, including the control ones.
+//
+// It removes the CSI sequences (ESC [), the OSC, DCS, SOS, PM and APC strings, terminated by BEL or ST (ESC \), and the
+// other two characters escape sequences. An incomplete sequence at the end of the data is also removed.
+func Strip(data string) string {
+	var builder strings.Builder
+	builder.Grow(len(data))
+
+	for i := 0; i < len(data); i++ {
+		if data[i] != esc {
+			builder.WriteByte(data[i])
+
+			continue
+		}
+
+		if i+1 >= len(data) {
+			break
+		}
+
+		switch data[i+1] {
+		case '[':
+			// CSI: parameter and intermediate bytes, ended by a final byte in the range 0x40–0x7E.
+			i += 2
+			for i < len(data) && (data[i] < 0x40 || data[i] > 0x7e) {
+				i++
+			}
+		case ']', 'P', 'X', '^', '_':
+			// String sequences, ended by BEL or ST.
+			i += 2
+			for i < len(data) {
+				if data[i] == bel {
+					break
+				}
+
+				if data[i] == esc && i+1 < len(data) && data[i+1] == '\\' {
+					i++
+
+					break
+				}
+
+				i++
+			}
+		default:
+			// Two characters sequences, as ESC 7 and ESC =, with an optional intermediate byte, as ESC ( B.
+			i++
+			for i < len(data) && data[i] >= 0x20 && data[i] <= 0x2f {
+				i++
+			}
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,52 @@
+package ansi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrip(t *testing.T) {
+	cases := []struct {
+		description string
+		data        string
+		expected    string
+	}{
+		{
+			description: "keeps the data without escape sequences",
+			data:        "rm -rf /tmp/data\r\n",
+			expected:    "rm -rf /tmp/data\r\n",
+		},
+		{
+			description: "removes the colors",
+			data:        "\x1b[01;34mdir\x1b[0m  \x1b[01;32mfile\x1b[0m",
+			expected:    "dir  file",
+		},
+		{
+			description: "removes the cursor movements and the private modes",
+			data:        "\x1b[?2004h\x1b[2J\x1b[H$ ls\x1b[K",
+			expected:    "$ ls",
+		},
+		{
+			description: "removes the window's title",
+			data:        "\x1b]0;user@device: ~\x07$ \x1b]2;title\x1b\\ls",
+			expected:    "$ ls",
+		},
+		{
+			description: "removes the two characters sequences",
+			data:        "\x1b7saved\x1b8\x1b(Bcharset\x1b=",
+			expected:    "savedcharset",
+		},
+		{
+			description: "removes an incomplete sequence at the end",
+			data:        "text\x1b[01;3",
+			expected:    "text",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Strip(tc.data))
+		})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -15,6 +17,7 @@ const (
 	apiPort    = 8080
 	apiScheme  = "http"
 	billingURL = "billing-api"
+	sshURL     = "ssh:8080"
 )
 
 type Client interface {
@@ -26,12 +29,19 @@ type internalAPI interface {
 	LookupDevice()
 	GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error)
 	CreatePrivateKey() (*models.PrivateKey, error)
+	IssueUserCertificate(device, username, session, publicKey string) (*models.UserCertificate, error)
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
 	DevicesOffline(id string) error
 	DevicesHeartbeat(id string) error
+	ReportDeviceHostKeyMismatch(uid, hostKey string) error
+	EvaluateDeviceScope(uid, member, fingerprint string) error
+	AuthUnlock(username, ip string) error
 	FirewallEvaluate(lookup map[string]string) error
 	SessionAsAuthenticated(uid string) []error
 	FinishSession(uid string) []error
+	CloseSession(uid, device string) error
+	EvaluateAccessRequest(device, member, fingerprint, reason string) (*models.AccessRequest, error)
+	GetAccessRequest(tenant, id string) (*models.AccessRequest, error)
 	KeepAliveSession(uid string) []error
 	RecordSession(session *models.SessionRecorded, recordURL string)
 	BillingEvaluate(tenantID string) (*models.Namespace, int, error)
@@ -72,7 +82,7 @@ func (c *client) GetPublicKey(fingerprint, tenant string) (*models.PublicKey, er
 	var pubKey *models.PublicKey
 	resp, err := c.http.R().
 		SetResult(&pubKey).
-		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/%s/%s", fingerprint, tenant)))
+		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/%s/%s", url.PathEscape(fingerprint), tenant)))
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +113,7 @@ func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username st
 	resp, err := c.http.R().
 		SetBody(dev).
 		SetResult(&evaluate).
-		Post(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/evaluate/%s/%s", fingerprint, username)))
+		Post(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/evaluate/%s/%s", url.PathEscape(fingerprint), username)))
 	if err != nil {
 		return false, err
 	}
@@ -127,6 +137,33 @@ func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
 	return privKey, nil
 }
 
+// IssueUserCertificate gets a user certificate for the public key, in the authorized keys format, to authenticate as the
+// username on the device during the session.
+func (c *client) IssueUserCertificate(device, username, session, publicKey string) (*models.UserCertificate, error) {
+	var certificate *models.UserCertificate
+	resp, err := c.http.R().
+		SetBody(map[string]string{
+			"device":     device,
+			"username":   username,
+			"session":    session,
+			"public_key": publicKey,
+		}).
+		SetResult(&certificate).
+		Post(buildURL(c, "/internal/sshkeys/certificates"))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return certificate, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
 func (c *client) DevicesOffline(id string) error {
 	_, err := c.http.R().
 		Post(buildURL(c, fmt.Sprintf("/internal/devices/%s/offline", id)))
@@ -147,6 +184,62 @@ func (c *client) DevicesHeartbeat(id string) error {
 	return nil
 }
 
+// ReportDeviceHostKeyMismatch alerts the device's namespace that the host key, in the authorized keys format, was
+// presented on the device's behalf instead of the one pinned to it.
+func (c *client) ReportDeviceHostKeyMismatch(uid, hostKey string) error {
+	resp, err := c.http.R().
+		SetBody(map[string]string{"host_key": hostKey}).
+		Post(buildURL(c, fmt.Sprintf("/internal/devices/%s/host-key/mismatch", uid)))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+// AuthUnlock unlocks the logins of the username and from the IP, locked out after too many failed attempts.
+func (c *client) AuthUnlock(username, ip string) error {
+	resp, err := c.http.R().
+		SetBody(map[string]string{"username": username, "ip": ip}).
+		Post(buildURL(c, "/internal/auth/unlock"))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
+
+// EvaluateDeviceScope checks if a connection to the device, opened by the member or authenticated by the public key
+// with the fingerprint, is in the scope of its member. It returns ErrNotFound when it is not.
+func (c *client) EvaluateDeviceScope(uid, member, fingerprint string) error {
+	resp, err := c.http.R().
+		SetBody(map[string]string{"member": member, "fingerprint": fingerprint}).
+		Post(buildURL(c, fmt.Sprintf("/internal/devices/%s/scope", uid)))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
 var (
 	ErrFirewallConnection = errors.New("failed to make the request to evaluate the firewall")
 	ErrFirewallBlock      = errors.New("a firewall rule prohibit this connection")
@@ -162,20 +255,30 @@ func (c *client) FirewallEvaluate(lookup map[string]string) error {
 		return r.StatusCode() >= http.StatusInternalServerError && r.StatusCode() != http.StatusNotImplemented
 	})
 
+	// Cloud and enterprise instances evaluate the firewall rules on their own service.
+	url := buildURL(c, "/internal/firewall/rules/evaluate")
+	if envs.IsCloud() || envs.IsEnterprise() {
+		url = "http://cloud-api:8080/internal/firewall/rules/evaluate"
+	}
+
 	resp, err := local.
 		SetRetryCount(10).
 		R().
 		SetQueryParams(lookup).
-		Get("http://cloud-api:8080/internal/firewall/rules/evaluate")
+		Get(url)
 	if err != nil {
 		return ErrFirewallConnection
 	}
 
-	if resp.StatusCode() != http.StatusOK {
+	// Only a forbidden connection is blocked by a rule, the other statuses are failures of the evaluation.
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return nil
+	case http.StatusForbidden:
 		return ErrFirewallBlock
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknown, resp.Status())
 	}
-
-	return nil
 }
 
 // SessionAsAuthenticated makes a HTTP request to ShellHub API server to mark the session as authenticated.
@@ -204,6 +307,70 @@ func (c *client) FinishSession(uid string) []error {
 	return errors
 }
 
+// CloseSession asks the SSH server to close a session through the agent of its device. As the device can be offline,
+// the request is not retried.
+func (c *client) CloseSession(uid, device string) error {
+	resp, err := resty.New().R().
+		SetBody(map[string]string{"device": device}).
+		Post(fmt.Sprintf("http://%s/sessions/%s/close", sshURL, uid))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
+
+// EvaluateAccessRequest checks if a connection to a device must wait for an access request to be approved, returning
+// the pending request the connection must wait for, or nil when it can go on.
+func (c *client) EvaluateAccessRequest(device, member, fingerprint, reason string) (*models.AccessRequest, error) {
+	var request *models.AccessRequest
+	resp, err := c.http.R().
+		SetBody(map[string]string{
+			"device":      device,
+			"member":      member,
+			"fingerprint": fingerprint,
+			"reason":      reason,
+		}).
+		SetResult(&request).
+		Post(buildURL(c, "/internal/access-requests/evaluate"))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return request, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
+// GetAccessRequest gets an access request of a namespace, used to follow the request a connection waits for.
+func (c *client) GetAccessRequest(tenant, id string) (*models.AccessRequest, error) {
+	var request *models.AccessRequest
+	resp, err := c.http.R().
+		SetResult(&request).
+		Get(buildURL(c, fmt.Sprintf("/internal/access-requests/%s/%s", tenant, id)))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return request, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
 func (c *client) KeepAliveSession(uid string) []error {
 	var errors []error
 	_, err := c.http.R().
@@ -12,6 +12,20 @@ type Client struct {
 	mock.Mock
 }
 
+// AuthUnlock provides a mock function with given fields: username, ip
+func (_m *Client) AuthUnlock(username string, ip string) error {
+	ret := _m.Called(username, ip)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(username, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BillingEvaluate provides a mock function with given fields: tenantID
 func (_m *Client) BillingEvaluate(tenantID string) (*models.Namespace, int, error) {
 	ret := _m.Called(tenantID)
@@ -42,6 +56,20 @@ func (_m *Client) BillingEvaluate(tenantID string) (*models.Namespace, int, erro
 	return r0, r1, r2
 }
 
+// CloseSession provides a mock function with given fields: uid, device
+func (_m *Client) CloseSession(uid string, device string) error {
+	ret := _m.Called(uid, device)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(uid, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreatePrivateKey provides a mock function with given fields:
 func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	ret := _m.Called()
@@ -118,6 +146,43 @@ func (_m *Client) DevicesOffline(id string) error {
 	return r0
 }
 
+// EvaluateAccessRequest provides a mock function with given fields: device, member, fingerprint, reason
+func (_m *Client) EvaluateAccessRequest(device string, member string, fingerprint string, reason string) (*models.AccessRequest, error) {
+	ret := _m.Called(device, member, fingerprint, reason)
+
+	var r0 *models.AccessRequest
+	if rf, ok := ret.Get(0).(func(string, string, string, string) *models.AccessRequest); ok {
+		r0 = rf(device, member, fingerprint, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(device, member, fingerprint, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateDeviceScope provides a mock function with given fields: uid, member, fingerprint
+func (_m *Client) EvaluateDeviceScope(uid string, member string, fingerprint string) error {
+	ret := _m.Called(uid, member, fingerprint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(uid, member, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EvaluateKey provides a mock function with given fields: fingerprint, dev, username
 func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	ret := _m.Called(fingerprint, dev, username)
@@ -169,6 +234,29 @@ func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 	return r0
 }
 
+// GetAccessRequest provides a mock function with given fields: tenant, id
+func (_m *Client) GetAccessRequest(tenant string, id string) (*models.AccessRequest, error) {
+	ret := _m.Called(tenant, id)
+
+	var r0 *models.AccessRequest
+	if rf, ok := ret.Get(0).(func(string, string) *models.AccessRequest); ok {
+		r0 = rf(tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -215,6 +303,29 @@ func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.Publi
 	return r0, r1
 }
 
+// IssueUserCertificate provides a mock function with given fields: device, username, session, publicKey
+func (_m *Client) IssueUserCertificate(device string, username string, session string, publicKey string) (*models.UserCertificate, error) {
+	ret := _m.Called(device, username, session, publicKey)
+
+	var r0 *models.UserCertificate
+	if rf, ok := ret.Get(0).(func(string, string, string, string) *models.UserCertificate); ok {
+		r0 = rf(device, username, session, publicKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserCertificate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
+		r1 = rf(device, username, session, publicKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // KeepAliveSession provides a mock function with given fields: uid
 func (_m *Client) KeepAliveSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -308,6 +419,20 @@ func (_m *Client) ReportDelete(ns *models.Namespace) (int, error) {
 	return r0, r1
 }
 
+// ReportDeviceHostKeyMismatch provides a mock function with given fields: uid, hostKey
+func (_m *Client) ReportDeviceHostKeyMismatch(uid string, hostKey string) error {
+	ret := _m.Called(uid, hostKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(uid, hostKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReportUsage provides a mock function with given fields: ur
 func (_m *Client) ReportUsage(ur *models.UsageRecord) (int, error) {
 	ret := _m.Called(ur)
@@ -0,0 +1,30 @@
+package requests
+
+// AccessGrantParam is a structure to represent and validate an access grant ID as path param.
+type AccessGrantParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AccessGrantCreate is the structure to represent the request data for create access grant endpoint. The access is
+// granted either to a member or to a public key, and either to a device or to the devices with a tag.
+type AccessGrantCreate struct {
+	// Member is the ID of the namespace's member granted the access.
+	Member string `json:"member" validate:"required_without=Fingerprint,excluded_with=Fingerprint"`
+	// Fingerprint is the fingerprint of the namespace's public key granted the access.
+	Fingerprint string `json:"fingerprint" validate:"required_without=Member"`
+	Device      string `json:"device" validate:"required_without=Tag,excluded_with=Tag"`
+	Tag         string `json:"tag" validate:"required_without=Device,omitempty,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Reason      string `json:"reason" validate:"max=255"`
+	// ExpiresIn is the number of minutes until the grant expires, up to 30 days.
+	ExpiresIn int `json:"expires_in" validate:"required,min=1,max=43200"`
+}
+
+// AccessGrantGet is the structure to represent the request data for get access grant endpoint.
+type AccessGrantGet struct {
+	AccessGrantParam
+}
+
+// AccessGrantRevoke is the structure to represent the request data for revoke access grant endpoint.
+type AccessGrantRevoke struct {
+	AccessGrantParam
+}
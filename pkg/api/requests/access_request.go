@@ -0,0 +1,56 @@
+package requests
+
+// AccessRequestParam is a structure to represent and validate an access request ID as path param.
+type AccessRequestParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AccessRequestList is the structure to represent the request data for list access requests endpoint.
+type AccessRequestList struct {
+	// Status filters the requests by their status. When it is empty, all requests are listed.
+	Status string `query:"status" validate:"omitempty,oneof=pending approved denied"`
+}
+
+// AccessRequestCreate is the structure to represent the request data for create access request endpoint.
+type AccessRequestCreate struct {
+	Device string `json:"device" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=255"`
+	// Duration is the number of minutes the access is requested for, up to 30 days.
+	Duration int `json:"duration" validate:"required,min=1,max=43200"`
+}
+
+// AccessRequestGet is the structure to represent the request data for get access request endpoint.
+type AccessRequestGet struct {
+	AccessRequestParam
+}
+
+// AccessRequestDecide is the structure to represent the request data for approve and deny access request endpoints.
+type AccessRequestDecide struct {
+	AccessRequestParam
+}
+
+// AccessRequestGetInternal is the structure to represent the request data for the internal get access request
+// endpoint, used by the SSH server to follow the request of a held connection.
+type AccessRequestGetInternal struct {
+	TenantParam
+	AccessRequestParam
+}
+
+// AccessRequestEvaluate is the structure to represent the request data for the internal evaluate access request
+// endpoint, used by the SSH server to check if a connection to a device must wait for approval.
+type AccessRequestEvaluate struct {
+	Device string `json:"device" validate:"required"`
+	// Member is the ID of the namespace's member who opened the connection from the web terminal.
+	Member string `json:"member"`
+	// Fingerprint is the fingerprint of the public key that authenticated the connection.
+	Fingerprint string `json:"fingerprint"`
+	// Reason describes the connection, as the reason of the request filed on its behalf.
+	Reason string `json:"reason"`
+}
+
+// NamespaceEditApprovalTags is the structure to represent the request data for edit namespace's approval tags
+// endpoint.
+type NamespaceEditApprovalTags struct {
+	TenantParam
+	Tags []string `json:"tags" validate:"omitempty,max=16,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+}
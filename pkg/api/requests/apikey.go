@@ -0,0 +1,21 @@
+package requests
+
+// APIKeyParam is a structure to represent and validate an API key ID as path param.
+type APIKeyParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// APIKeyCreate is the structure to represent the request data for create API key endpoint.
+type APIKeyCreate struct {
+	Name string `json:"name" validate:"required,min=3,max=255"`
+	// Role is the namespace's role used by the requests authenticated with the key. It cannot be greater than the role
+	// of the member who creates it.
+	Role string `json:"role" validate:"required,oneof=observer operator administrator owner"`
+	// ExpiresIn is the number of days until the key expires. When it is zero, the key never expires.
+	ExpiresIn int `json:"expires_in" validate:"min=0"`
+}
+
+// APIKeyDelete is the structure to represent the request data for delete API key endpoint.
+type APIKeyDelete struct {
+	APIKeyParam
+}
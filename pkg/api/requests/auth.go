@@ -9,3 +9,19 @@ type AuthTokenGet struct {
 type AuthTokenSwap struct {
 	TenantParam
 }
+
+// OIDCAuth is the structure to represent the request data for OpenID Connect authentication endpoint.
+type OIDCAuth struct {
+	// Code is the authorization code sent by the OpenID Connect provider.
+	Code string `json:"code" validate:"required"`
+	// State is the login's state sent back by the OpenID Connect provider.
+	State string `json:"state" validate:"required"`
+}
+
+// AuthUnlock is the structure to represent the request data for the login unlock endpoint.
+type AuthUnlock struct {
+	// Username is the username, or email, whose login is unlocked.
+	Username string `json:"username" validate:"required_without=IP"`
+	// IP is the source IP whose logins are unlocked.
+	IP string `json:"ip" validate:"required_without=Username,omitempty,ip"`
+}
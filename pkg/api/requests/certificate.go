@@ -0,0 +1,11 @@
+package requests
+
+// UserCertificateIssue is the structure to represent the request data for the issue user certificate internal endpoint,
+// used by the SSH server to authenticate on a device.
+type UserCertificateIssue struct {
+	Device   string `json:"device" validate:"required"`
+	Username string `json:"username" validate:"required"`
+	Session  string `json:"session" validate:"required"`
+	// PublicKey is the key to certify, in the authorized keys format.
+	PublicKey string `json:"public_key" validate:"required"`
+}
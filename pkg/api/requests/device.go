@@ -32,6 +32,9 @@ type DeviceLookup struct {
 	Name      string `query:"name" validate:"required"`
 	Username  string `query:"username" validate:""`
 	IPAddress string `query:"ip_address" validate:""`
+	// Member is the namespace's member connecting through the web terminal. When set, the device must be in the
+	// member's device scope.
+	Member string `query:"member" validate:""`
 }
 
 // DeviceUpdateStatus is the structure to represent the request data for device update status endpoint.
@@ -68,6 +71,69 @@ type DeviceUpdateTag struct {
 	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// DeviceAttributeParam is a structure to represent and validate a device's attribute key as path param.
+type DeviceAttributeParam struct {
+	Key string `param:"key" validate:"required,attribute"`
+}
+
+// DeviceSetAttribute is the structure to represent the request data for device set attribute endpoint.
+type DeviceSetAttribute struct {
+	DeviceParam
+	DeviceAttributeParam
+	Value string `json:"value" validate:"max=255"`
+}
+
+// DeviceRemoveAttribute is the structure to represent the request data for device remove attribute endpoint.
+type DeviceRemoveAttribute struct {
+	DeviceParam
+	DeviceAttributeParam
+}
+
+// DeviceUpdateAttributes is the structure to represent the request data for device update attributes endpoint.
+type DeviceUpdateAttributes struct {
+	DeviceParam
+	Attributes map[string]string `json:"attributes" validate:"required,max=32,dive,keys,attribute,endkeys,max=255"`
+}
+
+// DeviceRotateHostKey is the structure to represent the request data for device rotate host key endpoint.
+type DeviceRotateHostKey struct {
+	DeviceParam
+	HostKey string `json:"host_key" validate:"required"`
+}
+
+// DeviceReportHostKeyMismatch is the structure to represent the request data for device report host key mismatch
+// endpoint.
+type DeviceReportHostKeyMismatch struct {
+	DeviceParam
+	HostKey string `json:"host_key" validate:"required"`
+}
+
+// DeviceEvaluateScope is the structure to represent the request data for device evaluate scope endpoint.
+type DeviceEvaluateScope struct {
+	DeviceParam
+	// Member is the namespace's member opening the connection, when it is known, as on the web terminal.
+	Member string `json:"member"`
+	// Fingerprint is the fingerprint of the public key that authenticated the connection, if any.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// DeviceBulk is the structure to represent the request data for device bulk endpoint.
+//
+// The devices are selected by only one of UIDs, Filter or Group.
+type DeviceBulk struct {
+	UIDs []string `json:"uids" validate:"required_without_all=Filter Group,excluded_with=Filter Group,max=1000,unique,dive,required"`
+	// Filter is encoded as the filter accepted by the device's list: a base64 encoded JSON list of filters.
+	Filter string `json:"filter" validate:"required_without_all=UIDs Group,excluded_with=UIDs Group,omitempty,base64"`
+	// Group is the ID of a namespace's device group.
+	Group  string `json:"group" validate:"required_without_all=UIDs Filter,excluded_with=UIDs Filter"`
+	Action string `json:"action" validate:"required,oneof=accept reject remove add_tag remove_tag rename"`
+	// Tag is the tag added or removed by the "add_tag" and "remove_tag" actions.
+	Tag string `json:"tag" validate:"required_if=Action add_tag,required_if=Action remove_tag,omitempty,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Template is the template of the names set by the "rename" action, as "web-{{.Index}}". It is executed with the
+	// device, as .Device, and its position in the batch, starting at 1, as .Index.
+	Template string `json:"template" validate:"required_if=Action rename,max=255"`
+}
+
 type DeviceIdentity struct {
 	MAC string `json:"mac"`
 }
@@ -88,6 +154,11 @@ type DeviceAuth struct {
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
 	TenantID  string          `json:"tenant_id" validate:"required"`
+	// Attributes are the device's attributes set by the agent, replacing the values of the same keys.
+	Attributes map[string]string `json:"attributes,omitempty" validate:"max=32,dive,keys,attribute,endkeys,max=255"`
+	// HostKey is the SSH host key of the agent, in the authorized keys format. The agents that predate it use the
+	// public key they register with as their host key.
+	HostKey string `json:"host_key,omitempty"`
 }
 
 type DeviceGetPublicURL struct {
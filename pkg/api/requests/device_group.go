@@ -0,0 +1,43 @@
+package requests
+
+// DeviceGroupParam is a structure to represent and validate a device group ID as path param.
+type DeviceGroupParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// DeviceGroupFields contains the fields that can be set on a device group.
+type DeviceGroupFields struct {
+	Name string `json:"name" validate:"required,min=1,max=64"`
+	// Filter is the group's filter expression, encoded as the filter accepted by the device's list: a base64 encoded
+	// JSON list of filters.
+	Filter string `json:"filter" validate:"required,base64"`
+}
+
+// DeviceGroupCreate is the structure to represent the request data for create device group endpoint.
+type DeviceGroupCreate struct {
+	DeviceGroupFields
+}
+
+// DeviceGroupGet is the structure to represent the request data for get device group endpoint.
+type DeviceGroupGet struct {
+	DeviceGroupParam
+}
+
+// DeviceGroupUpdate is the structure to represent the request data for update device group endpoint.
+type DeviceGroupUpdate struct {
+	DeviceGroupParam
+	DeviceGroupFields
+}
+
+// DeviceGroupDelete is the structure to represent the request data for delete device group endpoint.
+type DeviceGroupDelete struct {
+	DeviceGroupParam
+}
+
+// DeviceGroupDevicesList is the structure to represent the request data for list device group's devices endpoint.
+type DeviceGroupDevicesList struct {
+	DeviceGroupParam
+	Status  string `query:"status" validate:"omitempty,oneof=accepted pending rejected unused"`
+	SortBy  string `query:"sort_by"`
+	OrderBy string `query:"order_by"`
+}
@@ -0,0 +1,82 @@
+package requests
+
+// FirewallRuleParam is a structure to represent and validate a firewall rule ID as path param.
+type FirewallRuleParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+type FirewallFilter struct {
+	Hostname string `json:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
+	// FIXME: add validation for tags when it has at least one item.
+	//
+	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
+	// it throws a error even with `required_without` and `excluded_with`.
+	Tags []string `json:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of a namespace's device group, matching the devices resolved by the group.
+	Group string `json:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
+}
+
+// FirewallRuleFields contains the fields that can be set on a firewall rule.
+type FirewallRuleFields struct {
+	// Priority is the order in which the rule is evaluated. Rules with lower priority value are evaluated first.
+	Priority int `json:"priority"`
+	// Action is what to do with a connection matched by the rule. It can be "allow" or "deny".
+	Action string `json:"action" validate:"required,oneof=allow deny"`
+	// Active indicates if the rule is considered in the evaluation.
+	Active bool `json:"active"`
+	// SourceIP is a regexp matched against the IP address of the client.
+	SourceIP string `json:"source_ip" validate:"required,regexp"`
+	// Username is a regexp matched against the user on the device.
+	Username string `json:"username" validate:"required,regexp"`
+	// Filter selects the devices the rule applies to.
+	Filter FirewallFilter `json:"filter" validate:"required"`
+}
+
+// FirewallRuleGet is the structure to represent the request data for get firewall rule endpoint.
+type FirewallRuleGet struct {
+	FirewallRuleParam
+}
+
+// FirewallRuleCreate is the structure to represent the request data for create firewall rule endpoint.
+type FirewallRuleCreate struct {
+	FirewallRuleFields
+}
+
+// FirewallRuleUpdate is the structure to represent the request data for update firewall rule endpoint.
+type FirewallRuleUpdate struct {
+	FirewallRuleParam
+	FirewallRuleFields
+}
+
+// FirewallRuleDelete is the structure to represent the request data for delete firewall rule endpoint.
+type FirewallRuleDelete struct {
+	FirewallRuleParam
+}
+
+// FirewallRuleTagAdd is the structure to represent the request data for add tag to firewall rule endpoint.
+type FirewallRuleTagAdd struct {
+	FirewallRuleParam
+	TagBody
+}
+
+// FirewallRuleTagRemove is the structure to represent the request data for remove tag from firewall rule endpoint.
+type FirewallRuleTagRemove struct {
+	FirewallRuleParam
+	TagParam
+}
+
+// FirewallRuleTagsUpdate is the structure to represent the request data for update tags from firewall rule endpoint.
+type FirewallRuleTagsUpdate struct {
+	FirewallRuleParam
+	Tags []string `json:"tags" validate:"required,min=1,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+}
+
+// FirewallEvaluate is the structure to represent the request data for the firewall evaluation endpoint.
+//
+// Its fields are the same sent by the SSH server when it looks up a device.
+type FirewallEvaluate struct {
+	Domain    string `query:"domain" validate:"required"`
+	Name      string `query:"name" validate:"required"`
+	Username  string `query:"username" validate:"required"`
+	IPAddress string `query:"ip_address" validate:"required"`
+}
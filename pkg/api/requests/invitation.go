@@ -0,0 +1,34 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// InvitationParam is a structure to represent and validate an invitation ID as path param.
+type InvitationParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// InvitationCreate is the structure to represent the request data for create namespace invitation endpoint.
+type InvitationCreate struct {
+	TenantParam
+	Email string `json:"email" validate:"required,email"`
+	RoleBody
+	// ExpiresIn is the number of days until the invitation expires. When it is zero, the invitation expires in 7 days.
+	ExpiresIn int `json:"expires_in" validate:"min=0,max=30"`
+}
+
+// InvitationList is the structure to represent the request data for list namespace invitations endpoint.
+type InvitationList struct {
+	TenantParam
+	paginator.Query
+}
+
+// InvitationRevoke is the structure to represent the request data for revoke namespace invitation endpoint.
+type InvitationRevoke struct {
+	TenantParam
+	InvitationParam
+}
+
+// InvitationToken is the structure to represent the request data for accept and decline invitation endpoints.
+type InvitationToken struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,27 @@
+package requests
+
+// MFAEnable is the structure to represent the request data for enable MFA endpoint.
+type MFAEnable struct {
+	// Code is the TOTP code generated from the secret, confirming that the authenticator app was configured.
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// MFADisable is the structure to represent the request data for disable MFA endpoint.
+type MFADisable struct {
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+// MFAAuth is the structure to represent the request data for MFA authentication endpoint.
+type MFAAuth struct {
+	// Token is the MFA-pending token returned by the user authentication.
+	Token string `json:"token" validate:"required"`
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" validate:"required"`
+}
+
+// NamespaceEditRequireMFA is the structure to represent the request data for edit namespace's MFA requirement endpoint.
+type NamespaceEditRequireMFA struct {
+	TenantParam
+	RequireMFA bool `json:"require_mfa"`
+}
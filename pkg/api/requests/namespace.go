@@ -5,9 +5,10 @@ type TenantParam struct {
 	Tenant string `param:"tenant" validate:"required,min=3,max=255,ascii,excludes=/@&:"`
 }
 
-// RoleBody is a structure to represent and validate a namespace role as request body.
+// RoleBody is a structure to represent and validate a namespace role as request body. The role is either a builtin
+// role, other than the owner's, or the name of one of the namespace's custom roles.
 type RoleBody struct {
-	Role string `json:"role" validate:"required,oneof=administrator operator observer"`
+	Role string `json:"role" validate:"required,ne=owner,min=3,max=30,hostname_rfc1123,excludes=."`
 }
 
 // MemberParam is a structure to represent and validate a member UID as path param.
@@ -57,8 +58,19 @@ type NamespaceEditUser struct {
 	RoleBody
 }
 
+// NamespaceEditUserScope is the structure to represent the request data for edit member's device scope endpoint. When
+// both the tags and the groups are empty, the member is not restricted.
+type NamespaceEditUserScope struct {
+	TenantParam
+	MemberParam
+	Tags   []string `json:"tags" validate:"omitempty,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Groups []string `json:"groups" validate:"omitempty,dive,required"`
+}
+
 // SessionEditRecordStatus is the structure to represent the request data for edit session record status endpoint.
 type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
+	// RecordInput defines if the input of the recorded sessions is also recorded. It is kept when not sent.
+	RecordInput *bool `json:"record_input,omitempty"`
 }
@@ -0,0 +1,35 @@
+package requests
+
+// RoleParam is a structure to represent and validate a custom role name as path param.
+type RoleParam struct {
+	Name string `param:"name" validate:"required"`
+}
+
+// RoleFields contains the fields that can be set on a custom role.
+type RoleFields struct {
+	Description string `json:"description" validate:"max=255"`
+	// Permissions are the names of the permissions granted by the role, as "device:connect".
+	Permissions []string `json:"permissions" validate:"required,dive,required"`
+}
+
+// RoleCreate is the structure to represent the request data for create role endpoint.
+type RoleCreate struct {
+	Name string `json:"name" validate:"required,min=3,max=30,hostname_rfc1123,excludes=."`
+	RoleFields
+}
+
+// RoleGet is the structure to represent the request data for get role endpoint.
+type RoleGet struct {
+	RoleParam
+}
+
+// RoleUpdate is the structure to represent the request data for update role endpoint.
+type RoleUpdate struct {
+	RoleParam
+	RoleFields
+}
+
+// RoleDelete is the structure to represent the request data for delete role endpoint.
+type RoleDelete struct {
+	RoleParam
+}
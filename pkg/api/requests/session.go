@@ -25,6 +25,10 @@ type SessionCreate struct {
 	IPAddress string `json:"ip_address" validate:"required"`
 	Type      string `json:"type" validate:"required"`
 	Term      string `json:"term" validate:""`
+	// Member is the ID of the namespace's member who opened the session from the web terminal.
+	Member string `json:"member"`
+	// Fingerprint is the fingerprint of the public key that authenticated the session.
+	Fingerprint string `json:"fingerprint"`
 }
 
 // SessionFinish is the structure to represent the request data for finish session endpoint.
@@ -36,3 +40,19 @@ type SessionFinish struct {
 type SessionKeepAlive struct {
 	SessionIDParam
 }
+
+// SessionRecord is the structure to represent the request data for record session endpoint.
+type SessionRecord struct {
+	SessionIDParam
+	Message string `json:"message"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+	// Type is the type of the frame, "o" for the terminal's output or "i" for the client's input. A frame without type
+	// is an output one.
+	Type string `json:"type" validate:"omitempty,oneof=o i"`
+}
+
+// SessionSearch is the structure to represent the request data for search session records endpoint.
+type SessionSearch struct {
+	Query string `query:"q" validate:"required,min=2"`
+}
@@ -0,0 +1,29 @@
+package requests
+
+// WebhookParam is a structure to represent and validate a webhook ID as path param.
+type WebhookParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// WebhookCreate is the structure to represent the request data for create webhook endpoint.
+type WebhookCreate struct {
+	URL string `json:"url" validate:"required,url"`
+	// Events are the namespace's events delivered to the webhook.
+	Events []string `json:"events" validate:"required,min=1,unique,dive,oneof=device.pending device.accepted device.online device.offline session.started session.closed publickey.created access_request.created device.host_key_mismatch"`
+}
+
+// WebhookDelete is the structure to represent the request data for delete webhook endpoint.
+type WebhookDelete struct {
+	WebhookParam
+}
+
+// WebhookDeliveriesList is the structure to represent the request data for list webhook's deliveries endpoint.
+type WebhookDeliveriesList struct {
+	WebhookParam
+}
+
+// WebhookRedeliver is the structure to represent the request data for redeliver webhook's delivery endpoint.
+type WebhookRedeliver struct {
+	WebhookParam
+	DeliveryID string `param:"delivery" validate:"required"`
+}
@@ -0,0 +1,11 @@
+package responses
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// APIKeyCreate is the structure to represent the response data for create API key endpoint.
+//
+// Key is the only time the API key itself is returned, as only its digest is stored.
+type APIKeyCreate struct {
+	models.APIKey
+	Key string `json:"key"`
+}
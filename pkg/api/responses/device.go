@@ -0,0 +1,17 @@
+package responses
+
+// DeviceBulkResult is the result of a bulk operation's action on a device.
+type DeviceBulkResult struct {
+	UID     string `json:"uid"`
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	// Error is the reason why the action has failed on the device.
+	Error string `json:"error,omitempty"`
+}
+
+// DeviceBulk is the structure to represent the response data for device bulk endpoint.
+type DeviceBulk struct {
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []DeviceBulkResult `json:"results"`
+}
@@ -0,0 +1,14 @@
+package responses
+
+// MFAGenerate is the structure to represent the response data for generate MFA endpoint.
+type MFAGenerate struct {
+	Secret string `json:"secret"`
+	// URI is the otpauth provisioning URI of the secret, to be shown as a QR code to the authenticator apps.
+	URI string `json:"uri"`
+}
+
+// MFARecoveryCodes is the structure to represent the response data for enable MFA endpoint. The recovery codes are
+// only returned once.
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -1,12 +1,14 @@
 package responses
 
 type PublicKeyFilter struct {
-	Hostname string `json:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
+	Hostname string `json:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
 	// FIXME: add validation for tags when it has at least one item.
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of a namespace's device group, matching the devices resolved by the group.
+	Group string `json:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
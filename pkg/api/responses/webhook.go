@@ -0,0 +1,11 @@
+package responses
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// WebhookCreate is the structure to represent the response data for create webhook endpoint.
+//
+// Secret is the only time the webhook's secret is returned, and it is used to check the signature of the deliveries.
+type WebhookCreate struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// deliveryTimeout is the maximum time to wait for a webhook's receiver to respond.
+const deliveryTimeout = 10 * time.Second
+
+var deliveryClient = &http.Client{Timeout: deliveryTimeout}
+
+// Sign returns the signature of a payload, which is its HMAC-SHA256 with the webhook's secret, hex encoded.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) //nolint:errcheck
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the payload of an event to a webhook's URL, identified by the delivery's ID and signed with the webhook's
+// secret. It returns the status code of the response, and an error when the receiver could not be reached or when it
+// did not respond with a 2xx status code.
+func Send(ctx context.Context, url, secret, id, event string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, id)
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, Sign(secret, payload))
+
+	res, err := deliveryClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrConnectionFailed, err) //nolint:errorlint
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("%w: status code %d", ErrUnknown, res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"event":"device.online"}`)
+
+	cases := []struct {
+		description string
+		status      int
+		expected    error
+	}{
+		{
+			description: "succeeds when the receiver responds with 2xx",
+			status:      http.StatusNoContent,
+			expected:    nil,
+		},
+		{
+			description: "fails when the receiver does not respond with 2xx",
+			status:      http.StatusInternalServerError,
+			expected:    ErrUnknown,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				assert.Equal(t, payload, body)
+				assert.Equal(t, "id", r.Header.Get(WebhookIDHeader))
+				assert.Equal(t, WebhookDeviceOnlineEvent, r.Header.Get(WebhookEventHeader))
+				assert.Equal(t, Sign("secret", payload), r.Header.Get(WebhookSignatureHeader))
+
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			status, err := Send(context.TODO(), server.URL, "secret", "id", WebhookDeviceOnlineEvent, payload)
+			assert.Equal(t, tc.status, status)
+			assert.True(t, errors.Is(err, tc.expected))
+		})
+	}
+}
+
+func TestSendConnectionFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	_, err := Send(context.TODO(), server.URL, "secret", "id", WebhookDeviceOnlineEvent, nil)
+	assert.True(t, errors.Is(err, ErrConnectionFailed))
+}
@@ -1,5 +1,9 @@
 package webhook
 
+import (
+	"time"
+)
+
 // Webhook request headers.
 const (
 	// A unique ID that identifies the delivered webhook.
@@ -14,6 +18,24 @@ const (
 const (
 	// A new connection was made to the SSH Server.
 	WebhookIncomingConnectionEvent = "incoming_connection"
+	// A new device is waiting to be accepted.
+	WebhookDevicePendingEvent = "device.pending"
+	// A device was accepted.
+	WebhookDeviceAcceptedEvent = "device.accepted"
+	// A device connected to the server.
+	WebhookDeviceOnlineEvent = "device.online"
+	// A device disconnected from the server.
+	WebhookDeviceOfflineEvent = "device.offline"
+	// A session was started on a device.
+	WebhookSessionStartedEvent = "session.started"
+	// A session was closed.
+	WebhookSessionClosedEvent = "session.closed"
+	// A public key was created.
+	WebhookPublicKeyCreatedEvent = "publickey.created"
+	// An access request to a device that requires approval was filed.
+	WebhookAccessRequestCreatedEvent = "access_request.created"
+	// A device presented a host key other than the one pinned to it.
+	WebhookDeviceHostKeyMismatchEvent = "device.host_key_mismatch"
 )
 
 // IncomingConnectionWebhookRequest is the body payload.
@@ -29,3 +51,11 @@ type IncomingConnectionWebhookResponse struct {
 	// Timeout to wait for connection to be established
 	Timeout int `json:"timeout"`
 }
+
+// EventWebhookRequest is the body payload of the events delivered to the namespace's webhooks.
+type EventWebhookRequest struct {
+	Event     string      `json:"event"`
+	TenantID  string      `json:"tenant_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
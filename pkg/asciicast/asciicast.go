@@ -0,0 +1,90 @@
+// Package asciicast encodes terminal recordings in the asciicast v2 format, understood by asciinema and compatible
+// players.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/.
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Version is the version of the asciicast format encoded.
+const Version = 2
+
+// ContentType is the media type of an asciicast file.
+const ContentType = "application/x-asciicast"
+
+// Header is the first line of an asciicast file, describing the recording.
+type Header struct {
+	Version int `json:"version"`
+	// Width is the terminal's initial number of columns.
+	Width int `json:"width"`
+	// Height is the terminal's initial number of rows.
+	Height int `json:"height"`
+	// Timestamp is the Unix time when the recording started.
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Env holds the environment variables of the recorded terminal, as TERM.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// EventType is the type of an event on the recording.
+type EventType string
+
+const (
+	// EventOutput is the data written to the terminal.
+	EventOutput EventType = "o"
+	// EventInput is the data read from the terminal.
+	EventInput EventType = "i"
+	// EventResize is the terminal's resize, with the data as "COLUMNSxROWS".
+	EventResize EventType = "r"
+)
+
+// Event is a line of an asciicast file after its header.
+type Event struct {
+	// Time is the number of seconds since the recording started.
+	Time float64
+	Type EventType
+	Data string
+}
+
+// MarshalJSON encodes the event as the [time, type, data] array defined by the format.
+//
+// The data is not HTML escaped, keeping it as written to the terminal.
+func (e Event) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode([]interface{}{e.Time, e.Type, e.Data}); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// Cast is a recording in the asciicast format.
+type Cast struct {
+	Header Header
+	Events []Event
+}
+
+// Encode writes the recording to w as newline-delimited JSON, the header first.
+func (c *Cast) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(c.Header); err != nil {
+		return err
+	}
+
+	for _, event := range c.Events {
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
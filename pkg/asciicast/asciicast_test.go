@@ -0,0 +1,33 @@
+package asciicast
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	cast := &Cast{
+		Header: Header{
+			Version:   Version,
+			Width:     80,
+			Height:    24,
+			Timestamp: 1700000000,
+			Env:       map[string]string{"TERM": "xterm"},
+		},
+		Events: []Event{
+			{Time: 0.5, Type: EventOutput, Data: "<html>\r\n"},
+			{Time: 1.25, Type: EventResize, Data: "100x30"},
+		},
+	}
+
+	var buffer bytes.Buffer
+	assert.NoError(t, cast.Encode(&buffer))
+
+	expected := `{"version":2,"width":80,"height":24,"timestamp":1700000000,"env":{"TERM":"xterm"}}
+[0.5,"o","<html>\r\n"]
+[1.25,"r","100x30"]
+`
+	assert.Equal(t, expected, buffer.String())
+}
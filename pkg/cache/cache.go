@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Incr atomically increments the integer value of the key, created with zero when it does not exist, returning the
+	// incremented value. The key expires after ttl.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
 }
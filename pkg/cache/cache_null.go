@@ -24,3 +24,7 @@ func (n *nullCache) Set(ctx context.Context, key string, value interface{}, ttl
 func (n *nullCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
+
+func (n *nullCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return 0, nil
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type redisCache struct {
-	cache *rediscache.Cache
+	cache  *rediscache.Cache
+	client *redis.Client
 }
 
 var _ Cache = &redisCache{}
@@ -21,10 +22,13 @@ func NewRedisCache(uri string) (Cache, error) {
 		return nil, err
 	}
 
+	client := redis.NewClient(opt)
+
 	return &redisCache{
 		cache: rediscache.New(&rediscache.Options{
-			Redis: redis.NewClient(opt),
+			Redis: client,
 		}),
+		client: client,
 	}, nil
 }
 
@@ -52,3 +56,16 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 
 	return c.cache.Delete(ctx, key)
 }
+
+// Incr increments the integer value of the key, setting its expire time, in a single transaction.
+func (c *redisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
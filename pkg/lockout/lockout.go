@@ -0,0 +1,153 @@
+// Package lockout protects an authentication against brute force, counting its failed attempts and locking it out
+// temporarily when they are too many.
+//
+// The attempts are counted by key, as a username or an IP address, in a cache shared by all the instances of a
+// service, incrementing the count atomically so the concurrent attempts are all counted. Each new lockout of a key
+// lasts twice as long as the previous one, until the key stops being locked out for a while.
+package lockout
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+)
+
+// Config defines when a key is locked out and for how long.
+type Config struct {
+	// Attempts is the number of failed attempts that locks a key out. When it is zero, the keys are never locked out.
+	Attempts int
+	// Duration is the duration of the first lockout of a key.
+	Duration time.Duration
+	// MaxDuration is the maximum duration of a lockout.
+	MaxDuration time.Duration
+	// Window is how long the failed attempts of a key are remembered after its last failure, and its lockouts after the
+	// last one ends.
+	Window time.Duration
+}
+
+// Lockout counts the failed attempts of the keys.
+type Lockout struct {
+	cache  cache.Cache
+	prefix string
+	config Config
+}
+
+// attempts is the lockout state of a key in the cache. Its failed attempts are counted apart, under the failures key.
+type attempts struct {
+	// Lockouts is the number of times the key was locked out.
+	Lockouts int
+	// Until is when the last lockout ends.
+	Until time.Time
+}
+
+// New returns a lockout storing the keys' attempts in the cache, under the prefix.
+func New(cache cache.Cache, prefix string, config Config) *Lockout {
+	return &Lockout{cache: cache, prefix: prefix, config: config}
+}
+
+func (l *Lockout) key(key string) string {
+	return l.prefix + "/" + key
+}
+
+func (l *Lockout) failuresKey(key string) string {
+	return l.key(key) + "/failures"
+}
+
+func (l *Lockout) get(ctx context.Context, key string) (*attempts, error) {
+	var value *attempts
+	if err := l.cache.Get(ctx, l.key(key), &value); err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		value = &attempts{}
+	}
+
+	return value, nil
+}
+
+// Check returns how long the key is still locked out, or zero when it is not.
+func (l *Lockout) Check(ctx context.Context, key string) (time.Duration, error) {
+	if l.config.Attempts <= 0 {
+		return 0, nil
+	}
+
+	value, err := l.get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	if value.Until.IsZero() {
+		return 0, nil
+	}
+
+	if left := value.Until.Sub(clock.Now()); left > 0 {
+		return left, nil
+	}
+
+	return 0, nil
+}
+
+// Fail records a failed attempt of the key. When the attempt locks the key out, it returns the lockout's duration.
+func (l *Lockout) Fail(ctx context.Context, key string) (time.Duration, error) {
+	if l.config.Attempts <= 0 {
+		return 0, nil
+	}
+
+	failures, err := l.cache.Incr(ctx, l.failuresKey(key), l.config.Window)
+	if err != nil {
+		return 0, err
+	}
+
+	// Only the attempt that reaches the limit locks the key out, as the concurrent ones counted after it are already
+	// in the lockout.
+	if failures != int64(l.config.Attempts) {
+		return 0, nil
+	}
+
+	value, err := l.get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	duration := l.duration(value.Lockouts)
+
+	value.Lockouts++
+	value.Until = clock.Now().Add(duration)
+
+	// The key is remembered until its lockout ends and the window passes, so its next lockout is longer.
+	if err := l.cache.Set(ctx, l.key(key), value, duration+l.config.Window); err != nil {
+		return 0, err
+	}
+
+	if err := l.cache.Delete(ctx, l.failuresKey(key)); err != nil {
+		return 0, err
+	}
+
+	return duration, nil
+}
+
+// Reset forgets the failed attempts and the lockouts of the key, unlocking it.
+func (l *Lockout) Reset(ctx context.Context, key string) error {
+	if err := l.cache.Delete(ctx, l.failuresKey(key)); err != nil {
+		return err
+	}
+
+	return l.cache.Delete(ctx, l.key(key))
+}
+
+// duration returns the duration of a lockout, doubling the first one for each previous lockout.
+func (l *Lockout) duration(lockouts int) time.Duration {
+	duration := l.config.Duration
+	for i := 0; i < lockouts && (l.config.MaxDuration <= 0 || duration < l.config.MaxDuration); i++ {
+		duration *= 2
+	}
+
+	if l.config.MaxDuration > 0 && duration > l.config.MaxDuration {
+		duration = l.config.MaxDuration
+	}
+
+	return duration
+}
@@ -0,0 +1,163 @@
+package lockout
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// cacheMap is an in-memory cache that records the TTL of each key.
+type cacheMap struct {
+	values map[string][]byte
+	ttls   map[string]time.Duration
+}
+
+var _ cache.Cache = &cacheMap{}
+
+func newCacheMap() *cacheMap {
+	return &cacheMap{values: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *cacheMap) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := c.values[key]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func (c *cacheMap) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.values[key] = data
+	c.ttls[key] = ttl
+
+	return nil
+}
+
+func (c *cacheMap) Delete(_ context.Context, key string) error {
+	delete(c.values, key)
+	delete(c.ttls, key)
+
+	return nil
+}
+
+func (c *cacheMap) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var value int64
+	if err := c.Get(ctx, key, &value); err != nil {
+		return 0, err
+	}
+
+	value++
+
+	return value, c.Set(ctx, key, value, ttl)
+}
+
+func TestLockout(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	clockMock := &mocks.Clock{}
+	clock.DefaultBackend = clockMock
+	clockMock.On("Now").Return(func() time.Time { return now })
+
+	ctx := context.TODO()
+
+	cache := newCacheMap()
+	lockout := New(cache, "login", Config{Attempts: 3, Duration: time.Minute, MaxDuration: 3 * time.Minute, Window: 10 * time.Minute})
+
+	fail := func(times int) time.Duration {
+		var duration time.Duration
+		for i := 0; i < times; i++ {
+			var err error
+			duration, err = lockout.Fail(ctx, "john")
+			assert.NoError(t, err)
+		}
+
+		return duration
+	}
+
+	left, err := lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+
+	// The attempts before the last one do not lock the key out.
+	assert.Zero(t, fail(2))
+	assert.Equal(t, 10*time.Minute, cache.ttls["login/john/failures"])
+
+	left, err = lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+
+	// The last attempt locks the key out, remembering it until the lockout ends and the window passes.
+	assert.Equal(t, time.Minute, fail(1))
+	assert.Equal(t, 11*time.Minute, cache.ttls["login/john"])
+
+	// The failed attempts are counted again from the lockout.
+	assert.NotContains(t, cache.values, "login/john/failures")
+
+	left, err = lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, left)
+
+	now = now.Add(40 * time.Second)
+
+	left, err = lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Second, left)
+
+	// The other keys are not locked out.
+	left, err = lockout.Check(ctx, "jane")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+
+	now = now.Add(20 * time.Second)
+
+	left, err = lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+
+	// Each new lockout is twice as long as the previous one, up to the maximum duration.
+	assert.Equal(t, 2*time.Minute, fail(3))
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, 3*time.Minute, fail(3))
+
+	now = now.Add(3 * time.Minute)
+	assert.Equal(t, 3*time.Minute, fail(3))
+
+	// The reset unlocks the key and forgets its lockouts.
+	assert.NoError(t, lockout.Reset(ctx, "john"))
+
+	left, err = lockout.Check(ctx, "john")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+
+	assert.Zero(t, fail(2))
+	assert.Equal(t, time.Minute, fail(1))
+}
+
+func TestLockoutDisabled(t *testing.T) {
+	cache := newCacheMap()
+	lockout := New(cache, "login", Config{})
+
+	for i := 0; i < 10; i++ {
+		duration, err := lockout.Fail(context.TODO(), "john")
+		assert.NoError(t, err)
+		assert.Zero(t, duration)
+	}
+
+	left, err := lockout.Check(context.TODO(), "john")
+	assert.NoError(t, err)
+	assert.Zero(t, left)
+	assert.Empty(t, cache.values)
+}
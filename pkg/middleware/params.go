@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/url"
+
+	echo "github.com/labstack/echo/v4"
+)
+
+// UnescapeParams unescapes the path params of the requests with escaped paths. Echo routes those requests by their
+// raw path, so the params hold escaped values, as the SHA256 fingerprints with an escaped slash.
+func UnescapeParams(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().URL.RawPath == "" {
+			return next(c)
+		}
+
+		values := c.ParamValues()
+		for i, value := range values {
+			if unescaped, err := url.PathUnescape(value); err == nil {
+				values[i] = unescaped
+			}
+		}
+
+		c.SetParamValues(values...)
+
+		return next(c)
+	}
+}
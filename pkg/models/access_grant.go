@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+)
+
+// AccessGrant is a time-bound access of a namespace's member, or of a public key, to a device or to the devices with a
+// tag, on top of the access they already have. A grant has either a member or a fingerprint, and either a device or a
+// tag.
+//
+// When the grant expires, the sessions opened under it are closed and it is marked as expired.
+type AccessGrant struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Member is the ID of the namespace's member granted the access.
+	Member string `json:"member,omitempty" bson:"member,omitempty"`
+	// Fingerprint is the fingerprint of the namespace's public key granted the access.
+	Fingerprint string    `json:"fingerprint,omitempty" bson:"fingerprint,omitempty"`
+	Device      string    `json:"device,omitempty" bson:"device,omitempty"`
+	Tag         string    `json:"tag,omitempty" bson:"tag,omitempty"`
+	Reason      string    `json:"reason" bson:"reason"`
+	CreatedBy   string    `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at" bson:"expires_at"`
+	// Expired reports whether the grant's expiration was already enforced.
+	Expired bool `json:"expired" bson:"expired"`
+}
+
+// Covers reports whether the grant gives access to the device.
+func (g *AccessGrant) Covers(device *Device) bool {
+	if g.Device != "" {
+		return g.Device == device.UID
+	}
+
+	for _, tag := range device.Tags {
+		if tag == g.Tag {
+			return true
+		}
+	}
+
+	return false
+}
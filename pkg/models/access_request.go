@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+type AccessRequestStatus string
+
+const (
+	AccessRequestStatusPending  AccessRequestStatus = "pending"
+	AccessRequestStatusApproved AccessRequestStatus = "approved"
+	AccessRequestStatusDenied   AccessRequestStatus = "denied"
+)
+
+// AccessRequest is a request to connect to a device that requires approval, filed by a namespace's member or by the
+// SSH server on behalf of a connection held until the request is decided.
+//
+// When an administrator approves the request, an access grant to the device is given to the requester for the
+// request's duration.
+type AccessRequest struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Member is the ID of the namespace's member who requested the access.
+	Member string `json:"member,omitempty" bson:"member,omitempty"`
+	// Fingerprint is the fingerprint of the public key whose connection requested the access.
+	Fingerprint string `json:"fingerprint,omitempty" bson:"fingerprint,omitempty"`
+	Device      string `json:"device" bson:"device"`
+	Reason      string `json:"reason" bson:"reason"`
+	// Duration is the number of minutes the access is granted for when the request is approved.
+	Duration  int                 `json:"duration" bson:"duration"`
+	Status    AccessRequestStatus `json:"status" bson:"status"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+	// DecidedBy is the ID of the member who approved or denied the request.
+	DecidedBy string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"`
+	DecidedAt *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+	// Grant is the ID of the access grant given when the request was approved.
+	Grant string `json:"grant,omitempty" bson:"grant,omitempty"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKey is a long-lived credential to call the API on behalf of a namespace's member, with a role of the namespace.
+//
+// Only the key's digest is stored, so the key itself is known only when it is created.
+type APIKey struct {
+	ID         string     `json:"id" bson:"_id,omitempty"`
+	TenantID   string     `json:"tenant_id" bson:"tenant_id"`
+	UserID     string     `json:"user_id" bson:"user_id"`
+	Name       string     `json:"name" bson:"name"`
+	Role       string     `json:"role" bson:"role"`
+	Digest     string     `json:"-" bson:"digest"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at" bson:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at" bson:"last_used_at"`
+}
+
+// IsExpired reports whether the API key has an expiration time and it is before t.
+func (k *APIKey) IsExpired(t time.Time) bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.After(t)
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"time"
+)
+
+// Actions recorded on the namespace's audit log.
+const (
+	AuditActionDeviceDelete       = "device.delete"
+	AuditActionDeviceRename       = "device.rename"
+	AuditActionDeviceUpdate       = "device.update"
+	AuditActionDeviceUpdateStatus = "device.update_status"
+	AuditActionDeviceTagCreate    = "device.tag.create"
+	AuditActionDeviceTagRemove    = "device.tag.remove"
+	AuditActionDeviceTagUpdate    = "device.tag.update"
+
+	AuditActionDeviceAttributeSet    = "device.attribute.set"
+	AuditActionDeviceAttributeRemove = "device.attribute.remove"
+	AuditActionDeviceAttributeUpdate = "device.attribute.update"
+
+	AuditActionDeviceHostKeyRotate = "device.host_key.rotate"
+
+	AuditActionTagRename = "tag.rename"
+	AuditActionTagDelete = "tag.delete"
+
+	AuditActionPublicKeyCreate    = "public_key.create"
+	AuditActionPublicKeyUpdate    = "public_key.update"
+	AuditActionPublicKeyDelete    = "public_key.delete"
+	AuditActionPublicKeyTagAdd    = "public_key.tag.add"
+	AuditActionPublicKeyTagRemove = "public_key.tag.remove"
+	AuditActionPublicKeyTagUpdate = "public_key.tag.update"
+
+	AuditActionFirewallRuleCreate    = "firewall_rule.create"
+	AuditActionFirewallRuleUpdate    = "firewall_rule.update"
+	AuditActionFirewallRuleDelete    = "firewall_rule.delete"
+	AuditActionFirewallRuleTagAdd    = "firewall_rule.tag.add"
+	AuditActionFirewallRuleTagRemove = "firewall_rule.tag.remove"
+	AuditActionFirewallRuleTagUpdate = "firewall_rule.tag.update"
+
+	AuditActionNamespaceCreate        = "namespace.create"
+	AuditActionNamespaceRename        = "namespace.rename"
+	AuditActionNamespaceDelete        = "namespace.delete"
+	AuditActionNamespaceMemberAdd     = "namespace.member.add"
+	AuditActionNamespaceMemberRemove  = "namespace.member.remove"
+	AuditActionNamespaceMemberEdit    = "namespace.member.edit"
+	AuditActionNamespaceMemberScope   = "namespace.member.scope"
+	AuditActionNamespaceSessionRecord = "namespace.session_record"
+	AuditActionNamespaceRequireMFA    = "namespace.require_mfa"
+	AuditActionNamespaceApprovalTags  = "namespace.approval_tags"
+
+	AuditActionNamespaceInvitationCreate  = "namespace.invitation.create"
+	AuditActionNamespaceInvitationRevoke  = "namespace.invitation.revoke"
+	AuditActionNamespaceInvitationAccept  = "namespace.invitation.accept"
+	AuditActionNamespaceInvitationDecline = "namespace.invitation.decline"
+
+	AuditActionAPIKeyCreate = "api_key.create"
+	AuditActionAPIKeyDelete = "api_key.delete"
+
+	AuditActionWebhookCreate = "webhook.create"
+	AuditActionWebhookDelete = "webhook.delete"
+
+	AuditActionDeviceGroupCreate = "device_group.create"
+	AuditActionDeviceGroupUpdate = "device_group.update"
+	AuditActionDeviceGroupDelete = "device_group.delete"
+
+	AuditActionRoleCreate = "role.create"
+	AuditActionRoleUpdate = "role.update"
+	AuditActionRoleDelete = "role.delete"
+
+	AuditActionAccessGrantCreate = "access_grant.create"
+	AuditActionAccessGrantRevoke = "access_grant.revoke"
+
+	AuditActionAccessRequestCreate  = "access_request.create"
+	AuditActionAccessRequestApprove = "access_request.approve"
+	AuditActionAccessRequestDeny    = "access_request.deny"
+)
+
+// Types of the resources changed by the actions recorded on the audit log.
+const (
+	AuditTargetDevice        = "device"
+	AuditTargetTag           = "tag"
+	AuditTargetPublicKey     = "public_key"
+	AuditTargetFirewallRule  = "firewall_rule"
+	AuditTargetNamespace     = "namespace"
+	AuditTargetMember        = "member"
+	AuditTargetAPIKey        = "api_key"
+	AuditTargetWebhook       = "webhook"
+	AuditTargetDeviceGroup   = "device_group"
+	AuditTargetInvitation    = "invitation"
+	AuditTargetRole          = "role"
+	AuditTargetAccessGrant   = "access_grant"
+	AuditTargetAccessRequest = "access_request"
+)
+
+// AuditActor is the user who performed an action recorded on the audit log.
+type AuditActor struct {
+	ID       string `json:"id" bson:"id"`
+	Username string `json:"username" bson:"username"`
+	Role     string `json:"role" bson:"role"`
+}
+
+// AuditTarget is the resource changed by an action recorded on the audit log.
+type AuditTarget struct {
+	Type string `json:"type" bson:"type"`
+	ID   string `json:"id" bson:"id"`
+}
+
+// AuditDiff holds the fields of the target changed by an action, with their values before and after it.
+type AuditDiff struct {
+	Before map[string]interface{} `json:"before,omitempty" bson:"before,omitempty"`
+	After  map[string]interface{} `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+// AuditLog is an entry of the namespace's audit log.
+type AuditLog struct {
+	ID        string      `json:"id" bson:"_id,omitempty"`
+	TenantID  string      `json:"tenant_id" bson:"tenant_id"`
+	Actor     AuditActor  `json:"actor" bson:"actor"`
+	Action    string      `json:"action" bson:"action"`
+	Target    AuditTarget `json:"target" bson:"target"`
+	Diff      AuditDiff   `json:"diff" bson:"diff"`
+	IPAddress string      `json:"ip_address" bson:"ip_address"`
+	RequestID string      `json:"request_id" bson:"request_id"`
+	CreatedAt time.Time   `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// CertificateAuthority is the key of a namespace that signs the short-lived OpenSSH user certificates the SSH server
+// authenticates with on the namespace's devices.
+type CertificateAuthority struct {
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Data is the PEM encoded private key of the authority.
+	Data []byte `json:"-" bson:"data"`
+	// PublicKey is the public key of the authority, in the authorized keys format, trusted by the devices.
+	PublicKey string    `json:"public_key" bson:"public_key"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// UserCertificate is an OpenSSH user certificate, in the authorized keys format.
+type UserCertificate struct {
+	Certificate string `json:"certificate"`
+}
+
+// CertificateOptionDevice is the critical option of the user certificates binding them to the UID of the device they
+// authenticate on.
+const CertificateOptionDevice = "device@shellhub.io"
@@ -31,8 +31,13 @@ type Device struct {
 	RemoteAddr string          `json:"remote_addr" bson:"remote_addr"`
 	Position   *DevicePosition `json:"position" bson:"position"`
 	Tags       []string        `json:"tags" bson:"tags,omitempty"`
-	PublicURL  bool            `json:"public_url" bson:"public_url,omitempty"`
-	Acceptable bool            `json:"acceptable" bson:"acceptable,omitempty"`
+	// Attributes are the user-defined key/value pairs of the device, as "site=berlin" or "rack=12".
+	Attributes map[string]string `json:"attributes" bson:"attributes,omitempty"`
+	PublicURL  bool              `json:"public_url" bson:"public_url,omitempty"`
+	Acceptable bool              `json:"acceptable" bson:"acceptable,omitempty"`
+	// HostKey is the SSH host key of the device's agent, in the authorized keys format, pinned at its registration.
+	// The connections to the device are refused when the agent presents another one.
+	HostKey string `json:"host_key,omitempty" bson:"host_key,omitempty"`
 }
 
 type DeviceAuthClaims struct {
@@ -45,6 +50,10 @@ type DeviceAuthClaims struct {
 type DeviceAuthRequest struct {
 	Info     *DeviceInfo `json:"info"`
 	Sessions []string    `json:"sessions,omitempty"`
+	// HostKey is the SSH host key of the agent, in the authorized keys format.
+	HostKey string `json:"host_key,omitempty"`
+	// Attributes are set on the device at its registration, replacing the values of the same keys.
+	Attributes map[string]string `json:"attributes,omitempty"`
 	*DeviceAuth
 }
 
@@ -60,6 +69,9 @@ type DeviceAuthResponse struct {
 	Token     string `json:"token"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	// CertificateAuthority is the public key of the namespace's certificate authority, in the authorized keys format,
+	// whose user certificates the device trusts.
+	CertificateAuthority string `json:"certificate_authority,omitempty"`
 }
 
 type DeviceIdentity struct {
@@ -91,3 +103,12 @@ type DeviceRemoved struct {
 	Tenant    string    `json:"tenant_id" bson:"tenant_id"`
 	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
 }
+
+// DeviceHostKeyMismatch is the payload of the alert raised when a device presents a host key other than the one pinned
+// to it.
+type DeviceHostKeyMismatch struct {
+	UID           string `json:"uid"`
+	Name          string `json:"name"`
+	HostKey       string `json:"host_key"`
+	PinnedHostKey string `json:"pinned_host_key"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// DeviceGroup is a named device query of a namespace, resolved to the devices that match its filter each time it is
+// used, so devices joining or leaving the filter's conditions join or leave the group.
+type DeviceGroup struct {
+	ID       string `json:"id" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Filter is the group's filter expression, encoded as the filter accepted by the device's list: a base64 encoded
+	// JSON list of Filter.
+	Filter    string    `json:"filter" bson:"filter"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
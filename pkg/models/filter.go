@@ -1,9 +1,14 @@
 package models
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 )
 
+// ErrFilterInvalid is returned when an encoded filter cannot be decoded to a list of Filter.
+var ErrFilterInvalid = errors.New("filter is invalid")
+
 // Filter is a helper struct to filter results from the database.
 // TODO: Gives a better explanation about the filter and how to use it.
 type Filter struct {
@@ -55,3 +60,52 @@ type PropertyParams struct {
 type OperatorParams struct {
 	Name string `json:"name"`
 }
+
+// IsOperator checks if the filter is an operator the stores join the properties before it by, as the unknown ones are
+// skipped by them.
+func (f Filter) IsOperator() bool {
+	params, ok := f.Params.(*OperatorParams)
+
+	return ok && (params.Name == "and" || params.Name == "or")
+}
+
+// ValidateFilter checks that each filter is a property or a known operator. As the stores skip the unknown operators,
+// the properties before one would be joined to the ones after it.
+func ValidateFilter(filters []Filter) error {
+	for _, filter := range filters {
+		switch filter.Params.(type) {
+		case *PropertyParams:
+			if filter.Type != "property" {
+				return ErrFilterInvalid
+			}
+		case *OperatorParams:
+			if !filter.IsOperator() {
+				return ErrFilterInvalid
+			}
+		default:
+			return ErrFilterInvalid
+		}
+	}
+
+	return nil
+}
+
+// DecodeFilter decodes a filter encoded as the base64 of a JSON list of Filter, the format of the filter accepted by
+// the lists. Each filter must be a property or a known operator.
+func DecodeFilter(encoded string) ([]Filter, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrFilterInvalid
+	}
+
+	var filters []Filter
+	if err := json.Unmarshal(raw, &filters); err != nil {
+		return nil, ErrFilterInvalid
+	}
+
+	if err := ValidateFilter(filters); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
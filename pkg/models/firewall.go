@@ -8,10 +8,13 @@ import (
 
 // FirewallFilter contains the filter rule of a Public Key.
 //
-// A FirewallFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A FirewallFilter can contain either Hostname, string, Tags, slice of strings, or Group, a device group's ID,
+// never more than one.
 type FirewallFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
+	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of a namespace's device group, matching the devices resolved by the group.
+	Group string `json:"group,omitempty" bson:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
 }
 
 type FirewallRuleFields struct {
@@ -27,7 +30,7 @@ func (f *FirewallRuleFields) Validate() error {
 	v := validator.New()
 
 	_ = v.RegisterValidation("regexp", func(fl validator.FieldLevel) bool {
-		_, err := regexp.Compile(fl.Field().String())
+		_, err := CompileFirewallPattern(fl.Field().String())
 
 		return err == nil
 	})
@@ -35,6 +38,17 @@ func (f *FirewallRuleFields) Validate() error {
 	return v.Struct(f)
 }
 
+// CompileFirewallPattern compiles a pattern of a firewall rule, anchored to match the whole value instead of any part
+// of it.
+func CompileFirewallPattern(pattern string) (*regexp.Regexp, error) {
+	// The pattern is compiled by itself first, so it cannot close the group that anchors it.
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 type FirewallRule struct {
 	ID                 string `json:"id,omitempty" bson:"_id,omitempty"`
 	TenantID           string `json:"tenant_id" bson:"tenant_id"`
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// Invitation is an invite, sent by email, to join a namespace with a role.
+//
+// The invited person may not have an account yet, so the invitation is bound to an email address and accepted by the
+// user with that address. Only the digest of the invitation's token is stored, as the token itself is only sent by
+// email.
+type Invitation struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	Email     string    `json:"email" bson:"email"`
+	Role      string    `json:"role" bson:"role"`
+	InvitedBy string    `json:"invited_by" bson:"invited_by"`
+	Digest    string    `json:"-" bson:"digest"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// IsExpired reports whether the invitation's expiration time is before t.
+func (i *Invitation) IsExpired(t time.Time) bool {
+	return !i.ExpiresAt.After(t)
+}
@@ -36,10 +36,47 @@ func (n *Namespace) HasMaxDevicesReached(removedDevices int64) bool {
 
 type NamespaceSettings struct {
 	SessionRecord bool `json:"session_record" bson:"session_record,omitempty"`
+	// RecordInput defines if the input of the recorded sessions, as the keystrokes, is also recorded.
+	RecordInput bool `json:"record_input" bson:"record_input,omitempty"`
+	// RequireMFA defines if the members must have MFA enabled to access the namespace.
+	RequireMFA bool `json:"require_mfa" bson:"require_mfa,omitempty"`
+	// ApprovalTags are the tags of the devices that can only be connected to with an approved access request.
+	ApprovalTags []string `json:"approval_tags,omitempty" bson:"approval_tags,omitempty"`
+}
+
+// RequiresApproval checks if connecting to the device requires an approved access request, because it has one of the
+// namespace's approval tags.
+func (n *Namespace) RequiresApproval(device *Device) bool {
+	if n.Settings == nil {
+		return false
+	}
+
+	for _, tag := range n.Settings.ApprovalTags {
+		for _, t := range device.Tags {
+			if t == tag {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 type Member struct {
 	ID       string `json:"id,omitempty" bson:"id,omitempty"`
 	Username string `json:"username,omitempty" bson:"username,omitempty" validate:"min=3,max=30,alphanum,ascii"`
-	Role     string `json:"role" bson:"role" validate:"required,oneof=administrator operator observer"`
+	Role     string `json:"role" bson:"role" validate:"required,ne=owner,min=3,max=30,hostname_rfc1123,excludes=."`
+	// Scope restricts the devices the member can see and connect to. When it is empty, the member is not restricted.
+	Scope *MemberScope `json:"scope,omitempty" bson:"scope,omitempty"`
+}
+
+// MemberScope restricts a member to the devices with one of its tags or in one of its device groups.
+type MemberScope struct {
+	Tags   []string `json:"tags,omitempty" bson:"tags,omitempty"`
+	Groups []string `json:"groups,omitempty" bson:"groups,omitempty"`
+}
+
+// IsEmpty checks if the scope does not restrict the member.
+func (s *MemberScope) IsEmpty() bool {
+	return s == nil || (len(s.Tags) == 0 && len(s.Groups) == 0)
 }
@@ -6,4 +6,7 @@ type PrivateKey struct {
 	Data        []byte    `json:"data"`
 	Fingerprint string    `json:"fingerprint"`
 	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	// LegacyFingerprint is the legacy MD5 fingerprint, which the agents that predate the SHA256 fingerprints look the
+	// key up by.
+	LegacyFingerprint string `json:"legacy_fingerprint,omitempty" bson:"legacy_fingerprint,omitempty"`
 }
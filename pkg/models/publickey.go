@@ -9,10 +9,13 @@ import (
 
 // PublicKeyFilter contains the filter rule of a Public Key.
 //
-// A PublicKeyFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A PublicKeyFilter can contain either Hostname, string, Tags, slice of strings, or Group, a device group's ID,
+// never more than one.
 type PublicKeyFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Group,excluded_with=Tags Group,regexp"`
+	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Group,excluded_with=Hostname Group,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of a namespace's device group, matching the devices resolved by the group.
+	Group string `json:"group,omitempty" bson:"group,omitempty" validate:"required_without_all=Hostname Tags,excluded_with=Hostname Tags"`
 }
 
 type PublicKeyFields struct {
@@ -34,10 +37,13 @@ func (p *PublicKeyFields) Validate() error {
 }
 
 type PublicKey struct {
-	Data            []byte    `json:"data"`
-	Fingerprint     string    `json:"fingerprint"`
-	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
-	TenantID        string    `json:"tenant_id" bson:"tenant_id"`
+	Data        []byte    `json:"data"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	TenantID    string    `json:"tenant_id" bson:"tenant_id"`
+	// CreatedBy is the ID of the member who added the key. The connections authenticated by the key are restricted to
+	// the member's device scope.
+	CreatedBy       string `json:"created_by,omitempty" bson:"created_by,omitempty"`
 	PublicKeyFields `bson:",inline"`
 }
 
@@ -52,4 +58,7 @@ type PublicKeyAuthRequest struct {
 
 type PublicKeyAuthResponse struct {
 	Signature string `json:"signature"`
+	// Format is the SSH signature format of Signature, as "rsa-sha2-256" or "ssh-ed25519". Servers that predate it
+	// only sign with RSA keys, so an empty format is "rsa-sha2-256".
+	Format string `json:"format,omitempty"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// Role is a namespace's custom role, granting to its members the permissions listed by name, as "device:connect", on
+// top of the builtin roles. A role is identified by its name inside the namespace.
+type Role struct {
+	TenantID    string    `json:"tenant_id" bson:"tenant_id"`
+	Name        string    `json:"name" bson:"name"`
+	Description string    `json:"description" bson:"description"`
+	Permissions []string  `json:"permissions" bson:"permissions"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
@@ -25,6 +25,8 @@ type Session struct {
 	Type          string          `json:"type" bson:"type"`
 	Term          string          `json:"term" bson:"term"`
 	Position      SessionPosition `json:"position" bson:"position"`
+	// Grant is the ID of the access grant the session was opened under, when the access was given only by it.
+	Grant string `json:"grant,omitempty" bson:"grant,omitempty"`
 }
 
 type ActiveSession struct {
@@ -32,6 +34,13 @@ type ActiveSession struct {
 	LastSeen time.Time `json:"last_seen" bson:"last_seen"`
 }
 
+const (
+	// RecordedSessionTypeOutput is the type of a frame with the data written to the session's terminal.
+	RecordedSessionTypeOutput = "o"
+	// RecordedSessionTypeInput is the type of a frame with the data read from the session's client.
+	RecordedSessionTypeInput = "i"
+)
+
 type RecordedSession struct {
 	UID      UID       `json:"uid"`
 	Message  string    `json:"message" bson:"message"`
@@ -39,6 +48,13 @@ type RecordedSession struct {
 	Time     time.Time `json:"time" bson:"time,omitempty"`
 	Width    int       `json:"width" bson:"width,omitempty"`
 	Height   int       `json:"height" bson:"height,omitempty"`
+	// Type is the type of the frame. A frame without type is an output one, as recorded before the input was.
+	Type string `json:"type" bson:"type,omitempty"`
+}
+
+// IsInput checks if the frame holds the data read from the session's client.
+func (r *RecordedSession) IsInput() bool {
+	return r.Type == RecordedSessionTypeInput
 }
 
 type Status struct {
@@ -51,4 +67,22 @@ type SessionRecorded struct {
 	Message   string `json:"message" bson:"message"`
 	Width     int    `json:"width" bson:"width,omitempty"`
 	Height    int    `json:"height" bson:"height,omitempty"`
+	// Type is the type of the frame, as RecordedSessionTypeOutput or RecordedSessionTypeInput.
+	Type string `json:"type,omitempty" bson:"type,omitempty"`
+}
+
+// SessionRecordLine is a line of a session's record, stripped of the terminal's escape sequences, indexed to be
+// searched.
+type SessionRecordLine struct {
+	UID       UID       `json:"uid" bson:"uid"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID UID       `json:"device_uid" bson:"device_uid"`
+	Username  string    `json:"username" bson:"username"`
+	StartedAt time.Time `json:"started_at" bson:"started_at"`
+	// Type is the type of the frames where the line was recorded, as RecordedSessionTypeOutput or
+	// RecordedSessionTypeInput.
+	Type string `json:"type" bson:"type"`
+	// Offset is the time, in seconds since the session started, when the line started to be recorded.
+	Offset float64 `json:"offset" bson:"offset"`
+	Text   string  `json:"text" bson:"text"`
 }
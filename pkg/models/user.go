@@ -16,6 +16,18 @@ type UserPassword struct {
 	Password string `json:"password" bson:",omitempty" validate:"required,min=5,max=30"`
 }
 
+// UserMFA is the TOTP two-factor authentication of a user.
+type UserMFA struct {
+	// Enabled indicates if the user is asked for a TOTP code, or a recovery code, after the password.
+	Enabled bool `json:"enabled" bson:"enabled"`
+	// Secret is the base32 encoded TOTP secret. It is generated before MFA is enabled, to be confirmed by a code.
+	Secret string `json:"-" bson:"secret,omitempty"`
+	// RecoveryCodes are the digests of the unused recovery codes, each one replacing a TOTP code once.
+	RecoveryCodes []string `json:"-" bson:"recovery_codes,omitempty"`
+	// LastCounter is the counter of the last TOTP code accepted, so the codes at or before it are not accepted again.
+	LastCounter uint64 `json:"-" bson:"last_counter,omitempty"`
+}
+
 type User struct {
 	ID             string    `json:"id,omitempty" bson:"_id,omitempty"`
 	Namespaces     int       `json:"namespaces" bson:"namespaces,omitempty"`
@@ -26,6 +38,7 @@ type User struct {
 	EmailMarketing bool      `json:"email_marketing" bson:"email_marketing"`
 	UserData       `bson:",inline"`
 	UserPassword   `bson:",inline"`
+	MFA            UserMFA `json:"mfa" bson:"mfa"`
 }
 
 type UserAuthRequest struct {
@@ -41,6 +54,9 @@ type UserAuthResponse struct {
 	Tenant string `json:"tenant"`
 	Role   string `json:"role"`
 	Email  string `json:"email"`
+	// MFAToken is the short-lived token returned, instead of Token, to a user with MFA enabled. It is exchanged for
+	// the Token with a TOTP code or a recovery code.
+	MFAToken string `json:"mfa_token,omitempty"`
 }
 
 type UserAuthClaims struct {
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// Status of a webhook's delivery.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// Webhook is a namespace's subscription to events, delivered to its URL.
+//
+// The secret is used to sign the deliveries, so the receiver can check they were sent by ShellHub.
+type Webhook struct {
+	ID        string    `json:"id" bson:"_id,omitempty"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	URL       string    `json:"url" bson:"url"`
+	Secret    string    `json:"-" bson:"secret"`
+	Events    []string  `json:"events" bson:"events"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// HasEvent reports whether the webhook is subscribed to event.
+func (w *Webhook) HasEvent(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookDelivery is a delivery of an event to a webhook, with the result of its last attempt.
+type WebhookDelivery struct {
+	ID          string     `json:"id" bson:"_id,omitempty"`
+	TenantID    string     `json:"tenant_id" bson:"tenant_id"`
+	WebhookID   string     `json:"webhook_id" bson:"webhook_id"`
+	Event       string     `json:"event" bson:"event"`
+	Payload     string     `json:"payload" bson:"payload"`
+	Status      string     `json:"status" bson:"status"`
+	StatusCode  int        `json:"status_code" bson:"status_code"`
+	Error       string     `json:"error,omitempty" bson:"error"`
+	Attempts    int        `json:"attempts" bson:"attempts"`
+	CreatedAt   time.Time  `json:"created_at" bson:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at" bson:"delivered_at"`
+}
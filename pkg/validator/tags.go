@@ -5,4 +5,6 @@ const (
 	TagRegexp = "regexp"
 	// TagUsername is the tag used to validate ShellHub's username.
 	TagUsername = "username"
+	// TagAttribute is the tag used to validate a device's attribute key.
+	TagAttribute = "attribute"
 )
@@ -17,3 +17,9 @@ func regexpValidator(field validator.FieldLevel) bool {
 func usernameValidator(field validator.FieldLevel) bool {
 	return regexp.MustCompile(`^([a-zA-Z0-9-_.@]){3,30}$`).MatchString(field.Field().String())
 }
+
+// attributeValidator is a function used to validate a device's attribute key. As the key is used as a filter's
+// property, as "attributes.site", it cannot have dots.
+func attributeValidator(field validator.FieldLevel) bool {
+	return regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`).MatchString(field.Field().String())
+}
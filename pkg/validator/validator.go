@@ -15,8 +15,9 @@ type Validator struct {
 // The ShellHub validator contains validations rules to name, username, email, password, etc.
 func New() *Validator {
 	validate := validator.New()
-	validate.RegisterValidation(TagRegexp, regexpValidator)     //nolint:errcheck
-	validate.RegisterValidation(TagUsername, usernameValidator) //nolint:errcheck
+	validate.RegisterValidation(TagRegexp, regexpValidator)       //nolint:errcheck
+	validate.RegisterValidation(TagUsername, usernameValidator)   //nolint:errcheck
+	validate.RegisterValidation(TagAttribute, attributeValidator) //nolint:errcheck
 
 	return &Validator{
 		Validate: validate,
@@ -10,7 +10,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kelseyhightower/envconfig"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/lockout"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/ssh/pkg/throttle"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
 	"github.com/shellhub-io/shellhub/ssh/server/handler"
@@ -34,6 +37,16 @@ func main() {
 		log.WithError(err).Fatal("Failed to connect to redis")
 	}
 
+	attempts, err := storecache.NewRedisCache(opts.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to redis")
+	}
+
+	throttle.Setup(attempts,
+		lockout.Config{Attempts: opts.PasswordMaxAttemptsPerIP, Duration: opts.PasswordLockoutDuration, MaxDuration: opts.PasswordLockoutMaxDuration, Window: opts.PasswordAttemptsWindow},
+		lockout.Config{Attempts: opts.PasswordMaxAttempts, Duration: opts.PasswordLockoutDuration, MaxDuration: opts.PasswordLockoutMaxDuration, Window: opts.PasswordAttemptsWindow},
+	)
+
 	tunnel := sshTunnel.NewTunnel("/ssh/connection", "/ssh/revdial")
 
 	router := tunnel.GetRouter()
@@ -4,6 +4,7 @@ import (
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/scope"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -48,9 +49,10 @@ func StorePassword(ctx gliderssh.Context, value string) {
 	store(ctx, password, value)
 }
 
-// MaybeStoreFingerprint stores the fingerprint in the context as metadata if is not set yet.
-func MaybeStoreFingerprint(ctx gliderssh.Context, value string) string {
-	return maybeStore(ctx, fingerprint, value).(string)
+// StoreFingerprint stores the fingerprint of the public key that authenticated the connection in the context as
+// metadata.
+func StoreFingerprint(ctx gliderssh.Context, value string) {
+	store(ctx, fingerprint, value)
 }
 
 // MaybeStoreTarget stores the target in the context as metadata if is not set yet.
@@ -99,6 +101,11 @@ func MaybeStoreLookup(ctx gliderssh.Context, tag *target.Target, api internalcli
 		setValue(device.Namespace, device.Name)
 	}
 
+	// The connections from the web terminal are restricted to the device scope of the member who opened them.
+	if member := scope.Member(ctx.RemoteAddr()); member != "" {
+		value["member"] = member
+	}
+
 	return maybeStore(ctx, lookup, value).(map[string]string), nil
 }
 
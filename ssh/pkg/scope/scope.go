@@ -0,0 +1,42 @@
+// Package scope binds the web terminal's connections to the namespace members who opened them, so the SSH server can
+// restrict the devices they reach to the members' device scopes.
+//
+// The web terminal connects to the SSH server of the same process, so a connection is identified by its local address.
+package scope
+
+import (
+	"net"
+	"sync"
+)
+
+var (
+	mu      sync.RWMutex
+	members = map[string]string{}
+)
+
+// Bind binds the connection from the address to the member until the returned function is called.
+func Bind(addr net.Addr, member string) func() {
+	key := addr.String()
+
+	mu.Lock()
+	members[key] = member
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		delete(members, key)
+		mu.Unlock()
+	}
+}
+
+// Member returns the member bound to the connection from the address, or an empty string when there is none.
+func Member(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return members[addr.String()]
+}
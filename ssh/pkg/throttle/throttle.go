@@ -0,0 +1,113 @@
+// Package throttle protects the devices' passwords against brute force, locking out the source IPs and the targets
+// with too many failed password attempts before the passwords are forwarded to the agents.
+package throttle
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/lockout"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	ips     *lockout.Lockout
+	targets *lockout.Lockout
+)
+
+// Setup enables the throttle, storing the attempts in the cache. Until it is called, the attempts are not limited.
+func Setup(c cache.Cache, ip, target lockout.Config) {
+	ips = lockout.New(c, "ssh_ip", ip)
+	targets = lockout.New(c, "ssh_target", target)
+}
+
+// host returns the IP of an address, or an empty string when the address is the loopback, as the web terminal's
+// connections, which come from the server itself.
+func host(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+
+	ip, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		ip = addr.String()
+	}
+
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.IsLoopback() {
+		return ""
+	}
+
+	return ip
+}
+
+// Check returns how long the password attempts from the address to the target are still locked out, or zero when they
+// are not. The attempts are never locked out when the cache fails.
+func Check(ctx context.Context, addr net.Addr, target string) time.Duration {
+	if ips == nil {
+		return 0
+	}
+
+	left, err := targets.Check(ctx, strings.ToLower(target))
+	if err != nil {
+		log.WithError(err).WithField("target", target).Warn("failed to check the password lockout of the target")
+	}
+
+	if ip := host(addr); ip != "" {
+		byIP, err := ips.Check(ctx, ip)
+		if err != nil {
+			log.WithError(err).WithField("ip", ip).Warn("failed to check the password lockout of the ip")
+		}
+
+		if byIP > left {
+			left = byIP
+		}
+	}
+
+	return left
+}
+
+// Fail records a password rejected by the agent of the target, from the address.
+func Fail(ctx context.Context, addr net.Addr, target string) {
+	if ips == nil {
+		return
+	}
+
+	locked, err := targets.Fail(ctx, strings.ToLower(target))
+	if err != nil {
+		log.WithError(err).WithField("target", target).Warn("failed to record the failed password of the target")
+	}
+
+	ip := host(addr)
+	if ip != "" {
+		byIP, err := ips.Fail(ctx, ip)
+		if err != nil {
+			log.WithError(err).WithField("ip", ip).Warn("failed to record the failed password from the ip")
+		}
+
+		if byIP > locked {
+			locked = byIP
+		}
+	}
+
+	if locked > 0 {
+		log.WithFields(log.Fields{
+			"target":   target,
+			"ip":       ip,
+			"duration": locked,
+		}).Warn("password authentication locked out after too many failed attempts")
+	}
+}
+
+// Succeed forgets the failed passwords of the target.
+func Succeed(ctx context.Context, target string) {
+	if targets == nil {
+		return
+	}
+
+	if err := targets.Reset(ctx, strings.ToLower(target)); err != nil {
+		log.WithError(err).WithField("target", target).Warn("failed to reset the failed passwords of the target")
+	}
+}
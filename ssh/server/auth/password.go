@@ -4,6 +4,7 @@ import (
 	"github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/throttle"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -21,6 +22,17 @@ func PasswordHandler(ctx ssh.Context, password string) bool {
 		"sshid": sshid,
 	}).Trace("trying to use password authentication")
 
+	// The password is not forwarded to the agent while the client or the target is locked out.
+	if left := throttle.Check(ctx, ctx.RemoteAddr(), sshid); left > 0 {
+		log.WithFields(log.Fields{
+			"sshid":       sshid,
+			"remote":      ctx.RemoteAddr().String(),
+			"retry_after": left,
+		}).Warn("password authentication is locked out after too many failed attempts")
+
+		return false
+	}
+
 	tag, err := metadata.MaybeStoreTarget(ctx, sshid)
 	if err != nil {
 		return false
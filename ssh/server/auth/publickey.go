@@ -1,8 +1,11 @@
 package auth
 
 import (
+	"bytes"
+
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
@@ -17,7 +20,7 @@ import (
 // Returns true if the public key authentication method is used and false otherwise.
 func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool {
 	sshid := metadata.MaybeStoreSSHID(ctx, ctx.User())
-	fingerprint := metadata.MaybeStoreFingerprint(ctx, gossh.FingerprintLegacyMD5(publicKey))
+	fingerprint := gossh.FingerprintSHA256(publicKey)
 
 	log.WithFields(log.Fields{
 		"sshid":       sshid,
@@ -46,16 +49,20 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		return false
 	}
 
-	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = api.GetPublicKey(fingerprint, device.TenantID); err != nil {
+	if !bytes.Equal(magic.Marshal(), publicKey.Marshal()) {
+		key, err := lookupPublicKey(api, publicKey, device.TenantID)
+		if err != nil {
 			return false
 		}
 
+		fingerprint = key.Fingerprint
+
 		if ok, err := api.EvaluateKey(fingerprint, device, tag.Username); !ok || err != nil {
 			return false
 		}
 	}
 
+	metadata.StoreFingerprint(ctx, fingerprint)
 	metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
 
 	log.WithFields(log.Fields{
@@ -65,3 +72,22 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 
 	return true
 }
+
+// lookupPublicKey gets the namespace's public key by its SHA256 fingerprint. The public keys added before the
+// fingerprints moved to SHA256 are still stored with their legacy MD5 ones, so it falls back to those.
+func lookupPublicKey(api internalclient.Client, publicKey gliderssh.PublicKey, tenant string) (*models.PublicKey, error) {
+	key, err := api.GetPublicKey(gossh.FingerprintSHA256(publicKey), tenant)
+	if err == internalclient.ErrNotFound {
+		key, err = api.GetPublicKey(gossh.FingerprintLegacyMD5(publicKey), tenant)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, internalclient.ErrNotFound
+	}
+
+	return key, nil
+}
@@ -2,8 +2,7 @@ package handler
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
+	"errors"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
@@ -44,36 +43,20 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 
 		config := &gossh.ClientConfig{ // nolint: exhaustruct
 			User:            sess.Username,
-			HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+			HostKeyCallback: sess.HostKeyCallback(),
 		}
 
 		switch metadata.RestoreAuthenticationMethod(ctx) {
 		case metadata.PublicKeyAuthenticationMethod:
-			privateKey, err := api.CreatePrivateKey()
+			signers, err := publicKeySigners(api, sess)
 			if err != nil {
-				sendAndInformError(client, err, ErrPrivateKey)
-
-				return
-			}
-
-			block, _ := pem.Decode(privateKey.Data)
-
-			parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-			if err != nil {
-				sendAndInformError(client, err, ErrPublicKey)
-
-				return
-			}
-
-			signer, err := gossh.NewSignerFromKey(parsed)
-			if err != nil {
-				sendAndInformError(client, err, ErrSigner)
+				sendAndInformError(client, err, err)
 
 				return
 			}
 
 			config.Auth = []gossh.AuthMethod{
-				gossh.PublicKeys(signer),
+				gossh.PublicKeys(signers...),
 			}
 		case metadata.PasswordAuthenticationMethod:
 			password := metadata.RestorePassword(ctx)
@@ -93,6 +76,11 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 
 func connectSFTP(ctx context.Context, client gliderssh.Session, sess *session.Session, api internalclient.Client, config *gossh.ClientConfig) error {
 	connection, reqs, err := sess.NewClientConnWithDeadline(config)
+	if errors.Is(err, session.ErrHostKey) {
+		return err
+	}
+
+	throttlePassword(ctx.(gliderssh.Context), err)
 	if err != nil {
 		return ErrAuthentication
 	}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// publicKeySigners returns the signers used to authenticate on the device with public key. The user certificate issued
+// by the namespace's certificate authority is offered first, followed by the private key created by the server, so the
+// agents that do not trust the authority yet can still be connected to.
+func publicKeySigners(api internalclient.Client, sess *session.Session) ([]gossh.Signer, error) {
+	signers := make([]gossh.Signer, 0, 2)
+
+	certificate, err := certificateSigner(api, sess)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"uid":    sess.UID,
+			"device": sess.Device,
+		}).Warn("failed to get a user certificate for the session")
+	} else {
+		signers = append(signers, certificate)
+	}
+
+	signer, err := privateKeySigner(api)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(signers, signer), nil
+}
+
+// certificateSigner creates an ephemeral key and gets it certified by the namespace's certificate authority, to
+// authenticate as the session's user on its device.
+func certificateSigner(api internalclient.Client, sess *session.Session) (gossh.Signer, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := gossh.NewSignerFromKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := api.IssueUserCertificate(sess.Device, sess.Username, sess.UID, string(gossh.MarshalAuthorizedKey(signer.PublicKey())))
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(issued.Certificate)) // nolint: dogsled
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, ok := key.(*gossh.Certificate)
+	if !ok {
+		return nil, ErrCertificate
+	}
+
+	return gossh.NewCertSigner(certificate, signer)
+}
+
+// privateKeySigner gets a private key created by the server, which the agent checks calling back to the server.
+func privateKeySigner(api internalclient.Client) (gossh.Signer, error) {
+	privateKey, err := api.CreatePrivateKey()
+	if err != nil {
+		log.WithError(err).Error("failed to create the private key")
+
+		return nil, ErrPrivateKey
+	}
+
+	parsed, err := gossh.ParseRawPrivateKey(privateKey.Data)
+	if err != nil {
+		log.WithError(err).Error("failed to parse the private key")
+
+		return nil, ErrPublicKey
+	}
+
+	signer, err := gossh.NewSignerFromKey(parsed)
+	if err != nil {
+		log.WithError(err).Error("failed to create a signer from the private key")
+
+		return nil, ErrSigner
+	}
+
+	return signer, nil
+}
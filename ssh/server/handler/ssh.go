@@ -4,11 +4,10 @@ package handler
 import (
 	"bytes"
 	"context"
-	"crypto/x509"
-	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
@@ -20,6 +19,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/flow"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/throttle"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -35,6 +35,7 @@ var (
 	ErrPublicKey          = fmt.Errorf("failed to get the parsed public key")
 	ErrPrivateKey         = fmt.Errorf("failed to get a key data from the server")
 	ErrSigner             = fmt.Errorf("failed to create a signer from the private key")
+	ErrCertificate        = fmt.Errorf("failed to get the user certificate from the server")
 	ErrConnect            = fmt.Errorf("failed to connect to device")
 	ErrSession            = fmt.Errorf("failed to create a session between the server to the agent")
 	ErrGetAuth            = fmt.Errorf("failed to get auth data from key")
@@ -55,6 +56,9 @@ var (
 	ErrShell              = fmt.Errorf("failed to get the shell to agent")
 	ErrTarget             = fmt.Errorf("failed to get client target")
 	ErrAuthentication     = fmt.Errorf("failed to authenticate to device")
+	ErrAccessRequest      = fmt.Errorf("failed to evaluate the access request to device")
+	ErrAccessDenied       = fmt.Errorf("the access request to device was denied")
+	ErrAccessTimeout      = fmt.Errorf("the access request to device was not decided in time")
 )
 
 // sendAndInformError sends the external error to client and log the internal one to server.
@@ -66,8 +70,14 @@ func sendAndInformError(client io.Writer, internal, external error) {
 
 type ConfigOptions struct {
 	RecordURL string `envconfig:"record_url"`
+	// AccessRequestTimeout is how long a connection to a device that requires approval is held waiting for its access
+	// request to be decided.
+	AccessRequestTimeout time.Duration `envconfig:"access_request_timeout" default:"5m"`
 }
 
+// accessRequestInterval is the interval between the checks of an access request a connection is waiting for.
+const accessRequestInterval = 5 * time.Second
+
 // SSHHandler handlers a "normal" SSH connection.
 func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 	return func(client gliderssh.Session) {
@@ -117,38 +127,28 @@ func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 			return
 		}
 
+		if err := waitAccessRequest(ctx, client, sess, api, opts); err != nil {
+			sendAndInformError(client, err, err)
+
+			return
+		}
+
 		config := &gossh.ClientConfig{ // nolint: exhaustruct
 			User:            sess.Username,
-			HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint: gosec
+			HostKeyCallback: sess.HostKeyCallback(),
 		}
 
 		switch metadata.RestoreAuthenticationMethod(ctx) {
 		case metadata.PublicKeyAuthenticationMethod:
-			privateKey, err := api.CreatePrivateKey()
-			if err != nil {
-				sendAndInformError(client, err, ErrPrivateKey)
-
-				return
-			}
-
-			block, _ := pem.Decode(privateKey.Data)
-
-			parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-			if err != nil {
-				sendAndInformError(client, err, ErrPublicKey)
-
-				return
-			}
-
-			signer, err := gossh.NewSignerFromKey(parsed)
+			signers, err := publicKeySigners(api, sess)
 			if err != nil {
-				sendAndInformError(client, err, ErrSigner)
+				sendAndInformError(client, err, err)
 
 				return
 			}
 
 			config.Auth = []gossh.AuthMethod{
-				gossh.PublicKeys(signer),
+				gossh.PublicKeys(signers...),
 			}
 		case metadata.PasswordAuthenticationMethod:
 			password := metadata.RestorePassword(ctx)
@@ -167,8 +167,82 @@ func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 	}
 }
 
+// waitAccessRequest holds the connection while the access request required to connect to the device is not approved.
+// It returns nil right away when the device does not require approval or the connection is already allowed.
+func waitAccessRequest(ctx context.Context, client gliderssh.Session, sess *session.Session, api internalclient.Client, opts ConfigOptions) error {
+	reason := fmt.Sprintf("connection as %s from %s", sess.Username, sess.IPAddress)
+
+	request, err := api.EvaluateAccessRequest(sess.Device, sess.Member, sess.Fingerprint, reason)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"uid":    sess.UID,
+			"device": sess.Device,
+		}).Error("failed to evaluate the access request")
+
+		return ErrAccessRequest
+	}
+
+	if request == nil {
+		return nil
+	}
+
+	client.Stderr().Write([]byte(fmt.Sprintf("Waiting for approval of the access request %s\n", request.ID))) // nolint:errcheck
+
+	timeout := time.NewTimer(opts.AccessRequestTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(accessRequestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return ErrAccessTimeout
+		case <-ticker.C:
+			current, err := api.GetAccessRequest(request.TenantID, request.ID)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"uid":     sess.UID,
+					"request": request.ID,
+				}).Warn("failed to get the access request")
+
+				continue
+			}
+
+			switch current.Status {
+			case models.AccessRequestStatusApproved:
+				return nil
+			case models.AccessRequestStatusDenied:
+				return ErrAccessDenied
+			}
+		}
+	}
+}
+
+// throttlePassword reports the result of a password authentication on the agent to the throttle, so the clients that
+// keep guessing the passwords are locked out.
+func throttlePassword(ctx gliderssh.Context, err error) {
+	if metadata.RestoreAuthenticationMethod(ctx) != metadata.PasswordAuthenticationMethod {
+		return
+	}
+
+	switch {
+	case err == nil:
+		throttle.Succeed(ctx, ctx.User())
+	case strings.Contains(err.Error(), "unable to authenticate"):
+		throttle.Fail(ctx, ctx.RemoteAddr(), ctx.User())
+	}
+}
+
 func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Session, config *gossh.ClientConfig, api internalclient.Client, opts ConfigOptions) error {
 	connection, reqs, err := sess.NewClientConnWithDeadline(config)
+	if errors.Is(err, session.ErrHostKey) {
+		return err
+	}
+
+	throttlePassword(ctx.(gliderssh.Context), err)
 	if err != nil {
 		return ErrAuthentication
 	}
@@ -231,6 +305,28 @@ func exitCodeFromError(err error) int {
 	return fault.ExitStatus()
 }
 
+// inputRecorder sends the data read from a session's client to be recorded as input frames.
+type inputRecorder struct {
+	api       internalclient.Client
+	uid       string
+	namespace string
+	pty       gliderssh.Pty
+	recordURL string
+}
+
+func (r *inputRecorder) Write(data []byte) (int, error) {
+	r.api.RecordSession(&models.SessionRecorded{
+		UID:       r.uid,
+		Namespace: r.namespace,
+		Message:   string(data),
+		Width:     r.pty.Window.Width,
+		Height:    r.pty.Window.Height,
+		Type:      models.RecordedSessionTypeInput,
+	}, r.recordURL)
+
+	return len(data), nil
+}
+
 // shell handles an interactive terminal session.
 func shell(api internalclient.Client, sess *session.Session, uid string, agent *gossh.Session, client gliderssh.Session, pty gliderssh.Pty, winCh <-chan gliderssh.Window, opts ConfigOptions) error {
 	if errs := api.SessionAsAuthenticated(uid); len(errs) > 0 {
@@ -262,7 +358,19 @@ func shell(api internalclient.Client, sess *session.Session, uid string, agent *
 
 	done := make(chan bool)
 
-	go flw.PipeIn(client, done)
+	var stdin io.Reader = client
+	if envs.IsEnterprise() || envs.IsCloud() {
+		// The input is sent to be recorded as its own frames, which are kept only when the namespace records it.
+		stdin = io.TeeReader(client, &inputRecorder{
+			api:       api,
+			uid:       uid,
+			namespace: sess.Lookup["domain"],
+			pty:       pty,
+			recordURL: opts.RecordURL,
+		})
+	}
+
+	go flw.PipeIn(stdin, done)
 
 	go func() {
 		buffer := make([]byte, 1024)
@@ -287,8 +395,9 @@ func shell(api internalclient.Client, sess *session.Session, uid string, agent *
 					UID:       uid,
 					Namespace: sess.Lookup["domain"],
 					Message:   message,
-					Width:     pty.Window.Height,
-					Height:    pty.Window.Width,
+					Width:     pty.Window.Width,
+					Height:    pty.Window.Height,
+					Type:      models.RecordedSessionTypeOutput,
 				}, opts.RecordURL)
 			}
 		}
@@ -6,6 +6,8 @@ import (
 	"encoding/base64"
 	"errors"
 	"io"
+	"net"
+	"os"
 	"strconv"
 	"time"
 	"unicode/utf8"
@@ -14,6 +16,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/ssh/pkg/flow"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
+	"github.com/shellhub-io/shellhub/ssh/pkg/scope"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/shellhub-io/shellhub/ssh/web"
 	log "github.com/sirupsen/logrus"
@@ -31,7 +34,12 @@ type WebData struct {
 	// Fingerprint is the public key fingerprint.
 	// when Fingerprint is set, Password must not be set.
 	Fingerprint string
-	Signature   string
+	// LegacyFingerprint is the public key legacy MD5 fingerprint, looked up when Fingerprint is not found.
+	LegacyFingerprint string
+	Signature         string
+	// SignatureFormat is the SSH signature format of Signature. The RSA signatures are verified with SHA-256 when it
+	// is not set, and never with SHA-1.
+	SignatureFormat string
 	// Columns is the width size of pty.
 	Columns int
 	// Rows is the height size of pty.
@@ -75,12 +83,14 @@ func NewWebData(socket *websocket.Conn, input *web.Session) (*WebData, error) {
 	target := input.Username + "@" + input.Device
 
 	return &WebData{
-		User:        target,
-		Password:    input.Password,
-		Fingerprint: input.Fingerprint,
-		Signature:   input.Signature,
-		Columns:     columns,
-		Rows:        rows,
+		User:              target,
+		Password:          input.Password,
+		Fingerprint:       input.Fingerprint,
+		LegacyFingerprint: input.LegacyFingerprint,
+		Signature:         input.Signature,
+		SignatureFormat:   input.SignatureFormat,
+		Columns:           columns,
+		Rows:              rows,
 	}, nil
 }
 
@@ -113,14 +123,19 @@ func (c *WebData) GetAuth(magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
 		return nil, ErrFindDevice
 	}
 
-	// Trys to get a public key from the API.
+	// Trys to get a public key from the API. The public keys added before the fingerprints moved to SHA256 are still
+	// stored with their legacy MD5 ones.
 	key, err := cli.GetPublicKey(c.Fingerprint, device.TenantID)
-	if err != nil {
+	if err == internalclient.ErrNotFound && c.LegacyFingerprint != "" {
+		key, err = cli.GetPublicKey(c.LegacyFingerprint, device.TenantID)
+	}
+
+	if err != nil || key == nil {
 		return nil, ErrFindPublicKey
 	}
 
 	// Trys to evaluate the public key from the API.
-	ok, err := cli.EvaluateKey(c.Fingerprint, device, tag.Username)
+	ok, err := cli.EvaluateKey(key.Fingerprint, device, tag.Username)
 	if err != nil {
 		return nil, ErrEvaluatePublicKey
 	}
@@ -140,7 +155,7 @@ func (c *WebData) GetAuth(magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
 	}
 
 	if err := pubKey.Verify([]byte(tag.Username), &ssh.Signature{ //nolint: exhaustruct
-		Format: pubKey.Type(),
+		Format: signatureFormat(pubKey, c.SignatureFormat),
 		Blob:   digest,
 	}); err != nil {
 		return nil, ErrVerifyPublicKey
@@ -154,6 +169,35 @@ func (c *WebData) GetAuth(magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
 	return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
 }
 
+// signatureFormat returns the format to verify a signature of the public key with. The RSA signatures are verified
+// with SHA-256, unless the format requests SHA-512, as the SHA-1 of the "ssh-rsa" format is not accepted.
+func signatureFormat(key ssh.PublicKey, format string) string {
+	if key.Type() != ssh.KeyAlgoRSA {
+		return key.Type()
+	}
+
+	if format == ssh.KeyAlgoRSASHA512 {
+		return format
+	}
+
+	return ssh.KeyAlgoRSASHA256
+}
+
+// serverHostKey returns the host key of the SSH server, which the web terminal connects to on behalf of the member.
+func serverHostKey() (ssh.PublicKey, error) {
+	data, err := os.ReadFile(os.Getenv("PRIVATE_KEY"))
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.PublicKey(), nil
+}
+
 // WebSession is the Client's handler for connection coming from the web terminal.
 func WebSession(socket *websocket.Conn, input *web.Session) {
 	log.Info("handling web client request started")
@@ -171,17 +215,39 @@ func WebSession(socket *websocket.Conn, input *web.Session) {
 		return
 	}
 
-	connection, err := ssh.Dial("tcp", "localhost:2222", &ssh.ClientConfig{ //nolint: exhaustruct
+	hostKey, err := serverHostKey()
+	if err != nil {
+		sendAndInformError(socket, err, ErrDialSSH)
+
+		return
+	}
+
+	dialed, err := net.Dial("tcp", "localhost:2222")
+	if err != nil {
+		sendAndInformError(socket, err, ErrDialSSH)
+
+		return
+	}
+
+	// The SSH server restricts the connection to the device scope of the member who opened the session.
+	if input.Member != "" {
+		defer scope.Bind(dialed.LocalAddr(), input.Member)()
+	}
+
+	client, chans, reqs, err := ssh.NewClientConn(dialed, "localhost:2222", &ssh.ClientConfig{ //nolint: exhaustruct
 		User:            data.User,
 		Auth:            auth,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		HostKeyCallback: ssh.FixedHostKey(hostKey),
 	})
 	if err != nil {
+		dialed.Close() //nolint: errcheck
 		sendAndInformError(socket, err, ErrDialSSH)
 
 		return
 	}
 
+	connection := ssh.NewClient(client, chans, reqs)
+
 	defer connection.Close()
 
 	agent, err := connection.NewSession()
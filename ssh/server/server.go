@@ -18,6 +18,16 @@ import (
 type Options struct {
 	ConnectTimeout time.Duration `envconfig:"connect_timeout" default:"30s"`
 	RedisURI       string        `envconfig:"redis_uri" default:"redis://redis:6379"`
+	// PasswordMaxAttempts is the number of failed passwords that locks a target out. Zero disables the lockout.
+	PasswordMaxAttempts int `envconfig:"password_max_attempts" default:"5"`
+	// PasswordMaxAttemptsPerIP is the number of failed passwords that locks a source IP out. Zero disables the lockout.
+	PasswordMaxAttemptsPerIP int `envconfig:"password_max_attempts_per_ip" default:"20"`
+	// PasswordLockoutDuration is the duration of the first lockout, doubled on each following one.
+	PasswordLockoutDuration time.Duration `envconfig:"password_lockout_duration" default:"1m"`
+	// PasswordLockoutMaxDuration is the maximum duration of a lockout.
+	PasswordLockoutMaxDuration time.Duration `envconfig:"password_lockout_max_duration" default:"1h"`
+	// PasswordAttemptsWindow is how long the failed passwords are remembered after the last one.
+	PasswordAttemptsWindow time.Duration `envconfig:"password_attempts_window" default:"15m"`
 }
 
 type Server struct {
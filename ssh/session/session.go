@@ -1,7 +1,10 @@
 package session
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
@@ -15,6 +18,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/host"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
@@ -29,7 +33,9 @@ var (
 	ErrFirewallUnknown    = fmt.Errorf("failed to evaluate the firewall rule")
 	ErrHost               = fmt.Errorf("failed to get the device address")
 	ErrFindDevice         = fmt.Errorf("failed to find the device")
+	ErrScope              = fmt.Errorf("you cannot connect to this device because it is not in your device scope")
 	ErrDial               = fmt.Errorf("failed to connect to device agent, please check the device connection")
+	ErrHostKey            = fmt.Errorf("the device presented a host key other than the one pinned to it, so the connection was refused.\nIf the device's agent was reinstalled with a new key, ask an administrator to rotate the device's host key")
 )
 
 type Session struct {
@@ -43,9 +49,18 @@ type Session struct {
 	Type          string `json:"type"`
 	Term          string `json:"term"`
 	Authenticated bool   `json:"authenticated"`
-	Lookup        map[string]string
-	Pty           bool
-	Dialed        net.Conn
+	// Member is the namespace's member who opened the session from the web terminal.
+	Member string `json:"member,omitempty"`
+	// Fingerprint is the fingerprint of the public key that authenticated the session.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Lookup      map[string]string
+	Pty         bool
+	Dialed      net.Conn
+	// hostKey is the host key pinned to the device, which its agent must present.
+	hostKey gossh.PublicKey
+	// hostKeyMismatch tells that the agent presented a host key other than the pinned one.
+	hostKeyMismatch bool
+	api             internalclient.Client
 }
 
 const (
@@ -131,19 +146,28 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 	lookup["username"] = tag.Username
 	lookup["ip_address"] = hos.Host
 
-	if envs.IsCloud() || envs.IsEnterprise() {
-		if err := api.FirewallEvaluate(lookup); err != nil {
-			switch {
-			case errors.Is(err, internalclient.ErrFirewallConnection):
-				return nil, ErrFirewallConnection
-			case errors.Is(err, internalclient.ErrFirewallBlock):
-				return nil, ErrFirewallBlock
-			default:
-				return nil, ErrFirewallUnknown
-			}
+	if err := api.FirewallEvaluate(lookup); err != nil {
+		switch {
+		case errors.Is(err, internalclient.ErrFirewallConnection):
+			return nil, ErrFirewallConnection
+		case errors.Is(err, internalclient.ErrFirewallBlock):
+			return nil, ErrFirewallBlock
+		default:
+			return nil, ErrFirewallUnknown
 		}
 	}
 
+	// The connections authenticated by a public key act for the member who added it, and the ones from the web terminal
+	// for the member who opened it.
+	var fingerprint string
+	if metadata.RestoreAuthenticationMethod(client.Context()) == metadata.PublicKeyAuthenticationMethod {
+		fingerprint = metadata.RestoreFingerprint(client.Context())
+	}
+
+	if err := api.EvaluateDeviceScope(device.UID, lookup["member"], fingerprint); err != nil {
+		return nil, ErrScope
+	}
+
 	if envs.IsCloud() && envs.HasBilling() {
 		device, err := api.GetDevice(device.UID)
 		if err != nil {
@@ -175,6 +199,18 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 		Device:    device.UID,
 		Lookup:    lookup,
 		Dialed:    dialed,
+		Member:    lookup["member"],
+		api:       api,
+	}
+
+	// A device whose host key is not verifiable is connected to anyway, as its agent is authenticated by the tunnel.
+	if session.hostKey, err = pinnedHostKey(device); err != nil {
+		log.WithError(err).WithField("device", device.UID).Warn("failed to get the host key pinned to the device")
+	}
+
+	// The API ties the session to the access grant it is opened under through the member or the public key.
+	if metadata.RestoreAuthenticationMethod(client.Context()) == metadata.PublicKeyAuthenticationMethod {
+		session.Fingerprint = metadata.RestoreFingerprint(client.Context())
 	}
 
 	handlePty(session)
@@ -188,6 +224,32 @@ func (s *Session) GetType() string {
 	return s.Type
 }
 
+// HostKeyCallback returns the callback that verifies the host key presented by the device's agent against the one
+// pinned to the device, reporting a mismatch to the API.
+func (s *Session) HostKeyCallback() gossh.HostKeyCallback {
+	return func(_ string, _ net.Addr, key gossh.PublicKey) error {
+		if s.hostKey == nil || bytes.Equal(s.hostKey.Marshal(), key.Marshal()) {
+			return nil
+		}
+
+		s.hostKeyMismatch = true
+
+		log.WithFields(log.Fields{
+			"uid":         s.UID,
+			"device":      s.Device,
+			"fingerprint": gossh.FingerprintSHA256(key),
+			"pinned":      gossh.FingerprintSHA256(s.hostKey),
+		}).Error("the device presented a host key other than the one pinned to it")
+
+		hostKey := strings.TrimSuffix(string(gossh.MarshalAuthorizedKey(key)), "\n")
+		if err := s.api.ReportDeviceHostKeyMismatch(s.Device, hostKey); err != nil {
+			log.WithError(err).WithField("device", s.Device).Warn("failed to report the host key mismatch")
+		}
+
+		return ErrHostKey
+	}
+}
+
 // NewClientConnWithDeadline creates a new connection to the agent.
 func (s *Session) NewClientConnWithDeadline(config *gossh.ClientConfig) (*gossh.Client, <-chan *gossh.Request, error) {
 	const Addr = "tcp"
@@ -200,6 +262,11 @@ func (s *Session) NewClientConnWithDeadline(config *gossh.ClientConfig) (*gossh.
 
 	cli, chans, reqs, err := gossh.NewClientConn(s.Dialed, Addr, config)
 	if err != nil {
+		// The handshake does not wrap the error returned by the host key callback.
+		if s.hostKeyMismatch {
+			return nil, nil, ErrHostKey
+		}
+
 		return nil, nil, err
 	}
 
@@ -244,6 +311,28 @@ func (s *Session) Finish() error {
 	return nil
 }
 
+// pinnedHostKey returns the host key pinned to the device. The devices registered before their host key was pinned
+// use the RSA public key, PEM encoded, they register with as their host key.
+func pinnedHostKey(device *models.Device) (gossh.PublicKey, error) {
+	if device.HostKey != "" {
+		key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(device.HostKey)) //nolint:dogsled
+
+		return key, err
+	}
+
+	block, _ := pem.Decode([]byte(device.PublicKey))
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return gossh.NewPublicKey(key)
+}
+
 func loadEnv(env []string) map[string]string {
 	m := make(map[string]string, cap(env))
 
@@ -20,6 +20,8 @@ type (
 //
 // It receives on request's body the device's UID and the device's username, either the device's password or the
 // device's fingerprint and the device's signature, to returns a JWT token that can be used to connect to the device.
+// The request must be authenticated by the gateway, as the session is restricted to the device scope of the member who
+// opened it.
 // The JWT token is generated using a UUID as payload, and encrypted using a runtime generated RSA private key.
 //
 // If a error occurs, it logs on the server the error and returns the error message and the HTTP status code related to
@@ -30,7 +32,12 @@ func HandlerCreateSession(create functionHandleCreateSession) func(http.Response
 		Username    string `json:"username"`
 		Password    string `json:"password"`
 		Fingerprint string `json:"fingerprint"`
-		Signature   string `json:"signature"`
+		// LegacyFingerprint is the MD5 fingerprint of the public key, as the keys added before the fingerprints moved
+		// to SHA256 are still stored with it.
+		LegacyFingerprint string `json:"legacy_fingerprint"`
+		Signature         string `json:"signature"`
+		// SignatureFormat is the SSH signature format of Signature, as "rsa-sha2-256".
+		SignatureFormat string `json:"signature_format"`
 	}
 
 	type Response struct {
@@ -65,12 +72,23 @@ func HandlerCreateSession(create functionHandleCreateSession) func(http.Response
 			fail(res, "", "", http.StatusBadRequest, errors.New("failed to decode the request body"))
 		}
 
+		// The gateway sets the member when the request is authenticated, so a session without it is not opened.
+		member := req.Header.Get("X-ID")
+		if member == "" {
+			fail(res, request.Device, request.Username, http.StatusUnauthorized, errors.New("the request is not authenticated"))
+
+			return
+		}
+
 		data := &Input{
-			Device:      request.Device,
-			Username:    request.Username,
-			Password:    request.Password,
-			Fingerprint: request.Fingerprint,
-			Signature:   request.Signature,
+			Device:            request.Device,
+			Username:          request.Username,
+			Password:          request.Password,
+			Fingerprint:       request.Fingerprint,
+			LegacyFingerprint: request.LegacyFingerprint,
+			Signature:         request.Signature,
+			SignatureFormat:   request.SignatureFormat,
+			Member:            member,
 		}
 
 		session, err := create(req.Context(), data)
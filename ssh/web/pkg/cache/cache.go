@@ -28,9 +28,17 @@ type Data struct {
 	// Fingerprint is the fingerprint of the public key.
 	// Fingerprint is should be empty if the user is using a password.
 	Fingerprint string
+	// LegacyFingerprint is the legacy MD5 fingerprint of the public key.
+	// LegacyFingerprint is should be empty if the user is using a password.
+	LegacyFingerprint string
 	// Signature is the signature of the public key.
 	// Signature is should be empty if the user is using a password.
 	Signature string
+	// SignatureFormat is the SSH signature format of Signature.
+	// SignatureFormat is empty if the user is using a password.
+	SignatureFormat string
+	// Member is the namespace's member who opened the session.
+	Member string
 }
 
 type Token struct {
@@ -88,11 +96,14 @@ func Restore(ctx context.Context, token *token.Token) (*Data, error) {
 	}
 
 	var value struct {
-		Device      string
-		Username    string
-		Password    string
-		Fingerprint string
-		Signature   string
+		Device            string
+		Username          string
+		Password          string
+		Fingerprint       string
+		LegacyFingerprint string
+		Signature         string
+		SignatureFormat   string
+		Member            string
 	}
 
 	if err := connection.Get(ctx, token.ID, &value); err != nil {
@@ -100,10 +111,13 @@ func Restore(ctx context.Context, token *token.Token) (*Data, error) {
 	}
 
 	return &Data{
-		Device:      value.Device,
-		Username:    value.Username,
-		Password:    value.Password,
-		Fingerprint: value.Fingerprint,
-		Signature:   value.Signature,
+		Device:            value.Device,
+		Username:          value.Username,
+		Password:          value.Password,
+		Fingerprint:       value.Fingerprint,
+		LegacyFingerprint: value.LegacyFingerprint,
+		Signature:         value.Signature,
+		SignatureFormat:   value.SignatureFormat,
+		Member:            value.Member,
 	}, nil
 }
@@ -14,11 +14,15 @@ import (
 )
 
 type Input struct {
-	Device      string
-	Username    string
-	Password    string
-	Fingerprint string
-	Signature   string
+	Device            string
+	Username          string
+	Password          string
+	Fingerprint       string
+	LegacyFingerprint string
+	Signature         string
+	SignatureFormat   string
+	// Member is the namespace's member who opened the session.
+	Member string
 }
 
 type Output struct {
@@ -26,12 +30,15 @@ type Output struct {
 }
 
 type Session struct {
-	Token       string
-	Device      string
-	Username    string
-	Password    string
-	Fingerprint string
-	Signature   string
+	Token             string
+	Device            string
+	Username          string
+	Password          string
+	Fingerprint       string
+	LegacyFingerprint string
+	Signature         string
+	SignatureFormat   string
+	Member            string
 }
 
 // CreateSession creates a new web session.
@@ -57,23 +64,29 @@ func CreateSession(ctx context.Context, data *Input) (*Session, error) {
 	}
 
 	cached, err := cache.Save(ctx, token, &cache.Data{
-		Device:      data.Device,
-		Username:    data.Username,
-		Password:    data.Password,
-		Fingerprint: data.Fingerprint,
-		Signature:   data.Signature,
+		Device:            data.Device,
+		Username:          data.Username,
+		Password:          data.Password,
+		Fingerprint:       data.Fingerprint,
+		LegacyFingerprint: data.LegacyFingerprint,
+		Signature:         data.Signature,
+		SignatureFormat:   data.SignatureFormat,
+		Member:            data.Member,
 	})
 	if err != nil {
 		return nil, errors.New("failed to cache the session's token")
 	}
 
 	return &Session{
-		Token:       cached.Token,
-		Device:      data.Device,
-		Username:    data.Username,
-		Password:    data.Password,
-		Fingerprint: data.Fingerprint,
-		Signature:   data.Signature,
+		Token:             cached.Token,
+		Device:            data.Device,
+		Username:          data.Username,
+		Password:          data.Password,
+		Fingerprint:       data.Fingerprint,
+		LegacyFingerprint: data.LegacyFingerprint,
+		Signature:         data.Signature,
+		SignatureFormat:   data.SignatureFormat,
+		Member:            data.Member,
 	}, nil
 }
 
@@ -110,11 +123,14 @@ func RestoreSession(ctx context.Context, data *Output) (*Session, error) {
 	}
 
 	return &Session{
-		Token:       data.Token,
-		Device:      cached.Device,
-		Username:    cached.Username,
-		Password:    cached.Password,
-		Fingerprint: cached.Fingerprint,
-		Signature:   cached.Signature,
+		Token:             data.Token,
+		Device:            cached.Device,
+		Username:          cached.Username,
+		Password:          cached.Password,
+		Fingerprint:       cached.Fingerprint,
+		LegacyFingerprint: cached.LegacyFingerprint,
+		Signature:         cached.Signature,
+		SignatureFormat:   cached.SignatureFormat,
+		Member:            cached.Member,
 	}, nil
 }
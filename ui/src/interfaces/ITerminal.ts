@@ -2,6 +2,8 @@ export interface IConnectToTerminal {
   password?: string;
   signature?: string;
   fingerprint?: string;
+  legacy_fingerprint?: string;
+  signature_format?: string;
 }
 
 export interface ITerminalFrames {
@@ -16980,6 +16980,12 @@ const createSignatureOfPrivateKey = (
 };
 
 const createKeyFingerprint = (privateKeyData) => {
+  const key = sshpk.parsePrivateKey(privateKeyData);
+  const fingerprint = key.fingerprint("sha256").toString();
+  return fingerprint;
+};
+
+const createKeyLegacyFingerprint = (privateKeyData) => {
   const key = sshpk.parsePrivateKey(privateKeyData);
   const fingerprint = key.fingerprint("md5").toString("hex");
   return fingerprint;
@@ -17008,6 +17014,7 @@ const createSignerAndUpdate = (privateKey, username) => {
 
 window.global.createSignatureOfPrivateKey = createSignatureOfPrivateKey;
 window.global.createKeyFingerprint = createKeyFingerprint;
+window.global.createKeyLegacyFingerprint = createKeyLegacyFingerprint;
 window.global.convertKeyToFingerprint = convertKeyToFingerprint;
 window.global.createSignerAndUpdate = createSignerAndUpdate;
 window.global.parsePrivateKey = parsePrivateKey;
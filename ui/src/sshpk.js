@@ -7,12 +7,19 @@ const createSignatureOfPrivateKey = (
 ) => {
   let signature;
   const key = NodeRSA(privateKeyData);
-  key.setOptions({ signingScheme: "pkcs1-sha1" });
+  // The SSH server verifies the RSA signatures with SHA-256, as the "ssh-rsa" format signed with SHA-1 is not accepted.
+  key.setOptions({ signingScheme: "pkcs1-sha256" });
   signature = encodeURIComponent(key.sign(username, "base64"));
   return signature;
 };
 
 const createKeyFingerprint = (privateKeyData) => {
+  const key = sshpk.parsePrivateKey(privateKeyData);
+  const fingerprint = key.fingerprint("sha256").toString();
+  return fingerprint;
+};
+
+const createKeyLegacyFingerprint = (privateKeyData) => {
   const key = sshpk.parsePrivateKey(privateKeyData);
   const fingerprint = key.fingerprint("md5").toString("hex");
   return fingerprint;
@@ -41,6 +48,7 @@ const createSignerAndUpdate = (privateKey, username) => {
 
 window.global.createSignatureOfPrivateKey = createSignatureOfPrivateKey;
 window.global.createKeyFingerprint = createKeyFingerprint;
+window.global.createKeyLegacyFingerprint = createKeyLegacyFingerprint;
 window.global.convertKeyToFingerprint = convertKeyToFingerprint;
 window.global.createSignerAndUpdate = createSignerAndUpdate;
 window.global.parsePrivateKey = parsePrivateKey;
@@ -52,3 +52,8 @@ export const createKeyFingerprint = async (privateKeyData: any) => {
   const fingerprint = await window.global.createKeyFingerprint(privateKeyData);
   return fingerprint;
 };
+
+export const createKeyLegacyFingerprint = async (privateKeyData: any) => {
+  const fingerprint = await window.global.createKeyLegacyFingerprint(privateKeyData);
+  return fingerprint;
+};